@@ -2,9 +2,18 @@ package main
 
 import (
 	"app/config"
+	"app/internal/controllers"
+	"app/internal/migrations"
+	"app/internal/models"
+	"app/internal/repositories"
+	"app/internal/repositories/memdb"
 	"app/internal/server"
 	"app/logger"
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
 
 	"net/http"
 	"time"
@@ -16,10 +25,13 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const usage = "usage: main [migrate up|down|status | recommend [count]]"
+
 func main() {
 	cfg := config.Config{}
 	_, err := toml.DecodeFile("config/config.toml", &cfg)
@@ -31,7 +43,49 @@ func main() {
 		log.Fatal(err)
 	}
 
-	var db interface{}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if len(os.Args) != 3 {
+			log_default.Fatal(usage)
+		}
+		client, err := sqlx.Connect("postgres", cfg.Db_url)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Close()
+		err = migrate(migrations.NewMigrator(client, log), os.Args[2])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] != "recommend" {
+		log_default.Fatal(usage)
+	}
+
+	db, closeDB := openDB(cfg, log)
+	defer closeDB()
+
+	if len(os.Args) > 1 {
+		err = recommend(db, log, os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// tech_ui.Run(db, log)
+
+	s := server.NewServer(log, db, cfg.SessionKey, cfg.RequestTimeout, models.NewWordFilter(cfg.BannedWords))
+
+	err = http.ListenAndServe(cfg.Port, s)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// openDB connects to the database of the configured type and returns it
+// with the function closing it.
+func openDB(cfg config.Config, log *logrus.Logger) (interface{}, func()) {
 	switch cfg.Db_type {
 	case "postgres":
 		{
@@ -39,9 +93,16 @@ func main() {
 			if err != nil {
 				log.Fatal(err)
 			}
-			db = client
-			defer client.Close()
 			log.Info("Successfully connected to Postgres")
+
+			if cfg.Migrate {
+				cnt, err := migrations.NewMigrator(client, log).Up()
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Infof("Applied %d migrations", cnt)
+			}
+			return client, func() { client.Close() }
 		}
 	case "mongo":
 		{
@@ -52,31 +113,92 @@ func main() {
 			if err != nil {
 				log.Fatal(err)
 			}
-			defer func() {
-				if err = client.Disconnect(ctx); err != nil {
-					log.Fatal(err)
-				}
-			}()
 
 			err = client.Ping(ctx, nil)
 			if err != nil {
 				log.Fatal(err)
 			}
-			db = client
 			log.Info("Successfully connected to MongoDB")
+
+			err = repositories.SetupMongo(ctx, client, log)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return client, func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := client.Disconnect(ctx); err != nil {
+					log.Fatal(err)
+				}
+			}
 		}
-	default:
+	case "memory":
 		{
-			log.Fatal("Unknown db type")
+			log.Info("Using in-memory database")
+			return memdb.New(), func() {}
 		}
 	}
+	log.Fatal("Unknown db type")
+	return nil, nil
+}
 
-	// tech_ui.Run(db, log)
-
-	s := server.NewServer(log, db, cfg.SessionKey)
-
-	err = http.ListenAndServe(cfg.Port, s)
+// recommend runs the recommend subcommand: it ranks the recommended serials
+// for every user, models.RecommendCount of them unless the count is given.
+func recommend(db interface{}, log *logrus.Logger, args []string) error {
+	n := models.RecommendCount
+	if len(args) > 1 {
+		return errors.New(usage)
+	}
+	if len(args) == 1 {
+		var err error
+		n, err = strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > models.MaxRecommendations {
+			return fmt.Errorf("count must be from 1 to %d", models.MaxRecommendations)
+		}
+	}
+	start := time.Now()
+	cnt, err := controllers.NewRecommendationsCtrl(repositories.NewUnitOfWork(db, log)).RankAll(context.Background(), n)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	fmt.Printf("Ranked %d serials for %d users in %s\n", n, cnt, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// migrate runs the migrate subcommand: "up" applies the pending migrations,
+// "down" reverts the last applied one and "status" lists them all.
+func migrate(m *migrations.Migrator, cmd string) error {
+	switch cmd {
+	case "up":
+		cnt, err := m.Up()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", cnt)
+	case "down":
+		migration, err := m.Down()
+		if errors.Is(err, migrations.ErrNoApplied) {
+			fmt.Println("No applied migrations")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q", cmd)
 	}
+	return nil
 }
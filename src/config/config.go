@@ -1,9 +1,14 @@
 package config
 
+import "time"
+
 type Config struct {
-	Port       string `toml:"port"`
-	Db_url     string `toml:"db_url"`
-	Db_type    string `toml:"db_type"`
-	Log_path   string `toml:"log_path"`
-	SessionKey string `toml:"session"`
+	Port           string        `toml:"port"`
+	Db_url         string        `toml:"db_url"`
+	Db_type        string        `toml:"db_type"`
+	Log_path       string        `toml:"log_path"`
+	Migrate        bool          `toml:"migrate"`
+	SessionKey     string        `toml:"session"`
+	RequestTimeout time.Duration `toml:"request_timeout"`
+	BannedWords    []string      `toml:"banned_words"`
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type ActorsCtrl struct {
@@ -13,26 +14,26 @@ func NewActorsCtrl(service interfaces.IRepoActors) *ActorsCtrl {
 	return &ActorsCtrl{ActorsService: service}
 }
 
-func (ctrl *ActorsCtrl) GetActors() ([]*models.Actors, error) {
-	return ctrl.ActorsService.GetActors()
+func (ctrl *ActorsCtrl) GetActors(ctx context.Context) ([]*models.Actors, error) {
+	return ctrl.ActorsService.GetActors(ctx)
 }
 
-func (ctrl *ActorsCtrl) GetActorById(id int) (*models.Actors, error) {
-	return ctrl.ActorsService.GetActorById(id)
+func (ctrl *ActorsCtrl) GetActorById(ctx context.Context, id int) (*models.Actors, error) {
+	return ctrl.ActorsService.GetActorById(ctx, id)
 }
 
-func (ctrl *ActorsCtrl) CreateActor(actor *models.Actors) error {
-	return ctrl.ActorsService.CreateActor(actor)
+func (ctrl *ActorsCtrl) CreateActor(ctx context.Context, actor *models.Actors) error {
+	return ctrl.ActorsService.CreateActor(ctx, actor)
 }
 
-func (ctrl *ActorsCtrl) UpdateActor(actor *models.Actors) error {
-	return ctrl.ActorsService.UpdateActor(actor)
+func (ctrl *ActorsCtrl) UpdateActor(ctx context.Context, actor *models.Actors) error {
+	return ctrl.ActorsService.UpdateActor(ctx, actor)
 }
 
-func (ctrl *ActorsCtrl) DeleteActor(id int) error {
-	return ctrl.ActorsService.DeleteActor(id)
+func (ctrl *ActorsCtrl) DeleteActor(ctx context.Context, id int) error {
+	return ctrl.ActorsService.DeleteActor(ctx, id)
 }
 
-func (ctrl *ActorsCtrl) CheckActor(actor *models.Actors) bool {
-	return ctrl.ActorsService.CheckActor(actor)
+func (ctrl *ActorsCtrl) CheckActor(ctx context.Context, actor *models.Actors) bool {
+	return ctrl.ActorsService.CheckActor(ctx, actor)
 }
@@ -3,48 +3,222 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"errors"
 )
 
 type CommentsCtrl struct {
 	CommentsService interfaces.IRepoComments
+	UnitOfWork      interfaces.IUnitOfWork
+	Filter          *models.WordFilter
 }
 
-func NewCommentsCtrl(service interfaces.IRepoComments) *CommentsCtrl {
-	return &CommentsCtrl{CommentsService: service}
+// NewCommentsCtrl creates the controller, the comments with the words banned
+// by filter are rejected, filter may be nil.
+func NewCommentsCtrl(service interfaces.IRepoComments, uow interfaces.IUnitOfWork, filter *models.WordFilter) *CommentsCtrl {
+	return &CommentsCtrl{CommentsService: service, UnitOfWork: uow, Filter: filter}
 }
 
-func (ctrl *CommentsCtrl) GetComments() ([]*models.Comments, error) {
-	return ctrl.CommentsService.GetComments()
+func (ctrl *CommentsCtrl) GetComments(ctx context.Context) ([]*models.Comments, error) {
+	return ctrl.CommentsService.GetComments(ctx)
 }
 
-func (ctrl *CommentsCtrl) GetCommentById(id int) (*models.Comments, error) {
-	return ctrl.CommentsService.GetCommentById(id)
+func (ctrl *CommentsCtrl) GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error) {
+	return ctrl.CommentsService.GetCommentsPage(ctx, page)
 }
 
-func (ctrl *CommentsCtrl) GetCommentsBySerialId(idSerial int) ([]*models.Comments, error) {
-	return ctrl.CommentsService.GetCommentsBySerialId(idSerial)
+func (ctrl *CommentsCtrl) GetCommentById(ctx context.Context, id int) (*models.Comments, error) {
+	return ctrl.CommentsService.GetCommentById(ctx, id)
 }
 
-func (ctrl *CommentsCtrl) GetCommentsByUserId(idUser int) ([]*models.Comments, error) {
-	return ctrl.CommentsService.GetCommentsByUserId(idUser)
+func (ctrl *CommentsCtrl) GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	return ctrl.CommentsService.GetCommentsBySerialId(ctx, idSerial)
 }
 
-func (ctrl *CommentsCtrl) GetCommentsBySerialIdUserId(idSerial, idUser int) (*models.Comments, error) {
-	return ctrl.CommentsService.GetCommentsBySerialIdUserId(idSerial, idUser)
+func (ctrl *CommentsCtrl) GetCommentsBySerialIdPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.Comments, int, error) {
+	return ctrl.CommentsService.GetCommentsBySerialIdPage(ctx, idSerial, sort, page)
 }
 
-func (ctrl *CommentsCtrl) CreateComment(comment *models.Comments) error {
-	return ctrl.CommentsService.CreateComment(comment)
+// GetCommentThreadsPage returns a page of the reviews of the serial in the
+// order sort, each with all the replies to it, and the number of the reviews.
+func (ctrl *CommentsCtrl) GetCommentThreadsPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.CommentThread, int, error) {
+	reviews, total, err := ctrl.CommentsService.GetCommentsBySerialIdPage(ctx, idSerial, sort, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	replies, err := ctrl.CommentsService.GetRepliesBySerialId(ctx, idSerial)
+	if err != nil {
+		return nil, 0, err
+	}
+	return models.NewCommentThreads(reviews, replies), total, nil
 }
 
-func (ctrl *CommentsCtrl) UpdateComment(comment *models.Comments) error {
-	return ctrl.CommentsService.UpdateComment(comment)
+func (ctrl *CommentsCtrl) GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error) {
+	return ctrl.CommentsService.GetCommentsByUserId(ctx, idUser)
 }
 
-func (ctrl *CommentsCtrl) DeleteComment(id int) error {
-	return ctrl.CommentsService.DeleteComment(id)
+// CountReviews returns the number of the reviews of the user, not counting
+// the replies and the reviews hidden by the moderators.
+func (ctrl *CommentsCtrl) CountReviews(ctx context.Context, idUser int) (int, error) {
+	comments, err := ctrl.CommentsService.GetCommentsByUserId(ctx, idUser)
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, comment := range comments {
+		if comment.IsReview() && !comment.GetHidden() {
+			cnt++
+		}
+	}
+	return cnt, nil
 }
 
-func (ctrl *CommentsCtrl) CheckComment(idUser, idSerial int) bool {
-	return ctrl.CommentsService.CheckComment(idUser, idSerial)
+func (ctrl *CommentsCtrl) GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error) {
+	return ctrl.CommentsService.GetCommentsBySerialIdUserId(ctx, idSerial, idUser)
+}
+
+func (ctrl *CommentsCtrl) CreateComment(ctx context.Context, comment *models.Comments) error {
+	if err := ctrl.checkWords(comment); err != nil {
+		return err
+	}
+	return ctrl.CommentsService.CreateComment(ctx, comment)
+}
+
+func (ctrl *CommentsCtrl) UpdateComment(ctx context.Context, comment *models.Comments) error {
+	if err := ctrl.checkWords(comment); err != nil {
+		return err
+	}
+	return ctrl.CommentsService.UpdateComment(ctx, comment)
+}
+
+// ReplyComment adds the reply to the comment reply.C_idParent to the serial of
+// that comment.
+func (ctrl *CommentsCtrl) ReplyComment(ctx context.Context, reply *models.Comments) error {
+	if reply.GetIdParent() <= 0 {
+		return models.ErrInvalidModel
+	}
+	if err := ctrl.checkWords(reply); err != nil {
+		return err
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		parent, err := tx.Comments().GetCommentById(ctx, reply.GetIdParent())
+		if err != nil {
+			return err
+		}
+		reply.SetIdSerial(parent.GetIdSerial())
+		reply.SetVotes(0, 0)
+		return tx.Comments().CreateComment(ctx, reply)
+	})
+}
+
+// DeleteComment deletes the comment with all the replies to it and their
+// votes, all in one transaction.
+func (ctrl *CommentsCtrl) DeleteComment(ctx context.Context, id int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comment, err := tx.Comments().GetCommentById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return deleteCommentThread(ctx, tx, comment)
+	})
+}
+
+// VoteComment sets the vote of the user for the comment, 1 for an upvote and
+// -1 for a downvote, replacing the previous one, 0 takes the vote back. The
+// counts of the votes of the comment are updated in the same transaction.
+func (ctrl *CommentsCtrl) VoteComment(ctx context.Context, idComment, idUser, value int) error {
+	if value < -1 || value > 1 {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comment, err := tx.Comments().GetCommentById(ctx, idComment)
+		if err != nil {
+			return err
+		}
+		if comment.GetIdUser() == idUser {
+			return ErrOwnComment
+		}
+		prev, err := tx.CommentsVotes().GetCommentVoteByIds(ctx, idComment, idUser)
+		if err != nil && !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		switch {
+		case prev == nil && value == 0:
+			return nil
+		case prev == nil:
+			err = tx.CommentsVotes().CreateCommentVote(ctx, &models.CommentsVotes{Cv_idComment: idComment, Cv_idUser: idUser, Cv_value: value})
+		case value == 0:
+			err = tx.CommentsVotes().DeleteCommentVote(ctx, prev.GetId())
+		default:
+			prev.SetValue(value)
+			err = tx.CommentsVotes().UpdateCommentVote(ctx, prev)
+		}
+		if err != nil {
+			return err
+		}
+		return updateCommentVotes(ctx, tx, idComment)
+	})
+}
+
+func (ctrl *CommentsCtrl) CheckComment(ctx context.Context, idUser, idSerial int) bool {
+	return ctrl.CommentsService.CheckComment(ctx, idUser, idSerial)
+}
+
+// checkWords returns a *BannedWordsError if the text of the comment has words
+// banned by the filter.
+func (ctrl *CommentsCtrl) checkWords(comment *models.Comments) error {
+	if words := ctrl.Filter.Find(comment.GetText()); len(words) > 0 {
+		return &BannedWordsError{Words: words}
+	}
+	return nil
+}
+
+// deleteCommentThread deletes the comment and the replies to it at any depth
+// with the votes for and the reports on all of them.
+func deleteCommentThread(ctx context.Context, tx interfaces.ITx, comment *models.Comments) error {
+	replies, err := tx.Comments().GetRepliesBySerialId(ctx, comment.GetIdSerial())
+	if err != nil {
+		return err
+	}
+	thread := models.NewCommentThreads([]*models.Comments{comment}, replies)[0]
+
+	var remove func(thread *models.CommentThread) error
+	remove = func(thread *models.CommentThread) error {
+		for _, reply := range thread.Replies {
+			if err := remove(reply); err != nil {
+				return err
+			}
+		}
+		err := tx.CommentsVotes().DeleteCommentVotesByCommentId(ctx, thread.GetId())
+		if err != nil {
+			return err
+		}
+		err = tx.CommentsReports().DeleteCommentReportsByCommentId(ctx, thread.GetId())
+		if err != nil {
+			return err
+		}
+		return tx.Comments().DeleteComment(ctx, thread.GetId())
+	}
+	return remove(thread)
+}
+
+// updateCommentVotes sets the counts of the upvotes and the downvotes of the
+// comment to the numbers of its votes. The comment is locked before the votes
+// are counted and read again after, so neither a concurrent vote nor an edit
+// of the comment is lost.
+func updateCommentVotes(ctx context.Context, tx interfaces.ITx, idComment int) error {
+	err := tx.Comments().LockComment(ctx, idComment)
+	if err != nil {
+		return err
+	}
+	up, down, err := tx.CommentsVotes().CountCommentVotes(ctx, idComment)
+	if err != nil {
+		return err
+	}
+	comment, err := tx.Comments().GetCommentById(ctx, idComment)
+	if err != nil {
+		return err
+	}
+	comment.SetVotes(up, down)
+	return tx.Comments().UpdateComment(ctx, comment)
 }
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ComparisonsCtrl compares serials by their metrics, see models.NewComparison,
+// and keeps the comparisons the users save to share them by a link.
+type ComparisonsCtrl struct {
+	UnitOfWork interfaces.IUnitOfWork
+}
+
+func NewComparisonsCtrl(uow interfaces.IUnitOfWork) *ComparisonsCtrl {
+	return &ComparisonsCtrl{UnitOfWork: uow}
+}
+
+// Compare compares the serials with the ids, see models.ValidCompareIds.
+func (ctrl *ComparisonsCtrl) Compare(ctx context.Context, ids []int) (*models.Comparison, error) {
+	if !models.ValidCompareIds(ids) {
+		return nil, models.ErrInvalidModel
+	}
+	var comparison *models.Comparison
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		serials := []*models.Serial{}
+		for _, id := range ids {
+			serial, err := tx.Serials().GetSerialById(ctx, id)
+			if err != nil {
+				return err
+			}
+			serials = append(serials, serial)
+		}
+		var err error
+		comparison, err = compare(ctx, tx, serials)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// SaveComparison saves the comparison of the serials with the ids for the
+// user and gives it a slug for the link.
+func (ctrl *ComparisonsCtrl) SaveComparison(ctx context.Context, idUser int, ids []int) (*models.Comparisons, error) {
+	comparison := &models.Comparisons{
+		Cm_idUser: idUser,
+		Cm_slug:   models.NewSlug(),
+		Cm_date:   time.Now().Format("2006-01-02"),
+	}
+	comparison.SetSerialIds(ids)
+	if !comparison.Validate() {
+		return nil, models.ErrInvalidModel
+	}
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		for _, id := range ids {
+			_, err := tx.Serials().GetSerialById(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Comparisons().CreateComparison(ctx, comparison)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// GetSavedComparison compares the serials of the saved comparison with the
+// slug, the serials deleted since it was saved are left out.
+func (ctrl *ComparisonsCtrl) GetSavedComparison(ctx context.Context, slug string) (*models.Comparison, error) {
+	var comparison *models.Comparison
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		saved, err := tx.Comparisons().GetComparisonBySlug(ctx, slug)
+		if err != nil {
+			return err
+		}
+		serials, err := savedSerials(ctx, tx, saved)
+		if err != nil {
+			return err
+		}
+		comparison, err = compare(ctx, tx, serials)
+		if err != nil {
+			return err
+		}
+		comparison.Slug = saved.GetSlug()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// GetComparisonsByUserId returns the comparisons saved by the user with their
+// serials.
+func (ctrl *ComparisonsCtrl) GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.SavedComparison, error) {
+	res := []*models.SavedComparison{}
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comparisons, err := tx.Comparisons().GetComparisonsByUserId(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		for _, comparison := range comparisons {
+			serials, err := savedSerials(ctx, tx, comparison)
+			if err != nil {
+				return err
+			}
+			res = append(res, &models.SavedComparison{Comparison: comparison, Serials: serials})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteComparison deletes the saved comparison, the comparisons of other
+// users are not found.
+func (ctrl *ComparisonsCtrl) DeleteComparison(ctx context.Context, idUser, id int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comparison, err := tx.Comparisons().GetComparisonById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if comparison.GetIdUser() != idUser {
+			return models.ErrNotFound
+		}
+		return tx.Comparisons().DeleteComparison(ctx, id)
+	})
+}
+
+// savedSerials returns the serials of the saved comparison which still exist.
+func savedSerials(ctx context.Context, tx interfaces.ITx, comparison *models.Comparisons) ([]*models.Serial, error) {
+	serials := []*models.Serial{}
+	for _, id := range comparison.GetSerialIds() {
+		serial, err := tx.Serials().GetSerialById(ctx, id)
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		serials = append(serials, serial)
+	}
+	return serials, nil
+}
+
+// compare reads what the serials are compared by and compares them.
+func compare(ctx context.Context, tx interfaces.ITx, serials []*models.Serial) (*models.Comparison, error) {
+	actors, err := tx.Actors().GetActors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actorsById := make(map[int]*models.Actors, len(actors))
+	for _, actor := range actors {
+		actorsById[actor.GetId()] = actor
+	}
+	users, err := tx.Users().GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[int]int, len(users))
+	for _, user := range users {
+		owners[user.GetIdFavourites()] = user.GetId()
+	}
+
+	inputs := []*models.CompareInput{}
+	for _, serial := range serials {
+		in := &models.CompareInput{Serial: serial}
+		in.Seasons, err = tx.Seasons().GetSeasonsBySerialId(ctx, serial.GetId())
+		if err != nil {
+			return nil, err
+		}
+		for _, season := range in.Seasons {
+			episodes, err := tx.Episodes().GetEpisodesBySeasonId(ctx, season.GetId())
+			if err != nil {
+				return nil, err
+			}
+			in.Episodes = append(in.Episodes, episodes...)
+		}
+
+		serialsActors, err := tx.SerialsActors().GetActorsBySerialId(ctx, serial.GetId())
+		if err != nil {
+			return nil, err
+		}
+		for _, sa := range serialsActors {
+			if actor := actorsById[sa.GetIdActor()]; actor != nil {
+				in.Actors = append(in.Actors, actor)
+			}
+		}
+
+		saved, err := tx.SerialsFavourites().GetFavouritesBySerialId(ctx, serial.GetId())
+		if err != nil {
+			return nil, err
+		}
+		users := map[int]bool{}
+		for _, sf := range saved {
+			if owner := owners[sf.GetIdFavourite()]; owner > 0 {
+				users[owner] = true
+			}
+		}
+		in.Favourited = len(users)
+		inputs = append(inputs, in)
+	}
+	return models.NewComparison(inputs, time.Now().Year()), nil
+}
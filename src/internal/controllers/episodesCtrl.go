@@ -3,36 +3,148 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"sort"
 )
 
 type EpisodesCtrl struct {
 	EpisodesService interfaces.IRepoEpisodes
+	SeasonsService  interfaces.IRepoSeasons
+	SerialsService  interfaces.IRepoSerials
+	UnitOfWork      interfaces.IUnitOfWork
 }
 
-func NewEpisodesCtrl(service interfaces.IRepoEpisodes) *EpisodesCtrl {
-	return &EpisodesCtrl{EpisodesService: service}
+func NewEpisodesCtrl(Eservice interfaces.IRepoEpisodes, SSservice interfaces.IRepoSeasons, Sservice interfaces.IRepoSerials, uow interfaces.IUnitOfWork) *EpisodesCtrl {
+	return &EpisodesCtrl{EpisodesService: Eservice, SeasonsService: SSservice, SerialsService: Sservice, UnitOfWork: uow}
 }
 
-func (ctrl *EpisodesCtrl) GetEpisodes() ([]*models.Episodes, error) {
-	return ctrl.EpisodesService.GetEpisodes()
+// inTx runs fn with the controller over the repositories of one transaction,
+// so the episodes, their season and the serial synced with them change
+// together or not at all.
+func (ctrl *EpisodesCtrl) inTx(ctx context.Context, fn func(ctx context.Context, txCtrl *EpisodesCtrl) error) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		return fn(ctx, NewEpisodesCtrl(tx.Episodes(), tx.Seasons(), tx.Serials(), nil))
+	})
 }
 
-func (ctrl *EpisodesCtrl) GetEpisodeById(id int) (*models.Episodes, error) {
-	return ctrl.EpisodesService.GetEpisodeById(id)
+func (ctrl *EpisodesCtrl) GetEpisodes(ctx context.Context) ([]*models.Episodes, error) {
+	return ctrl.EpisodesService.GetEpisodes(ctx)
 }
 
-func (ctrl *EpisodesCtrl) GetEpisodesBySeasonId(id int) ([]*models.Episodes, error) {
-	return ctrl.EpisodesService.GetEpisodesBySeasonId(id)
+func (ctrl *EpisodesCtrl) GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error) {
+	return ctrl.EpisodesService.GetEpisodeById(ctx, id)
 }
 
-func (ctrl *EpisodesCtrl) CreateEpisode(episode *models.Episodes) error {
-	return ctrl.EpisodesService.CreateEpisode(episode)
+// GetEpisodesBySeasonId returns the episodes of the season ordered by their number.
+func (ctrl *EpisodesCtrl) GetEpisodesBySeasonId(ctx context.Context, id int) ([]*models.Episodes, error) {
+	episodes, err := ctrl.EpisodesService.GetEpisodesBySeasonId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].GetNum() < episodes[j].GetNum()
+	})
+	return episodes, nil
 }
 
-func (ctrl *EpisodesCtrl) UpdateEpisode(episode *models.Episodes) error {
-	return ctrl.EpisodesService.UpdateEpisode(episode)
+func (ctrl *EpisodesCtrl) CreateEpisode(ctx context.Context, episode *models.Episodes) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *EpisodesCtrl) error {
+		err := txCtrl.EpisodesService.CreateEpisode(ctx, episode)
+		if err != nil {
+			return err
+		}
+		return txCtrl.SyncSeason(ctx, episode.GetIdSeason())
+	})
 }
 
-func (ctrl *EpisodesCtrl) DeleteEpisode(id int) error {
-	return ctrl.EpisodesService.DeleteEpisode(id)
+func (ctrl *EpisodesCtrl) UpdateEpisode(ctx context.Context, episode *models.Episodes) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *EpisodesCtrl) error {
+		prev, err := txCtrl.EpisodesService.GetEpisodeById(ctx, episode.GetId())
+		if err != nil {
+			return err
+		}
+		err = txCtrl.EpisodesService.UpdateEpisode(ctx, episode)
+		if err != nil {
+			return err
+		}
+		if prev.GetIdSeason() != episode.GetIdSeason() {
+			err = txCtrl.SyncSeason(ctx, prev.GetIdSeason())
+			if err != nil {
+				return err
+			}
+		}
+		return txCtrl.SyncSeason(ctx, episode.GetIdSeason())
+	})
+}
+
+func (ctrl *EpisodesCtrl) DeleteEpisode(ctx context.Context, id int) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *EpisodesCtrl) error {
+		episode, err := txCtrl.EpisodesService.GetEpisodeById(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = txCtrl.EpisodesService.DeleteEpisode(ctx, id)
+		if err != nil {
+			return err
+		}
+		return txCtrl.SyncSeason(ctx, episode.GetIdSeason())
+	})
+}
+
+// ReorderEpisodes renumbers the episodes of the season in the given order.
+// order must contain every episode id of the season exactly once.
+func (ctrl *EpisodesCtrl) ReorderEpisodes(ctx context.Context, idSeason int, order []int) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *EpisodesCtrl) error {
+		episodes, err := txCtrl.EpisodesService.GetEpisodesBySeasonId(ctx, idSeason)
+		if err != nil {
+			return err
+		}
+		byId := make(map[int]*models.Episodes, len(episodes))
+		for _, episode := range episodes {
+			byId[episode.GetId()] = episode
+		}
+		if len(order) != len(byId) {
+			return ErrInvalidOrder
+		}
+		seen := make(map[int]bool, len(order))
+		for _, id := range order {
+			if byId[id] == nil || seen[id] {
+				return ErrInvalidOrder
+			}
+			seen[id] = true
+		}
+		for i, id := range order {
+			episode := byId[id]
+			if episode.GetNum() == i+1 {
+				continue
+			}
+			episode.SetNum(i + 1)
+			err = txCtrl.EpisodesService.UpdateEpisode(ctx, episode)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SyncSeason stores the actual number of episodes in Seasons.Ss_cntEpisodes
+// and recalculates the number of seasons and the duration of the serial.
+func (ctrl *EpisodesCtrl) SyncSeason(ctx context.Context, idSeason int) error {
+	season, err := ctrl.SeasonsService.GetSeasonById(ctx, idSeason)
+	if err != nil {
+		return err
+	}
+	episodes, err := ctrl.EpisodesService.GetEpisodesBySeasonId(ctx, idSeason)
+	if err != nil {
+		return err
+	}
+	if season.GetCntEpisodes() != len(episodes) {
+		season.SetCntEpisodes(len(episodes))
+		err = ctrl.SeasonsService.UpdateSeason(ctx, season)
+		if err != nil {
+			return err
+		}
+	}
+	return NewSeasonsCtrl(ctrl.SeasonsService, ctrl.SerialsService, ctrl.EpisodesService, nil).SyncSerial(ctx, season.GetIdSerial())
 }
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+type EpisodesUsersCtrl struct {
+	EpisodesUsersService interfaces.IRepoEpisodesUsers
+	SeasonsService       interfaces.IRepoSeasons
+	EpisodesService      interfaces.IRepoEpisodes
+	UnitOfWork           interfaces.IUnitOfWork
+}
+
+func NewEpisodesUsersCtrl(EUservice interfaces.IRepoEpisodesUsers, SSservice interfaces.IRepoSeasons, Eservice interfaces.IRepoEpisodes, uow interfaces.IUnitOfWork) *EpisodesUsersCtrl {
+	return &EpisodesUsersCtrl{EpisodesUsersService: EUservice, SeasonsService: SSservice, EpisodesService: Eservice, UnitOfWork: uow}
+}
+
+// MarkWatched marks the episode as watched by the user and puts its serial
+// into the history of the user with today's date. Marking an episode twice
+// returns the first mark.
+func (ctrl *EpisodesUsersCtrl) MarkWatched(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error) {
+	var episodeUser *models.EpisodesUsers
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		episode, err := tx.Episodes().GetEpisodeById(ctx, episodeId)
+		if err != nil {
+			return err
+		}
+		season, err := tx.Seasons().GetSeasonById(ctx, episode.GetIdSeason())
+		if err != nil {
+			return err
+		}
+		episodeUser, err = tx.EpisodesUsers().GetEpisodeUserByIds(ctx, episodeId, userId)
+		if errors.Is(err, models.ErrNotFound) {
+			episodeUser = &models.EpisodesUsers{
+				Eu_idEpisode: episodeId,
+				Eu_idSerial:  season.GetIdSerial(),
+				Eu_idUser:    userId,
+			}
+			err = tx.EpisodesUsers().CreateEpisodesUsers(ctx, episodeUser)
+		}
+		if err != nil {
+			return err
+		}
+		return addToHistory(ctx, tx, season.GetIdSerial(), userId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return episodeUser, nil
+}
+
+// UnmarkWatched removes the mark of the episode. The serial leaves the history
+// of the user with the last of its watched episodes.
+func (ctrl *EpisodesUsersCtrl) UnmarkWatched(ctx context.Context, episodeId, userId int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		episodeUser, err := tx.EpisodesUsers().GetEpisodeUserByIds(ctx, episodeId, userId)
+		if err != nil {
+			return err
+		}
+		err = tx.EpisodesUsers().DeleteEpisodesUsers(ctx, episodeUser.GetId())
+		if err != nil {
+			return err
+		}
+		watched, err := tx.EpisodesUsers().GetEpisodesBySerialIdUserId(ctx, episodeUser.GetIdSerial(), userId)
+		if err != nil || len(watched) > 0 {
+			return err
+		}
+		history, err := tx.SerialsUsers().GetSerialUserByIds(ctx, episodeUser.GetIdSerial(), userId)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.SerialsUsers().DeleteSerialsUsers(ctx, history.GetId())
+	})
+}
+
+// GetProgress returns the progress of the user in the serial, its seasons and
+// episodes are ordered by their numbers.
+func (ctrl *EpisodesUsersCtrl) GetProgress(ctx context.Context, serialId, userId int) (*models.SerialProgress, error) {
+	seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(ctx, serialId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(seasons, func(i, j int) bool {
+		return seasons[i].GetNum() < seasons[j].GetNum()
+	})
+	episodes := map[int][]*models.Episodes{}
+	for _, season := range seasons {
+		list, err := ctrl.EpisodesService.GetEpisodesBySeasonId(ctx, season.GetId())
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].GetNum() < list[j].GetNum()
+		})
+		episodes[season.GetId()] = list
+	}
+	watched, err := ctrl.EpisodesUsersService.GetEpisodesBySerialIdUserId(ctx, serialId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewSerialProgress(serialId, seasons, episodes, watched), nil
+}
+
+// ClearHistory removes the history of the user together with the marks of the
+// watched episodes.
+func (ctrl *EpisodesUsersCtrl) ClearHistory(ctx context.Context, userId int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		err := tx.EpisodesUsers().DeleteEpisodesByUserId(ctx, userId)
+		if err != nil {
+			return err
+		}
+		return tx.SerialsUsers().DeleteSerialsByUserId(ctx, userId)
+	})
+}
+
+// addToHistory sets the date the user last watched the serial to today.
+func addToHistory(ctx context.Context, tx interfaces.ITx, serialId, userId int) error {
+	today := time.Now().Format("2006-01-02")
+	history, err := tx.SerialsUsers().GetSerialUserByIds(ctx, serialId, userId)
+	if errors.Is(err, models.ErrNotFound) {
+		return tx.SerialsUsers().CreateSerialsUsers(ctx, &models.SerialsUsers{
+			Su_idSerial: serialId,
+			Su_idUser:   userId,
+			Su_lastSeen: today,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	history.SetLastSeen(today)
+	return tx.SerialsUsers().UpdateSerialsUsers(ctx, history)
+}
@@ -2,10 +2,26 @@ package controllers
 
 import (
 	"errors"
+	"strings"
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrInvalidPass  = errors.New("invalid password")
-	ErrUserExists   = errors.New("user already exists")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidPass     = errors.New("invalid password")
+	ErrUserExists      = errors.New("user already exists")
+	ErrInvalidOrder    = errors.New("invalid order")
+	ErrOwnComment      = errors.New("cannot vote for or report own comment")
+	ErrAlreadyReported = errors.New("comment already reported")
+	ErrListExists      = errors.New("list already exists")
+	ErrDefaultList     = errors.New("cannot delete the default list")
+	ErrSerialInList    = errors.New("serial already in list")
 )
+
+// BannedWordsError rejects a comment with the words banned by the filter.
+type BannedWordsError struct {
+	Words []string
+}
+
+func (e *BannedWordsError) Error() string {
+	return "banned words: " + strings.Join(e.Words, ", ")
+}
@@ -3,28 +3,317 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"strings"
+	"time"
 )
 
 type FavouritesCtrl struct {
 	FavouritesService interfaces.IRepoFavourites
+	UnitOfWork        interfaces.IUnitOfWork
 }
 
-func NewFavouritesCtrl(service interfaces.IRepoFavourites) *FavouritesCtrl {
-	return &FavouritesCtrl{FavouritesService: service}
+func NewFavouritesCtrl(service interfaces.IRepoFavourites, uow interfaces.IUnitOfWork) *FavouritesCtrl {
+	return &FavouritesCtrl{FavouritesService: service, UnitOfWork: uow}
 }
 
-func (ctrl *FavouritesCtrl) GetFavourites() ([]*models.Favourites, error) {
-	return ctrl.FavouritesService.GetFavourites()
+func (ctrl *FavouritesCtrl) GetFavourites(ctx context.Context) ([]*models.Favourites, error) {
+	return ctrl.FavouritesService.GetFavourites(ctx)
 }
 
-func (ctrl *FavouritesCtrl) GetFavouriteById(id int) (*models.Favourites, error) {
-	return ctrl.FavouritesService.GetFavouriteById(id)
+func (ctrl *FavouritesCtrl) GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error) {
+	return ctrl.FavouritesService.GetFavouriteById(ctx, id)
 }
 
-func (ctrl *FavouritesCtrl) UpdateFavourite(favourite *models.Favourites) error {
-	return ctrl.FavouritesService.UpdateFavourite(favourite)
+func (ctrl *FavouritesCtrl) UpdateFavourite(ctx context.Context, favourite *models.Favourites) error {
+	return ctrl.FavouritesService.UpdateFavourite(ctx, favourite)
 }
 
-func (ctrl *FavouritesCtrl) DeleteFavourite(id int) error {
-	return ctrl.FavouritesService.DeleteFavourite(id)
+func (ctrl *FavouritesCtrl) DeleteFavourite(ctx context.Context, id int) error {
+	return ctrl.FavouritesService.DeleteFavourite(ctx, id)
+}
+
+// GetLists returns the lists of the user in their order.
+func (ctrl *FavouritesCtrl) GetLists(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	return ctrl.FavouritesService.GetFavouritesByUserId(ctx, idUser)
+}
+
+// GetList returns the list if it belongs to the user, models.ErrNotFound
+// otherwise.
+func (ctrl *FavouritesCtrl) GetList(ctx context.Context, idUser, id int) (*models.Favourites, error) {
+	return userList(ctx, ctrl.FavouritesService, idUser, id)
+}
+
+// CreateList adds the empty list after the other lists of its user. The names
+// of the lists of a user are unique regardless of case.
+func (ctrl *FavouritesCtrl) CreateList(ctx context.Context, list *models.Favourites) error {
+	list.SetName(strings.TrimSpace(list.GetName()))
+	list.SetCntSerials(0)
+	if list.GetVisibility() == "" {
+		list.SetVisibility(models.ListPrivate)
+	}
+	shareList(list)
+	if list.GetIdUser() <= 0 || !list.Validate() {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		lists, err := tx.Favourites().GetFavouritesByUserId(ctx, list.GetIdUser())
+		if err != nil {
+			return err
+		}
+		position := 0
+		for _, other := range lists {
+			if strings.EqualFold(other.GetName(), list.GetName()) {
+				return ErrListExists
+			}
+			position = max(position, other.GetPosition())
+		}
+		list.SetPosition(position + 1)
+		id, err := tx.Favourites().CreateFavourite(ctx, list)
+		if err != nil {
+			return err
+		}
+		list.SetId(id)
+		return nil
+	})
+}
+
+// UpdateList renames the list of the user and replaces its note.
+func (ctrl *FavouritesCtrl) UpdateList(ctx context.Context, idUser, id int, name, note string) error {
+	name = strings.TrimSpace(name)
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		list, err := userList(ctx, tx.Favourites(), idUser, id)
+		if err != nil {
+			return err
+		}
+		lists, err := tx.Favourites().GetFavouritesByUserId(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		for _, other := range lists {
+			if other.GetId() != id && strings.EqualFold(other.GetName(), name) {
+				return ErrListExists
+			}
+		}
+		list.SetName(name)
+		list.SetNote(note)
+		return tx.Favourites().UpdateFavourite(ctx, list)
+	})
+}
+
+// SetVisibility makes the list of the user private, unlisted or public. The
+// list gets its slug when it is shared for the first time and keeps it.
+func (ctrl *FavouritesCtrl) SetVisibility(ctx context.Context, idUser, id int, visibility string) error {
+	if !models.ValidListVisibility(visibility) {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		list, err := userList(ctx, tx.Favourites(), idUser, id)
+		if err != nil {
+			return err
+		}
+		list.SetVisibility(visibility)
+		shareList(list)
+		return tx.Favourites().UpdateFavourite(ctx, list)
+	})
+}
+
+// GetSharedList returns the unlisted or public list with the slug,
+// models.ErrNotFound for private lists.
+func (ctrl *FavouritesCtrl) GetSharedList(ctx context.Context, slug string) (*models.Favourites, error) {
+	list, err := ctrl.FavouritesService.GetFavouriteBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !list.Shared() {
+		return nil, models.ErrNotFound
+	}
+	return list, nil
+}
+
+// GetPublicLists returns the public lists of the user in their order.
+func (ctrl *FavouritesCtrl) GetPublicLists(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	lists, err := ctrl.FavouritesService.GetFavouritesByUserId(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	public := []*models.Favourites{}
+	for _, list := range lists {
+		if list.GetVisibility() == models.ListPublic {
+			public = append(public, list)
+		}
+	}
+	return public, nil
+}
+
+// ReorderLists renumbers the lists of the user in the given order. order must
+// contain every list id of the user exactly once.
+func (ctrl *FavouritesCtrl) ReorderLists(ctx context.Context, idUser int, order []int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		lists, err := tx.Favourites().GetFavouritesByUserId(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		byId := make(map[int]*models.Favourites, len(lists))
+		for _, list := range lists {
+			byId[list.GetId()] = list
+		}
+		if len(order) != len(byId) {
+			return ErrInvalidOrder
+		}
+		seen := make(map[int]bool, len(order))
+		for _, id := range order {
+			if byId[id] == nil || seen[id] {
+				return ErrInvalidOrder
+			}
+			seen[id] = true
+		}
+		for i, id := range order {
+			list := byId[id]
+			if list.GetPosition() == i+1 {
+				continue
+			}
+			list.SetPosition(i + 1)
+			err = tx.Favourites().UpdateFavourite(ctx, list)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteList deletes the list of the user with its serials. The default list
+// of the user cannot be deleted.
+func (ctrl *FavouritesCtrl) DeleteList(ctx context.Context, idUser, id int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		user, err := tx.Users().GetUserById(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		if user.GetIdFavourites() == id {
+			return ErrDefaultList
+		}
+		_, err = userList(ctx, tx.Favourites(), idUser, id)
+		if err != nil {
+			return err
+		}
+		err = deleteListSerials(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return tx.Favourites().DeleteFavourite(ctx, id)
+	})
+}
+
+// AddSerial puts the serial in the list of the user with today's date.
+func (ctrl *FavouritesCtrl) AddSerial(ctx context.Context, idUser, id, idSerial int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		list, err := userList(ctx, tx.Favourites(), idUser, id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Serials().GetSerialById(ctx, idSerial)
+		if err != nil {
+			return err
+		}
+		return addListSerial(ctx, tx, list, idSerial)
+	})
+}
+
+// RemoveSerial takes the serial out of the list of the user.
+func (ctrl *FavouritesCtrl) RemoveSerial(ctx context.Context, idUser, id, idSerial int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		list, err := userList(ctx, tx.Favourites(), idUser, id)
+		if err != nil {
+			return err
+		}
+		return removeListSerial(ctx, tx, list, idSerial)
+	})
+}
+
+// MoveSerial moves the serial from one list of the user to another, where it
+// gets today's date.
+func (ctrl *FavouritesCtrl) MoveSerial(ctx context.Context, idUser, from, to, idSerial int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		source, err := userList(ctx, tx.Favourites(), idUser, from)
+		if err != nil {
+			return err
+		}
+		target, err := userList(ctx, tx.Favourites(), idUser, to)
+		if err != nil {
+			return err
+		}
+		err = removeListSerial(ctx, tx, source, idSerial)
+		if err != nil {
+			return err
+		}
+		return addListSerial(ctx, tx, target, idSerial)
+	})
+}
+
+// userList returns the list if it belongs to the user, models.ErrNotFound
+// otherwise.
+func userList(ctx context.Context, repo interfaces.IRepoFavourites, idUser, id int) (*models.Favourites, error) {
+	list, err := repo.GetFavouriteById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.GetIdUser() != idUser {
+		return nil, models.ErrNotFound
+	}
+	return list, nil
+}
+
+// shareList gives the shared list without a slug a new one.
+func shareList(list *models.Favourites) {
+	if list.Shared() && list.GetSlug() == "" {
+		list.SetSlug(models.NewSlug())
+	}
+}
+
+// addListSerial puts the serial in the list with today's date and counts it.
+func addListSerial(ctx context.Context, tx interfaces.ITx, list *models.Favourites, idSerial int) error {
+	serialFav := &models.SerialsFavourites{
+		Sf_idSerial:    idSerial,
+		Sf_idFavourite: list.GetId(),
+		Sf_date:        time.Now().Format("2006-01-02"),
+	}
+	if tx.SerialsFavourites().CheckSerialInFavourite(ctx, serialFav) {
+		return ErrSerialInList
+	}
+	err := tx.SerialsFavourites().CreateSerialsFavourites(ctx, serialFav)
+	if err != nil {
+		return err
+	}
+	list.SetCntSerials(list.GetCntSerials() + 1)
+	return tx.Favourites().UpdateFavourite(ctx, list)
+}
+
+// removeListSerial takes the serial out of the list and uncounts it.
+func removeListSerial(ctx context.Context, tx interfaces.ITx, list *models.Favourites, idSerial int) error {
+	if !tx.SerialsFavourites().CheckSerialInFavourite(ctx, &models.SerialsFavourites{Sf_idSerial: idSerial, Sf_idFavourite: list.GetId()}) {
+		return models.ErrNotFound
+	}
+	err := tx.SerialsFavourites().DeleteSerialById(ctx, list.GetId(), idSerial)
+	if err != nil {
+		return err
+	}
+	list.SetCntSerials(max(list.GetCntSerials()-1, 0))
+	return tx.Favourites().UpdateFavourite(ctx, list)
+}
+
+// deleteListSerials takes all the serials out of the list.
+func deleteListSerials(ctx context.Context, tx interfaces.ITx, id int) error {
+	serials, err := tx.SerialsFavourites().GetSerialsByFavouriteId(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, serial := range serials {
+		err = tx.SerialsFavourites().DeleteSerialsFavourites(ctx, serial.GetId())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
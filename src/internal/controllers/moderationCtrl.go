@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+type ModerationCtrl struct {
+	ReportsService     interfaces.IRepoCommentsReports
+	ModerationsService interfaces.IRepoModerations
+	UnitOfWork         interfaces.IUnitOfWork
+}
+
+func NewModerationCtrl(Rservice interfaces.IRepoCommentsReports, Mservice interfaces.IRepoModerations, uow interfaces.IUnitOfWork) *ModerationCtrl {
+	return &ModerationCtrl{ReportsService: Rservice, ModerationsService: Mservice, UnitOfWork: uow}
+}
+
+func (ctrl *ModerationCtrl) GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error) {
+	return ctrl.ModerationsService.GetModerationsPage(ctx, page)
+}
+
+// ReportComment files the complaint of the user about the comment. A user
+// cannot report their own comment or report a comment twice.
+func (ctrl *ModerationCtrl) ReportComment(ctx context.Context, idComment, idUser int, reason string) error {
+	report := &models.CommentsReports{
+		Cr_idComment: idComment,
+		Cr_idUser:    idUser,
+		Cr_reason:    reason,
+		Cr_date:      time.Now().Format("2006-01-02"),
+		Cr_state:     models.ReportOpen,
+	}
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comment, err := tx.Comments().GetCommentById(ctx, idComment)
+		if err != nil {
+			return err
+		}
+		if comment.GetIdUser() == idUser {
+			return ErrOwnComment
+		}
+		_, err = tx.CommentsReports().GetCommentReportByIds(ctx, idComment, idUser)
+		if err == nil {
+			return ErrAlreadyReported
+		}
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		return tx.CommentsReports().CreateCommentReport(ctx, report)
+	})
+}
+
+// GetQueue returns the reported comments awaiting a decision with their open
+// reports, the comment reported first goes first.
+func (ctrl *ModerationCtrl) GetQueue(ctx context.Context) ([]*models.ModerationItem, error) {
+	var queue []*models.ModerationItem
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		reports, err := tx.CommentsReports().GetOpenCommentsReports(ctx)
+		if err != nil {
+			return err
+		}
+		comments := map[int]*models.Comments{}
+		for _, report := range reports {
+			if _, ok := comments[report.GetIdComment()]; ok {
+				continue
+			}
+			comment, err := tx.Comments().GetCommentById(ctx, report.GetIdComment())
+			if errors.Is(err, models.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			comments[comment.GetId()] = comment
+		}
+		queue = models.NewModerationQueue(comments, reports)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// Moderate carries out the decision of the moderator on the comment: hide
+// keeps the comment without its text, delete removes it with the replies to
+// it and dismiss leaves it as it is. The open reports on the comment are
+// closed and the decision is recorded in the same transaction.
+func (ctrl *ModerationCtrl) Moderate(ctx context.Context, idComment, idModerator int, action string) error {
+	if !models.ValidModerationAction(action) {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		comment, err := tx.Comments().GetCommentById(ctx, idComment)
+		if err != nil {
+			return err
+		}
+		moderation := &models.Moderations{
+			Md_idComment:   idComment,
+			Md_idModerator: idModerator,
+			Md_action:      action,
+			Md_text:        comment.GetText(),
+			Md_date:        time.Now().Format(models.ModerationTimeLayout),
+		}
+
+		switch action {
+		case models.ModerationHide:
+			comment.SetHidden(true)
+			err = tx.Comments().UpdateComment(ctx, comment)
+			if err != nil {
+				return err
+			}
+			err = closeCommentReports(ctx, tx, idComment)
+		case models.ModerationDelete:
+			err = deleteCommentThread(ctx, tx, comment)
+		case models.ModerationDismiss:
+			err = closeCommentReports(ctx, tx, idComment)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Moderations().CreateModeration(ctx, moderation)
+	})
+}
+
+// closeCommentReports closes the open reports on the comment.
+func closeCommentReports(ctx context.Context, tx interfaces.ITx, idComment int) error {
+	reports, err := tx.CommentsReports().GetCommentsReportsByCommentId(ctx, idComment)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if report.GetState() != models.ReportOpen {
+			continue
+		}
+		report.SetState(models.ReportClosed)
+		err = tx.CommentsReports().UpdateCommentReport(ctx, report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
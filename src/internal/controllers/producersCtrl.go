@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type ProducersCtrl struct {
@@ -13,22 +14,22 @@ func NewProducersCtrl(service interfaces.IRepoProducers) *ProducersCtrl {
 	return &ProducersCtrl{ProducersService: service}
 }
 
-func (ctrl *ProducersCtrl) GetProducers() ([]*models.Producers, error) {
-	return ctrl.ProducersService.GetProducers()
+func (ctrl *ProducersCtrl) GetProducers(ctx context.Context) ([]*models.Producers, error) {
+	return ctrl.ProducersService.GetProducers(ctx)
 }
 
-func (ctrl *ProducersCtrl) GetProducerById(id int) (*models.Producers, error) {
-	return ctrl.ProducersService.GetProducerById(id)
+func (ctrl *ProducersCtrl) GetProducerById(ctx context.Context, id int) (*models.Producers, error) {
+	return ctrl.ProducersService.GetProducerById(ctx, id)
 }
 
-func (ctrl *ProducersCtrl) CreateProducer(producer *models.Producers) error {
-	return ctrl.ProducersService.CreateProducer(producer)
+func (ctrl *ProducersCtrl) CreateProducer(ctx context.Context, producer *models.Producers) error {
+	return ctrl.ProducersService.CreateProducer(ctx, producer)
 }
 
-func (ctrl *ProducersCtrl) UpdateProducer(producer *models.Producers) error {
-	return ctrl.ProducersService.UpdateProducer(producer)
+func (ctrl *ProducersCtrl) UpdateProducer(ctx context.Context, producer *models.Producers) error {
+	return ctrl.ProducersService.UpdateProducer(ctx, producer)
 }
 
-func (ctrl *ProducersCtrl) DeleteProducer(id int) error {
-	return ctrl.ProducersService.DeleteProducer(id)
+func (ctrl *ProducersCtrl) DeleteProducer(ctx context.Context, id int) error {
+	return ctrl.ProducersService.DeleteProducer(ctx, id)
 }
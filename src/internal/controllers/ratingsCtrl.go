@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+	"errors"
+)
+
+type RatingsCtrl struct {
+	RatingsService interfaces.IRepoRatings
+	UnitOfWork     interfaces.IUnitOfWork
+}
+
+func NewRatingsCtrl(service interfaces.IRepoRatings, uow interfaces.IUnitOfWork) *RatingsCtrl {
+	return &RatingsCtrl{RatingsService: service, UnitOfWork: uow}
+}
+
+func (ctrl *RatingsCtrl) GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error) {
+	return ctrl.RatingsService.GetRatingBySerialIdUserId(ctx, idSerial, idUser)
+}
+
+// RateSerial sets the score the user gives the serial, replacing the previous
+// one, and updates the rating of the serial, all in one transaction.
+func (ctrl *RatingsCtrl) RateSerial(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		_, err := tx.Serials().GetSerialById(ctx, rating.GetIdSerial())
+		if err != nil {
+			return err
+		}
+		prev, err := tx.Ratings().GetRatingBySerialIdUserId(ctx, rating.GetIdSerial(), rating.GetIdUser())
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			err = tx.Ratings().CreateRating(ctx, rating)
+		case err == nil:
+			rating.SetId(prev.GetId())
+			err = tx.Ratings().UpdateRating(ctx, rating)
+		}
+		if err != nil {
+			return err
+		}
+		return updateSerialRating(ctx, tx, rating.GetIdSerial())
+	})
+}
+
+// DeleteRating removes the score the user gave the serial and updates the
+// rating of the serial, all in one transaction.
+func (ctrl *RatingsCtrl) DeleteRating(ctx context.Context, idSerial, idUser int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		rating, err := tx.Ratings().GetRatingBySerialIdUserId(ctx, idSerial, idUser)
+		if err != nil {
+			return err
+		}
+		err = tx.Ratings().DeleteRating(ctx, rating.GetId())
+		if err != nil {
+			return err
+		}
+		return updateSerialRating(ctx, tx, idSerial)
+	})
+}
+
+// updateSerialRating sets the rating of the serial to the mean of its scores
+// and the number of votes to the number of them. The serial is locked before
+// the scores are summed, so of two concurrent votes the later one sums both.
+func updateSerialRating(ctx context.Context, tx interfaces.ITx, idSerial int) error {
+	err := tx.Serials().LockSerial(ctx, idSerial)
+	if err != nil {
+		return err
+	}
+	sum, votes, err := tx.Ratings().SumRatings(ctx, idSerial)
+	if err != nil {
+		return err
+	}
+	serial, err := tx.Serials().GetSerialById(ctx, idSerial)
+	if err != nil {
+		return err
+	}
+	serial.SetRating(models.MeanRating(sum, votes))
+	serial.SetVotes(votes)
+	return tx.Serials().UpdateSerial(ctx, serial)
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+	"errors"
+)
+
+// RecommendationsCtrl recommends serials to the users, see
+// models.Recommender. The offline ranking job stores the recommendations of
+// every user, the users without stored ones get them ranked at request time.
+type RecommendationsCtrl struct {
+	UnitOfWork interfaces.IUnitOfWork
+}
+
+func NewRecommendationsCtrl(uow interfaces.IUnitOfWork) *RecommendationsCtrl {
+	return &RecommendationsCtrl{UnitOfWork: uow}
+}
+
+// GetRecommendations returns at most n serials for the user, n is from 1 to
+// models.MaxRecommendations. The stored recommendations skip the serials the
+// user viewed, saved, commented or rated since they were ranked and the
+// deleted serials, so fewer than n may be left.
+func (ctrl *RecommendationsCtrl) GetRecommendations(ctx context.Context, idUser, n int) ([]*models.Recommendation, error) {
+	if n < 1 || n > models.MaxRecommendations {
+		return nil, models.ErrInvalidModel
+	}
+	recs := []*models.Recommendation{}
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		_, err := tx.Users().GetUserById(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		stored, err := tx.Recommendations().GetRecommendationsByUserId(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		if len(stored) == 0 {
+			recommender, err := newRecommender(ctx, tx)
+			if err != nil {
+				return err
+			}
+			recs = recommender.Recommend(idUser, n)
+			return nil
+		}
+
+		seen, err := userSerials(ctx, tx, idUser)
+		if err != nil {
+			return err
+		}
+		for _, rec := range stored {
+			if len(recs) == n {
+				break
+			}
+			if seen[rec.GetIdSerial()] {
+				continue
+			}
+			serial, err := tx.Serials().GetSerialById(ctx, rec.GetIdSerial())
+			if errors.Is(err, models.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			recs = append(recs, &models.Recommendation{Serial: serial, Score: rec.GetScore(), Source: rec.GetSource()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// RankAll ranks n serials for every user and stores them in place of their
+// previous recommendations, n is from 1 to models.MaxRecommendations. It
+// returns the number of the users ranked.
+func (ctrl *RecommendationsCtrl) RankAll(ctx context.Context, n int) (int, error) {
+	if n < 1 || n > models.MaxRecommendations {
+		return 0, models.ErrInvalidModel
+	}
+	var recommender *models.Recommender
+	var users []*models.Users
+	err := ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		var err error
+		recommender, err = newRecommender(ctx, tx)
+		if err != nil {
+			return err
+		}
+		users, err = tx.Users().GetUsers(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, user := range users {
+		recs := recommender.Recommend(user.GetId(), n)
+		err = ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+			err := tx.Recommendations().DeleteRecommendationsByUserId(ctx, user.GetId())
+			if err != nil {
+				return err
+			}
+			for i, rec := range recs {
+				err = tx.Recommendations().CreateRecommendation(ctx, &models.Recommendations{
+					Rc_idUser:   user.GetId(),
+					Rc_idSerial: rec.Serial.GetId(),
+					Rc_score:    rec.Score,
+					Rc_source:   rec.Source,
+					Rc_position: i + 1,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(users), nil
+}
+
+// newRecommender returns the recommender with the interactions of all the
+// users.
+func newRecommender(ctx context.Context, tx interfaces.ITx) (*models.Recommender, error) {
+	serials, err := tx.Serials().GetSerials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	serialsActors, err := tx.SerialsActors().GetSerialsActors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recommender := models.NewRecommender(serials, serialsActors)
+
+	history, err := tx.SerialsUsers().GetSerialsUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, su := range history {
+		recommender.AddViewed(su.GetIdUser(), su.GetIdSerial())
+	}
+
+	lists, err := tx.Favourites().GetFavourites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[int]int, len(lists))
+	for _, list := range lists {
+		owners[list.GetId()] = list.GetIdUser()
+	}
+	saved, err := tx.SerialsFavourites().GetSerialsFavourites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sf := range saved {
+		if owner := owners[sf.GetIdFavourite()]; owner > 0 {
+			recommender.AddSaved(owner, sf.GetIdSerial())
+		}
+	}
+
+	comments, err := tx.Comments().GetComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		recommender.AddComment(comment.GetIdUser(), comment.GetIdSerial())
+	}
+
+	ratings, err := tx.Ratings().GetRatings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		recommender.AddRating(rating.GetIdUser(), rating.GetIdSerial(), rating.GetScore())
+	}
+	return recommender, nil
+}
+
+// userSerials returns the ids of the serials the user viewed, saved,
+// commented or rated.
+func userSerials(ctx context.Context, tx interfaces.ITx, idUser int) (map[int]bool, error) {
+	seen := map[int]bool{}
+	history, err := tx.SerialsUsers().GetSerialsByUserId(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, su := range history {
+		seen[su.GetIdSerial()] = true
+	}
+	lists, err := tx.Favourites().GetFavouritesByUserId(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		saved, err := tx.SerialsFavourites().GetSerialsByFavouriteId(ctx, list.GetId())
+		if err != nil {
+			return nil, err
+		}
+		for _, sf := range saved {
+			seen[sf.GetIdSerial()] = true
+		}
+	}
+	comments, err := tx.Comments().GetCommentsByUserId(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		seen[comment.GetIdSerial()] = true
+	}
+	ratings, err := tx.Ratings().GetRatingsByUserId(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		seen[rating.GetIdSerial()] = true
+	}
+	return seen, nil
+}
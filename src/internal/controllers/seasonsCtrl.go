@@ -3,36 +3,162 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"sort"
 )
 
 type SeasonsCtrl struct {
-	SeasonsService interfaces.IRepoSeasons
+	SeasonsService  interfaces.IRepoSeasons
+	SerialsService  interfaces.IRepoSerials
+	EpisodesService interfaces.IRepoEpisodes
+	UnitOfWork      interfaces.IUnitOfWork
 }
 
-func NewSeasonsCtrl(service interfaces.IRepoSeasons) *SeasonsCtrl {
-	return &SeasonsCtrl{SeasonsService: service}
+func NewSeasonsCtrl(SSservice interfaces.IRepoSeasons, Sservice interfaces.IRepoSerials, Eservice interfaces.IRepoEpisodes, uow interfaces.IUnitOfWork) *SeasonsCtrl {
+	return &SeasonsCtrl{SeasonsService: SSservice, SerialsService: Sservice, EpisodesService: Eservice, UnitOfWork: uow}
 }
 
-func (ctrl *SeasonsCtrl) GetSeasons() ([]*models.Seasons, error) {
-	return ctrl.SeasonsService.GetSeasons()
+// inTx runs fn with the controller over the repositories of one transaction,
+// so the seasons, their episodes and the serial synced with them change
+// together or not at all.
+func (ctrl *SeasonsCtrl) inTx(ctx context.Context, fn func(ctx context.Context, txCtrl *SeasonsCtrl) error) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		return fn(ctx, NewSeasonsCtrl(tx.Seasons(), tx.Serials(), tx.Episodes(), nil))
+	})
 }
 
-func (ctrl *SeasonsCtrl) GetSeasonById(id int) (*models.Seasons, error) {
-	return ctrl.SeasonsService.GetSeasonById(id)
+func (ctrl *SeasonsCtrl) GetSeasons(ctx context.Context) ([]*models.Seasons, error) {
+	return ctrl.SeasonsService.GetSeasons(ctx)
 }
 
-func (ctrl *SeasonsCtrl) GetSeasonsBySerialId(id int) ([]*models.Seasons, error) {
-	return ctrl.SeasonsService.GetSeasonsBySerialId(id)
+func (ctrl *SeasonsCtrl) GetSeasonById(ctx context.Context, id int) (*models.Seasons, error) {
+	return ctrl.SeasonsService.GetSeasonById(ctx, id)
 }
 
-func (ctrl *SeasonsCtrl) CreateSeason(season *models.Seasons) error {
-	return ctrl.SeasonsService.CreateSeason(season)
+// GetSeasonsBySerialId returns the seasons of the serial ordered by their number.
+func (ctrl *SeasonsCtrl) GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error) {
+	seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(seasons, func(i, j int) bool {
+		return seasons[i].GetNum() < seasons[j].GetNum()
+	})
+	return seasons, nil
 }
 
-func (ctrl *SeasonsCtrl) UpdateSeason(season *models.Seasons) error {
-	return ctrl.SeasonsService.UpdateSeason(season)
+func (ctrl *SeasonsCtrl) CreateSeason(ctx context.Context, season *models.Seasons) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *SeasonsCtrl) error {
+		err := txCtrl.SeasonsService.CreateSeason(ctx, season)
+		if err != nil {
+			return err
+		}
+		return txCtrl.SyncSerial(ctx, season.GetIdSerial())
+	})
 }
 
-func (ctrl *SeasonsCtrl) DeleteSeason(id int) error {
-	return ctrl.SeasonsService.DeleteSeason(id)
+func (ctrl *SeasonsCtrl) UpdateSeason(ctx context.Context, season *models.Seasons) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *SeasonsCtrl) error {
+		prev, err := txCtrl.SeasonsService.GetSeasonById(ctx, season.GetId())
+		if err != nil {
+			return err
+		}
+		err = txCtrl.SeasonsService.UpdateSeason(ctx, season)
+		if err != nil {
+			return err
+		}
+		if prev.GetIdSerial() != season.GetIdSerial() {
+			err = txCtrl.SyncSerial(ctx, prev.GetIdSerial())
+			if err != nil {
+				return err
+			}
+		}
+		return txCtrl.SyncSerial(ctx, season.GetIdSerial())
+	})
+}
+
+// DeleteSeason removes the season together with its episodes in one
+// transaction with the sync of the serial.
+func (ctrl *SeasonsCtrl) DeleteSeason(ctx context.Context, id int) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *SeasonsCtrl) error {
+		season, err := txCtrl.SeasonsService.GetSeasonById(ctx, id)
+		if err != nil {
+			return err
+		}
+		episodes, err := txCtrl.EpisodesService.GetEpisodesBySeasonId(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, episode := range episodes {
+			err = txCtrl.EpisodesService.DeleteEpisode(ctx, episode.GetId())
+			if err != nil {
+				return err
+			}
+		}
+		err = txCtrl.SeasonsService.DeleteSeason(ctx, id)
+		if err != nil {
+			return err
+		}
+		return txCtrl.SyncSerial(ctx, season.GetIdSerial())
+	})
+}
+
+// ReorderSeasons renumbers the seasons of the serial in the given order.
+// order must contain every season id of the serial exactly once.
+func (ctrl *SeasonsCtrl) ReorderSeasons(ctx context.Context, idSerial int, order []int) error {
+	return ctrl.inTx(ctx, func(ctx context.Context, txCtrl *SeasonsCtrl) error {
+		seasons, err := txCtrl.SeasonsService.GetSeasonsBySerialId(ctx, idSerial)
+		if err != nil {
+			return err
+		}
+		byId := make(map[int]*models.Seasons, len(seasons))
+		for _, season := range seasons {
+			byId[season.GetId()] = season
+		}
+		if len(order) != len(byId) {
+			return ErrInvalidOrder
+		}
+		seen := make(map[int]bool, len(order))
+		for _, id := range order {
+			if byId[id] == nil || seen[id] {
+				return ErrInvalidOrder
+			}
+			seen[id] = true
+		}
+		for i, id := range order {
+			season := byId[id]
+			if season.GetNum() == i+1 {
+				continue
+			}
+			season.SetNum(i + 1)
+			err = txCtrl.SeasonsService.UpdateSeason(ctx, season)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SyncSerial stores the actual number of seasons in Serial.S_seasons
+// and the total duration of its episodes in Serial.S_duration.
+func (ctrl *SeasonsCtrl) SyncSerial(ctx context.Context, idSerial int) error {
+	serial, err := ctrl.SerialsService.GetSerialById(ctx, idSerial)
+	if err != nil {
+		return err
+	}
+	seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(ctx, idSerial)
+	if err != nil {
+		return err
+	}
+	prevSeasons, prevDuration := serial.GetSeasons(), serial.S_duration
+	serial.SetSeasons(len(seasons))
+	err = NewSerialsCtrl(ctrl.SerialsService, ctrl.SeasonsService, ctrl.EpisodesService).CalculateDuration(ctx, serial)
+	if err != nil {
+		return err
+	}
+	if serial.GetSeasons() == prevSeasons && serial.S_duration == prevDuration {
+		return nil
+	}
+	return ctrl.SerialsService.UpdateSerial(ctx, serial)
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type SerialsActorsCtrl struct {
@@ -13,30 +14,30 @@ func NewSerialsActorsCtrl(service interfaces.IRepoSerialsActors) *SerialsActorsC
 	return &SerialsActorsCtrl{SerialsActorsService: service}
 }
 
-func (ctrl *SerialsActorsCtrl) GetSerialsActors() ([]*models.SerialsActors, error) {
-	return ctrl.SerialsActorsService.GetSerialsActors()
+func (ctrl *SerialsActorsCtrl) GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error) {
+	return ctrl.SerialsActorsService.GetSerialsActors(ctx)
 }
 
-func (ctrl *SerialsActorsCtrl) GetSerialsByActorId(id int) ([]*models.SerialsActors, error) {
-	return ctrl.SerialsActorsService.GetSerialsByActorId(id)
+func (ctrl *SerialsActorsCtrl) GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	return ctrl.SerialsActorsService.GetSerialsByActorId(ctx, id)
 }
 
-func (ctrl *SerialsActorsCtrl) GetActorsBySerialId(id int) ([]*models.SerialsActors, error) {
-	return ctrl.SerialsActorsService.GetActorsBySerialId(id)
+func (ctrl *SerialsActorsCtrl) GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	return ctrl.SerialsActorsService.GetActorsBySerialId(ctx, id)
 }
 
-func (ctrl *SerialsActorsCtrl) GetSerialsActorsById(id int) (*models.SerialsActors, error) {
-	return ctrl.SerialsActorsService.GetSerialsActorsById(id)
+func (ctrl *SerialsActorsCtrl) GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error) {
+	return ctrl.SerialsActorsService.GetSerialsActorsById(ctx, id)
 }
 
-func (ctrl *SerialsActorsCtrl) CreateSerialsActors(serialActor *models.SerialsActors) error {
-	return ctrl.SerialsActorsService.CreateSerialsActors(serialActor)
+func (ctrl *SerialsActorsCtrl) CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
+	return ctrl.SerialsActorsService.CreateSerialsActors(ctx, serialActor)
 }
 
-func (ctrl *SerialsActorsCtrl) UpdateSerialsActors(serialActor *models.SerialsActors) error {
-	return ctrl.SerialsActorsService.UpdateSerialsActors(serialActor)
+func (ctrl *SerialsActorsCtrl) UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
+	return ctrl.SerialsActorsService.UpdateSerialsActors(ctx, serialActor)
 }
 
-func (ctrl *SerialsActorsCtrl) DeleteSerialsActors(id int) error {
-	return ctrl.SerialsActorsService.DeleteSerialsActors(id)
+func (ctrl *SerialsActorsCtrl) DeleteSerialsActors(ctx context.Context, id int) error {
+	return ctrl.SerialsActorsService.DeleteSerialsActors(ctx, id)
 }
@@ -3,49 +3,105 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"time"
 )
 
 type SerialsCtrl struct {
-	SerialsService interfaces.IRepoSerials
-	SeasonsService interfaces.IRepoSeasons
+	SerialsService  interfaces.IRepoSerials
+	SeasonsService  interfaces.IRepoSeasons
+	EpisodesService interfaces.IRepoEpisodes
 }
 
-func NewSerialsCtrl(service interfaces.IRepoSerials) *SerialsCtrl {
-	return &SerialsCtrl{SerialsService: service}
+func NewSerialsCtrl(Sservice interfaces.IRepoSerials, SSservice interfaces.IRepoSeasons, Eservice interfaces.IRepoEpisodes) *SerialsCtrl {
+	return &SerialsCtrl{SerialsService: Sservice, SeasonsService: SSservice, EpisodesService: Eservice}
 }
 
-func (ctrl *SerialsCtrl) GetSerials() ([]*models.Serial, error) {
-	return ctrl.SerialsService.GetSerials()
+func (ctrl *SerialsCtrl) GetSerials(ctx context.Context) ([]*models.Serial, error) {
+	return ctrl.SerialsService.GetSerials(ctx)
 }
 
-func (ctrl *SerialsCtrl) GetSerialById(id int) (*models.Serial, error) {
-	return ctrl.SerialsService.GetSerialById(id)
+func (ctrl *SerialsCtrl) GetSerialById(ctx context.Context, id int) (*models.Serial, error) {
+	return ctrl.SerialsService.GetSerialById(ctx, id)
 }
 
-func (ctrl *SerialsCtrl) CreateSerial(serial *models.Serial) error {
-	err := ctrl.SerialsService.CalculateDuration(serial)
+func (ctrl *SerialsCtrl) CreateSerial(ctx context.Context, serial *models.Serial) error {
+	err := ctrl.CalculateDuration(ctx, serial)
 	if err != nil {
 		return err
 	}
-	return ctrl.SerialsService.CreateSerial(serial)
+	return ctrl.SerialsService.CreateSerial(ctx, serial)
 }
 
-func (ctrl *SerialsCtrl) UpdateSerial(serial *models.Serial) error {
-	return ctrl.SerialsService.UpdateSerial(serial)
+func (ctrl *SerialsCtrl) UpdateSerial(ctx context.Context, serial *models.Serial) error {
+	err := ctrl.CalculateDuration(ctx, serial)
+	if err != nil {
+		return err
+	}
+	return ctrl.SerialsService.UpdateSerial(ctx, serial)
+}
+
+func (ctrl *SerialsCtrl) DeleteSerial(ctx context.Context, id int) error {
+	return ctrl.SerialsService.DeleteSerial(ctx, id)
+}
+
+func (ctrl *SerialsCtrl) GetSerialByTitle(ctx context.Context, title string) ([]*models.Serial, error) {
+	return ctrl.SerialsService.GetSerialsByTitle(ctx, title)
 }
 
-func (ctrl *SerialsCtrl) DeleteSerial(id int) error {
-	return ctrl.SerialsService.DeleteSerial(id)
+// SearchSerials returns the page of the serials matching the query in the order
+// it sets and the number of all the matching serials.
+func (ctrl *SerialsCtrl) SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error) {
+	return ctrl.SerialsService.SearchSerials(ctx, query, page)
 }
 
-func (ctrl *SerialsCtrl) GetSerialByTitle(title string) ([]*models.Serial, error) {
-	return ctrl.SerialsService.GetSerialsByTitle(title)
+// FullTextSearch returns the page of the serials found by the words of the text
+// in their names, descriptions and cast, best matches first.
+func (ctrl *SerialsCtrl) FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error) {
+	return ctrl.SerialsService.FullTextSearch(ctx, text, page)
 }
 
-func (ctrl *SerialsCtrl) CountSeasons(id int) (int, error) {
-	seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(id)
+// Suggest returns the names of the serials, actors and producers completing
+// the text typed so far, best matches first.
+func (ctrl *SerialsCtrl) Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error) {
+	return ctrl.SerialsService.Suggest(ctx, query)
+}
+
+func (ctrl *SerialsCtrl) GetSerialsPage(ctx context.Context, page models.Page) ([]*models.Serial, int, error) {
+	return ctrl.SerialsService.SearchSerials(ctx, &models.SerialsQuery{}, page)
+}
+
+func (ctrl *SerialsCtrl) CountSeasons(ctx context.Context, id int) (int, error) {
+	seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(ctx, id)
 	if err != nil {
 		return 0, err
 	}
 	return len(seasons), nil
 }
+
+// CalculateDuration sets S_duration to the sum of the durations of all episodes
+// of all seasons of the serial, see models.DurationFormat.
+func (ctrl *SerialsCtrl) CalculateDuration(ctx context.Context, serial *models.Serial) error {
+	var total time.Duration
+	if serial.GetId() != 0 {
+		seasons, err := ctrl.SeasonsService.GetSeasonsBySerialId(ctx, serial.GetId())
+		if err != nil {
+			return err
+		}
+		for _, season := range seasons {
+			episodes, err := ctrl.EpisodesService.GetEpisodesBySeasonId(ctx, season.GetId())
+			if err != nil {
+				return err
+			}
+			for _, episode := range episodes {
+				d, err := models.ParseDuration(episode.GetDuration())
+				if err != nil {
+					return err
+				}
+				total += d
+			}
+		}
+	}
+	serial.S_duration = models.FormatDuration(total)
+	return nil
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type SerialsFavouritesCtrl struct {
@@ -13,38 +14,38 @@ func NewSerialsFavouritesCtrl(service interfaces.IRepoSerialsFavourites) *Serial
 	return &SerialsFavouritesCtrl{SerialsFavouritesService: service}
 }
 
-func (ctrl *SerialsFavouritesCtrl) GetSerialsFavourites() ([]*models.SerialsFavourites, error) {
-	return ctrl.SerialsFavouritesService.GetSerialsFavourites()
+func (ctrl *SerialsFavouritesCtrl) GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error) {
+	return ctrl.SerialsFavouritesService.GetSerialsFavourites(ctx)
 }
 
-func (ctrl *SerialsFavouritesCtrl) GetSerialsByFavouriteId(id int) ([]*models.SerialsFavourites, error) {
-	return ctrl.SerialsFavouritesService.GetSerialsByFavouriteId(id)
+func (ctrl *SerialsFavouritesCtrl) GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	return ctrl.SerialsFavouritesService.GetSerialsByFavouriteId(ctx, id)
 }
 
-func (ctrl *SerialsFavouritesCtrl) GetFavouritesBySerialId(id int) ([]*models.SerialsFavourites, error) {
-	return ctrl.SerialsFavouritesService.GetFavouritesBySerialId(id)
+func (ctrl *SerialsFavouritesCtrl) GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	return ctrl.SerialsFavouritesService.GetFavouritesBySerialId(ctx, id)
 }
 
-func (ctrl *SerialsFavouritesCtrl) GetSerialsFavouritesById(id int) (*models.SerialsFavourites, error) {
-	return ctrl.SerialsFavouritesService.GetSerialsFavouritesById(id)
+func (ctrl *SerialsFavouritesCtrl) GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error) {
+	return ctrl.SerialsFavouritesService.GetSerialsFavouritesById(ctx, id)
 }
 
-func (ctrl *SerialsFavouritesCtrl) CreateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
-	return ctrl.SerialsFavouritesService.CreateSerialsFavourites(serialFavourite)
+func (ctrl *SerialsFavouritesCtrl) CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
+	return ctrl.SerialsFavouritesService.CreateSerialsFavourites(ctx, serialFavourite)
 }
 
-func (ctrl *SerialsFavouritesCtrl) UpdateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
-	return ctrl.SerialsFavouritesService.UpdateSerialsFavourites(serialFavourite)
+func (ctrl *SerialsFavouritesCtrl) UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
+	return ctrl.SerialsFavouritesService.UpdateSerialsFavourites(ctx, serialFavourite)
 }
 
-func (ctrl *SerialsFavouritesCtrl) CheckSerialInFavourite(serialFavourite *models.SerialsFavourites) bool {
-	return ctrl.SerialsFavouritesService.CheckSerialInFavourite(serialFavourite)
+func (ctrl *SerialsFavouritesCtrl) CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool {
+	return ctrl.SerialsFavouritesService.CheckSerialInFavourite(ctx, serialFavourite)
 }
 
-func (ctrl *SerialsFavouritesCtrl) DeleteSerialById(idfav, idserial int) error {
-	return ctrl.SerialsFavouritesService.DeleteSerialById(idfav, idserial)
+func (ctrl *SerialsFavouritesCtrl) DeleteSerialById(ctx context.Context, idfav, idserial int) error {
+	return ctrl.SerialsFavouritesService.DeleteSerialById(ctx, idfav, idserial)
 }
 
-func (ctrl *SerialsFavouritesCtrl) DeleteSerialsFavourites(id int) error {
-	return ctrl.SerialsFavouritesService.DeleteSerialsFavourites(id)
+func (ctrl *SerialsFavouritesCtrl) DeleteSerialsFavourites(ctx context.Context, id int) error {
+	return ctrl.SerialsFavouritesService.DeleteSerialsFavourites(ctx, id)
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type SerialsUsersCtrl struct {
@@ -13,34 +14,42 @@ func NewSerialsUsersCtrl(service interfaces.IRepoSerialsUsers) *SerialsUsersCtrl
 	return &SerialsUsersCtrl{SerialsUsersService: service}
 }
 
-func (ctrl *SerialsUsersCtrl) GetSerialsUsers() ([]*models.SerialsUsers, error) {
-	return ctrl.SerialsUsersService.GetSerialsUsers()
+func (ctrl *SerialsUsersCtrl) GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error) {
+	return ctrl.SerialsUsersService.GetSerialsUsers(ctx)
 }
 
-func (ctrl *SerialsUsersCtrl) GetSerialsByUserId(id int) ([]*models.SerialsUsers, error) {
-	return ctrl.SerialsUsersService.GetSerialsByUserId(id)
+func (ctrl *SerialsUsersCtrl) GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error) {
+	return ctrl.SerialsUsersService.GetSerialsUsersPage(ctx, page)
 }
 
-func (ctrl *SerialsUsersCtrl) GetUsersBySerialId(id int) ([]*models.SerialsUsers, error) {
-	return ctrl.SerialsUsersService.GetUsersBySerialId(id)
+func (ctrl *SerialsUsersCtrl) GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	return ctrl.SerialsUsersService.GetSerialsByUserId(ctx, id)
 }
 
-func (ctrl *SerialsUsersCtrl) GetSerialsUsersById(id int) (*models.SerialsUsers, error) {
-	return ctrl.SerialsUsersService.GetSerialsUsersById(id)
+func (ctrl *SerialsUsersCtrl) GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error) {
+	return ctrl.SerialsUsersService.GetSerialsByUserIdPage(ctx, id, page)
 }
 
-func (ctrl *SerialsUsersCtrl) GetSerialUserByIds(userId, serialId int) (*models.SerialsUsers, error) {
-	return ctrl.SerialsUsersService.GetSerialUserByIds(userId, serialId)
+func (ctrl *SerialsUsersCtrl) GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	return ctrl.SerialsUsersService.GetUsersBySerialId(ctx, id)
 }
 
-func (ctrl *SerialsUsersCtrl) CreateSerialsUsers(serialUser *models.SerialsUsers) error {
-	return ctrl.SerialsUsersService.CreateSerialsUsers(serialUser)
+func (ctrl *SerialsUsersCtrl) GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error) {
+	return ctrl.SerialsUsersService.GetSerialsUsersById(ctx, id)
 }
 
-func (ctrl *SerialsUsersCtrl) UpdateSerialsUsers(serialUser *models.SerialsUsers) error {
-	return ctrl.SerialsUsersService.UpdateSerialsUsers(serialUser)
+func (ctrl *SerialsUsersCtrl) GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error) {
+	return ctrl.SerialsUsersService.GetSerialUserByIds(ctx, serialId, userId)
 }
 
-func (ctrl *SerialsUsersCtrl) DeleteSerialsByUserId(id int) error {
-	return ctrl.SerialsUsersService.DeleteSerialsByUserId(id)
+func (ctrl *SerialsUsersCtrl) CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
+	return ctrl.SerialsUsersService.CreateSerialsUsers(ctx, serialUser)
+}
+
+func (ctrl *SerialsUsersCtrl) UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
+	return ctrl.SerialsUsersService.UpdateSerialsUsers(ctx, serialUser)
+}
+
+func (ctrl *SerialsUsersCtrl) DeleteSerialsByUserId(ctx context.Context, id int) error {
+	return ctrl.SerialsUsersService.DeleteSerialsByUserId(ctx, id)
 }
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"context"
+)
+
+// SimilarCtrl finds the serials similar to a serial, see models.SimilarIndex.
+// The index is built from all the serials and their actors and kept in the
+// cache, a serial missing from the cached index rebuilds it.
+type SimilarCtrl struct {
+	SerialsService       interfaces.IRepoSerials
+	SerialsActorsService interfaces.IRepoSerialsActors
+	Cache                *models.SimilarCache
+}
+
+func NewSimilarCtrl(serials interfaces.IRepoSerials, serialsActors interfaces.IRepoSerialsActors, cache *models.SimilarCache) *SimilarCtrl {
+	return &SimilarCtrl{SerialsService: serials, SerialsActorsService: serialsActors, Cache: cache}
+}
+
+// GetSimilar returns at most n serials most similar to the serial, n is from
+// 1 to models.MaxSimilar.
+func (ctrl *SimilarCtrl) GetSimilar(ctx context.Context, id, n int) ([]*models.SimilarSerial, error) {
+	if n < 1 || n > models.MaxSimilar {
+		return nil, models.ErrInvalidModel
+	}
+	index := ctrl.Cache.Get()
+	if index == nil || !index.Has(id) {
+		_, err := ctrl.SerialsService.GetSerialById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		serials, err := ctrl.SerialsService.GetSerials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		serialsActors, err := ctrl.SerialsActorsService.GetSerialsActors(ctx)
+		if err != nil {
+			return nil, err
+		}
+		index = models.NewSimilarIndex(serials, serialsActors)
+		ctrl.Cache.Set(index)
+	}
+	if !index.Has(id) {
+		return nil, models.ErrNotFound
+	}
+	return index.Similar(id, n), nil
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
 )
 
 type StatisticCtrl struct {
@@ -13,10 +14,70 @@ func NewStatisticCtrl(Stservice interfaces.IRepoStatistic) *StatisticCtrl {
 	return &StatisticCtrl{StatisticService: Stservice}
 }
 
-func (ctrl *StatisticCtrl) GetStatistic() (*models.Statistic, error) {
-	return ctrl.StatisticService.GetStatistic()
+func (ctrl *StatisticCtrl) GetStatistic(ctx context.Context) (*models.Statistic, error) {
+	return ctrl.StatisticService.GetStatistic(ctx)
 }
 
-func (ctrl *StatisticCtrl) UpdateStatistic(stat *models.Statistic) error {
-	return ctrl.StatisticService.UpdateStatistic(stat)
+func (ctrl *StatisticCtrl) UpdateStatistic(ctx context.Context, stat *models.Statistic) error {
+	return ctrl.StatisticService.UpdateStatistic(ctx, stat)
+}
+
+// AddUser counts the user by gender, role and age.
+func (ctrl *StatisticCtrl) AddUser(ctx context.Context, user *models.Users) error {
+	stat, err := ctrl.StatisticService.GetStatistic(ctx)
+	if err != nil {
+		return err
+	}
+	if user.U_gender == "мужской" {
+		stat.IncreaseGenderMale()
+	} else {
+		stat.IncreaseGenderFemale()
+	}
+	if user.U_role == "user" {
+		stat.IncreaseRoleUser()
+	} else {
+		stat.IncreaseRoleAdmin()
+	}
+	age := user.GetAge()
+	switch {
+	case age <= 18:
+		stat.IncreaseAge0_18()
+	case age <= 30:
+		stat.IncreaseAge19_30()
+	case age <= 50:
+		stat.IncreaseAge31_50()
+	case age > 50:
+		stat.IncreaseAge51_100()
+	}
+	return ctrl.StatisticService.UpdateStatistic(ctx, stat)
+}
+
+// RemoveUser undoes AddUser.
+func (ctrl *StatisticCtrl) RemoveUser(ctx context.Context, user *models.Users) error {
+	stat, err := ctrl.StatisticService.GetStatistic(ctx)
+	if err != nil {
+		return err
+	}
+	if user.U_gender == "мужской" {
+		stat.DecreaseGenderMale()
+	} else {
+		stat.DecreaseGenderFemale()
+	}
+	if user.U_role == "user" {
+		stat.DecreaseRoleUser()
+	} else {
+		stat.DecreaseRoleAdmin()
+	}
+	age := user.GetAge()
+	switch {
+	case age <= 18:
+		stat.DecreaseAge0_18()
+	case age <= 30:
+		stat.DecreaseAge19_30()
+	case age <= 50:
+		stat.DecreaseAge31_50()
+	case age > 50:
+		stat.DecreaseAge51_100()
+	}
+	return ctrl.StatisticService.UpdateStatistic(ctx, stat)
 }
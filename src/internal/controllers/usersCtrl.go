@@ -3,6 +3,8 @@ package controllers
 import (
 	"app/internal/interfaces"
 	"app/internal/models"
+	"context"
+	"errors"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -10,58 +12,185 @@ import (
 type UsersCtrl struct {
 	UsersService interfaces.IRepoUsers
 	FavService   interfaces.IRepoFavourites
+	UnitOfWork   interfaces.IUnitOfWork
 }
 
-func NewUsersCtrl(Uservice interfaces.IRepoUsers, Fservice interfaces.IRepoFavourites) *UsersCtrl {
-	return &UsersCtrl{UsersService: Uservice, FavService: Fservice}
+func NewUsersCtrl(Uservice interfaces.IRepoUsers, Fservice interfaces.IRepoFavourites, uow interfaces.IUnitOfWork) *UsersCtrl {
+	return &UsersCtrl{UsersService: Uservice, FavService: Fservice, UnitOfWork: uow}
 }
 
-func (ctrl *UsersCtrl) GetUsers() ([]*models.Users, error) {
-	return ctrl.UsersService.GetUsers()
+func (ctrl *UsersCtrl) GetUsers(ctx context.Context) ([]*models.Users, error) {
+	return ctrl.UsersService.GetUsers(ctx)
 }
 
-func (ctrl *UsersCtrl) GetUserById(id int) (*models.Users, error) {
-	return ctrl.UsersService.GetUserById(id)
+func (ctrl *UsersCtrl) GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error) {
+	return ctrl.UsersService.GetUsersPage(ctx, page)
 }
 
-func (ctrl *UsersCtrl) CreateUser(user *models.Users) error {
-	if ctrl.UsersService.CheckUser(user.U_login) {
-		return ErrUserExists
-	}
-	id, err := ctrl.FavService.CreateFavourite(&models.Favourites{F_cntSerials: 0})
-	if err != nil {
-		return err
-	}
-	user.SetIdFavourites(id)
-	err = ctrl.UsersService.CreateUser(user)
-	if err != nil {
-		ctrl.FavService.DeleteFavourite(id)
-		return err
-	}
-	return nil
+func (ctrl *UsersCtrl) GetUserById(ctx context.Context, id int) (*models.Users, error) {
+	return ctrl.UsersService.GetUserById(ctx, id)
 }
 
-func (ctrl *UsersCtrl) UpdateUser(user *models.Users) error {
-	return ctrl.UsersService.UpdateUser(user)
+// CreateUser creates the user with the empty standard lists, the first of
+// them is the default one, and counts it in the statistic, all in one
+// transaction.
+func (ctrl *UsersCtrl) CreateUser(ctx context.Context, user *models.Users) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		if tx.Users().CheckUser(ctx, user.U_login) {
+			return ErrUserExists
+		}
+		list := &models.Favourites{F_name: models.DefaultListNames[0], F_position: 1, F_visibility: models.ListPrivate}
+		id, err := tx.Favourites().CreateFavourite(ctx, list)
+		if err != nil {
+			return err
+		}
+		list.SetId(id)
+		user.SetIdFavourites(id)
+		err = tx.Users().CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		list.SetIdUser(user.GetId())
+		err = tx.Favourites().UpdateFavourite(ctx, list)
+		if err != nil {
+			return err
+		}
+		for i, name := range models.DefaultListNames[1:] {
+			_, err = tx.Favourites().CreateFavourite(ctx, &models.Favourites{F_idUser: user.GetId(), F_name: name, F_position: i + 2, F_visibility: models.ListPrivate})
+			if err != nil {
+				return err
+			}
+		}
+		return NewStatisticCtrl(tx.Statistic()).AddUser(ctx, user)
+	})
 }
 
-func (ctrl *UsersCtrl) DeleteUser(id int) error {
-	user, _ := ctrl.GetUserById(id)
-
-	err := ctrl.UsersService.DeleteUser(id)
-	if err != nil {
-		return err
-	}
+func (ctrl *UsersCtrl) UpdateUser(ctx context.Context, user *models.Users) error {
+	return ctrl.UsersService.UpdateUser(ctx, user)
+}
 
-	return ctrl.FavService.DeleteFavourite(user.GetIdFavourites())
+// DeleteUser deletes the user with its lists, comments and the replies to
+// them, votes, ratings, watched episodes and history, updates the ratings of
+// the serials and the votes of the comments it rated and removes it from the
+// statistic, all in one transaction.
+func (ctrl *UsersCtrl) DeleteUser(ctx context.Context, id int) error {
+	return ctrl.UnitOfWork.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+		user, err := tx.Users().GetUserById(ctx, id)
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		lists, err := tx.Favourites().GetFavouritesByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		idLists := []int{user.GetIdFavourites()}
+		for _, list := range lists {
+			if list.GetId() != user.GetIdFavourites() {
+				idLists = append(idLists, list.GetId())
+			}
+		}
+		for _, idList := range idLists {
+			err = deleteListSerials(ctx, tx, idList)
+			if err != nil {
+				return err
+			}
+		}
+
+		comments, err := tx.Comments().GetCommentsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			err = deleteCommentThread(ctx, tx, comment)
+			if err != nil {
+				return err
+			}
+		}
+
+		votes, err := tx.CommentsVotes().GetCommentsVotesByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, vote := range votes {
+			err = tx.CommentsVotes().DeleteCommentVote(ctx, vote.GetId())
+			if err != nil {
+				return err
+			}
+			err = updateCommentVotes(ctx, tx, vote.GetIdComment())
+			if errors.Is(err, models.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		reports, err := tx.CommentsReports().GetCommentsReportsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, report := range reports {
+			err = tx.CommentsReports().DeleteCommentReport(ctx, report.GetId())
+			if err != nil {
+				return err
+			}
+		}
+
+		ratings, err := tx.Ratings().GetRatingsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, rating := range ratings {
+			err = tx.Ratings().DeleteRating(ctx, rating.GetId())
+			if err != nil {
+				return err
+			}
+			err = updateSerialRating(ctx, tx, rating.GetIdSerial())
+			if err != nil && !errors.Is(err, models.ErrNotFound) {
+				return err
+			}
+		}
+
+		err = tx.EpisodesUsers().DeleteEpisodesByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.SerialsUsers().DeleteSerialsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.Recommendations().DeleteRecommendationsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.Comparisons().DeleteComparisonsByUserId(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.Users().DeleteUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, idList := range idLists {
+			err = tx.Favourites().DeleteFavourite(ctx, idList)
+			if err != nil {
+				return err
+			}
+		}
+		return NewStatisticCtrl(tx.Statistic()).RemoveUser(ctx, user)
+	})
 }
 
-func (ctrl *UsersCtrl) GetUserByLogin(login string) (*models.Users, error) {
-	return ctrl.UsersService.GetUserByLogin(login)
+func (ctrl *UsersCtrl) GetUserByLogin(ctx context.Context, login string) (*models.Users, error) {
+	return ctrl.UsersService.GetUserByLogin(ctx, login)
 }
 
-func (ctrl *UsersCtrl) AuthUser(login, password string) (*models.Users, error) {
-	user, err := ctrl.UsersService.GetUserByLogin(login)
+func (ctrl *UsersCtrl) AuthUser(ctx context.Context, login, password string) (*models.Users, error) {
+	user, err := ctrl.UsersService.GetUserByLogin(ctx, login)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
@@ -73,13 +202,13 @@ func (ctrl *UsersCtrl) AuthUser(login, password string) (*models.Users, error) {
 	return user, nil
 }
 
-func (ctrl *UsersCtrl) GrantAdmin(id int) error {
-	user, err := ctrl.UsersService.GetUserById(id)
+func (ctrl *UsersCtrl) GrantAdmin(ctx context.Context, id int) error {
+	user, err := ctrl.UsersService.GetUserById(ctx, id)
 	if err != nil {
 		return ErrUserNotFound
 	}
 
 	user.U_role = "admin"
 
-	return ctrl.UsersService.UpdateUser(user)
+	return ctrl.UsersService.UpdateUser(ctx, user)
 }
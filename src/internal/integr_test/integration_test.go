@@ -6,85 +6,74 @@ import (
 	"app/internal/models"
 	"app/internal/repositories"
 	"app/logger"
-	"log"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGetActors(t *testing.T) {
+// The tests run against the Postgres database from the application config,
+// ../../config/config.toml by default or the file named by APP_CONFIG.
+// They are skipped when the database is not available.
+func connect(t *testing.T) (*sqlx.DB, *logrus.Logger) {
+	path := os.Getenv("APP_CONFIG")
+	if path == "" {
+		path = filepath.Join("..", "..", "config", "config.toml")
+	}
 	cfg := config.Config{}
-	_, err := toml.DecodeFile("D:/BMSTU/PPO/src/config/config.toml", &cfg)
+	_, err := toml.DecodeFile(path, &cfg)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
-	log, err := logger.InitLog("D:/BMSTU/PPO/src/logger/log.txt")
+	log, err := logger.InitLog(filepath.Join(t.TempDir(), "log.txt"))
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
 	db, err := sqlx.Connect("postgres", cfg.Db_url)
 	if err != nil {
-		log.Fatal(err)
+		t.Skipf("postgres is not available: %v", err)
 	}
-	defer db.Close()
+	t.Cleanup(func() { db.Close() })
+	return db, log
+}
+
+func TestGetActors(t *testing.T) {
+	db, log := connect(t)
 
 	repo := repositories.NewActorsRepo(db, log)
 	actCtrl := controllers.NewActorsCtrl(repo)
 
-	actors, err := actCtrl.GetActors()
+	actors, err := actCtrl.GetActors(context.Background())
 
 	assert.NoError(t, err)
 	assert.NotNil(t, actors)
 }
 
 func TestGetCommentsById(t *testing.T) {
-	cfg := config.Config{}
-	_, err := toml.DecodeFile("D:/BMSTU/PPO/src/config/config.toml", &cfg)
-	if err != nil {
-		log.Fatal(err)
-	}
-	log, err := logger.InitLog("D:/BMSTU/PPO/src/logger/log.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	db, err := sqlx.Connect("postgres", cfg.Db_url)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+	db, log := connect(t)
 
 	repo := repositories.NewCommentsRepo(db, log)
-	comCtrl := controllers.NewCommentsCtrl(repo)
+	comCtrl := controllers.NewCommentsCtrl(repo, repositories.NewUnitOfWork(db, log), nil)
 
-	comment, err := comCtrl.GetCommentById(1)
+	comment, err := comCtrl.GetCommentById(context.Background(), 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, comment)
 }
 
 func TestCreateSerial(t *testing.T) {
-	cfg := config.Config{}
-	_, err := toml.DecodeFile("D:/BMSTU/PPO/src/config/config.toml", &cfg)
-	if err != nil {
-		log.Fatal(err)
-	}
-	log, err := logger.InitLog("D:/BMSTU/PPO/src/logger/log.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	db, err := sqlx.Connect("postgres", cfg.Db_url)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+	db, log := connect(t)
 
 	repo := repositories.NewSerialsRepo(db, log)
-	serCtrl := controllers.NewSerialsCtrl(repo)
+	serCtrl := controllers.NewSerialsCtrl(repo, repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
 
-	err = serCtrl.CreateSerial(&models.Serial{
+	err := serCtrl.CreateSerial(context.Background(), &models.Serial{
 		S_id:          100,
 		S_name:        "Test",
 		S_year:        2021,
@@ -102,49 +91,23 @@ func TestCreateSerial(t *testing.T) {
 }
 
 func TestUpdateSeasons(t *testing.T) {
-	cfg := config.Config{}
-	_, err := toml.DecodeFile("D:/BMSTU/PPO/src/config/config.toml", &cfg)
-	if err != nil {
-		log.Fatal(err)
-	}
-	log, err := logger.InitLog("D:/BMSTU/PPO/src/logger/log.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	db, err := sqlx.Connect("postgres", cfg.Db_url)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+	db, log := connect(t)
 
 	repo := repositories.NewSeasonsRepo(db, log)
-	ssCtrl := controllers.NewSeasonsCtrl(repo)
+	ssCtrl := controllers.NewSeasonsCtrl(repo, repositories.NewSerialsRepo(db, log), repositories.NewEpisodesRepo(db, log), repositories.NewUnitOfWork(db, log))
 
-	err = ssCtrl.UpdateSeason(&models.Seasons{Ss_id: 1, Ss_name: "Test", Ss_date: "2021-01-01", Ss_idSerial: 1, Ss_num: 1, Ss_cntEpisodes: 1})
+	err := ssCtrl.UpdateSeason(context.Background(), &models.Seasons{Ss_id: 1, Ss_name: "Test", Ss_date: "2021-01-01", Ss_idSerial: 1, Ss_num: 1, Ss_cntEpisodes: 1})
 
 	assert.NoError(t, err)
 }
 
 func TestDeleteEpisode(t *testing.T) {
-	cfg := config.Config{}
-	_, err := toml.DecodeFile("D:/BMSTU/PPO/src/config/config.toml", &cfg)
-	if err != nil {
-		log.Fatal(err)
-	}
-	log, err := logger.InitLog("D:/BMSTU/PPO/src/logger/log.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	db, err := sqlx.Connect("postgres", cfg.Db_url)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+	db, log := connect(t)
 
 	repo := repositories.NewEpisodesRepo(db, log)
-	epCtrl := controllers.NewEpisodesCtrl(repo)
+	epCtrl := controllers.NewEpisodesCtrl(repo, repositories.NewSeasonsRepo(db, log), repositories.NewSerialsRepo(db, log), repositories.NewUnitOfWork(db, log))
 
-	err = epCtrl.DeleteEpisode(1)
+	err := epCtrl.DeleteEpisode(context.Background(), 1)
 
 	assert.NoError(t, err)
 }
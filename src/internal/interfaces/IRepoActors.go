@@ -2,13 +2,14 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoActors interface {
-	GetActors() ([]*models.Actors, error)
-	GetActorById(id int) (*models.Actors, error)
-	CreateActor(actor *models.Actors) error
-	UpdateActor(actor *models.Actors) error
-	DeleteActor(id int) error
-	CheckActor(actor *models.Actors) bool
+	GetActors(ctx context.Context) ([]*models.Actors, error)
+	GetActorById(ctx context.Context, id int) (*models.Actors, error)
+	CreateActor(ctx context.Context, actor *models.Actors) error
+	UpdateActor(ctx context.Context, actor *models.Actors) error
+	DeleteActor(ctx context.Context, id int) error
+	CheckActor(ctx context.Context, actor *models.Actors) bool
 }
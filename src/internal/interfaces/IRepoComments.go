@@ -2,16 +2,26 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoComments interface {
-	GetComments() ([]*models.Comments, error)
-	GetCommentById(id int) (*models.Comments, error)
-	GetCommentsBySerialId(idSerial int) ([]*models.Comments, error)
-	GetCommentsByUserId(idUser int) ([]*models.Comments, error)
-	GetCommentsBySerialIdUserId(idSerial, idUser int) (*models.Comments, error)
-	CreateComment(comment *models.Comments) error
-	UpdateComment(comment *models.Comments) error
-	DeleteComment(id int) error
-	CheckComment(idUser, idSerial int) bool
+	GetComments(ctx context.Context) ([]*models.Comments, error)
+	GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error)
+	GetCommentById(ctx context.Context, id int) (*models.Comments, error)
+	GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error)
+	// GetCommentsBySerialIdPage returns a page of the reviews of the serial,
+	// without the replies, in the order sort, see models.CommentsByNewest.
+	GetCommentsBySerialIdPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.Comments, int, error)
+	// GetRepliesBySerialId returns the replies to the comments of the serial.
+	GetRepliesBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error)
+	GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error)
+	// GetCommentsBySerialIdUserId returns the review of the serial by the user.
+	GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error)
+	CreateComment(ctx context.Context, comment *models.Comments) error
+	UpdateComment(ctx context.Context, comment *models.Comments) error
+	LockComment(ctx context.Context, id int) error
+	DeleteComment(ctx context.Context, id int) error
+	// CheckComment reports whether the user has reviewed the serial.
+	CheckComment(ctx context.Context, idUser, idSerial int) bool
 }
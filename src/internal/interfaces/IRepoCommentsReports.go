@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+type IRepoCommentsReports interface {
+	GetCommentsReports(ctx context.Context) ([]*models.CommentsReports, error)
+	GetCommentReportById(ctx context.Context, id int) (*models.CommentsReports, error)
+	GetCommentReportByIds(ctx context.Context, idComment, idUser int) (*models.CommentsReports, error)
+	GetCommentsReportsByUserId(ctx context.Context, idUser int) ([]*models.CommentsReports, error)
+	GetCommentsReportsByCommentId(ctx context.Context, idComment int) ([]*models.CommentsReports, error)
+	// GetOpenCommentsReports returns the open reports of all the comments,
+	// oldest first.
+	GetOpenCommentsReports(ctx context.Context) ([]*models.CommentsReports, error)
+	CreateCommentReport(ctx context.Context, report *models.CommentsReports) error
+	UpdateCommentReport(ctx context.Context, report *models.CommentsReports) error
+	DeleteCommentReport(ctx context.Context, id int) error
+	DeleteCommentReportsByCommentId(ctx context.Context, idComment int) error
+}
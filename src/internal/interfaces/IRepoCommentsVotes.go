@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+type IRepoCommentsVotes interface {
+	GetCommentsVotes(ctx context.Context) ([]*models.CommentsVotes, error)
+	GetCommentVoteById(ctx context.Context, id int) (*models.CommentsVotes, error)
+	GetCommentVoteByIds(ctx context.Context, idComment, idUser int) (*models.CommentsVotes, error)
+	GetCommentsVotesByUserId(ctx context.Context, idUser int) ([]*models.CommentsVotes, error)
+	// CountCommentVotes returns the numbers of the upvotes and the downvotes
+	// of the comment.
+	CountCommentVotes(ctx context.Context, idComment int) (int, int, error)
+	CreateCommentVote(ctx context.Context, vote *models.CommentsVotes) error
+	UpdateCommentVote(ctx context.Context, vote *models.CommentsVotes) error
+	DeleteCommentVote(ctx context.Context, id int) error
+	DeleteCommentVotesByCommentId(ctx context.Context, idComment int) error
+}
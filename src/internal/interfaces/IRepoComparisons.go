@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+type IRepoComparisons interface {
+	GetComparisonById(ctx context.Context, id int) (*models.Comparisons, error)
+	GetComparisonBySlug(ctx context.Context, slug string) (*models.Comparisons, error)
+	GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.Comparisons, error)
+	CreateComparison(ctx context.Context, comparison *models.Comparisons) error
+	DeleteComparison(ctx context.Context, id int) error
+	DeleteComparisonsByUserId(ctx context.Context, idUser int) error
+}
@@ -2,13 +2,14 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoEpisodes interface {
-	GetEpisodes() ([]*models.Episodes, error)
-	GetEpisodeById(id int) (*models.Episodes, error)
-	GetEpisodesBySeasonId(id int) ([]*models.Episodes, error)
-	CreateEpisode(episode *models.Episodes) error
-	UpdateEpisode(episode *models.Episodes) error
-	DeleteEpisode(id int) error
+	GetEpisodes(ctx context.Context) ([]*models.Episodes, error)
+	GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error)
+	GetEpisodesBySeasonId(ctx context.Context, id int) ([]*models.Episodes, error)
+	CreateEpisode(ctx context.Context, episode *models.Episodes) error
+	UpdateEpisode(ctx context.Context, episode *models.Episodes) error
+	DeleteEpisode(ctx context.Context, id int) error
 }
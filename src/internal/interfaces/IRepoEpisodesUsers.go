@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+type IRepoEpisodesUsers interface {
+	GetEpisodesUsers(ctx context.Context) ([]*models.EpisodesUsers, error)
+	GetEpisodesUsersById(ctx context.Context, id int) (*models.EpisodesUsers, error)
+	GetEpisodesBySerialIdUserId(ctx context.Context, serialId, userId int) ([]*models.EpisodesUsers, error)
+	GetEpisodeUserByIds(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error)
+	CreateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error
+	UpdateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error
+	DeleteEpisodesUsers(ctx context.Context, id int) error
+	DeleteEpisodesByUserId(ctx context.Context, id int) error
+}
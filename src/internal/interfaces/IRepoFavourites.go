@@ -2,12 +2,15 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoFavourites interface {
-	GetFavourites() ([]*models.Favourites, error)
-	GetFavouriteById(id int) (*models.Favourites, error)
-	CreateFavourite(favourite *models.Favourites) (int, error)
-	UpdateFavourite(favourite *models.Favourites) error
-	DeleteFavourite(id int) error
+	GetFavourites(ctx context.Context) ([]*models.Favourites, error)
+	GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error)
+	GetFavouritesByUserId(ctx context.Context, idUser int) ([]*models.Favourites, error)
+	GetFavouriteBySlug(ctx context.Context, slug string) (*models.Favourites, error)
+	CreateFavourite(ctx context.Context, favourite *models.Favourites) (int, error)
+	UpdateFavourite(ctx context.Context, favourite *models.Favourites) error
+	DeleteFavourite(ctx context.Context, id int) error
 }
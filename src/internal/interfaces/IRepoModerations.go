@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+// IRepoModerations is the log of the decisions of the moderators, the
+// decisions are never changed.
+type IRepoModerations interface {
+	GetModerations(ctx context.Context) ([]*models.Moderations, error)
+	// GetModerationsPage returns a page of the decisions, newest first.
+	GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error)
+	GetModerationById(ctx context.Context, id int) (*models.Moderations, error)
+	CreateModeration(ctx context.Context, moderation *models.Moderations) error
+}
@@ -2,12 +2,13 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoProducers interface {
-	GetProducers() ([]*models.Producers, error)
-	GetProducerById(id int) (*models.Producers, error)
-	CreateProducer(producer *models.Producers) error
-	UpdateProducer(producer *models.Producers) error
-	DeleteProducer(id int) error
+	GetProducers(ctx context.Context) ([]*models.Producers, error)
+	GetProducerById(ctx context.Context, id int) (*models.Producers, error)
+	CreateProducer(ctx context.Context, producer *models.Producers) error
+	UpdateProducer(ctx context.Context, producer *models.Producers) error
+	DeleteProducer(ctx context.Context, id int) error
 }
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+type IRepoRatings interface {
+	GetRatings(ctx context.Context) ([]*models.Ratings, error)
+	GetRatingById(ctx context.Context, id int) (*models.Ratings, error)
+	GetRatingsByUserId(ctx context.Context, idUser int) ([]*models.Ratings, error)
+	GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error)
+	// SumRatings returns the sum and the number of the scores of the serial.
+	SumRatings(ctx context.Context, idSerial int) (int, int, error)
+	CreateRating(ctx context.Context, rating *models.Ratings) error
+	UpdateRating(ctx context.Context, rating *models.Ratings) error
+	DeleteRating(ctx context.Context, id int) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"app/internal/models"
+	"context"
+)
+
+// IRepoRecommendations keeps the serials ranked for the users by the offline
+// ranking job.
+type IRepoRecommendations interface {
+	// GetRecommendationsByUserId returns the recommendations of the user in
+	// their order.
+	GetRecommendationsByUserId(ctx context.Context, idUser int) ([]*models.Recommendations, error)
+	CreateRecommendation(ctx context.Context, recommendation *models.Recommendations) error
+	DeleteRecommendationsByUserId(ctx context.Context, idUser int) error
+}
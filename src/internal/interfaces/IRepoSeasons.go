@@ -2,13 +2,14 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoSeasons interface {
-	GetSeasons() ([]*models.Seasons, error)
-	GetSeasonById(id int) (*models.Seasons, error)
-	GetSeasonsBySerialId(id int) ([]*models.Seasons, error)
-	CreateSeason(season *models.Seasons) error
-	UpdateSeason(season *models.Seasons) error
-	DeleteSeason(id int) error
+	GetSeasons(ctx context.Context) ([]*models.Seasons, error)
+	GetSeasonById(ctx context.Context, id int) (*models.Seasons, error)
+	GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error)
+	CreateSeason(ctx context.Context, season *models.Seasons) error
+	UpdateSeason(ctx context.Context, season *models.Seasons) error
+	DeleteSeason(ctx context.Context, id int) error
 }
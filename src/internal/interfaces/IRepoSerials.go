@@ -2,14 +2,18 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoSerials interface {
-	GetSerials() ([]*models.Serial, error)
-	GetSerialById(id int) (*models.Serial, error)
-	GetSerialsByTitle(title string) ([]*models.Serial, error)
-	CreateSerial(serial *models.Serial) error
-	UpdateSerial(serial *models.Serial) error
-	DeleteSerial(id int) error
-	CalculateDuration(serial *models.Serial) error
+	GetSerials(ctx context.Context) ([]*models.Serial, error)
+	GetSerialById(ctx context.Context, id int) (*models.Serial, error)
+	GetSerialsByTitle(ctx context.Context, title string) ([]*models.Serial, error)
+	SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error)
+	FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error)
+	Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error)
+	CreateSerial(ctx context.Context, serial *models.Serial) error
+	UpdateSerial(ctx context.Context, serial *models.Serial) error
+	LockSerial(ctx context.Context, id int) error
+	DeleteSerial(ctx context.Context, id int) error
 }
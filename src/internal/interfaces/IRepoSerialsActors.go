@@ -2,14 +2,15 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoSerialsActors interface {
-	GetSerialsActors() ([]*models.SerialsActors, error)
-	GetSerialsByActorId(id int) ([]*models.SerialsActors, error)
-	GetActorsBySerialId(id int) ([]*models.SerialsActors, error)
-	GetSerialsActorsById(id int) (*models.SerialsActors, error)
-	CreateSerialsActors(serialActor *models.SerialsActors) error
-	UpdateSerialsActors(serialActor *models.SerialsActors) error
-	DeleteSerialsActors(id int) error
+	GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error)
+	GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error)
+	GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error)
+	GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error)
+	CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error
+	UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error
+	DeleteSerialsActors(ctx context.Context, id int) error
 }
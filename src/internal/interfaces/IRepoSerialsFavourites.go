@@ -2,16 +2,17 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoSerialsFavourites interface {
-	GetSerialsFavourites() ([]*models.SerialsFavourites, error)
-	GetSerialsByFavouriteId(id int) ([]*models.SerialsFavourites, error)
-	GetFavouritesBySerialId(id int) ([]*models.SerialsFavourites, error)
-	GetSerialsFavouritesById(id int) (*models.SerialsFavourites, error)
-	CreateSerialsFavourites(serialFavourite *models.SerialsFavourites) error
-	UpdateSerialsFavourites(serialFavourite *models.SerialsFavourites) error
-	CheckSerialInFavourite(serialFavourite *models.SerialsFavourites) bool
-	DeleteSerialById(idfav, idserial int) error
-	DeleteSerialsFavourites(id int) error
+	GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error)
+	GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error)
+	GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error)
+	GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error)
+	CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error
+	UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error
+	CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool
+	DeleteSerialById(ctx context.Context, idfav, idserial int) error
+	DeleteSerialsFavourites(ctx context.Context, id int) error
 }
@@ -2,15 +2,19 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoSerialsUsers interface {
-	GetSerialsUsers() ([]*models.SerialsUsers, error)
-	GetSerialsByUserId(id int) ([]*models.SerialsUsers, error)
-	GetUsersBySerialId(id int) ([]*models.SerialsUsers, error)
-	GetSerialsUsersById(id int) (*models.SerialsUsers, error)
-	GetSerialUserByIds(userId, serialId int) (*models.SerialsUsers, error)
-	CreateSerialsUsers(serialUser *models.SerialsUsers) error
-	UpdateSerialsUsers(serialUser *models.SerialsUsers) error
-	DeleteSerialsByUserId(id int) error
+	GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error)
+	GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error)
+	GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error)
+	GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error)
+	GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error)
+	GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error)
+	GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error)
+	CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error
+	UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error
+	DeleteSerialsUsers(ctx context.Context, id int) error
+	DeleteSerialsByUserId(ctx context.Context, id int) error
 }
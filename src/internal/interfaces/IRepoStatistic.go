@@ -1,8 +1,11 @@
 package interfaces
 
-import "app/internal/models"
+import (
+	"app/internal/models"
+	"context"
+)
 
 type IRepoStatistic interface {
-	GetStatistic() (*models.Statistic, error)
-	UpdateStatistic(stat *models.Statistic) error
+	GetStatistic(ctx context.Context) (*models.Statistic, error)
+	UpdateStatistic(ctx context.Context, stat *models.Statistic) error
 }
@@ -2,14 +2,16 @@ package interfaces
 
 import (
 	"app/internal/models"
+	"context"
 )
 
 type IRepoUsers interface {
-	GetUsers() ([]*models.Users, error)
-	GetUserById(id int) (*models.Users, error)
-	GetUserByLogin(login string) (*models.Users, error)
-	CheckUser(login string) bool
-	CreateUser(user *models.Users) error
-	UpdateUser(user *models.Users) error
-	DeleteUser(id int) error
+	GetUsers(ctx context.Context) ([]*models.Users, error)
+	GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error)
+	GetUserById(ctx context.Context, id int) (*models.Users, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.Users, error)
+	CheckUser(ctx context.Context, login string) bool
+	CreateUser(ctx context.Context, user *models.Users) error
+	UpdateUser(ctx context.Context, user *models.Users) error
+	DeleteUser(ctx context.Context, id int) error
 }
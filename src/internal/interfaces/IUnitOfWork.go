@@ -0,0 +1,34 @@
+package interfaces
+
+import "context"
+
+// IUnitOfWork runs several repository calls atomically.
+type IUnitOfWork interface {
+	// Do calls fn with the repositories of a new transaction and the context
+	// they must be called with. The transaction is committed if fn returns nil
+	// and rolled back otherwise.
+	Do(ctx context.Context, fn func(ctx context.Context, tx ITx) error) error
+}
+
+// ITx gives the repositories taking part in a transaction.
+type ITx interface {
+	Actors() IRepoActors
+	Comments() IRepoComments
+	CommentsReports() IRepoCommentsReports
+	CommentsVotes() IRepoCommentsVotes
+	Comparisons() IRepoComparisons
+	Episodes() IRepoEpisodes
+	EpisodesUsers() IRepoEpisodesUsers
+	Favourites() IRepoFavourites
+	Moderations() IRepoModerations
+	Producers() IRepoProducers
+	Ratings() IRepoRatings
+	Recommendations() IRepoRecommendations
+	Seasons() IRepoSeasons
+	Serials() IRepoSerials
+	SerialsActors() IRepoSerialsActors
+	SerialsFavourites() IRepoSerialsFavourites
+	SerialsUsers() IRepoSerialsUsers
+	Statistic() IRepoStatistic
+	Users() IRepoUsers
+}
@@ -0,0 +1,197 @@
+// Package migrations keeps the Postgres schema in versioned SQL files embedded
+// into the binary. A migration is a pair of files sql/<version>_<name>.up.sql
+// and sql/<version>_<name>.down.sql; the applied versions are recorded in the
+// schema_migrations table.
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrNoApplied = errors.New("no applied migrations")
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration with the time it was applied, nil if it is pending.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		m := fileName.FindStringSubmatch(name[len("sql/"):])
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: bad file name", name)
+		}
+		version, _ := strconv.Atoi(m[1])
+		text, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d: names %s and %s differ", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(text)
+		} else {
+			migration.Down = string(text)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d: up or down file is missing", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+type Migrator struct {
+	db  *sqlx.DB
+	log *logrus.Logger
+}
+
+func NewMigrator(db *sqlx.DB, log *logrus.Logger) *Migrator {
+	return &Migrator{db: db, log: log}
+}
+
+func (m *Migrator) init() error {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	return err
+}
+
+// Status returns all the embedded migrations with their state.
+func (m *Migrator) Status() ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if err = m.init(); err != nil {
+		return nil, err
+	}
+	applied := []struct {
+		Version   int       `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}{}
+	err = m.db.Select(&applied, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(migrations))
+	for i, migration := range migrations {
+		statuses[i].Migration = migration
+		for _, a := range applied {
+			if a.Version == migration.Version {
+				appliedAt := a.AppliedAt
+				statuses[i].AppliedAt = &appliedAt
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies the pending migrations in order and returns their number.
+// Every migration runs in its own transaction.
+func (m *Migrator) Up() (int, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			continue
+		}
+		m.log.Infof("Applying migration %d_%s", status.Version, status.Name)
+		err = m.apply(status.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", status.Version, status.Name)
+		if err != nil {
+			return cnt, fmt.Errorf("migration %d_%s: %w", status.Version, status.Name, err)
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+// Down reverts the last applied migration and returns it.
+func (m *Migrator) Down() (*Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(statuses) - 1; i >= 0; i-- {
+		status := statuses[i]
+		if status.AppliedAt == nil {
+			continue
+		}
+		m.log.Infof("Reverting migration %d_%s", status.Version, status.Name)
+		err = m.apply(status.Down, "DELETE FROM schema_migrations WHERE version=$1", status.Version)
+		if err != nil {
+			return nil, fmt.Errorf("migration %d_%s: %w", status.Version, status.Name, err)
+		}
+		return &status.Migration, nil
+	}
+	return nil, ErrNoApplied
+}
+
+func (m *Migrator) apply(script string, record string, args ...interface{}) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(script); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
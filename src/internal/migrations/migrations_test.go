@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  []Migration
+		err   bool
+	}{
+		{"ordered by version", fstest.MapFS{
+			"sql/0002_b.up.sql":   {Data: []byte("up b")},
+			"sql/0002_b.down.sql": {Data: []byte("down b")},
+			"sql/0001_a.up.sql":   {Data: []byte("up a")},
+			"sql/0001_a.down.sql": {Data: []byte("down a")},
+		}, []Migration{{1, "a", "up a", "down a"}, {2, "b", "up b", "down b"}}, false},
+		{"missing down", fstest.MapFS{
+			"sql/0001_a.up.sql": {Data: []byte("up a")},
+		}, nil, true},
+		{"names differ", fstest.MapFS{
+			"sql/0001_a.up.sql":   {Data: []byte("up a")},
+			"sql/0001_b.down.sql": {Data: []byte("down b")},
+		}, nil, true},
+		{"bad file name", fstest.MapFS{
+			"sql/a.up.sql": {Data: []byte("up a")},
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := load(tt.files)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS statistic;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS serials_users;
+DROP TABLE IF EXISTS serials_favourites;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS favourites;
+DROP TABLE IF EXISTS serials_actors;
+DROP TABLE IF EXISTS actors;
+DROP TABLE IF EXISTS episodes;
+DROP TABLE IF EXISTS seasons;
+DROP TABLE IF EXISTS serials;
+DROP TABLE IF EXISTS producers;
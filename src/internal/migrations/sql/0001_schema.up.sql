@@ -0,0 +1,114 @@
+-- Tables used by the postgres repositories. Column names follow the model
+-- fields (Postgres folds them to lower case, as does sqlx when mapping rows).
+-- IF NOT EXISTS lets a database created by hand before the migrations adopt them.
+--
+-- The serial duration used to be computed by the calculate_total_duration
+-- function; it is now summed from the episodes by the application.
+
+CREATE TABLE IF NOT EXISTS producers (
+    p_id      SERIAL PRIMARY KEY,
+    p_name    TEXT NOT NULL,
+    p_surname TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS serials (
+    s_id          SERIAL PRIMARY KEY,
+    s_idProducer  INTEGER NOT NULL REFERENCES producers (p_id),
+    s_name        TEXT NOT NULL,
+    s_description TEXT NOT NULL,
+    s_year        INTEGER NOT NULL,
+    s_genre       TEXT NOT NULL,
+    s_rating      REAL NOT NULL DEFAULT 0,
+    s_seasons     INTEGER NOT NULL DEFAULT 0,
+    s_state       TEXT NOT NULL,
+    s_img         TEXT NOT NULL,
+    s_duration    TEXT NOT NULL DEFAULT '00:00:00'
+);
+
+CREATE TABLE IF NOT EXISTS seasons (
+    ss_id          SERIAL PRIMARY KEY,
+    ss_idSerial    INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    ss_name        TEXT NOT NULL,
+    ss_num         INTEGER NOT NULL,
+    ss_cntEpisodes INTEGER NOT NULL DEFAULT 0,
+    ss_date        DATE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS episodes (
+    e_id       SERIAL PRIMARY KEY,
+    e_idSeason INTEGER NOT NULL REFERENCES seasons (ss_id) ON DELETE CASCADE,
+    e_name     TEXT NOT NULL,
+    e_num      INTEGER NOT NULL,
+    e_duration TEXT NOT NULL,
+    e_date     DATE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS actors (
+    a_id      SERIAL PRIMARY KEY,
+    a_name    TEXT NOT NULL,
+    a_surname TEXT NOT NULL,
+    a_gender  TEXT NOT NULL,
+    a_bdate   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS serials_actors (
+    sa_id       SERIAL PRIMARY KEY,
+    sa_idSerial INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    sa_idActor  INTEGER NOT NULL REFERENCES actors (a_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS favourites (
+    f_id         SERIAL PRIMARY KEY,
+    f_cntSerials INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    u_id           SERIAL PRIMARY KEY,
+    u_login        TEXT NOT NULL UNIQUE,
+    u_password     TEXT NOT NULL,
+    u_role         TEXT NOT NULL,
+    u_name         TEXT NOT NULL,
+    u_surname      TEXT NOT NULL,
+    u_gender       TEXT NOT NULL,
+    u_bdate        DATE NOT NULL,
+    u_idFavourites INTEGER NOT NULL REFERENCES favourites (f_id)
+);
+
+CREATE TABLE IF NOT EXISTS serials_favourites (
+    sf_id          SERIAL PRIMARY KEY,
+    sf_idSerial    INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    sf_idFavourite INTEGER NOT NULL REFERENCES favourites (f_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS serials_users (
+    su_id       SERIAL PRIMARY KEY,
+    su_idSerial INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    su_idUser   INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    su_lastSeen DATE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+    c_id       SERIAL PRIMARY KEY,
+    c_idUser   INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    c_idSerial INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    c_text     TEXT NOT NULL,
+    c_date     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS statistic (
+    st_id            SERIAL PRIMARY KEY,
+    st_gender_male   INTEGER NOT NULL DEFAULT 0,
+    st_gender_female INTEGER NOT NULL DEFAULT 0,
+    st_role_user     INTEGER NOT NULL DEFAULT 0,
+    st_role_admin    INTEGER NOT NULL DEFAULT 0,
+    st_age_0_18      INTEGER NOT NULL DEFAULT 0,
+    st_age_19_30     INTEGER NOT NULL DEFAULT 0,
+    st_age_31_50     INTEGER NOT NULL DEFAULT 0,
+    st_age_51_100    INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS seasons_idserial_idx ON seasons (ss_idSerial);
+CREATE INDEX IF NOT EXISTS episodes_idseason_idx ON episodes (e_idSeason);
+CREATE INDEX IF NOT EXISTS comments_idserial_idx ON comments (c_idSerial);
+CREATE INDEX IF NOT EXISTS serials_users_iduser_idx ON serials_users (su_idUser);
+CREATE INDEX IF NOT EXISTS serials_favourites_idfavourite_idx ON serials_favourites (sf_idFavourite);
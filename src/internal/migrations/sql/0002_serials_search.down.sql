@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS serials_search_producers ON producers;
+DROP TRIGGER IF EXISTS serials_search_actors ON actors;
+DROP TRIGGER IF EXISTS serials_search_serials_actors ON serials_actors;
+DROP TRIGGER IF EXISTS serials_search_serials ON serials;
+DROP FUNCTION IF EXISTS serials_search_on_producer();
+DROP FUNCTION IF EXISTS serials_search_on_actor();
+DROP FUNCTION IF EXISTS serials_search_on_serial_actor();
+DROP FUNCTION IF EXISTS serials_search_on_serial();
+DROP FUNCTION IF EXISTS serials_search_refresh(INTEGER);
+DROP FUNCTION IF EXISTS serials_search_vector(TEXT, "char");
+DROP TABLE IF EXISTS serials_search;
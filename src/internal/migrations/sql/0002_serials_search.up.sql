@@ -0,0 +1,82 @@
+-- Full-text search over the serials. serials_search keeps a document per
+-- serial built from its name (weight A), description (B) and the names of its
+-- producer and actors (C). Every field is indexed with the russian, english
+-- and simple configurations so that a query parsed with any of them matches
+-- within its own lexemes. Triggers refresh the documents on every change of
+-- the tables they are built from.
+
+CREATE TABLE IF NOT EXISTS serials_search (
+    ss_idSerial INTEGER PRIMARY KEY REFERENCES serials (s_id) ON DELETE CASCADE,
+    ss_document TSVECTOR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS serials_search_document_idx ON serials_search USING GIN (ss_document);
+
+CREATE OR REPLACE FUNCTION serials_search_vector(field TEXT, weight "char") RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('russian', field) || to_tsvector('english', field) || to_tsvector('simple', field), weight)
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION serials_search_refresh(id INTEGER) RETURNS VOID AS $$
+    INSERT INTO serials_search (ss_idSerial, ss_document)
+    SELECT s.s_id,
+           serials_search_vector(s.s_name, 'A') ||
+           serials_search_vector(s.s_description, 'B') ||
+           serials_search_vector(concat_ws(' ', p.p_name, p.p_surname,
+               (SELECT string_agg(a.a_name || ' ' || a.a_surname, ' ')
+                  FROM serials_actors sa JOIN actors a ON a.a_id = sa.sa_idActor
+                 WHERE sa.sa_idSerial = s.s_id)), 'C')
+      FROM serials s LEFT JOIN producers p ON p.p_id = s.s_idProducer
+     WHERE s.s_id = id
+    ON CONFLICT (ss_idSerial) DO UPDATE SET ss_document = EXCLUDED.ss_document
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION serials_search_on_serial() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM serials_search_refresh(NEW.s_id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION serials_search_on_serial_actor() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM serials_search_refresh(OLD.sa_idSerial);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        PERFORM serials_search_refresh(NEW.sa_idSerial);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION serials_search_on_actor() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM serials_search_refresh(sa_idSerial) FROM serials_actors WHERE sa_idActor = NEW.a_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION serials_search_on_producer() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM serials_search_refresh(s_id) FROM serials WHERE s_idProducer = NEW.p_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS serials_search_serials ON serials;
+CREATE TRIGGER serials_search_serials AFTER INSERT OR UPDATE ON serials
+    FOR EACH ROW EXECUTE FUNCTION serials_search_on_serial();
+
+DROP TRIGGER IF EXISTS serials_search_serials_actors ON serials_actors;
+CREATE TRIGGER serials_search_serials_actors AFTER INSERT OR UPDATE OR DELETE ON serials_actors
+    FOR EACH ROW EXECUTE FUNCTION serials_search_on_serial_actor();
+
+DROP TRIGGER IF EXISTS serials_search_actors ON actors;
+CREATE TRIGGER serials_search_actors AFTER UPDATE ON actors
+    FOR EACH ROW EXECUTE FUNCTION serials_search_on_actor();
+
+DROP TRIGGER IF EXISTS serials_search_producers ON producers;
+CREATE TRIGGER serials_search_producers AFTER UPDATE ON producers
+    FOR EACH ROW EXECUTE FUNCTION serials_search_on_producer();
+
+SELECT serials_search_refresh(s_id) FROM serials;
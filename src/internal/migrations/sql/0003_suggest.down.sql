@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS producers_name_trgm_idx;
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS serials_name_trgm_idx;
@@ -0,0 +1,9 @@
+-- Autocomplete over the names of the serials, actors and producers. Trigram
+-- indexes serve the ILIKE patterns of the prefix typed so far and of its
+-- variants with a typo, word_similarity orders the candidates.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS serials_name_trgm_idx ON serials USING GIN (s_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx ON actors USING GIN ((a_name || ' ' || a_surname) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS producers_name_trgm_idx ON producers USING GIN ((p_name || ' ' || p_surname) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ratings;
+ALTER TABLE serials DROP COLUMN IF EXISTS s_votes;
@@ -0,0 +1,15 @@
+-- Ratings of the serials by the users. The rating of a serial is the mean of
+-- its scores, kept in s_rating by the application along with their number in
+-- s_votes.
+
+ALTER TABLE serials ADD COLUMN IF NOT EXISTS s_votes INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS ratings (
+    r_id       SERIAL PRIMARY KEY,
+    r_idUser   INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    r_idSerial INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    r_score    INTEGER NOT NULL CHECK (r_score BETWEEN 1 AND 10),
+    UNIQUE (r_idSerial, r_idUser)
+);
+
+CREATE INDEX IF NOT EXISTS ratings_iduser_idx ON ratings (r_idUser);
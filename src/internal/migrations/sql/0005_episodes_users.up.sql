@@ -0,0 +1,12 @@
+-- Episodes watched by the users. The serial of the episode is stored to read
+-- the progress of a user in a serial without joining seasons and episodes.
+
+CREATE TABLE IF NOT EXISTS episodes_users (
+    eu_id        SERIAL PRIMARY KEY,
+    eu_idEpisode INTEGER NOT NULL REFERENCES episodes (e_id) ON DELETE CASCADE,
+    eu_idSerial  INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    eu_idUser    INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    UNIQUE (eu_idEpisode, eu_idUser)
+);
+
+CREATE INDEX IF NOT EXISTS episodes_users_iduser_idserial_idx ON episodes_users (eu_idUser, eu_idSerial);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS comments_votes;
+DROP INDEX IF EXISTS comments_idparent_idx;
+ALTER TABLE comments DROP COLUMN IF EXISTS c_down;
+ALTER TABLE comments DROP COLUMN IF EXISTS c_up;
+ALTER TABLE comments DROP COLUMN IF EXISTS c_idParent;
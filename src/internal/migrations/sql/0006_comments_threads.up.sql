@@ -0,0 +1,20 @@
+-- Replies and votes of the comments. A comment with c_idParent 0 is a review
+-- of the serial, otherwise it replies to the comment c_idParent of the same
+-- serial; the application deletes the replies along with their parent. The
+-- votes are counted in c_up and c_down by the application.
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS c_idParent INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS c_up INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS c_down INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS comments_idparent_idx ON comments (c_idParent);
+
+CREATE TABLE IF NOT EXISTS comments_votes (
+    cv_id        SERIAL PRIMARY KEY,
+    cv_idComment INTEGER NOT NULL REFERENCES comments (c_id) ON DELETE CASCADE,
+    cv_idUser    INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    cv_value     INTEGER NOT NULL CHECK (cv_value IN (-1, 1)),
+    UNIQUE (cv_idComment, cv_idUser)
+);
+
+CREATE INDEX IF NOT EXISTS comments_votes_iduser_idx ON comments_votes (cv_idUser);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderations;
+DROP TABLE IF EXISTS comments_reports;
+ALTER TABLE comments DROP COLUMN IF EXISTS c_hidden;
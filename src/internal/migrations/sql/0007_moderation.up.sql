@@ -0,0 +1,27 @@
+-- Moderation of the comments. Users report comments, a moderator hides,
+-- deletes or keeps a reported comment and the decision is recorded in
+-- moderations, which keeps the text and the id of a deleted comment.
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS c_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS comments_reports (
+    cr_id        SERIAL PRIMARY KEY,
+    cr_idComment INTEGER NOT NULL REFERENCES comments (c_id) ON DELETE CASCADE,
+    cr_idUser    INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    cr_reason    TEXT NOT NULL,
+    cr_date      TEXT NOT NULL,
+    cr_state     TEXT NOT NULL CHECK (cr_state IN ('open', 'closed')),
+    UNIQUE (cr_idComment, cr_idUser)
+);
+
+CREATE INDEX IF NOT EXISTS comments_reports_state_idx ON comments_reports (cr_state);
+CREATE INDEX IF NOT EXISTS comments_reports_iduser_idx ON comments_reports (cr_idUser);
+
+CREATE TABLE IF NOT EXISTS moderations (
+    md_id          SERIAL PRIMARY KEY,
+    md_idComment   INTEGER NOT NULL,
+    md_idModerator INTEGER NOT NULL,
+    md_action      TEXT NOT NULL CHECK (md_action IN ('hide', 'delete', 'dismiss')),
+    md_text        TEXT NOT NULL,
+    md_date        TEXT NOT NULL
+);
@@ -0,0 +1,8 @@
+ALTER TABLE serials_favourites DROP CONSTRAINT IF EXISTS serials_favourites_list_serial_key;
+ALTER TABLE serials_favourites DROP COLUMN IF EXISTS sf_date;
+DELETE FROM favourites WHERE f_id NOT IN (SELECT u_idFavourites FROM users);
+DROP INDEX IF EXISTS favourites_iduser_idx;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_position;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_note;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_name;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_idUser;
@@ -0,0 +1,29 @@
+-- Named lists of serials. A favourites row is now a list of a user with a
+-- name, a note and a position among the lists of the user. The list of
+-- u_idFavourites stays the default one and keeps the existing favourites, the
+-- other standard lists are added for every user. serials_favourites records
+-- the date a serial was added to a list.
+
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_idUser INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_name TEXT NOT NULL DEFAULT 'Избранное';
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_note TEXT NOT NULL DEFAULT '';
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_position INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE favourites ALTER COLUMN f_idUser DROP DEFAULT;
+ALTER TABLE favourites ALTER COLUMN f_name DROP DEFAULT;
+
+UPDATE favourites SET f_idUser = u_id FROM users WHERE u_idFavourites = f_id;
+
+INSERT INTO favourites (f_idUser, f_name, f_position)
+SELECT u_id, list.name, list.position
+FROM users, (VALUES ('Смотрю', 2), ('Буду смотреть', 3), ('Брошено', 4)) AS list (name, position);
+
+CREATE INDEX IF NOT EXISTS favourites_iduser_idx ON favourites (f_idUser);
+
+ALTER TABLE serials_favourites ADD COLUMN IF NOT EXISTS sf_date TEXT NOT NULL DEFAULT to_char(CURRENT_DATE, 'YYYY-MM-DD');
+ALTER TABLE serials_favourites ALTER COLUMN sf_date DROP DEFAULT;
+
+DELETE FROM serials_favourites a USING serials_favourites b
+WHERE a.sf_idFavourite = b.sf_idFavourite AND a.sf_idSerial = b.sf_idSerial AND a.sf_id > b.sf_id;
+ALTER TABLE serials_favourites ADD CONSTRAINT serials_favourites_list_serial_key UNIQUE (sf_idFavourite, sf_idSerial);
+
+UPDATE favourites SET f_cntSerials = (SELECT COUNT(*) FROM serials_favourites WHERE sf_idFavourite = f_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS favourites_slug_idx;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_slug;
+ALTER TABLE favourites DROP COLUMN IF EXISTS f_visibility;
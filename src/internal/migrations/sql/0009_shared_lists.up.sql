@@ -0,0 +1,9 @@
+-- Sharing of the lists. A list is private, unlisted (seen by everyone who has
+-- its link) or public (also shown on the profile of its owner). f_slug is the
+-- part of the link, empty until the list is shared for the first time.
+
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_visibility TEXT NOT NULL DEFAULT 'private'
+    CHECK (f_visibility IN ('private', 'unlisted', 'public'));
+ALTER TABLE favourites ADD COLUMN IF NOT EXISTS f_slug TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS favourites_slug_idx ON favourites (f_slug) WHERE f_slug <> '';
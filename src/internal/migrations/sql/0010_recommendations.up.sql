@@ -0,0 +1,13 @@
+-- The serials ranked for every user by the offline ranking job
+-- ("main recommend"), the job replaces all the rows of a user at once.
+
+CREATE TABLE IF NOT EXISTS recommendations (
+    rc_id       SERIAL PRIMARY KEY,
+    rc_idUser   INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    rc_idSerial INTEGER NOT NULL REFERENCES serials (s_id) ON DELETE CASCADE,
+    rc_score    DOUBLE PRECISION NOT NULL,
+    rc_source   TEXT NOT NULL CHECK (rc_source IN ('users', 'similar', 'popular')),
+    rc_position INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS recommendations_iduser_idx ON recommendations (rc_idUser, rc_position);
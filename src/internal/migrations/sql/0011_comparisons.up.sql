@@ -0,0 +1,12 @@
+-- The comparisons saved by the users, cm_serials holds the ids of the
+-- compared serials separated by commas.
+
+CREATE TABLE IF NOT EXISTS comparisons (
+    cm_id      SERIAL PRIMARY KEY,
+    cm_idUser  INTEGER NOT NULL REFERENCES users (u_id) ON DELETE CASCADE,
+    cm_slug    TEXT NOT NULL UNIQUE,
+    cm_serials TEXT NOT NULL,
+    cm_date    TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS comparisons_iduser_idx ON comparisons (cm_idUser);
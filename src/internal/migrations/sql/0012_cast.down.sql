@@ -0,0 +1,5 @@
+ALTER TABLE serials_actors DROP COLUMN IF EXISTS sa_seasonTo;
+ALTER TABLE serials_actors DROP COLUMN IF EXISTS sa_seasonFrom;
+ALTER TABLE serials_actors DROP COLUMN IF EXISTS sa_billing;
+ALTER TABLE serials_actors DROP COLUMN IF EXISTS sa_role;
+ALTER TABLE serials_actors DROP COLUMN IF EXISTS sa_character;
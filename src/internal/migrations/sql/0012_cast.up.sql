@@ -0,0 +1,10 @@
+-- The roles of the actors in the serials: the character, the type of the
+-- role, the place in the credits (0 for the actors not billed) and the seasons
+-- the actor appears in, 0 for all of them or up to the last one.
+
+ALTER TABLE serials_actors ADD COLUMN IF NOT EXISTS sa_character TEXT NOT NULL DEFAULT '';
+ALTER TABLE serials_actors ADD COLUMN IF NOT EXISTS sa_role TEXT NOT NULL DEFAULT 'main'
+    CHECK (sa_role IN ('main', 'recurring', 'guest'));
+ALTER TABLE serials_actors ADD COLUMN IF NOT EXISTS sa_billing INTEGER NOT NULL DEFAULT 0 CHECK (sa_billing >= 0);
+ALTER TABLE serials_actors ADD COLUMN IF NOT EXISTS sa_seasonFrom INTEGER NOT NULL DEFAULT 0 CHECK (sa_seasonFrom >= 0);
+ALTER TABLE serials_actors ADD COLUMN IF NOT EXISTS sa_seasonTo INTEGER NOT NULL DEFAULT 0 CHECK (sa_seasonTo >= 0);
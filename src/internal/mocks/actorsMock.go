@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,32 +11,32 @@ type MockRepoActors struct {
 	mock.Mock
 }
 
-func (m *MockRepoActors) GetActors() ([]*models.Actors, error) {
+func (m *MockRepoActors) GetActors(ctx context.Context) ([]*models.Actors, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Actors), args.Error(1)
 }
 
-func (m *MockRepoActors) GetActorById(id int) (*models.Actors, error) {
+func (m *MockRepoActors) GetActorById(ctx context.Context, id int) (*models.Actors, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Actors), args.Error(1)
 }
 
-func (m *MockRepoActors) CreateActor(actor *models.Actors) error {
+func (m *MockRepoActors) CreateActor(ctx context.Context, actor *models.Actors) error {
 	args := m.Called(actor)
 	return args.Error(0)
 }
 
-func (m *MockRepoActors) UpdateActor(actor *models.Actors) error {
+func (m *MockRepoActors) UpdateActor(ctx context.Context, actor *models.Actors) error {
 	args := m.Called(actor)
 	return args.Error(0)
 }
 
-func (m *MockRepoActors) DeleteActor(id int) error {
+func (m *MockRepoActors) DeleteActor(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRepoActors) CheckActor(actor *models.Actors) bool {
+func (m *MockRepoActors) CheckActor(ctx context.Context, actor *models.Actors) bool {
 	args := m.Called(actor)
 	return args.Bool(0)
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,47 +11,67 @@ type MockRepoComments struct {
 	mock.Mock
 }
 
-func (m *MockRepoComments) GetComments() ([]*models.Comments, error) {
+func (m *MockRepoComments) GetComments(ctx context.Context) ([]*models.Comments, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Comments), args.Error(1)
 }
 
-func (m *MockRepoComments) GetCommentById(id int) (*models.Comments, error) {
+func (m *MockRepoComments) GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error) {
+	args := m.Called(page)
+	return args.Get(0).([]*models.Comments), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoComments) GetCommentById(ctx context.Context, id int) (*models.Comments, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Comments), args.Error(1)
 }
 
-func (m *MockRepoComments) GetCommentsBySerialId(idSerial int) ([]*models.Comments, error) {
+func (m *MockRepoComments) GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	args := m.Called(idSerial)
+	return args.Get(0).([]*models.Comments), args.Error(1)
+}
+
+func (m *MockRepoComments) GetCommentsBySerialIdPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.Comments, int, error) {
+	args := m.Called(idSerial, sort, page)
+	return args.Get(0).([]*models.Comments), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoComments) GetRepliesBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
 	args := m.Called(idSerial)
 	return args.Get(0).([]*models.Comments), args.Error(1)
 }
 
-func (m *MockRepoComments) GetCommentsByUserId(idUser int) ([]*models.Comments, error) {
+func (m *MockRepoComments) GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error) {
 	args := m.Called(idUser)
 	return args.Get(0).([]*models.Comments), args.Error(1)
 }
 
-func (m *MockRepoComments) GetCommentsBySerialIdUserId(idSerial, idUser int) (*models.Comments, error) {
+func (m *MockRepoComments) GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error) {
 	args := m.Called(idSerial, idUser)
 	return args.Get(0).(*models.Comments), args.Error(1)
 }
 
-func (m *MockRepoComments) CreateComment(comment *models.Comments) error {
+func (m *MockRepoComments) CreateComment(ctx context.Context, comment *models.Comments) error {
 	args := m.Called(comment)
 	return args.Error(0)
 }
 
-func (m *MockRepoComments) UpdateComment(comment *models.Comments) error {
+func (m *MockRepoComments) UpdateComment(ctx context.Context, comment *models.Comments) error {
 	args := m.Called(comment)
 	return args.Error(0)
 }
 
-func (m *MockRepoComments) DeleteComment(id int) error {
+func (m *MockRepoComments) LockComment(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoComments) DeleteComment(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRepoComments) CheckComment(idUser, idSerial int) bool {
+func (m *MockRepoComments) CheckComment(ctx context.Context, idUser, idSerial int) bool {
 	args := m.Called(idUser, idSerial)
 	return args.Bool(0)
 }
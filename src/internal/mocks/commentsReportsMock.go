@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoCommentsReports struct {
+	mock.Mock
+}
+
+func (m *MockRepoCommentsReports) GetCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) GetCommentReportById(ctx context.Context, id int) (*models.CommentsReports, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) GetCommentReportByIds(ctx context.Context, idComment, idUser int) (*models.CommentsReports, error) {
+	args := m.Called(idComment, idUser)
+	return args.Get(0).(*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) GetCommentsReportsByUserId(ctx context.Context, idUser int) ([]*models.CommentsReports, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) GetCommentsReportsByCommentId(ctx context.Context, idComment int) ([]*models.CommentsReports, error) {
+	args := m.Called(idComment)
+	return args.Get(0).([]*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) GetOpenCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.CommentsReports), args.Error(1)
+}
+
+func (m *MockRepoCommentsReports) CreateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	args := m.Called(report)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsReports) UpdateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	args := m.Called(report)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsReports) DeleteCommentReport(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsReports) DeleteCommentReportsByCommentId(ctx context.Context, idComment int) error {
+	args := m.Called(idComment)
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoCommentsVotes struct {
+	mock.Mock
+}
+
+func (m *MockRepoCommentsVotes) GetCommentsVotes(ctx context.Context) ([]*models.CommentsVotes, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.CommentsVotes), args.Error(1)
+}
+
+func (m *MockRepoCommentsVotes) GetCommentVoteById(ctx context.Context, id int) (*models.CommentsVotes, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.CommentsVotes), args.Error(1)
+}
+
+func (m *MockRepoCommentsVotes) GetCommentVoteByIds(ctx context.Context, idComment, idUser int) (*models.CommentsVotes, error) {
+	args := m.Called(idComment, idUser)
+	return args.Get(0).(*models.CommentsVotes), args.Error(1)
+}
+
+func (m *MockRepoCommentsVotes) GetCommentsVotesByUserId(ctx context.Context, idUser int) ([]*models.CommentsVotes, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.CommentsVotes), args.Error(1)
+}
+
+func (m *MockRepoCommentsVotes) CountCommentVotes(ctx context.Context, idComment int) (int, int, error) {
+	args := m.Called(idComment)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoCommentsVotes) CreateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	args := m.Called(vote)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsVotes) UpdateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	args := m.Called(vote)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsVotes) DeleteCommentVote(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoCommentsVotes) DeleteCommentVotesByCommentId(ctx context.Context, idComment int) error {
+	args := m.Called(idComment)
+	return args.Error(0)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoComparisons struct {
+	mock.Mock
+}
+
+func (m *MockRepoComparisons) GetComparisonById(ctx context.Context, id int) (*models.Comparisons, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Comparisons), args.Error(1)
+}
+
+func (m *MockRepoComparisons) GetComparisonBySlug(ctx context.Context, slug string) (*models.Comparisons, error) {
+	args := m.Called(slug)
+	return args.Get(0).(*models.Comparisons), args.Error(1)
+}
+
+func (m *MockRepoComparisons) GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.Comparisons, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.Comparisons), args.Error(1)
+}
+
+func (m *MockRepoComparisons) CreateComparison(ctx context.Context, comparison *models.Comparisons) error {
+	args := m.Called(comparison)
+	return args.Error(0)
+}
+
+func (m *MockRepoComparisons) DeleteComparison(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoComparisons) DeleteComparisonsByUserId(ctx context.Context, idUser int) error {
+	args := m.Called(idUser)
+	return args.Error(0)
+}
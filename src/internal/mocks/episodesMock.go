@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,27 +10,32 @@ type MockRepoEpisodes struct {
 	mock.Mock
 }
 
-func (m *MockRepoEpisodes) GetEpisodes() ([]*models.Episodes, error) {
+func (m *MockRepoEpisodes) GetEpisodes(ctx context.Context) ([]*models.Episodes, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Episodes), args.Error(1)
 }
 
-func (m *MockRepoEpisodes) GetEpisodeById(id int) (*models.Episodes, error) {
+func (m *MockRepoEpisodes) GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Episodes), args.Error(1)
 }
 
-func (m *MockRepoEpisodes) CreateEpisode(episode *models.Episodes) error {
+func (m *MockRepoEpisodes) GetEpisodesBySeasonId(ctx context.Context, id int) ([]*models.Episodes, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*models.Episodes), args.Error(1)
+}
+
+func (m *MockRepoEpisodes) CreateEpisode(ctx context.Context, episode *models.Episodes) error {
 	args := m.Called(episode)
 	return args.Error(0)
 }
 
-func (m *MockRepoEpisodes) UpdateEpisode(episode *models.Episodes) error {
+func (m *MockRepoEpisodes) UpdateEpisode(ctx context.Context, episode *models.Episodes) error {
 	args := m.Called(episode)
 	return args.Error(0)
 }
 
-func (m *MockRepoEpisodes) DeleteEpisode(id int) error {
+func (m *MockRepoEpisodes) DeleteEpisode(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
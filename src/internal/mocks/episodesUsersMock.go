@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoEpisodesUsers struct {
+	mock.Mock
+}
+
+func (m *MockRepoEpisodesUsers) GetEpisodesUsers(ctx context.Context) ([]*models.EpisodesUsers, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.EpisodesUsers), args.Error(1)
+}
+
+func (m *MockRepoEpisodesUsers) GetEpisodesUsersById(ctx context.Context, id int) (*models.EpisodesUsers, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.EpisodesUsers), args.Error(1)
+}
+
+func (m *MockRepoEpisodesUsers) GetEpisodesBySerialIdUserId(ctx context.Context, serialId, userId int) ([]*models.EpisodesUsers, error) {
+	args := m.Called(serialId, userId)
+	return args.Get(0).([]*models.EpisodesUsers), args.Error(1)
+}
+
+func (m *MockRepoEpisodesUsers) GetEpisodeUserByIds(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error) {
+	args := m.Called(episodeId, userId)
+	return args.Get(0).(*models.EpisodesUsers), args.Error(1)
+}
+
+func (m *MockRepoEpisodesUsers) CreateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	args := m.Called(episodeUser)
+	return args.Error(0)
+}
+
+func (m *MockRepoEpisodesUsers) UpdateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	args := m.Called(episodeUser)
+	return args.Error(0)
+}
+
+func (m *MockRepoEpisodesUsers) DeleteEpisodesUsers(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoEpisodesUsers) DeleteEpisodesByUserId(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,27 +11,37 @@ type MockRepoFavourites struct {
 	mock.Mock
 }
 
-func (m *MockRepoFavourites) GetFavourites() ([]*models.Favourites, error) {
+func (m *MockRepoFavourites) GetFavourites(ctx context.Context) ([]*models.Favourites, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Favourites), args.Error(1)
 }
 
-func (m *MockRepoFavourites) GetFavouriteById(id int) (*models.Favourites, error) {
+func (m *MockRepoFavourites) GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Favourites), args.Error(1)
 }
 
-func (m *MockRepoFavourites) CreateFavourite(favourite *models.Favourites) (int, error) {
+func (m *MockRepoFavourites) GetFavouritesByUserId(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.Favourites), args.Error(1)
+}
+
+func (m *MockRepoFavourites) GetFavouriteBySlug(ctx context.Context, slug string) (*models.Favourites, error) {
+	args := m.Called(slug)
+	return args.Get(0).(*models.Favourites), args.Error(1)
+}
+
+func (m *MockRepoFavourites) CreateFavourite(ctx context.Context, favourite *models.Favourites) (int, error) {
 	args := m.Called(favourite)
 	return args.Int(0), args.Error(1)
 }
 
-func (m *MockRepoFavourites) UpdateFavourite(favourite *models.Favourites) error {
+func (m *MockRepoFavourites) UpdateFavourite(ctx context.Context, favourite *models.Favourites) error {
 	args := m.Called(favourite)
 	return args.Error(0)
 }
 
-func (m *MockRepoFavourites) DeleteFavourite(id int) error {
+func (m *MockRepoFavourites) DeleteFavourite(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
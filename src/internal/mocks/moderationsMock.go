@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoModerations struct {
+	mock.Mock
+}
+
+func (m *MockRepoModerations) GetModerations(ctx context.Context) ([]*models.Moderations, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Moderations), args.Error(1)
+}
+
+func (m *MockRepoModerations) GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error) {
+	args := m.Called(page)
+	return args.Get(0).([]*models.Moderations), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoModerations) GetModerationById(ctx context.Context, id int) (*models.Moderations, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Moderations), args.Error(1)
+}
+
+func (m *MockRepoModerations) CreateModeration(ctx context.Context, moderation *models.Moderations) error {
+	args := m.Called(moderation)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,27 +10,27 @@ type MockRepoProducers struct {
 	mock.Mock
 }
 
-func (m *MockRepoProducers) GetProducers() ([]*models.Producers, error) {
+func (m *MockRepoProducers) GetProducers(ctx context.Context) ([]*models.Producers, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Producers), args.Error(1)
 }
 
-func (m *MockRepoProducers) GetProducerById(id int) (*models.Producers, error) {
+func (m *MockRepoProducers) GetProducerById(ctx context.Context, id int) (*models.Producers, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Producers), args.Error(1)
 }
 
-func (m *MockRepoProducers) CreateProducer(producer *models.Producers) error {
+func (m *MockRepoProducers) CreateProducer(ctx context.Context, producer *models.Producers) error {
 	args := m.Called(producer)
 	return args.Error(0)
 }
 
-func (m *MockRepoProducers) UpdateProducer(producer *models.Producers) error {
+func (m *MockRepoProducers) UpdateProducer(ctx context.Context, producer *models.Producers) error {
 	args := m.Called(producer)
 	return args.Error(0)
 }
 
-func (m *MockRepoProducers) DeleteProducer(id int) error {
+func (m *MockRepoProducers) DeleteProducer(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoRatings struct {
+	mock.Mock
+}
+
+func (m *MockRepoRatings) GetRatings(ctx context.Context) ([]*models.Ratings, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Ratings), args.Error(1)
+}
+
+func (m *MockRepoRatings) GetRatingById(ctx context.Context, id int) (*models.Ratings, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Ratings), args.Error(1)
+}
+
+func (m *MockRepoRatings) GetRatingsByUserId(ctx context.Context, idUser int) ([]*models.Ratings, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.Ratings), args.Error(1)
+}
+
+func (m *MockRepoRatings) GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error) {
+	args := m.Called(idSerial, idUser)
+	return args.Get(0).(*models.Ratings), args.Error(1)
+}
+
+func (m *MockRepoRatings) SumRatings(ctx context.Context, idSerial int) (int, int, error) {
+	args := m.Called(idSerial)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoRatings) CreateRating(ctx context.Context, rating *models.Ratings) error {
+	args := m.Called(rating)
+	return args.Error(0)
+}
+
+func (m *MockRepoRatings) UpdateRating(ctx context.Context, rating *models.Ratings) error {
+	args := m.Called(rating)
+	return args.Error(0)
+}
+
+func (m *MockRepoRatings) DeleteRating(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
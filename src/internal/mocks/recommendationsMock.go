@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoRecommendations struct {
+	mock.Mock
+}
+
+func (m *MockRepoRecommendations) GetRecommendationsByUserId(ctx context.Context, idUser int) ([]*models.Recommendations, error) {
+	args := m.Called(idUser)
+	return args.Get(0).([]*models.Recommendations), args.Error(1)
+}
+
+func (m *MockRepoRecommendations) CreateRecommendation(ctx context.Context, recommendation *models.Recommendations) error {
+	args := m.Called(recommendation)
+	return args.Error(0)
+}
+
+func (m *MockRepoRecommendations) DeleteRecommendationsByUserId(ctx context.Context, idUser int) error {
+	args := m.Called(idUser)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,32 +11,32 @@ type MockRepoSeasons struct {
 	mock.Mock
 }
 
-func (m *MockRepoSeasons) GetSeasons() ([]*models.Seasons, error) {
+func (m *MockRepoSeasons) GetSeasons(ctx context.Context) ([]*models.Seasons, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Seasons), args.Error(1)
 }
 
-func (m *MockRepoSeasons) GetSeasonById(id int) (*models.Seasons, error) {
+func (m *MockRepoSeasons) GetSeasonById(ctx context.Context, id int) (*models.Seasons, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Seasons), args.Error(1)
 }
 
-func (m *MockRepoSeasons) GetSeasonsBySerialId(id int) ([]*models.Seasons, error) {
+func (m *MockRepoSeasons) GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error) {
 	args := m.Called(id)
 	return args.Get(0).([]*models.Seasons), args.Error(1)
 }
 
-func (m *MockRepoSeasons) CreateSeason(season *models.Seasons) error {
+func (m *MockRepoSeasons) CreateSeason(ctx context.Context, season *models.Seasons) error {
 	args := m.Called(season)
 	return args.Error(0)
 }
 
-func (m *MockRepoSeasons) UpdateSeason(season *models.Seasons) error {
+func (m *MockRepoSeasons) UpdateSeason(ctx context.Context, season *models.Seasons) error {
 	args := m.Called(season)
 	return args.Error(0)
 }
 
-func (m *MockRepoSeasons) DeleteSeason(id int) error {
+func (m *MockRepoSeasons) DeleteSeason(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,32 +10,37 @@ type MockRepoSerialsActors struct {
 	mock.Mock
 }
 
-func (m *MockRepoSerialsActors) GetSerialsActors() ([]*models.SerialsActors, error) {
+func (m *MockRepoSerialsActors) GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.SerialsActors), args.Error(1)
 }
 
-func (m *MockRepoSerialsActors) GetSerialsByActorId(id int) ([]*models.SerialsActors, error) {
+func (m *MockRepoSerialsActors) GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
 	args := m.Called(id)
 	return args.Get(0).([]*models.SerialsActors), args.Error(1)
 }
 
-func (m *MockRepoSerialsActors) GetActorsBySerialId(id int) ([]*models.SerialsActors, error) {
+func (m *MockRepoSerialsActors) GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
 	args := m.Called(id)
 	return args.Get(0).([]*models.SerialsActors), args.Error(1)
 }
 
-func (m *MockRepoSerialsActors) GetSerialsActorsById(id int) (*models.SerialsActors, error) {
+func (m *MockRepoSerialsActors) GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.SerialsActors), args.Error(1)
 }
 
-func (m *MockRepoSerialsActors) CreateSerialsActors(serialActor *models.SerialsActors) error {
+func (m *MockRepoSerialsActors) CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	args := m.Called(serialActor)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerialsActors) UpdateSerialsActors(serialActor *models.SerialsActors) error {
+func (m *MockRepoSerialsActors) UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	args := m.Called(serialActor)
 	return args.Error(0)
 }
+
+func (m *MockRepoSerialsActors) DeleteSerialsActors(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
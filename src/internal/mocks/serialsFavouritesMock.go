@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,27 +11,47 @@ type MockRepoSerialsFavourites struct {
 	mock.Mock
 }
 
-func (m *MockRepoSerialsFavourites) GetSerialsFavourites() ([]*models.SerialsFavourites, error) {
+func (m *MockRepoSerialsFavourites) GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.SerialsFavourites), args.Error(1)
 }
 
-func (m *MockRepoSerialsFavourites) GetSerialsFavouritesById(id int) (*models.SerialsFavourites, error) {
+func (m *MockRepoSerialsFavourites) GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.SerialsFavourites), args.Error(1)
 }
 
-func (m *MockRepoSerialsFavourites) CreateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (m *MockRepoSerialsFavourites) CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	args := m.Called(serialFavourite)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerialsFavourites) UpdateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (m *MockRepoSerialsFavourites) UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	args := m.Called(serialFavourite)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerialsFavourites) DeleteSerialsFavourites(id int) error {
+func (m *MockRepoSerialsFavourites) DeleteSerialsFavourites(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockRepoSerialsFavourites) CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool {
+	args := m.Called(serialFavourite)
+	return args.Bool(0)
+}
+
+func (m *MockRepoSerialsFavourites) GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*models.SerialsFavourites), args.Error(1)
+}
+
+func (m *MockRepoSerialsFavourites) GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*models.SerialsFavourites), args.Error(1)
+}
+
+func (m *MockRepoSerialsFavourites) DeleteSerialById(ctx context.Context, idfav, idserial int) error {
+	args := m.Called(idfav, idserial)
+	return args.Error(0)
+}
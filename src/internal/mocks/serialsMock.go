@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,37 +11,52 @@ type MockRepoSerials struct {
 	mock.Mock
 }
 
-func (m *MockRepoSerials) GetSerials() ([]*models.Serial, error) {
+func (m *MockRepoSerials) GetSerials(ctx context.Context) ([]*models.Serial, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Serial), args.Error(1)
 }
 
-func (m *MockRepoSerials) GetSerialById(id int) (*models.Serial, error) {
+func (m *MockRepoSerials) GetSerialById(ctx context.Context, id int) (*models.Serial, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Serial), args.Error(1)
 }
 
-func (m *MockRepoSerials) GetSerialsByTitle(title string) ([]*models.Serial, error) {
+func (m *MockRepoSerials) GetSerialsByTitle(ctx context.Context, title string) ([]*models.Serial, error) {
 	args := m.Called(title)
 	return args.Get(0).([]*models.Serial), args.Error(1)
 }
 
-func (m *MockRepoSerials) CreateSerial(serial *models.Serial) error {
+func (m *MockRepoSerials) SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error) {
+	args := m.Called(query, page)
+	return args.Get(0).([]*models.Serial), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoSerials) FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error) {
+	args := m.Called(text, page)
+	return args.Get(0).([]*models.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoSerials) Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockRepoSerials) CreateSerial(ctx context.Context, serial *models.Serial) error {
 	args := m.Called(serial)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerials) UpdateSerial(serial *models.Serial) error {
+func (m *MockRepoSerials) UpdateSerial(ctx context.Context, serial *models.Serial) error {
 	args := m.Called(serial)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerials) DeleteSerial(id int) error {
+func (m *MockRepoSerials) LockSerial(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerials) CalculateDuration(serial *models.Serial) error {
-	args := m.Called(serial)
+func (m *MockRepoSerials) DeleteSerial(ctx context.Context, id int) error {
+	args := m.Called(id)
 	return args.Error(0)
 }
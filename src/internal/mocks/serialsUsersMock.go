@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"app/internal/models"
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,32 +10,57 @@ type MockRepoSerialsUsers struct {
 	mock.Mock
 }
 
-func (m *MockRepoSerialsUsers) GetSerialsUsers() ([]*models.SerialsUsers, error) {
+func (m *MockRepoSerialsUsers) GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.SerialsUsers), args.Error(1)
 }
 
-func (m *MockRepoSerialsUsers) GetSerialsUsersById(id int) (*models.SerialsUsers, error) {
+func (m *MockRepoSerialsUsers) GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error) {
+	args := m.Called(page)
+	return args.Get(0).([]*models.SerialsUsers), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoSerialsUsers) GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.SerialsUsers), args.Error(1)
 }
 
-func (m *MockRepoSerialsUsers) GetSerialsByUserId(id int) ([]*models.SerialsUsers, error) {
+func (m *MockRepoSerialsUsers) GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
 	args := m.Called(id)
 	return args.Get(0).([]*models.SerialsUsers), args.Error(1)
 }
 
-func (m *MockRepoSerialsUsers) GetUsersBySerialId(id int) ([]*models.SerialsUsers, error) {
+func (m *MockRepoSerialsUsers) GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error) {
+	args := m.Called(id, page)
+	return args.Get(0).([]*models.SerialsUsers), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoSerialsUsers) GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
 	args := m.Called(id)
 	return args.Get(0).([]*models.SerialsUsers), args.Error(1)
 }
 
-func (m *MockRepoSerialsUsers) CreateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (m *MockRepoSerialsUsers) CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	args := m.Called(serialUser)
 	return args.Error(0)
 }
 
-func (m *MockRepoSerialsUsers) UpdateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (m *MockRepoSerialsUsers) UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	args := m.Called(serialUser)
 	return args.Error(0)
 }
+
+func (m *MockRepoSerialsUsers) DeleteSerialsUsers(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoSerialsUsers) DeleteSerialsByUserId(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepoSerialsUsers) GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error) {
+	args := m.Called(serialId, userId)
+	return args.Get(0).(*models.SerialsUsers), args.Error(1)
+}
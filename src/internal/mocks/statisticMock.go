@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"app/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepoStatistic struct {
+	mock.Mock
+}
+
+func (m *MockRepoStatistic) GetStatistic(ctx context.Context) (*models.Statistic, error) {
+	args := m.Called()
+	return args.Get(0).(*models.Statistic), args.Error(1)
+}
+
+func (m *MockRepoStatistic) UpdateStatistic(ctx context.Context, stat *models.Statistic) error {
+	args := m.Called(stat)
+	return args.Error(0)
+}
@@ -0,0 +1,117 @@
+package mocks
+
+import (
+	"app/internal/interfaces"
+	"context"
+)
+
+// MockUnitOfWork calls the function with the mock repositories of Tx.
+// Do returns the error of the function, that is the error the transaction
+// would be rolled back with.
+type MockUnitOfWork struct {
+	Tx    *MockTx
+	Calls int
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx interfaces.ITx) error) error {
+	m.Calls++
+	return fn(ctx, m.Tx)
+}
+
+type MockTx struct {
+	ActorsRepo            *MockRepoActors
+	CommentsRepo          *MockRepoComments
+	CommentsReportsRepo   *MockRepoCommentsReports
+	CommentsVotesRepo     *MockRepoCommentsVotes
+	ComparisonsRepo       *MockRepoComparisons
+	EpisodesRepo          *MockRepoEpisodes
+	EpisodesUsersRepo     *MockRepoEpisodesUsers
+	FavouritesRepo        *MockRepoFavourites
+	ModerationsRepo       *MockRepoModerations
+	ProducersRepo         *MockRepoProducers
+	RatingsRepo           *MockRepoRatings
+	RecommendationsRepo   *MockRepoRecommendations
+	SeasonsRepo           *MockRepoSeasons
+	SerialsRepo           *MockRepoSerials
+	SerialsActorsRepo     *MockRepoSerialsActors
+	SerialsFavouritesRepo *MockRepoSerialsFavourites
+	SerialsUsersRepo      *MockRepoSerialsUsers
+	StatisticRepo         *MockRepoStatistic
+	UsersRepo             *MockRepoUsers
+}
+
+func (m *MockTx) Actors() interfaces.IRepoActors {
+	return m.ActorsRepo
+}
+
+func (m *MockTx) Comments() interfaces.IRepoComments {
+	return m.CommentsRepo
+}
+
+func (m *MockTx) CommentsReports() interfaces.IRepoCommentsReports {
+	return m.CommentsReportsRepo
+}
+
+func (m *MockTx) CommentsVotes() interfaces.IRepoCommentsVotes {
+	return m.CommentsVotesRepo
+}
+
+func (m *MockTx) Comparisons() interfaces.IRepoComparisons {
+	return m.ComparisonsRepo
+}
+
+func (m *MockTx) Episodes() interfaces.IRepoEpisodes {
+	return m.EpisodesRepo
+}
+
+func (m *MockTx) EpisodesUsers() interfaces.IRepoEpisodesUsers {
+	return m.EpisodesUsersRepo
+}
+
+func (m *MockTx) Favourites() interfaces.IRepoFavourites {
+	return m.FavouritesRepo
+}
+
+func (m *MockTx) Moderations() interfaces.IRepoModerations {
+	return m.ModerationsRepo
+}
+
+func (m *MockTx) Producers() interfaces.IRepoProducers {
+	return m.ProducersRepo
+}
+
+func (m *MockTx) Ratings() interfaces.IRepoRatings {
+	return m.RatingsRepo
+}
+
+func (m *MockTx) Recommendations() interfaces.IRepoRecommendations {
+	return m.RecommendationsRepo
+}
+
+func (m *MockTx) Seasons() interfaces.IRepoSeasons {
+	return m.SeasonsRepo
+}
+
+func (m *MockTx) Serials() interfaces.IRepoSerials {
+	return m.SerialsRepo
+}
+
+func (m *MockTx) SerialsActors() interfaces.IRepoSerialsActors {
+	return m.SerialsActorsRepo
+}
+
+func (m *MockTx) SerialsFavourites() interfaces.IRepoSerialsFavourites {
+	return m.SerialsFavouritesRepo
+}
+
+func (m *MockTx) SerialsUsers() interfaces.IRepoSerialsUsers {
+	return m.SerialsUsersRepo
+}
+
+func (m *MockTx) Statistic() interfaces.IRepoStatistic {
+	return m.StatisticRepo
+}
+
+func (m *MockTx) Users() interfaces.IRepoUsers {
+	return m.UsersRepo
+}
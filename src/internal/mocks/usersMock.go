@@ -3,6 +3,7 @@ package mocks
 import (
 	"app/internal/controllers"
 	"app/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -12,37 +13,42 @@ type MockRepoUsers struct {
 	mock.Mock
 }
 
-func (m *MockRepoUsers) GetUsers() ([]*models.Users, error) {
+func (m *MockRepoUsers) GetUsers(ctx context.Context) ([]*models.Users, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Users), args.Error(1)
 }
 
-func (m *MockRepoUsers) GetUserById(id int) (*models.Users, error) {
+func (m *MockRepoUsers) GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error) {
+	args := m.Called(page)
+	return args.Get(0).([]*models.Users), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepoUsers) GetUserById(ctx context.Context, id int) (*models.Users, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Users), args.Error(1)
 }
 
-func (m *MockRepoUsers) CheckUser(user string) bool {
+func (m *MockRepoUsers) CheckUser(ctx context.Context, user string) bool {
 	args := m.Called(user)
 	return args.Bool(0)
 }
 
-func (m *MockRepoUsers) CreateUser(user *models.Users) error {
+func (m *MockRepoUsers) CreateUser(ctx context.Context, user *models.Users) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockRepoUsers) UpdateUser(user *models.Users) error {
+func (m *MockRepoUsers) UpdateUser(ctx context.Context, user *models.Users) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockRepoUsers) DeleteUser(id int) error {
+func (m *MockRepoUsers) DeleteUser(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRepoUsers) GetUserByLogin(login string) (*models.Users, error) {
+func (m *MockRepoUsers) GetUserByLogin(ctx context.Context, login string) (*models.Users, error) {
 	pass, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 	if login == "admin" {
 		return &models.Users{
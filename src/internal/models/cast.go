@@ -0,0 +1,54 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+)
+
+// CastMember is an actor with their role in a serial.
+type CastMember struct {
+	Actor *Actors        `json:"actor"`
+	Role  *SerialsActors `json:"role"`
+}
+
+// CastGroup is the part of the cast of a serial in one type of role.
+type CastGroup struct {
+	Role    string
+	Members []*CastMember
+}
+
+// GroupCast groups the cast by the type of role in the order of CastRoles,
+// the empty groups are left out. A group is ordered by billing with the
+// actors not billed last, then by surname and name.
+func GroupCast(cast []*CastMember) []*CastGroup {
+	cast = slices.Clone(cast)
+	slices.SortStableFunc(cast, func(a, b *CastMember) int {
+		return cmp.Or(
+			cmp.Compare(billingKey(a.Role.GetBilling()), billingKey(b.Role.GetBilling())),
+			cmp.Compare(a.Actor.GetSurname(), b.Actor.GetSurname()),
+			cmp.Compare(a.Actor.GetName(), b.Actor.GetName()),
+			cmp.Compare(a.Role.GetId(), b.Role.GetId()))
+	})
+	groups := []*CastGroup{}
+	for _, role := range CastRoles {
+		group := &CastGroup{Role: role}
+		for _, member := range cast {
+			if member.Role.GetRole() == role {
+				group.Members = append(group.Members, member)
+			}
+		}
+		if len(group.Members) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// billingKey orders the billed actors by their place and the others after
+// them.
+func billingKey(billing int) int {
+	if billing == 0 {
+		return int(^uint(0) >> 1)
+	}
+	return billing
+}
@@ -1,20 +1,50 @@
 package models
 
+import "sort"
+
+// Orders of the reviews of a serial.
+const (
+	CommentsByNewest  = "new"
+	CommentsByHelpful = "helpful"
+)
+
+// Comments is a review of a serial or, with a parent, a reply to another
+// comment of the same serial. C_up and C_down count the votes of the users.
+// A comment hidden by a moderator is shown without its text.
 type Comments struct {
 	C_text     string `json:"text"`
 	C_date     string `json:"date"`
 	C_id       int    `json:"id"`
 	C_idUser   int    `json:"idUser"`
 	C_idSerial int    `json:"idSerial"`
+	C_idParent int    `json:"idParent"`
+	C_up       int    `json:"up"`
+	C_down     int    `json:"down"`
+	C_hidden   bool   `json:"hidden"`
 }
 
 func (c *Comments) Validate() bool {
-	if c.C_idUser <= 0 || c.C_text == "" || c.C_date == "" || c.C_idSerial <= 0 {
+	if c.C_idUser <= 0 || c.C_text == "" || c.C_date == "" || c.C_idSerial <= 0 || c.C_idParent < 0 || c.C_up < 0 || c.C_down < 0 {
 		return false
 	}
 	return true
 }
 
+// IsReview reports whether the comment is a review, not a reply.
+func (c *Comments) IsReview() bool {
+	return c.C_idParent == 0
+}
+
+// Markup returns the text of the comment split by ParseMarkup.
+func (c *Comments) Markup() []*MarkupNode {
+	return ParseMarkup(c.C_text)
+}
+
+// Helpfulness is the number of upvotes minus the number of downvotes.
+func (c *Comments) Helpfulness() int {
+	return c.C_up - c.C_down
+}
+
 func (c *Comments) GetId() int {
 	return c.C_id
 }
@@ -35,6 +65,22 @@ func (c *Comments) GetIdSerial() int {
 	return c.C_idSerial
 }
 
+func (c *Comments) GetIdParent() int {
+	return c.C_idParent
+}
+
+func (c *Comments) GetUp() int {
+	return c.C_up
+}
+
+func (c *Comments) GetDown() int {
+	return c.C_down
+}
+
+func (c *Comments) GetHidden() bool {
+	return c.C_hidden
+}
+
 func (c *Comments) SetId(id int) {
 	c.C_id = id
 }
@@ -54,3 +100,54 @@ func (c *Comments) SetDate(date string) {
 func (c *Comments) SetIdSerial(idSerial int) {
 	c.C_idSerial = idSerial
 }
+
+func (c *Comments) SetIdParent(idParent int) {
+	c.C_idParent = idParent
+}
+
+func (c *Comments) SetVotes(up, down int) {
+	c.C_up = up
+	c.C_down = down
+}
+
+func (c *Comments) SetHidden(hidden bool) {
+	c.C_hidden = hidden
+}
+
+// ValidCommentsOrder reports whether sort is one of the orders of the reviews.
+func ValidCommentsOrder(sort string) bool {
+	return sort == CommentsByNewest || sort == CommentsByHelpful
+}
+
+// CommentThread is a comment with the replies to it, oldest first.
+type CommentThread struct {
+	*Comments
+	Replies []*CommentThread
+}
+
+// NewCommentThreads builds the threads of the reviews from all the replies of
+// the serial. Replies whose parent is not among the reviews or the replies are
+// left out.
+func NewCommentThreads(reviews []*Comments, replies []*Comments) []*CommentThread {
+	children := map[int][]*Comments{}
+	for _, reply := range replies {
+		children[reply.GetIdParent()] = append(children[reply.GetIdParent()], reply)
+	}
+	for _, list := range children {
+		sort.Slice(list, func(i, j int) bool { return list[i].GetId() < list[j].GetId() })
+	}
+
+	var build func(comment *Comments) *CommentThread
+	build = func(comment *Comments) *CommentThread {
+		thread := &CommentThread{Comments: comment, Replies: []*CommentThread{}}
+		for _, reply := range children[comment.GetId()] {
+			thread.Replies = append(thread.Replies, build(reply))
+		}
+		return thread
+	}
+	threads := []*CommentThread{}
+	for _, review := range reviews {
+		threads = append(threads, build(review))
+	}
+	return threads
+}
@@ -0,0 +1,103 @@
+package models
+
+// States of a report.
+const (
+	ReportOpen   = "open"
+	ReportClosed = "closed"
+)
+
+// CommentsReports is the complaint of a user about a comment, at most one per
+// user and comment. A report stays open until a moderator decides on the
+// comment.
+type CommentsReports struct {
+	Cr_id        int    `json:"id"`
+	Cr_idComment int    `json:"idComment"`
+	Cr_idUser    int    `json:"idUser"`
+	Cr_reason    string `json:"reason"`
+	Cr_date      string `json:"date"`
+	Cr_state     string `json:"state"`
+}
+
+func (cr *CommentsReports) Validate() bool {
+	if cr.Cr_idComment <= 0 || cr.Cr_idUser <= 0 || cr.Cr_reason == "" || cr.Cr_date == "" || (cr.Cr_state != ReportOpen && cr.Cr_state != ReportClosed) {
+		return false
+	}
+	return true
+}
+
+func (cr *CommentsReports) GetId() int {
+	return cr.Cr_id
+}
+
+func (cr *CommentsReports) GetIdComment() int {
+	return cr.Cr_idComment
+}
+
+func (cr *CommentsReports) GetIdUser() int {
+	return cr.Cr_idUser
+}
+
+func (cr *CommentsReports) GetReason() string {
+	return cr.Cr_reason
+}
+
+func (cr *CommentsReports) GetDate() string {
+	return cr.Cr_date
+}
+
+func (cr *CommentsReports) GetState() string {
+	return cr.Cr_state
+}
+
+func (cr *CommentsReports) SetId(id int) {
+	cr.Cr_id = id
+}
+
+func (cr *CommentsReports) SetIdComment(idComment int) {
+	cr.Cr_idComment = idComment
+}
+
+func (cr *CommentsReports) SetIdUser(idUser int) {
+	cr.Cr_idUser = idUser
+}
+
+func (cr *CommentsReports) SetReason(reason string) {
+	cr.Cr_reason = reason
+}
+
+func (cr *CommentsReports) SetDate(date string) {
+	cr.Cr_date = date
+}
+
+func (cr *CommentsReports) SetState(state string) {
+	cr.Cr_state = state
+}
+
+// ModerationItem is a comment in the moderation queue with its open reports,
+// oldest first.
+type ModerationItem struct {
+	Comment *Comments
+	Reports []*CommentsReports
+}
+
+// NewModerationQueue groups the open reports by comment, the comment reported
+// first goes first. Reports of the comments missing from comments are left
+// out.
+func NewModerationQueue(comments map[int]*Comments, reports []*CommentsReports) []*ModerationItem {
+	queue := []*ModerationItem{}
+	items := map[int]*ModerationItem{}
+	for _, report := range reports {
+		item, ok := items[report.GetIdComment()]
+		if !ok {
+			comment, ok := comments[report.GetIdComment()]
+			if !ok {
+				continue
+			}
+			item = &ModerationItem{Comment: comment}
+			items[report.GetIdComment()] = item
+			queue = append(queue, item)
+		}
+		item.Reports = append(item.Reports, report)
+	}
+	return queue
+}
@@ -0,0 +1,49 @@
+package models
+
+// CommentsVotes is the vote of a user for a comment, at most one per user and
+// comment. Cv_value is 1 for an upvote and -1 for a downvote.
+type CommentsVotes struct {
+	Cv_id        int `json:"id"`
+	Cv_idComment int `json:"idComment"`
+	Cv_idUser    int `json:"idUser"`
+	Cv_value     int `json:"value"`
+}
+
+func (cv *CommentsVotes) Validate() bool {
+	if cv.Cv_idComment <= 0 || cv.Cv_idUser <= 0 || (cv.Cv_value != 1 && cv.Cv_value != -1) {
+		return false
+	}
+	return true
+}
+
+func (cv *CommentsVotes) GetId() int {
+	return cv.Cv_id
+}
+
+func (cv *CommentsVotes) GetIdComment() int {
+	return cv.Cv_idComment
+}
+
+func (cv *CommentsVotes) GetIdUser() int {
+	return cv.Cv_idUser
+}
+
+func (cv *CommentsVotes) GetValue() int {
+	return cv.Cv_value
+}
+
+func (cv *CommentsVotes) SetId(id int) {
+	cv.Cv_id = id
+}
+
+func (cv *CommentsVotes) SetIdComment(idComment int) {
+	cv.Cv_idComment = idComment
+}
+
+func (cv *CommentsVotes) SetIdUser(idUser int) {
+	cv.Cv_idUser = idUser
+}
+
+func (cv *CommentsVotes) SetValue(value int) {
+	cv.Cv_value = value
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Metrics of a Comparison, the larger value wins in each of them.
+const (
+	CompareRuntime           = "runtime"
+	CompareAvgRuntime        = "avgRuntime"
+	CompareEpisodesPerSeason = "episodesPerSeason"
+	CompareYearsOnAir        = "yearsOnAir"
+	CompareSharedActors      = "sharedActors"
+	CompareRating            = "rating"
+	CompareVotes             = "votes"
+	CompareFavourited        = "favourited"
+)
+
+// CompareInput is a serial with what it is compared by: all its seasons and
+// episodes, its actors and the number of the users having it in their
+// favourites, not counting their other lists.
+type CompareInput struct {
+	Serial     *Serial
+	Seasons    []*Seasons
+	Episodes   []*Episodes
+	Actors     []*Actors
+	Favourited int
+}
+
+// ComparedSerial is a serial with its metrics in a Comparison. Runtime is the
+// total duration of its episodes and AvgRuntime the duration of an episode on
+// average, both in the DurationFormat. The serial is on air from FirstYear,
+// its year, to LastYear, the last year of its seasons and episodes or the
+// current year while it goes on. SharedActors is the number of its actors
+// playing in the other compared serials. Best lists the metrics the serial
+// wins in.
+type ComparedSerial struct {
+	Serial            *Serial  `json:"serial"`
+	Seasons           int      `json:"seasons"`
+	Episodes          int      `json:"episodes"`
+	Runtime           string   `json:"runtime"`
+	AvgRuntime        string   `json:"avgRuntime"`
+	EpisodesPerSeason float64  `json:"episodesPerSeason"`
+	FirstYear         int      `json:"firstYear"`
+	LastYear          int      `json:"lastYear"`
+	YearsOnAir        int      `json:"yearsOnAir"`
+	SharedActors      int      `json:"sharedActors"`
+	Favourited        int      `json:"favourited"`
+	Best              []string `json:"best"`
+
+	runtime    time.Duration
+	avgRuntime time.Duration
+}
+
+// Wins reports whether the serial wins in the metric.
+func (cs *ComparedSerial) Wins(metric string) bool {
+	return slices.Contains(cs.Best, metric)
+}
+
+// SharedActor is an actor playing in several of the compared serials, Serials
+// holds their ids.
+type SharedActor struct {
+	Actor   *Actors `json:"actor"`
+	Serials []int   `json:"serials"`
+}
+
+// Plays reports whether the actor plays in the serial.
+func (sa *SharedActor) Plays(idSerial int) bool {
+	return slices.Contains(sa.Serials, idSerial)
+}
+
+// Comparison is the result of comparing serials, Slug is set for the saved
+// comparisons.
+type Comparison struct {
+	Serials      []*ComparedSerial `json:"serials"`
+	SharedActors []*SharedActor    `json:"sharedActors"`
+	Slug         string            `json:"slug,omitempty"`
+}
+
+var yearRegexp = regexp.MustCompile(`\d{4}`)
+
+// dateYear returns the year of a date of a season or an episode, the dates
+// are entered as text such as "20.01.2008" or "2008-01-20". It returns 0 if
+// the date has no year.
+func dateYear(date string) int {
+	year, _ := strconv.Atoi(yearRegexp.FindString(date))
+	return year
+}
+
+// NewComparison compares the serials in the order given, year is the current
+// year. A metric has winners only when the serials differ in it, all the
+// serials with the largest value win.
+func NewComparison(inputs []*CompareInput, year int) *Comparison {
+	c := &Comparison{Serials: []*ComparedSerial{}, SharedActors: []*SharedActor{}}
+
+	actors := map[int]*Actors{}
+	playing := map[int][]int{}
+	for _, in := range inputs {
+		for _, actor := range in.Actors {
+			if !slices.Contains(playing[actor.GetId()], in.Serial.GetId()) {
+				actors[actor.GetId()] = actor
+				playing[actor.GetId()] = append(playing[actor.GetId()], in.Serial.GetId())
+			}
+		}
+	}
+	shared := map[int]int{}
+	for id, serials := range playing {
+		if len(serials) < 2 {
+			continue
+		}
+		c.SharedActors = append(c.SharedActors, &SharedActor{Actor: actors[id], Serials: serials})
+		for _, idSerial := range serials {
+			shared[idSerial]++
+		}
+	}
+	slices.SortFunc(c.SharedActors, func(a, b *SharedActor) int {
+		return cmp.Or(cmp.Compare(len(b.Serials), len(a.Serials)), cmp.Compare(a.Actor.GetId(), b.Actor.GetId()))
+	})
+
+	for _, in := range inputs {
+		cs := &ComparedSerial{
+			Serial:       in.Serial,
+			Seasons:      len(in.Seasons),
+			Episodes:     len(in.Episodes),
+			FirstYear:    in.Serial.GetYear(),
+			LastYear:     in.Serial.GetYear(),
+			SharedActors: shared[in.Serial.GetId()],
+			Favourited:   in.Favourited,
+			Best:         []string{},
+		}
+		for _, episode := range in.Episodes {
+			d, err := ParseDuration(episode.GetDuration())
+			if err == nil {
+				cs.runtime += d
+			}
+			cs.LastYear = max(cs.LastYear, dateYear(episode.GetDate()))
+		}
+		for _, season := range in.Seasons {
+			cs.LastYear = max(cs.LastYear, dateYear(season.GetDate()))
+		}
+		if in.Serial.GetState() == SerialOngoing {
+			cs.LastYear = max(cs.LastYear, year)
+		}
+		cs.YearsOnAir = cs.LastYear - cs.FirstYear + 1
+		if cs.Episodes > 0 {
+			cs.avgRuntime = cs.runtime / time.Duration(cs.Episodes)
+		}
+		if cs.Seasons > 0 {
+			cs.EpisodesPerSeason = math.Round(float64(cs.Episodes)/float64(cs.Seasons)*100) / 100
+		}
+		cs.Runtime = FormatDuration(cs.runtime)
+		cs.AvgRuntime = FormatDuration(cs.avgRuntime)
+		c.Serials = append(c.Serials, cs)
+	}
+
+	metrics := []struct {
+		name  string
+		value func(cs *ComparedSerial) float64
+	}{
+		{CompareRuntime, func(cs *ComparedSerial) float64 { return float64(cs.runtime) }},
+		{CompareAvgRuntime, func(cs *ComparedSerial) float64 { return float64(cs.avgRuntime) }},
+		{CompareEpisodesPerSeason, func(cs *ComparedSerial) float64 { return cs.EpisodesPerSeason }},
+		{CompareYearsOnAir, func(cs *ComparedSerial) float64 { return float64(cs.YearsOnAir) }},
+		{CompareSharedActors, func(cs *ComparedSerial) float64 { return float64(cs.SharedActors) }},
+		{CompareRating, func(cs *ComparedSerial) float64 { return float64(cs.Serial.GetRating()) }},
+		{CompareVotes, func(cs *ComparedSerial) float64 { return float64(cs.Serial.GetVotes()) }},
+		{CompareFavourited, func(cs *ComparedSerial) float64 { return float64(cs.Favourited) }},
+	}
+	for _, metric := range metrics {
+		if len(c.Serials) == 0 {
+			break
+		}
+		best, worst := metric.value(c.Serials[0]), metric.value(c.Serials[0])
+		for _, cs := range c.Serials {
+			best, worst = max(best, metric.value(cs)), min(worst, metric.value(cs))
+		}
+		if best == worst {
+			continue
+		}
+		for _, cs := range c.Serials {
+			if metric.value(cs) == best {
+				cs.Best = append(cs.Best, metric.name)
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	MinCompare = 2
+	// MaxCompare is the number of the serials compared at most at once.
+	MaxCompare = 10
+)
+
+// Comparisons is a comparison saved by a user, Cm_serials holds the ids of the
+// compared serials in their order separated by commas. Cm_slug is the part of
+// the link to the comparison.
+type Comparisons struct {
+	Cm_id      int    `json:"id"`
+	Cm_idUser  int    `json:"idUser"`
+	Cm_slug    string `json:"slug"`
+	Cm_serials string `json:"serials"`
+	Cm_date    string `json:"date"`
+}
+
+func (cm *Comparisons) Validate() bool {
+	if cm.Cm_idUser <= 0 || cm.Cm_slug == "" || cm.Cm_date == "" || !ValidCompareIds(cm.GetSerialIds()) {
+		return false
+	}
+	return true
+}
+
+func (cm *Comparisons) GetId() int {
+	return cm.Cm_id
+}
+
+func (cm *Comparisons) GetIdUser() int {
+	return cm.Cm_idUser
+}
+
+func (cm *Comparisons) GetSlug() string {
+	return cm.Cm_slug
+}
+
+func (cm *Comparisons) GetSerials() string {
+	return cm.Cm_serials
+}
+
+func (cm *Comparisons) GetDate() string {
+	return cm.Cm_date
+}
+
+// GetSerialIds returns the ids of the compared serials, nil if Cm_serials is
+// malformed.
+func (cm *Comparisons) GetSerialIds() []int {
+	ids := []int{}
+	for _, part := range strings.Split(cm.Cm_serials, ",") {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (cm *Comparisons) SetId(id int) {
+	cm.Cm_id = id
+}
+
+func (cm *Comparisons) SetIdUser(idUser int) {
+	cm.Cm_idUser = idUser
+}
+
+func (cm *Comparisons) SetSlug(slug string) {
+	cm.Cm_slug = slug
+}
+
+func (cm *Comparisons) SetSerials(serials string) {
+	cm.Cm_serials = serials
+}
+
+func (cm *Comparisons) SetDate(date string) {
+	cm.Cm_date = date
+}
+
+// SetSerialIds sets the ids of the compared serials.
+func (cm *Comparisons) SetSerialIds(ids []int) {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	cm.Cm_serials = strings.Join(parts, ",")
+}
+
+// ValidCompareIds reports whether the serials can be compared: there are from
+// MinCompare to MaxCompare of them and they are different.
+func ValidCompareIds(ids []int) bool {
+	if len(ids) < MinCompare || len(ids) > MaxCompare {
+		return false
+	}
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// SavedComparison is a saved comparison with its serials, the deleted serials
+// are left out.
+type SavedComparison struct {
+	Comparison *Comparisons
+	Serials    []*Serial
+}
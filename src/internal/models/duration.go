@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Durations of episodes (E_duration) and serials (S_duration) are stored as
+// strings in the "ЧЧ:ММ:СС" format: hours, minutes and seconds separated by
+// colons. Minutes and seconds always have two digits, hours have at least two
+// and are not limited to 24, so the total duration of a long serial looks like
+// "153:20:00".
+const DurationFormat = "ЧЧ:ММ:СС"
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration parses a duration in the DurationFormat.
+func ParseDuration(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 || len(parts[0]) < 2 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return 0, ErrInvalidDuration
+	}
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || strings.HasPrefix(part, "+") {
+			return 0, ErrInvalidDuration
+		}
+		values[i] = v
+	}
+	if values[1] > 59 || values[2] > 59 {
+		return 0, ErrInvalidDuration
+	}
+	return time.Duration(values[0])*time.Hour + time.Duration(values[1])*time.Minute + time.Duration(values[2])*time.Second, nil
+}
+
+// FormatDuration formats a duration in the DurationFormat, dropping fractions of a second.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	total := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total/60%60, total%60)
+}
@@ -10,10 +10,11 @@ type Episodes struct {
 }
 
 func (e *Episodes) Validate() bool {
-	if e.E_idSeason <= 0 || e.E_name == "" || e.E_num < 0 || e.E_duration == "" || e.E_date == "" {
+	if e.E_idSeason <= 0 || e.E_name == "" || e.E_num < 0 || e.E_date == "" {
 		return false
 	}
-	return true
+	_, err := ParseDuration(e.E_duration)
+	return err == nil
 }
 
 func (e *Episodes) GetId() int {
@@ -0,0 +1,134 @@
+package models
+
+// EpisodesUsers marks the episode as watched by the user. The serial of the
+// episode is stored too, so that the progress of a serial is read at once.
+type EpisodesUsers struct {
+	Eu_id        int `json:"id"`
+	Eu_idEpisode int `json:"idEpisode"`
+	Eu_idSerial  int `json:"idSerial"`
+	Eu_idUser    int `json:"idUser"`
+}
+
+func (eu *EpisodesUsers) Validate() bool {
+	if eu.Eu_idEpisode <= 0 || eu.Eu_idSerial <= 0 || eu.Eu_idUser <= 0 {
+		return false
+	}
+	return true
+}
+
+func (eu *EpisodesUsers) GetId() int {
+	return eu.Eu_id
+}
+
+func (eu *EpisodesUsers) GetIdEpisode() int {
+	return eu.Eu_idEpisode
+}
+
+func (eu *EpisodesUsers) GetIdSerial() int {
+	return eu.Eu_idSerial
+}
+
+func (eu *EpisodesUsers) GetIdUser() int {
+	return eu.Eu_idUser
+}
+
+func (eu *EpisodesUsers) SetId(id int) {
+	eu.Eu_id = id
+}
+
+func (eu *EpisodesUsers) SetIdEpisode(idEpisode int) {
+	eu.Eu_idEpisode = idEpisode
+}
+
+func (eu *EpisodesUsers) SetIdSerial(idSerial int) {
+	eu.Eu_idSerial = idSerial
+}
+
+func (eu *EpisodesUsers) SetIdUser(idUser int) {
+	eu.Eu_idUser = idUser
+}
+
+// Progress is the number of the watched episodes out of all of them.
+type Progress struct {
+	Watched int `json:"watched"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
+}
+
+func newProgress(watched, total int) Progress {
+	p := Progress{Watched: watched, Total: total}
+	if total > 0 {
+		p.Percent = watched * 100 / total
+	}
+	return p
+}
+
+// WatchedEpisode is an episode with whether the user has watched it.
+type WatchedEpisode struct {
+	Episode *Episodes `json:"episode"`
+	Watched bool      `json:"watched"`
+}
+
+// SeasonProgress is the progress of the user in one season.
+type SeasonProgress struct {
+	Progress
+	Season   *Seasons          `json:"season"`
+	Episodes []*WatchedEpisode `json:"episodes"`
+}
+
+// SerialProgress is the progress of the user in the serial. Next is the
+// episode to watch next and NextSeason its season, both are nil when every
+// episode is watched.
+type SerialProgress struct {
+	Progress
+	IdSerial   int               `json:"idSerial"`
+	Seasons    []*SeasonProgress `json:"seasons"`
+	Next       *Episodes         `json:"next"`
+	NextSeason *Seasons          `json:"nextSeason"`
+}
+
+// NewSerialProgress counts the watched episodes of the serial. The seasons
+// and the episodes of every season, keyed by the season id, are in the order
+// they are watched in. The next episode is the one after the last watched
+// episode, or the first unwatched one when there is none after it.
+func NewSerialProgress(idSerial int, seasons []*Seasons, episodes map[int][]*Episodes, watched []*EpisodesUsers) *SerialProgress {
+	isWatched := map[int]bool{}
+	for _, eu := range watched {
+		isWatched[eu.GetIdEpisode()] = true
+	}
+
+	sp := &SerialProgress{IdSerial: idSerial, Seasons: []*SeasonProgress{}}
+	var first, afterLast *Episodes
+	var firstSeason, afterLastSeason *Seasons
+	cntWatched, cntTotal := 0, 0
+	for _, season := range seasons {
+		ssp := &SeasonProgress{Season: season, Episodes: []*WatchedEpisode{}}
+		seasonWatched := 0
+		for _, episode := range episodes[season.GetId()] {
+			ok := isWatched[episode.GetId()]
+			ssp.Episodes = append(ssp.Episodes, &WatchedEpisode{Episode: episode, Watched: ok})
+			if ok {
+				seasonWatched++
+				afterLast, afterLastSeason = nil, nil
+				continue
+			}
+			if first == nil {
+				first, firstSeason = episode, season
+			}
+			if afterLast == nil {
+				afterLast, afterLastSeason = episode, season
+			}
+		}
+		ssp.Progress = newProgress(seasonWatched, len(ssp.Episodes))
+		sp.Seasons = append(sp.Seasons, ssp)
+		cntWatched += seasonWatched
+		cntTotal += len(ssp.Episodes)
+	}
+	sp.Progress = newProgress(cntWatched, cntTotal)
+
+	sp.Next, sp.NextSeason = afterLast, afterLastSeason
+	if sp.Next == nil {
+		sp.Next, sp.NextSeason = first, firstSeason
+	}
+	return sp
+}
@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrInvalidModel = errors.New("invalid model")
+	ErrNotFound     = errors.New("not found")
 )
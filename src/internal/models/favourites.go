@@ -1,26 +1,151 @@
 package models
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of the name and the note of a list.
+const (
+	MaxListName = 50
+	MaxListNote = 500
+)
+
+// Visibility of a list. A private list is seen only by its owner, an unlisted
+// one by everyone who has its link and a public one is also shown on the
+// profile of the owner.
+const (
+	ListPrivate  = "private"
+	ListUnlisted = "unlisted"
+	ListPublic   = "public"
+)
+
+// ValidListVisibility reports whether v is a visibility of a list.
+func ValidListVisibility(v string) bool {
+	return v == ListPrivate || v == ListUnlisted || v == ListPublic
+}
+
+// NewSlug returns a random slug for the link of a shared list or a saved
+// comparison, long enough not to be guessed.
+func NewSlug() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// DefaultListNames are the lists every user starts with. The first one is the
+// default list of Users.U_idFavourites, which cannot be deleted.
+var DefaultListNames = []string{"Избранное", "Смотрю", "Буду смотреть", "Брошено"}
+
+// Favourites is a named list of serials of a user. The lists of a user are
+// shown ordered by F_position, F_cntSerials is the number of serials in the
+// list. F_idUser of the default list is zero only while its user is created.
+// F_slug is the stable part of the link of the list, given when the list is
+// shared for the first time.
 type Favourites struct {
-	F_id         int `json:"id"`
-	F_cntSerials int `json:"cntSerials"`
+	F_id         int    `json:"id"`
+	F_idUser     int    `json:"idUser"`
+	F_name       string `json:"name"`
+	F_note       string `json:"note"`
+	F_position   int    `json:"position"`
+	F_cntSerials int    `json:"cntSerials"`
+	F_visibility string `json:"visibility"`
+	F_slug       string `json:"slug"`
 }
 
 func (f *Favourites) Validate() bool {
-	return f.F_cntSerials >= 0
+	if f.F_idUser < 0 || strings.TrimSpace(f.F_name) == "" || utf8.RuneCountInString(f.F_name) > MaxListName ||
+		utf8.RuneCountInString(f.F_note) > MaxListNote || f.F_position < 0 || f.F_cntSerials < 0 ||
+		!ValidListVisibility(f.F_visibility) || (f.F_visibility != ListPrivate && f.F_slug == "") {
+		return false
+	}
+	return true
 }
 
 func (f *Favourites) GetId() int {
 	return f.F_id
 }
 
+func (f *Favourites) GetIdUser() int {
+	return f.F_idUser
+}
+
+func (f *Favourites) GetName() string {
+	return f.F_name
+}
+
+func (f *Favourites) GetNote() string {
+	return f.F_note
+}
+
+func (f *Favourites) GetPosition() int {
+	return f.F_position
+}
+
 func (f *Favourites) GetCntSerials() int {
 	return f.F_cntSerials
 }
 
+func (f *Favourites) GetVisibility() string {
+	return f.F_visibility
+}
+
+func (f *Favourites) GetSlug() string {
+	return f.F_slug
+}
+
+// Shared reports whether the list may be seen by other users.
+func (f *Favourites) Shared() bool {
+	return f.F_visibility == ListUnlisted || f.F_visibility == ListPublic
+}
+
 func (f *Favourites) SetId(id int) {
 	f.F_id = id
 }
 
+func (f *Favourites) SetIdUser(idUser int) {
+	f.F_idUser = idUser
+}
+
+func (f *Favourites) SetName(name string) {
+	f.F_name = name
+}
+
+func (f *Favourites) SetNote(note string) {
+	f.F_note = note
+}
+
+func (f *Favourites) SetPosition(position int) {
+	f.F_position = position
+}
+
 func (f *Favourites) SetCntSerials(cntSerials int) {
 	f.F_cntSerials = cntSerials
 }
+
+func (f *Favourites) SetVisibility(visibility string) {
+	f.F_visibility = visibility
+}
+
+func (f *Favourites) SetSlug(slug string) {
+	f.F_slug = slug
+}
+
+// ListEntry is a serial of a list with the date it was added.
+type ListEntry struct {
+	Serial *Serial `json:"serial"`
+	Date   string  `json:"date"`
+}
+
+// SharedList is a list as other users see it, with the name of its owner and
+// its serials.
+type SharedList struct {
+	Name    string       `json:"name"`
+	Note    string       `json:"note"`
+	IdOwner int          `json:"idOwner"`
+	Owner   string       `json:"owner"`
+	Slug    string       `json:"slug"`
+	Entries []*ListEntry `json:"entries"`
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Kinds of the nodes of the comment markup.
+const (
+	MarkupText    = "text"
+	MarkupBold    = "bold"
+	MarkupItalic  = "italic"
+	MarkupLink    = "link"
+	MarkupSpoiler = "spoiler"
+)
+
+// MarkupNode is a piece of a comment text. Text nodes and links keep the text
+// as written, the other nodes hold the pieces they format.
+type MarkupNode struct {
+	Kind     string        `json:"kind"`
+	Text     string        `json:"text,omitempty"`
+	URL      string        `json:"url,omitempty"`
+	Children []*MarkupNode `json:"children,omitempty"`
+}
+
+// markupDelims are the delimiters of the formatting nodes, the longer ones
+// go first.
+var markupDelims = []struct {
+	delim string
+	kind  string
+}{
+	{"||", MarkupSpoiler},
+	{"**", MarkupBold},
+	{"*", MarkupItalic},
+}
+
+// ParseMarkup splits the comment text into nodes. The markup is **bold**,
+// *italics*, [text](http://link) and ||spoiler||, a backslash keeps the next
+// markup character as is. The formatting nodes may hold each other but not
+// themselves, links take plain text and only http and https addresses.
+// Unclosed delimiters, delimiters followed by a space and other links are
+// kept as text.
+func ParseMarkup(text string) []*MarkupNode {
+	nodes, _, _ := parseMarkup(text, "", map[string]bool{})
+	return nodes
+}
+
+// parseMarkup parses text up to the delimiter stop and returns the nodes, the
+// text after stop and whether stop was found. Open holds the kinds of the
+// enclosing nodes.
+func parseMarkup(text, stop string, open map[string]bool) ([]*MarkupNode, string, bool) {
+	nodes := []*MarkupNode{}
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			nodes = append(nodes, &MarkupNode{Kind: MarkupText, Text: plain.String()})
+			plain.Reset()
+		}
+	}
+
+next:
+	for text != "" {
+		if len(text) > 1 && text[0] == '\\' && strings.ContainsRune(`\*|[`, rune(text[1])) {
+			plain.WriteByte(text[1])
+			text = text[2:]
+			continue
+		}
+		if stop != "" && strings.HasPrefix(text, stop) {
+			flush()
+			return nodes, text[len(stop):], true
+		}
+		for _, d := range markupDelims {
+			if open[d.kind] || !strings.HasPrefix(text, d.delim) || !opens(text[len(d.delim):], d.delim) {
+				continue
+			}
+			open[d.kind] = true
+			children, rest, ok := parseMarkup(text[len(d.delim):], d.delim, open)
+			delete(open, d.kind)
+			if ok && len(children) > 0 {
+				flush()
+				nodes = append(nodes, &MarkupNode{Kind: d.kind, Children: children})
+				text = rest
+				continue next
+			}
+		}
+		if link, rest, ok := parseLink(text); ok {
+			flush()
+			nodes = append(nodes, link)
+			text = rest
+			continue
+		}
+		for _, d := range markupDelims {
+			if strings.HasPrefix(text, d.delim) {
+				plain.WriteString(d.delim)
+				text = text[len(d.delim):]
+				continue next
+			}
+		}
+		plain.WriteByte(text[0])
+		text = text[1:]
+	}
+	flush()
+	return nodes, "", false
+}
+
+// opens reports whether the text after a delimiter may start a node: it does
+// not start with a space and has the closing delimiter.
+func opens(text, delim string) bool {
+	return text != "" && !unicode.IsSpace(rune(text[0])) && strings.Contains(text, delim)
+}
+
+// parseLink parses the link [text](url) at the start of text.
+func parseLink(text string) (*MarkupNode, string, bool) {
+	if !strings.HasPrefix(text, "[") {
+		return nil, text, false
+	}
+	end := strings.Index(text, "](")
+	if end < 2 || strings.ContainsAny(text[1:end], "[]\n") {
+		return nil, text, false
+	}
+	n := strings.IndexByte(text[end+2:], ')')
+	if n < 0 {
+		return nil, text, false
+	}
+	address := text[end+2 : end+2+n]
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.ContainsAny(address, " \n") {
+		return nil, text, false
+	}
+	return &MarkupNode{Kind: MarkupLink, Text: text[1:end], URL: address}, text[end+3+n:], true
+}
@@ -0,0 +1,83 @@
+package models
+
+// Decisions of a moderator on a comment.
+const (
+	ModerationHide    = "hide"
+	ModerationDelete  = "delete"
+	ModerationDismiss = "dismiss"
+)
+
+// ModerationTimeLayout is the layout of the time of a decision.
+const ModerationTimeLayout = "2006-01-02 15:04:05"
+
+// Moderations records the decision of a moderator on a comment. The text of
+// the comment is copied since a deleted comment is gone.
+type Moderations struct {
+	Md_id          int    `json:"id"`
+	Md_idComment   int    `json:"idComment"`
+	Md_idModerator int    `json:"idModerator"`
+	Md_action      string `json:"action"`
+	Md_text        string `json:"text"`
+	Md_date        string `json:"date"`
+}
+
+func (md *Moderations) Validate() bool {
+	if md.Md_idComment <= 0 || md.Md_idModerator <= 0 || !ValidModerationAction(md.Md_action) || md.Md_date == "" {
+		return false
+	}
+	return true
+}
+
+func (md *Moderations) GetId() int {
+	return md.Md_id
+}
+
+func (md *Moderations) GetIdComment() int {
+	return md.Md_idComment
+}
+
+func (md *Moderations) GetIdModerator() int {
+	return md.Md_idModerator
+}
+
+func (md *Moderations) GetAction() string {
+	return md.Md_action
+}
+
+func (md *Moderations) GetText() string {
+	return md.Md_text
+}
+
+func (md *Moderations) GetDate() string {
+	return md.Md_date
+}
+
+func (md *Moderations) SetId(id int) {
+	md.Md_id = id
+}
+
+func (md *Moderations) SetIdComment(idComment int) {
+	md.Md_idComment = idComment
+}
+
+func (md *Moderations) SetIdModerator(idModerator int) {
+	md.Md_idModerator = idModerator
+}
+
+func (md *Moderations) SetAction(action string) {
+	md.Md_action = action
+}
+
+func (md *Moderations) SetText(text string) {
+	md.Md_text = text
+}
+
+func (md *Moderations) SetDate(date string) {
+	md.Md_date = date
+}
+
+// ValidModerationAction reports whether action is one of the decisions of a
+// moderator.
+func ValidModerationAction(action string) bool {
+	return action == ModerationHide || action == ModerationDelete || action == ModerationDismiss
+}
@@ -0,0 +1,47 @@
+package models
+
+import "math"
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Page selects a part of an ordered list: the Num-th (from 1) run of Size rows.
+type Page struct {
+	Num  int `json:"page"`
+	Size int `json:"pageSize"`
+}
+
+func NewPage(num, size int) Page {
+	return Page{Num: num, Size: size}
+}
+
+// Validate also rejects the pages too far for their offset to fit in an int.
+func (p Page) Validate() bool {
+	return p.Num > 0 && p.Size > 0 && p.Size <= MaxPageSize && p.Num <= math.MaxInt/p.Size
+}
+
+// Offset returns the number of rows before the page.
+func (p Page) Offset() int {
+	return (p.Num - 1) * p.Size
+}
+
+func (p Page) Limit() int {
+	return p.Size
+}
+
+// Count returns the number of pages of a list of total rows, at least one.
+func (p Page) Count(total int) int {
+	if total <= p.Size {
+		return 1
+	}
+	return (total + p.Size - 1) / p.Size
+}
+
+// Paged is a page of a list together with the length of the whole list.
+type Paged[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+	Page
+}
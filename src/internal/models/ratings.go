@@ -0,0 +1,65 @@
+package models
+
+import "math"
+
+// Bounds of the score of a rating.
+const (
+	MinScore = 1
+	MaxScore = 10
+)
+
+// Ratings is the score a user gives a serial, at most one per user and serial.
+type Ratings struct {
+	R_id       int `json:"id"`
+	R_idUser   int `json:"idUser"`
+	R_idSerial int `json:"idSerial"`
+	R_score    int `json:"score"`
+}
+
+func (r *Ratings) Validate() bool {
+	if r.R_idUser <= 0 || r.R_idSerial <= 0 || r.R_score < MinScore || r.R_score > MaxScore {
+		return false
+	}
+	return true
+}
+
+func (r *Ratings) GetId() int {
+	return r.R_id
+}
+
+func (r *Ratings) GetIdUser() int {
+	return r.R_idUser
+}
+
+func (r *Ratings) GetIdSerial() int {
+	return r.R_idSerial
+}
+
+func (r *Ratings) GetScore() int {
+	return r.R_score
+}
+
+func (r *Ratings) SetId(id int) {
+	r.R_id = id
+}
+
+func (r *Ratings) SetIdUser(idUser int) {
+	r.R_idUser = idUser
+}
+
+func (r *Ratings) SetIdSerial(idSerial int) {
+	r.R_idSerial = idSerial
+}
+
+func (r *Ratings) SetScore(score int) {
+	r.R_score = score
+}
+
+// MeanRating returns the mean of votes scores summing to sum rounded to
+// hundredths, 0 without votes. It is the rating of a serial.
+func MeanRating(sum, votes int) float32 {
+	if votes == 0 {
+		return 0
+	}
+	return float32(math.Round(float64(sum)/float64(votes)*100) / 100)
+}
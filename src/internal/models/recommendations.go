@@ -0,0 +1,94 @@
+package models
+
+// Sources of the recommendations, see Recommender.
+const (
+	RecommendByUsers   = "users"
+	RecommendBySimilar = "similar"
+	RecommendPopular   = "popular"
+)
+
+const (
+	// RecommendCount is the number of the recommendations ranked for a user.
+	RecommendCount     = 12
+	MaxRecommendations = 50
+)
+
+// Recommendations is a serial ranked for a user by the offline ranking job,
+// the recommendations of a user are numbered by Position from 1.
+type Recommendations struct {
+	Rc_id       int     `json:"id"`
+	Rc_idUser   int     `json:"idUser"`
+	Rc_idSerial int     `json:"idSerial"`
+	Rc_score    float64 `json:"score"`
+	Rc_source   string  `json:"source"`
+	Rc_position int     `json:"position"`
+}
+
+func (rc *Recommendations) Validate() bool {
+	if rc.Rc_idUser <= 0 || rc.Rc_idSerial <= 0 || rc.Rc_position <= 0 || !ValidRecommendSource(rc.Rc_source) {
+		return false
+	}
+	return true
+}
+
+func (rc *Recommendations) GetId() int {
+	return rc.Rc_id
+}
+
+func (rc *Recommendations) GetIdUser() int {
+	return rc.Rc_idUser
+}
+
+func (rc *Recommendations) GetIdSerial() int {
+	return rc.Rc_idSerial
+}
+
+func (rc *Recommendations) GetScore() float64 {
+	return rc.Rc_score
+}
+
+func (rc *Recommendations) GetSource() string {
+	return rc.Rc_source
+}
+
+func (rc *Recommendations) GetPosition() int {
+	return rc.Rc_position
+}
+
+func (rc *Recommendations) SetId(id int) {
+	rc.Rc_id = id
+}
+
+func (rc *Recommendations) SetIdUser(idUser int) {
+	rc.Rc_idUser = idUser
+}
+
+func (rc *Recommendations) SetIdSerial(idSerial int) {
+	rc.Rc_idSerial = idSerial
+}
+
+func (rc *Recommendations) SetScore(score float64) {
+	rc.Rc_score = score
+}
+
+func (rc *Recommendations) SetSource(source string) {
+	rc.Rc_source = source
+}
+
+func (rc *Recommendations) SetPosition(position int) {
+	rc.Rc_position = position
+}
+
+// ValidRecommendSource reports whether source is one of the sources of the
+// recommendations.
+func ValidRecommendSource(source string) bool {
+	return source == RecommendByUsers || source == RecommendBySimilar || source == RecommendPopular
+}
+
+// Recommendation is a serial recommended to a user with its score and the
+// source it came from.
+type Recommendation struct {
+	Serial *Serial `json:"serial"`
+	Score  float64 `json:"score"`
+	Source string  `json:"source"`
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Weights of the interactions of a user with a serial, see Recommender.
+const (
+	recommendViewed  = 1.0
+	recommendSaved   = 2.0
+	recommendComment = 1.0
+	// recommendRating is the weight of a score a point above the middle of
+	// the scale, the scores below the middle weigh negatively.
+	recommendRating = 0.4
+)
+
+// Recommender ranks the serials for the users from what they viewed, saved
+// in their lists, commented and rated. Every interaction adds to the weight of
+// the serial for the user: viewing 1, saving 2 (however many lists hold the
+// serial), commenting 1 (however many comments) and rating 0.4 per point away
+// from the middle of the scale, a user likes the serials of positive weight.
+// The serials a user interacted with in any way are never recommended to them.
+//
+// The serials are ranked by item-based collaborative filtering: the score of
+// a serial is the sum over the serials the user likes of their weight times
+// the cosine similarity of the two serials, the vectors of a serial being the
+// weights the other users like it with. When that gives too few serials, the
+// serials most similar to the liked ones by SimilarIndex follow and then the
+// popular ones: liked by more users and then better rated. A Recommender is
+// not safe for concurrent use.
+type Recommender struct {
+	serials   []*Serial
+	byId      map[int]*Serial
+	similar   *SimilarIndex
+	weights   map[int]map[int]float64
+	saved     map[[2]int]bool
+	commented map[[2]int]bool
+
+	ready   bool
+	likes   map[int]map[int]float64
+	norms   map[int]float64
+	popular []*Serial
+}
+
+func NewRecommender(serials []*Serial, serialsActors []*SerialsActors) *Recommender {
+	r := &Recommender{
+		serials:   serials,
+		byId:      make(map[int]*Serial, len(serials)),
+		similar:   NewSimilarIndex(serials, serialsActors),
+		weights:   map[int]map[int]float64{},
+		saved:     map[[2]int]bool{},
+		commented: map[[2]int]bool{},
+	}
+	for _, serial := range serials {
+		r.byId[serial.GetId()] = serial
+	}
+	return r
+}
+
+// add adds weight to the serial for the user, the serials missing from the
+// recommender are skipped.
+func (r *Recommender) add(idUser, idSerial int, weight float64) {
+	if r.byId[idSerial] == nil {
+		return
+	}
+	if r.weights[idUser] == nil {
+		r.weights[idUser] = map[int]float64{}
+	}
+	r.weights[idUser][idSerial] += weight
+	r.ready = false
+}
+
+// AddViewed records the serial in the history of the user.
+func (r *Recommender) AddViewed(idUser, idSerial int) {
+	r.add(idUser, idSerial, recommendViewed)
+}
+
+// AddSaved records the serial in a list of the user.
+func (r *Recommender) AddSaved(idUser, idSerial int) {
+	key := [2]int{idUser, idSerial}
+	if r.saved[key] {
+		return
+	}
+	r.saved[key] = true
+	r.add(idUser, idSerial, recommendSaved)
+}
+
+// AddComment records a comment of the user on the serial.
+func (r *Recommender) AddComment(idUser, idSerial int) {
+	key := [2]int{idUser, idSerial}
+	if r.commented[key] {
+		return
+	}
+	r.commented[key] = true
+	r.add(idUser, idSerial, recommendComment)
+}
+
+// AddRating records the score of the serial by the user.
+func (r *Recommender) AddRating(idUser, idSerial, score int) {
+	r.add(idUser, idSerial, recommendRating*(float64(score)-float64(MinScore+MaxScore)/2))
+}
+
+// prepare indexes the likes of the serials after the interactions change.
+func (r *Recommender) prepare() {
+	if r.ready {
+		return
+	}
+	r.likes = map[int]map[int]float64{}
+	r.norms = map[int]float64{}
+	for idUser, weights := range r.weights {
+		for idSerial, weight := range weights {
+			if weight <= 0 {
+				continue
+			}
+			if r.likes[idSerial] == nil {
+				r.likes[idSerial] = map[int]float64{}
+			}
+			r.likes[idSerial][idUser] = weight
+			r.norms[idSerial] += weight * weight
+		}
+	}
+	for idSerial, norm := range r.norms {
+		r.norms[idSerial] = math.Sqrt(norm)
+	}
+	r.popular = slices.Clone(r.serials)
+	slices.SortFunc(r.popular, func(a, b *Serial) int {
+		return cmp.Or(
+			cmp.Compare(len(r.likes[b.GetId()]), len(r.likes[a.GetId()])),
+			cmp.Compare(b.GetRating(), a.GetRating()),
+			cmp.Compare(a.GetId(), b.GetId()))
+	})
+	r.ready = true
+}
+
+// Recommend returns at most n serials for the user, the better ones first.
+func (r *Recommender) Recommend(idUser, n int) []*Recommendation {
+	r.prepare()
+	seen := r.weights[idUser]
+	unseen := func(id int) bool {
+		_, ok := seen[id]
+		return !ok
+	}
+	recs := []*Recommendation{}
+	picked := map[int]bool{}
+	pick := func(scores map[int]float64, source string) {
+		ids := []int{}
+		for id, score := range scores {
+			if score > 0 && unseen(id) && !picked[id] {
+				ids = append(ids, id)
+			}
+		}
+		slices.SortFunc(ids, func(a, b int) int {
+			return cmp.Or(cmp.Compare(scores[b], scores[a]), cmp.Compare(a, b))
+		})
+		for _, id := range ids[:min(n-len(recs), len(ids))] {
+			recs = append(recs, &Recommendation{Serial: r.byId[id], Score: math.Round(scores[id]*1000) / 1000, Source: source})
+			picked[id] = true
+		}
+	}
+
+	scores := map[int]float64{}
+	for i, weight := range seen {
+		if weight <= 0 {
+			continue
+		}
+		dots := map[int]float64{}
+		for idUser2, weight2 := range r.likes[i] {
+			if idUser2 == idUser {
+				continue
+			}
+			for j, weight3 := range r.weights[idUser2] {
+				if j != i && weight3 > 0 {
+					dots[j] += weight2 * weight3
+				}
+			}
+		}
+		for j, dot := range dots {
+			scores[j] += weight * dot / (r.norms[i] * r.norms[j])
+		}
+	}
+	pick(scores, RecommendByUsers)
+
+	if len(recs) < n {
+		scores = map[int]float64{}
+		for i, weight := range seen {
+			if weight <= 0 {
+				continue
+			}
+			for _, s := range r.similar.Similar(i, MaxSimilar) {
+				scores[s.Serial.GetId()] += weight * s.Score
+			}
+		}
+		pick(scores, RecommendBySimilar)
+	}
+
+	for _, serial := range r.popular {
+		if len(recs) >= n {
+			break
+		}
+		id := serial.GetId()
+		if !unseen(id) || picked[id] {
+			continue
+		}
+		recs = append(recs, &Recommendation{Serial: serial, Score: float64(len(r.likes[id])), Source: RecommendPopular})
+		picked[id] = true
+	}
+	return recs
+}
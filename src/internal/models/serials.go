@@ -1,5 +1,11 @@
 package models
 
+// States of the serials.
+const (
+	SerialFinished = "завершен"
+	SerialOngoing  = "продолжается"
+)
+
 type Serial struct {
 	S_name        string  `json:"name"`
 	S_description string  `json:"description"`
@@ -10,12 +16,13 @@ type Serial struct {
 	S_year        int     `json:"year"`
 	S_seasons     int     `json:"seasons"`
 	S_rating      float32 `json:"rating"`
+	S_votes       int     `json:"votes"`
 	S_img         string  `json:"img"`
 	S_duration    string  `json:"duration"`
 }
 
 func (s *Serial) Validate() bool {
-	if s.S_idProducer <= 0 || s.S_name == "" || s.S_description == "" || s.S_year <= 0 || s.S_genre == "" || s.S_rating < 0 || s.S_seasons < 0 || s.S_state == "" || s.S_img == "" || s.S_duration == "" {
+	if s.S_idProducer <= 0 || s.S_name == "" || s.S_description == "" || s.S_year <= 0 || s.S_genre == "" || s.S_rating < 0 || s.S_votes < 0 || s.S_seasons < 0 || s.S_state == "" || s.S_img == "" || s.S_duration == "" {
 		return false
 	}
 	return true
@@ -49,6 +56,10 @@ func (s *Serial) GetRating() float32 {
 	return s.S_rating
 }
 
+func (s *Serial) GetVotes() int {
+	return s.S_votes
+}
+
 func (s *Serial) GetSeasons() int {
 	return s.S_seasons
 }
@@ -85,6 +96,10 @@ func (s *Serial) SetRating(rating float32) {
 	s.S_rating = rating
 }
 
+func (s *Serial) SetVotes(votes int) {
+	s.S_votes = votes
+}
+
 func (s *Serial) SetSeasons(seasons int) {
 	s.S_seasons = seasons
 }
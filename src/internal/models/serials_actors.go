@@ -1,18 +1,55 @@
 package models
 
+// Roles of the actors in the serials, in the order the cast is shown.
+const (
+	CastMain      = "main"
+	CastRecurring = "recurring"
+	CastGuest     = "guest"
+)
+
+var CastRoles = []string{CastMain, CastRecurring, CastGuest}
+
+// ValidCastRole reports whether role is one of CastRoles.
+func ValidCastRole(role string) bool {
+	return role == CastMain || role == CastRecurring || role == CastGuest
+}
+
+// SerialsActors is the role of an actor in a serial: the character played,
+// the type of the role and the place in the credits, Sa_billing, counted from
+// 1 (0 for the actors not billed). The actor appears from the season
+// Sa_seasonFrom to Sa_seasonTo, both by their number; Sa_seasonTo is 0 for
+// the actors still in the serial and both are 0 for the actors of all the
+// seasons.
 type SerialsActors struct {
-	Sa_id       int `json:"id"`
-	Sa_idSerial int `json:"idSerial"`
-	Sa_idActor  int `json:"idActor"`
+	Sa_id         int    `json:"id"`
+	Sa_idSerial   int    `json:"idSerial"`
+	Sa_idActor    int    `json:"idActor"`
+	Sa_character  string `json:"character"`
+	Sa_role       string `json:"role"`
+	Sa_billing    int    `json:"billing"`
+	Sa_seasonFrom int    `json:"seasonFrom"`
+	Sa_seasonTo   int    `json:"seasonTo"`
 }
 
 func (sa *SerialsActors) Validate() bool {
-	if sa.Sa_idSerial <= 0 || sa.Sa_idActor <= 0 {
+	if sa.Sa_idSerial <= 0 || sa.Sa_idActor <= 0 || !ValidCastRole(sa.Sa_role) || sa.Sa_billing < 0 {
+		return false
+	}
+	if sa.Sa_seasonFrom < 0 || sa.Sa_seasonTo < 0 || (sa.Sa_seasonFrom == 0 && sa.Sa_seasonTo != 0) ||
+		(sa.Sa_seasonTo != 0 && sa.Sa_seasonTo < sa.Sa_seasonFrom) {
 		return false
 	}
 	return true
 }
 
+// SetDefaultRole puts the actor in the main cast if the role is missing, as
+// for the links stored before the roles were added.
+func (sa *SerialsActors) SetDefaultRole() {
+	if sa.Sa_role == "" {
+		sa.Sa_role = CastMain
+	}
+}
+
 func (sa *SerialsActors) GetId() int {
 	return sa.Sa_id
 }
@@ -25,6 +62,26 @@ func (sa *SerialsActors) GetIdActor() int {
 	return sa.Sa_idActor
 }
 
+func (sa *SerialsActors) GetCharacter() string {
+	return sa.Sa_character
+}
+
+func (sa *SerialsActors) GetRole() string {
+	return sa.Sa_role
+}
+
+func (sa *SerialsActors) GetBilling() int {
+	return sa.Sa_billing
+}
+
+func (sa *SerialsActors) GetSeasonFrom() int {
+	return sa.Sa_seasonFrom
+}
+
+func (sa *SerialsActors) GetSeasonTo() int {
+	return sa.Sa_seasonTo
+}
+
 func (sa *SerialsActors) SetId(id int) {
 	sa.Sa_id = id
 }
@@ -36,3 +93,23 @@ func (sa *SerialsActors) SetIdSerial(idSerial int) {
 func (sa *SerialsActors) SetIdActor(idActor int) {
 	sa.Sa_idActor = idActor
 }
+
+func (sa *SerialsActors) SetCharacter(character string) {
+	sa.Sa_character = character
+}
+
+func (sa *SerialsActors) SetRole(role string) {
+	sa.Sa_role = role
+}
+
+func (sa *SerialsActors) SetBilling(billing int) {
+	sa.Sa_billing = billing
+}
+
+func (sa *SerialsActors) SetSeasonFrom(seasonFrom int) {
+	sa.Sa_seasonFrom = seasonFrom
+}
+
+func (sa *SerialsActors) SetSeasonTo(seasonTo int) {
+	sa.Sa_seasonTo = seasonTo
+}
@@ -1,13 +1,16 @@
 package models
 
+// SerialsFavourites puts the serial in a list, at most once per list.
+// Sf_date is the date the serial was added to the list.
 type SerialsFavourites struct {
-	Sf_id          int `json:"id"`
-	Sf_idSerial    int `json:"idSerial"`
-	Sf_idFavourite int `json:"idFavourite"`
+	Sf_id          int    `json:"id"`
+	Sf_idSerial    int    `json:"idSerial"`
+	Sf_idFavourite int    `json:"idFavourite"`
+	Sf_date        string `json:"date"`
 }
 
 func (sf *SerialsFavourites) Validate() bool {
-	if sf.Sf_idSerial <= 0 || sf.Sf_idFavourite <= 0 {
+	if sf.Sf_idSerial <= 0 || sf.Sf_idFavourite <= 0 || sf.Sf_date == "" {
 		return false
 	}
 	return true
@@ -25,6 +28,10 @@ func (sf *SerialsFavourites) GetIdFavourite() int {
 	return sf.Sf_idFavourite
 }
 
+func (sf *SerialsFavourites) GetDate() string {
+	return sf.Sf_date
+}
+
 func (sf *SerialsFavourites) SetId(id int) {
 	sf.Sf_id = id
 }
@@ -36,3 +43,7 @@ func (sf *SerialsFavourites) SetIdSerial(idSerial int) {
 func (sf *SerialsFavourites) SetIdFavourite(idFavourite int) {
 	sf.Sf_idFavourite = idFavourite
 }
+
+func (sf *SerialsFavourites) SetDate(date string) {
+	sf.Sf_date = date
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"cmp"
+	"strings"
+)
+
+// Orders of the serials search results.
+const (
+	SortById     = ""
+	SortByRating = "rating"
+	SortByYear   = "year"
+	SortByName   = "name"
+)
+
+// SerialsQuery is a search over the serials. The zero value of a field does not
+// restrict the result. Title is a case-insensitive substring of the name,
+// Genre and State are matched exactly, the year range is inclusive.
+//
+// The serials are ordered by Sort, ties are broken by id; Desc reverses the
+// whole order. Names are compared byte-wise, i.e. by their code points, so that
+// every backend orders them the same way.
+type SerialsQuery struct {
+	Title      string  `json:"title"`
+	Genre      string  `json:"genre"`
+	State      string  `json:"state"`
+	YearFrom   int     `json:"yearFrom"`
+	YearTo     int     `json:"yearTo"`
+	MinRating  float32 `json:"minRating"`
+	IdProducer int     `json:"idProducer"`
+	IdActor    int     `json:"idActor"`
+	Sort       string  `json:"sort"`
+	Desc       bool    `json:"desc"`
+}
+
+func (q *SerialsQuery) Validate() bool {
+	if q.YearFrom < 0 || q.YearTo < 0 || q.MinRating < 0 || q.IdProducer < 0 || q.IdActor < 0 {
+		return false
+	}
+	if q.YearFrom > 0 && q.YearTo > 0 && q.YearFrom > q.YearTo {
+		return false
+	}
+	switch q.Sort {
+	case SortById, SortByRating, SortByYear, SortByName:
+		return true
+	}
+	return false
+}
+
+// Match reports whether the serial satisfies all the filters but IdActor,
+// which needs the serials_actors rows.
+func (q *SerialsQuery) Match(s *Serial) bool {
+	if q.Title != "" && !strings.Contains(strings.ToLower(s.S_name), strings.ToLower(q.Title)) {
+		return false
+	}
+	if q.Genre != "" && s.S_genre != q.Genre {
+		return false
+	}
+	if q.State != "" && s.S_state != q.State {
+		return false
+	}
+	if q.YearFrom > 0 && s.S_year < q.YearFrom {
+		return false
+	}
+	if q.YearTo > 0 && s.S_year > q.YearTo {
+		return false
+	}
+	if q.MinRating > 0 && s.S_rating < q.MinRating {
+		return false
+	}
+	if q.IdProducer > 0 && s.S_idProducer != q.IdProducer {
+		return false
+	}
+	return true
+}
+
+// Less reports whether a goes before b in the order of the query.
+func (q *SerialsQuery) Less(a, b *Serial) bool {
+	var res int
+	switch q.Sort {
+	case SortByRating:
+		res = cmp.Compare(a.S_rating, b.S_rating)
+	case SortByYear:
+		res = cmp.Compare(a.S_year, b.S_year)
+	case SortByName:
+		res = strings.Compare(a.S_name, b.S_name)
+	}
+	if res == 0 {
+		res = cmp.Compare(a.S_id, b.S_id)
+	}
+	if q.Desc {
+		return res > 0
+	}
+	return res < 0
+}
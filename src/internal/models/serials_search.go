@@ -0,0 +1,154 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnippetWords is the length of a search snippet in words.
+const SnippetWords = 30
+
+// SearchHit is a serial found by the full-text search. Hits are ordered by
+// Rank, the higher the better; ranks are comparable only within one search.
+type SearchHit struct {
+	Serial  *Serial    `json:"serial"`
+	Rank    float32    `json:"rank"`
+	Snippet []Fragment `json:"snippet"`
+}
+
+// Fragment is a part of a snippet of the serial description; Match marks the
+// words found by the search.
+type Fragment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match"`
+}
+
+// SearchWords returns the distinct lower-case words of a full-text query.
+// A query without words is invalid.
+func SearchWords(text string) []string {
+	words := []string{}
+	seen := map[string]bool{}
+	for _, word := range splitWords(strings.ToLower(text)) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchWord reports whether a word of a document matches a query word, i.e.
+// starts with it ignoring case. This stands in for stemming where the storage
+// has none.
+func matchWord(word, query string) bool {
+	return strings.HasPrefix(strings.ToLower(word), query)
+}
+
+// ContainsWord reports whether a word of text matches the query word.
+func ContainsWord(text, query string) bool {
+	for _, word := range splitWords(text) {
+		if matchWord(word, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight returns the snippet of text around the first of the words found
+// in it, or its beginning, at most SnippetWords long.
+func Highlight(text string, words []string) []Fragment {
+	type token struct {
+		text  string
+		word  bool
+		match bool
+	}
+	tokens := []token{}
+	start := -1
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	for len(text) > 0 {
+		i := strings.IndexFunc(text, func(r rune) bool { return !isWord(r) })
+		if i == 0 {
+			i = strings.IndexFunc(text, isWord)
+			if i < 0 {
+				i = len(text)
+			}
+			tokens = append(tokens, token{text: text[:i]})
+			text = text[i:]
+			continue
+		}
+		if i < 0 {
+			i = len(text)
+		}
+		t := token{text: text[:i], word: true}
+		for _, word := range words {
+			if matchWord(t.text, word) {
+				t.match = true
+				if start < 0 {
+					start = len(tokens)
+				}
+				break
+			}
+		}
+		tokens = append(tokens, t)
+		text = text[i:]
+	}
+
+	// The snippet starts a few words before the first match.
+	from := 0
+	for before := SnippetWords / 4; start > 0 && before > 0; start-- {
+		if tokens[start-1].word {
+			before--
+		}
+		from = start - 1
+	}
+	res := []Fragment{}
+	if from > 0 {
+		res = append(res, Fragment{Text: "… "})
+	}
+	cnt := 0
+	for _, t := range tokens[from:] {
+		if t.word {
+			if cnt == SnippetWords {
+				res = append(res, Fragment{Text: " …"})
+				break
+			}
+			cnt++
+		}
+		if n := len(res); n > 0 && res[n-1].Match == t.match {
+			res[n-1].Text += t.text
+		} else {
+			res = append(res, Fragment{Text: t.text, Match: t.match})
+		}
+	}
+	return res
+}
+
+// SplitMarked splits a snippet with the matches put between start and stop
+// into fragments.
+func SplitMarked(text, start, stop string) []Fragment {
+	res := []Fragment{}
+	for text != "" {
+		i := strings.Index(text, start)
+		if i < 0 {
+			res = append(res, Fragment{Text: text})
+			break
+		}
+		if i > 0 {
+			res = append(res, Fragment{Text: text[:i]})
+		}
+		text = text[i+len(start):]
+		j := strings.Index(text, stop)
+		if j < 0 {
+			j = len(text)
+		}
+		res = append(res, Fragment{Text: text[:j], Match: true})
+		text = strings.TrimPrefix(text[j:], stop)
+	}
+	return res
+}
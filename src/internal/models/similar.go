@@ -0,0 +1,186 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SimilarCount is the number of the similar serials on the serial page.
+	SimilarCount = 6
+	MaxSimilar   = 20
+)
+
+// Weights of the parts of the similarity of two serials, see SimilarIndex.
+const (
+	similarGenre    = 3.0
+	similarActors   = 3.0
+	similarProducer = 2.0
+	similarYear     = 1.0
+	// similarYears is the year difference at which the year part is zero.
+	similarYears = 10
+)
+
+// SimilarSerial is a serial similar to another one with the score of their
+// similarity and what they have in common: the genre, the number of the
+// actors and the producer.
+type SimilarSerial struct {
+	Serial   *Serial `json:"serial"`
+	Score    float64 `json:"score"`
+	Genre    bool    `json:"genre"`
+	Actors   int     `json:"actors"`
+	Producer bool    `json:"producer"`
+}
+
+// SimilarIndex finds the serials similar to a serial. Serials are similar
+// when they share the genre, the producer or an actor; the score adds up
+// the genre, the share of the common actors among the actors of both serials,
+// the producer and the closeness of the years. The serials of the index must
+// not be changed. The similar serials of a serial are computed once.
+type SimilarIndex struct {
+	serials []*Serial
+	byId    map[int]*Serial
+	actors  map[int]map[int]bool
+
+	mu  sync.Mutex
+	top map[int][]*SimilarSerial
+}
+
+func NewSimilarIndex(serials []*Serial, serialsActors []*SerialsActors) *SimilarIndex {
+	idx := &SimilarIndex{
+		serials: serials,
+		byId:    make(map[int]*Serial, len(serials)),
+		actors:  map[int]map[int]bool{},
+		top:     map[int][]*SimilarSerial{},
+	}
+	for _, serial := range serials {
+		idx.byId[serial.GetId()] = serial
+	}
+	for _, sa := range serialsActors {
+		if idx.actors[sa.GetIdSerial()] == nil {
+			idx.actors[sa.GetIdSerial()] = map[int]bool{}
+		}
+		idx.actors[sa.GetIdSerial()][sa.GetIdActor()] = true
+	}
+	return idx
+}
+
+// Has reports whether the serial is in the index.
+func (idx *SimilarIndex) Has(id int) bool {
+	return idx.byId[id] != nil
+}
+
+// Similar returns at most n serials most similar to the serial with the id,
+// the more similar ones and then the ones with the smaller ids first.
+func (idx *SimilarIndex) Similar(id, n int) []*SimilarSerial {
+	idx.mu.Lock()
+	top, ok := idx.top[id]
+	if !ok {
+		top = idx.rank(id)
+		idx.top[id] = top
+	}
+	idx.mu.Unlock()
+	return top[:min(n, len(top))]
+}
+
+// rank returns MaxSimilar serials most similar to the serial with the id.
+func (idx *SimilarIndex) rank(id int) []*SimilarSerial {
+	serial := idx.byId[id]
+	if serial == nil {
+		return nil
+	}
+	similar := []*SimilarSerial{}
+	for _, other := range idx.serials {
+		if other.GetId() == id {
+			continue
+		}
+		if s := idx.compare(serial, other); s != nil {
+			similar = append(similar, s)
+		}
+	}
+	slices.SortFunc(similar, func(a, b *SimilarSerial) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Serial.GetId(), b.Serial.GetId()))
+	})
+	return similar[:min(MaxSimilar, len(similar))]
+}
+
+// compare returns the similarity of other to serial, nil when they are not
+// similar.
+func (idx *SimilarIndex) compare(serial, other *Serial) *SimilarSerial {
+	s := &SimilarSerial{Serial: other}
+	s.Genre = strings.EqualFold(strings.TrimSpace(serial.GetGenre()), strings.TrimSpace(other.GetGenre()))
+	if s.Genre {
+		s.Score += similarGenre
+	}
+	mine, theirs := idx.actors[serial.GetId()], idx.actors[other.GetId()]
+	for actor := range mine {
+		if theirs[actor] {
+			s.Actors++
+		}
+	}
+	if s.Actors > 0 {
+		s.Score += similarActors * float64(s.Actors) / float64(len(mine)+len(theirs)-s.Actors)
+	}
+	s.Producer = serial.GetIdProducer() == other.GetIdProducer()
+	if s.Producer {
+		s.Score += similarProducer
+	}
+	if !s.Genre && s.Actors == 0 && !s.Producer {
+		return nil
+	}
+	years := math.Abs(float64(serial.GetYear() - other.GetYear()))
+	s.Score += similarYear * max(0, 1-years/similarYears)
+	s.Score = math.Round(s.Score*100) / 100
+	return s
+}
+
+// SimilarCache keeps a SimilarIndex for ttl after it is built or until it is
+// invalidated. A nil cache keeps nothing.
+type SimilarCache struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	index *SimilarIndex
+	built time.Time
+}
+
+func NewSimilarCache(ttl time.Duration) *SimilarCache {
+	return &SimilarCache{ttl: ttl}
+}
+
+// Get returns the cached index, nil when there is none or it is stale.
+func (c *SimilarCache) Get() *SimilarIndex {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.index == nil || time.Since(c.built) > c.ttl {
+		return nil
+	}
+	return c.index
+}
+
+func (c *SimilarCache) Set(index *SimilarIndex) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index = index
+	c.built = time.Now()
+}
+
+// Invalidate drops the cached index, the serials or their actors changed.
+func (c *SimilarCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index = nil
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of the suggestions.
+const (
+	SuggestSerial   = "serial"
+	SuggestActor    = "actor"
+	SuggestProducer = "producer"
+)
+
+const (
+	DefaultSuggestions = 10
+	MaxSuggestions     = 20
+	// FuzzyPrefixLen is the shortest prefix matched with a typo.
+	FuzzyPrefixLen = 4
+)
+
+// Suggestion is an autocomplete entry: a serial name or the full name of
+// an actor or a producer.
+type Suggestion struct {
+	Kind string `json:"kind"`
+	Id   int    `json:"id"`
+	Text string `json:"text"`
+}
+
+// SuggestQuery is a request for at most Limit suggestions for the text typed
+// so far. A suggestion matches when one of its words starts with Prefix,
+// ignoring case; prefixes of FuzzyPrefixLen letters and longer may differ from
+// the start of the word by one typo: a wrong, missing, extra or swapped letter.
+type SuggestQuery struct {
+	Prefix string
+	Limit  int
+}
+
+func NewSuggestQuery(prefix string, limit int) *SuggestQuery {
+	return &SuggestQuery{Prefix: strings.ToLower(strings.TrimSpace(prefix)), Limit: limit}
+}
+
+func (q *SuggestQuery) Validate() bool {
+	return q.Prefix != "" && q.Limit > 0 && q.Limit <= MaxSuggestions
+}
+
+func (q *SuggestQuery) fuzzy() bool {
+	return len([]rune(q.Prefix)) >= FuzzyPrefixLen
+}
+
+// Distance returns 0 when a word of text starts with the prefix, 1 when it does
+// with a typo and -1 when the text does not match.
+func (q *SuggestQuery) Distance(text string) int {
+	prefix := []rune(q.Prefix)
+	res := -1
+	for _, word := range wordStarts(strings.ToLower(text)) {
+		if strings.HasPrefix(word, q.Prefix) {
+			return 0
+		}
+		if res < 0 && q.fuzzy() {
+			w := []rune(word)
+			for n := len(prefix) - 1; n <= len(prefix)+1 && n <= len(w); n++ {
+				if oneEdit(prefix, w[:n]) {
+					res = 1
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
+// wordStarts returns the suffixes of text starting at its words.
+func wordStarts(text string) []string {
+	res := []string{}
+	inWord := false
+	for i, r := range text {
+		letter := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if letter && !inWord {
+			res = append(res, text[i:])
+		}
+		inWord = letter
+	}
+	return res
+}
+
+// oneEdit reports whether a and b differ by exactly one substitution,
+// insertion, deletion or transposition of adjacent letters.
+func oneEdit(a, b []rune) bool {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	switch len(a) - len(b) {
+	case 0:
+		diff := []int{}
+		for i := range a {
+			if a[i] != b[i] {
+				diff = append(diff, i)
+			}
+		}
+		if len(diff) == 1 {
+			return true
+		}
+		return len(diff) == 2 && diff[1] == diff[0]+1 && a[diff[0]] == b[diff[1]] && a[diff[1]] == b[diff[0]]
+	case 1:
+		i := 0
+		for i < len(b) && a[i] == b[i] {
+			i++
+		}
+		return string(a[i+1:]) == string(b[i:])
+	}
+	return false
+}
+
+// Variants returns the patterns of the starts of the words matching the
+// prefix: the prefix itself and, when it may have a typo, its variants with a
+// wrong, missing, extra or swapped letter. quote escapes the literal parts of
+// a pattern for the storage and any matches a single letter.
+func (q *SuggestQuery) Variants(quote func(string) string, any string) []string {
+	prefix := []rune(q.Prefix)
+	variants := []string{quote(q.Prefix)}
+	if !q.fuzzy() {
+		return variants
+	}
+	part := func(rs []rune) string { return quote(string(rs)) }
+	for i := range prefix {
+		variants = append(variants,
+			part(prefix[:i])+any+part(prefix[i+1:]),
+			part(prefix[:i])+part(prefix[i+1:]),
+			part(prefix[:i])+any+part(prefix[i:]))
+		if i+1 < len(prefix) {
+			variants = append(variants, part(prefix[:i])+part(prefix[i+1:i+2])+part(prefix[i:i+1])+part(prefix[i+2:]))
+		}
+	}
+	return variants
+}
+
+// KeyVariants splits Variants for the storages matching them against the keys
+// of SuggestKeys: heads match the starts of the words and tails, the variants
+// with a typo in the first letter, match the starts of the words without it.
+// The patterns are anchored at the start of a key only by the caller.
+func (q *SuggestQuery) KeyVariants(quote func(string) string, any string) (heads, tails []string) {
+	for _, variant := range q.Variants(quote, any) {
+		if tail, ok := strings.CutPrefix(variant, any); ok {
+			tails = append(tails, tail)
+		} else {
+			heads = append(heads, variant)
+		}
+	}
+	return heads, tails
+}
+
+// SuggestKeys returns the keys of text for KeyVariants: heads are the starts
+// of its words up to the end of text in lower case and tails are the same
+// without their first letter.
+func SuggestKeys(text string) (heads, tails []string) {
+	heads = wordStarts(strings.ToLower(text))
+	tails = []string{}
+	for _, head := range heads {
+		_, size := utf8.DecodeRuneInString(head)
+		if head[size:] != "" {
+			tails = append(tails, head[size:])
+		}
+	}
+	return heads, tails
+}
+
+// Pattern returns a regular expression matching the texts with a word matching
+// the prefix, a superset of the texts matched by Distance for the storages
+// filtering by regular expressions.
+func (q *SuggestQuery) Pattern() string {
+	return `(^|[^\p{L}\p{N}])(` + strings.Join(q.Variants(regexp.QuoteMeta, "."), "|") + `)`
+}
+
+// Rank orders the matching suggestions: exact matches first, then those
+// matching at the start of the text, then by text, kind and id.
+func (q *SuggestQuery) Rank(suggestions []*Suggestion) []*Suggestion {
+	type ranked struct {
+		*Suggestion
+		distance int
+		inside   bool
+	}
+	res := []ranked{}
+	for _, s := range suggestions {
+		distance := q.Distance(s.Text)
+		if distance < 0 {
+			continue
+		}
+		inside := !strings.HasPrefix(strings.ToLower(s.Text), q.Prefix)
+		res = append(res, ranked{s, distance, inside})
+	}
+	slices.SortFunc(res, func(a, b ranked) int {
+		if c := cmp.Compare(a.distance, b.distance); c != 0 {
+			return c
+		}
+		if a.inside != b.inside {
+			if a.inside {
+				return 1
+			}
+			return -1
+		}
+		if c := strings.Compare(a.Text, b.Text); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	out := []*Suggestion{}
+	for _, r := range res {
+		if len(out) == q.Limit {
+			break
+		}
+		out = append(out, r.Suggestion)
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package models
+
+import "strings"
+
+// WordFilter finds the banned words in the texts of the comments. The words
+// are matched whole and ignoring case. A nil filter bans nothing.
+type WordFilter struct {
+	words map[string]bool
+}
+
+func NewWordFilter(words []string) *WordFilter {
+	f := &WordFilter{words: map[string]bool{}}
+	for _, word := range words {
+		for _, w := range splitWords(strings.ToLower(word)) {
+			f.words[w] = true
+		}
+	}
+	return f
+}
+
+// Find returns the distinct banned words of text in the order they appear.
+func (f *WordFilter) Find(text string) []string {
+	if f == nil {
+		return nil
+	}
+	found := []string{}
+	for _, word := range SearchWords(text) {
+		if f.words[word] {
+			found = append(found, word)
+		}
+	}
+	return found
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ActorsRepoMemory struct {
+	table *memdb.Table[models.Actors, *models.Actors]
+	log   *logrus.Logger
+}
+
+func NewActorsRepoMemory(db *memdb.DB, log *logrus.Logger) *ActorsRepoMemory {
+	return &ActorsRepoMemory{table: memdb.NewTable[models.Actors](db, "actors"), log: log}
+}
+
+func (repo *ActorsRepoMemory) GetActors(ctx context.Context) ([]*models.Actors, error) {
+	repo.log.WithContext(ctx).Info("Getting all actors from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *ActorsRepoMemory) GetActorById(ctx context.Context, id int) (*models.Actors, error) {
+	repo.log.WithContext(ctx).Info("Getting actor by id from the database")
+	actor, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return actor, nil
+}
+
+func (repo *ActorsRepoMemory) CreateActor(ctx context.Context, actor *models.Actors) error {
+	if !actor.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating actor in the database")
+	repo.table.Insert(actor)
+	return nil
+}
+
+func (repo *ActorsRepoMemory) UpdateActor(ctx context.Context, actor *models.Actors) error {
+	if !actor.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating actor in the database")
+	if !repo.table.Update(actor) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *ActorsRepoMemory) DeleteActor(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting actor from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *ActorsRepoMemory) CheckActor(ctx context.Context, actor *models.Actors) bool {
+	repo.log.WithContext(ctx).Info("Checking actor in the database")
+	found, ok := repo.table.First(func(row *models.Actors) bool {
+		return row.GetName() == actor.GetName() && row.GetSurname() == actor.GetSurname() &&
+			row.GetGender() == actor.GetGender() && row.GetBdate() == actor.GetBdate()
+	})
+	if !ok {
+		return false
+	}
+	*actor = *found
+	return true
+}
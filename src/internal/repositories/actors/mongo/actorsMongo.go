@@ -2,31 +2,54 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ActorsRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewActorsRepoMongo(client *mongo.Client, log *logrus.Logger) *ActorsRepoMongo {
 	db := client.Database("mydb")
-	return &ActorsRepoMongo{db: db, log: log}
+	return &ActorsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *ActorsRepoMongo) GetActors() ([]*models.Actors, error) {
-	repo.log.Info("Getting all actors from the database")
+// actorDocument is an actor as it is stored, A_keys and A_tails are
+// the keys of the full name for the suggestions, see mgdb.SuggestFilter.
+type actorDocument struct {
+	models.Actors `bson:",inline"`
+	A_keys        []string `bson:"a_keys"`
+	A_tails       []string `bson:"a_tails"`
+}
+
+func newActorDocument(actor *models.Actors) *actorDocument {
+	doc := &actorDocument{Actors: *actor}
+	doc.A_keys, doc.A_tails = models.SuggestKeys(actor.GetName() + " " + actor.GetSurname())
+	return doc
+}
+
+// Setup indexes the keys of the full names of the actors and fills them
+// for the actors stored without them. It is run once at startup, see
+// repositories.SetupMongo.
+func (repo *ActorsRepoMongo) Setup(ctx context.Context) error {
+	return mgdb.SetupSuggestKeys(ctx, repo.db.Collection("actors"), "a", "a_name", "a_surname")
+}
+
+func (repo *ActorsRepoMongo) GetActors(ctx context.Context) ([]*models.Actors, error) {
+	repo.log.WithContext(ctx).Info("Getting all actors from the database")
 	actors := []*models.Actors{}
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -48,98 +71,85 @@ func (repo *ActorsRepoMongo) GetActors() ([]*models.Actors, error) {
 	return actors, nil
 }
 
-func (repo *ActorsRepoMongo) GetActorById(id int) (*models.Actors, error) {
-	repo.log.Info("Getting actor by id from the database")
+func (repo *ActorsRepoMongo) GetActorById(ctx context.Context, id int) (*models.Actors, error) {
+	repo.log.WithContext(ctx).Info("Getting actor by id from the database")
 	actor := &models.Actors{}
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
+	err := collection.FindOne(ctx, bson.M{"a_id": id}).Decode(actor)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
 	}
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(actor)
 	if err != nil {
 		return nil, err
 	}
 	return actor, nil
 }
 
-func (repo *ActorsRepoMongo) CreateActor(actor *models.Actors) error {
+func (repo *ActorsRepoMongo) CreateActor(ctx context.Context, actor *models.Actors) error {
 	if !actor.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating actor in the database")
+	repo.log.WithContext(ctx).Info("Creating actor in the database")
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, actor)
+	id, err := repo.counters.NextId(ctx, "actors", "a_id")
+	if err != nil {
+		return err
+	}
+	actor.SetId(id)
+
+	_, err = collection.InsertOne(ctx, newActorDocument(actor))
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	actor.SetId(res)
 
 	return nil
 }
 
-func (repo *ActorsRepoMongo) UpdateActor(actor *models.Actors) error {
+func (repo *ActorsRepoMongo) UpdateActor(ctx context.Context, actor *models.Actors) error {
 	if !actor.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating actor in the database")
+	repo.log.WithContext(ctx).Info("Updating actor in the database")
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(actor.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"a_id": actor.GetId()}, newActorDocument(actor))
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"a_name":    actor.GetName(),
-			"a_surname": actor.GetSurname(),
-			"a_gender":  actor.GetGender(),
-			"a_bdate":   actor.GetBdate(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *ActorsRepoMongo) DeleteActor(id int) error {
-	repo.log.Info("Deleting actor from the database")
+func (repo *ActorsRepoMongo) DeleteActor(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting actor from the database")
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"a_id": id})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (repo *ActorsRepoMongo) CheckActor(actor *models.Actors) bool {
-	repo.log.Info("Checking actor in the database")
+func (repo *ActorsRepoMongo) CheckActor(ctx context.Context, actor *models.Actors) bool {
+	repo.log.WithContext(ctx).Info("Checking actor in the database")
 	collection := repo.db.Collection("actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	filter := bson.M{
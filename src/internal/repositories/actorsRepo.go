@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	mem "app/internal/repositories/actors/memory"
 	mg "app/internal/repositories/actors/mongo"
 	pg "app/internal/repositories/actors/postgres"
+	"app/internal/repositories/memdb"
 
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -15,8 +17,14 @@ func NewActorsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoActors {
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewActorsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewActorsRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewActorsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewActorsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewActorsRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CommentsRepoMemory struct {
+	table *memdb.Table[models.Comments, *models.Comments]
+	log   *logrus.Logger
+}
+
+func NewCommentsRepoMemory(db *memdb.DB, log *logrus.Logger) *CommentsRepoMemory {
+	return &CommentsRepoMemory{table: memdb.NewTable[models.Comments](db, "comments"), log: log}
+}
+
+func (repo *CommentsRepoMemory) GetComments(ctx context.Context) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments from the database")
+	comments := repo.table.Select(nil)
+	return memdb.Paginate(comments, page.Offset(), page.Limit()), len(comments), nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentById(ctx context.Context, id int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comment by id from the database")
+	comment, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return comment, nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by serial id from the database")
+	return repo.table.Select(func(row *models.Comments) bool {
+		return row.GetIdSerial() == idSerial
+	}), nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentsBySerialIdPage(ctx context.Context, idSerial int, order string, page models.Page) ([]*models.Comments, int, error) {
+	if !models.ValidCommentsOrder(order) || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments by serial id from the database")
+	comments := repo.table.Select(func(row *models.Comments) bool {
+		return row.GetIdSerial() == idSerial && row.IsReview()
+	})
+	sort.SliceStable(comments, func(i, j int) bool {
+		if order == models.CommentsByHelpful && comments[i].Helpfulness() != comments[j].Helpfulness() {
+			return comments[i].Helpfulness() > comments[j].Helpfulness()
+		}
+		return comments[i].GetId() > comments[j].GetId()
+	})
+	return memdb.Paginate(comments, page.Offset(), page.Limit()), len(comments), nil
+}
+
+func (repo *CommentsRepoMemory) GetRepliesBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting replies by serial id from the database")
+	return repo.table.Select(func(row *models.Comments) bool {
+		return row.GetIdSerial() == idSerial && !row.IsReview()
+	}), nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by user id from the database")
+	return repo.table.Select(func(row *models.Comments) bool {
+		return row.GetIdUser() == idUser
+	}), nil
+}
+
+func (repo *CommentsRepoMemory) GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comment by serial id and user id from the database")
+	comment, ok := repo.table.First(func(row *models.Comments) bool {
+		return row.GetIdSerial() == idSerial && row.GetIdUser() == idUser && row.IsReview()
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return comment, nil
+}
+
+func (repo *CommentsRepoMemory) CreateComment(ctx context.Context, comment *models.Comments) error {
+	if !comment.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comment in the database")
+	repo.table.Insert(comment)
+	return nil
+}
+
+func (repo *CommentsRepoMemory) UpdateComment(ctx context.Context, comment *models.Comments) error {
+	if !comment.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment in the database")
+	if !repo.table.Update(comment) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// LockComment only checks the comment exists: the transactions of memdb are
+// serialized already.
+func (repo *CommentsRepoMemory) LockComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking comment in the database")
+	if _, ok := repo.table.Get(id); !ok {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *CommentsRepoMemory) DeleteComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *CommentsRepoMemory) CheckComment(ctx context.Context, idUser, idSerial int) bool {
+	repo.log.WithContext(ctx).Info("Checking if comment exists in the database")
+	_, ok := repo.table.First(func(row *models.Comments) bool {
+		return row.GetIdUser() == idUser && row.GetIdSerial() == idSerial && row.IsReview()
+	})
+	return ok
+}
@@ -2,31 +2,34 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type CommentsRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewCommentsRepoMongo(client *mongo.Client, log *logrus.Logger) *CommentsRepoMongo {
 	db := client.Database("mydb")
-	return &CommentsRepoMongo{db: db, log: log}
+	return &CommentsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *CommentsRepoMongo) GetComments() ([]*models.Comments, error) {
-	repo.log.Info("Getting all comments from the database")
+func (repo *CommentsRepoMongo) GetComments(ctx context.Context) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments from the database")
 	comments := []*models.Comments{}
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -48,32 +51,121 @@ func (repo *CommentsRepoMongo) GetComments() ([]*models.Comments, error) {
 	return comments, nil
 }
 
-func (repo *CommentsRepoMongo) GetCommentById(id int) (*models.Comments, error) {
-	repo.log.Info("Getting comment by id from the database")
+func (repo *CommentsRepoMongo) GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return mgdb.FindPage[models.Comments](ctx, repo.db.Collection("comments"), bson.M{}, bson.D{{Key: "c_id", Value: 1}}, page)
+}
+
+func (repo *CommentsRepoMongo) GetCommentById(ctx context.Context, id int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comment by id from the database")
 	comment := &models.Comments{}
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
+	err := collection.FindOne(ctx, bson.M{"c_id": id}).Decode(comment)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(comment)
+	return comment, nil
+}
+
+func (repo *CommentsRepoMongo) GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by serial from the database")
+	comments := []*models.Comments{}
+	collection := repo.db.Collection("comments")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"c_idserial": idSerial})
 	if err != nil {
 		return nil, err
 	}
-	return comment, nil
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var comment models.Comments
+		if err = cursor.Decode(&comment); err != nil {
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// isReview matches the comments without a parent, including the ones stored
+// before the replies were added.
+var isReview = bson.M{"$not": bson.M{"$gt": 0}}
+
+func (repo *CommentsRepoMongo) GetCommentsBySerialIdPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.Comments, int, error) {
+	if !models.ValidCommentsOrder(sort) || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments by serial id from the database")
+	collection := repo.db.Collection("comments")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"c_idserial": idSerial, "c_idparent": isReview}
+	if sort == models.CommentsByNewest {
+		return mgdb.FindPage[models.Comments](ctx, collection, filter, bson.D{{Key: "c_id", Value: -1}}, page)
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"helpfulness": bson.M{"$subtract": bson.A{
+			bson.M{"$ifNull": bson.A{"$c_up", 0}}, bson.M{"$ifNull": bson.A{"$c_down", 0}},
+		}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "helpfulness", Value: -1}, {Key: "c_id", Value: -1}}}},
+		{{Key: "$skip", Value: page.Offset()}},
+		{{Key: "$limit", Value: page.Limit()}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	comments := []*models.Comments{}
+	for cursor.Next(ctx) {
+		var comment models.Comments
+		if err = cursor.Decode(&comment); err != nil {
+			return nil, 0, err
+		}
+		comments = append(comments, &comment)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+	return comments, int(total), nil
 }
 
-func (repo *CommentsRepoMongo) GetCommentsBySerialId(idSerial int) ([]*models.Comments, error) {
-	repo.log.Info("Getting comments by serial from the database")
+func (repo *CommentsRepoMongo) GetRepliesBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting replies by serial from the database")
 	comments := []*models.Comments{}
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"c_idSerial": idSerial})
+	opts := options.Find().SetSort(bson.D{{Key: "c_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"c_idserial": idSerial, "c_idparent": bson.M{"$gt": 0}}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -92,14 +184,14 @@ func (repo *CommentsRepoMongo) GetCommentsBySerialId(idSerial int) ([]*models.Co
 	return comments, nil
 }
 
-func (repo *CommentsRepoMongo) GetCommentsByUserId(idUser int) ([]*models.Comments, error) {
-	repo.log.Info("Getting comments by user from the database")
+func (repo *CommentsRepoMongo) GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by user from the database")
 	comments := []*models.Comments{}
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"c_idUser": idUser})
+	cursor, err := collection.Find(ctx, bson.M{"c_iduser": idUser})
 	if err != nil {
 		return nil, err
 	}
@@ -118,84 +210,95 @@ func (repo *CommentsRepoMongo) GetCommentsByUserId(idUser int) ([]*models.Commen
 	return comments, nil
 }
 
-func (repo *CommentsRepoMongo) GetCommentsBySerialIdUserId(idSerial, idUser int) (*models.Comments, error) {
-	repo.log.Info("Getting comments by serial and user from the database")
+func (repo *CommentsRepoMongo) GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by serial and user from the database")
 	comment := &models.Comments{}
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	err := collection.FindOne(ctx, bson.M{"c_idSerial": idSerial, "c_idUser": idUser}).Decode(comment)
+	err := collection.FindOne(ctx, bson.M{"c_idserial": idSerial, "c_iduser": idUser, "c_idparent": isReview}).Decode(comment)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return comment, nil
 }
 
-func (repo *CommentsRepoMongo) CreateComment(comment *models.Comments) error {
+func (repo *CommentsRepoMongo) CreateComment(ctx context.Context, comment *models.Comments) error {
 	if !comment.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating comment in the database")
+	repo.log.WithContext(ctx).Info("Creating comment in the database")
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, comment)
+	id, err := repo.counters.NextId(ctx, "comments", "c_id")
+	if err != nil {
+		return err
+	}
+	comment.SetId(id)
+
+	_, err = collection.InsertOne(ctx, comment)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	comment.SetId(res)
 
 	return nil
 }
 
-func (repo *CommentsRepoMongo) UpdateComment(comment *models.Comments) error {
+func (repo *CommentsRepoMongo) UpdateComment(ctx context.Context, comment *models.Comments) error {
 	if !comment.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating comment in the database")
+	repo.log.WithContext(ctx).Info("Updating comment in the database")
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(comment.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"c_id": comment.GetId()}, comment)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"c_text":     comment.GetText(),
-			"c_date":     comment.GetDate(),
-			"c_idUser":   comment.GetIdUser(),
-			"c_idSerial": comment.GetIdSerial(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *CommentsRepoMongo) DeleteComment(id int) error {
-	repo.log.Info("Deleting comment from the database")
+// LockComment writes the comment document, so a concurrent transaction
+// writing it gets a write conflict and is retried after this one, see
+// mongo.Session.WithTransaction.
+func (repo *CommentsRepoMongo) LockComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking comment in the database")
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
+	res, err := collection.UpdateOne(ctx, bson.M{"c_id": id}, bson.M{"$inc": bson.M{"c_lock": 1}})
 	if err != nil {
 		return err
 	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *CommentsRepoMongo) DeleteComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment from the database")
+	collection := repo.db.Collection("comments")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"c_id": id})
 	if err != nil {
 		return err
 	}
@@ -203,15 +306,16 @@ func (repo *CommentsRepoMongo) DeleteComment(id int) error {
 	return nil
 }
 
-func (repo *CommentsRepoMongo) CheckComment(idUser, idSerial int) bool {
-	repo.log.Info("Checking if comment exists in the database")
+func (repo *CommentsRepoMongo) CheckComment(ctx context.Context, idUser, idSerial int) bool {
+	repo.log.WithContext(ctx).Info("Checking if comment exists in the database")
 	collection := repo.db.Collection("comments")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	filter := bson.M{
-		"c_idUser":   idUser,
-		"c_idSerial": idSerial,
+		"c_iduser":   idUser,
+		"c_idserial": idSerial,
+		"c_idparent": isReview,
 	}
 
 	err := collection.FindOne(ctx, filter).Err()
@@ -2,79 +2,131 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type CommentsRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewCommentsRepoPostgres(db *sqlx.DB, log *logrus.Logger) *CommentsRepoPostgres {
+func NewCommentsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *CommentsRepoPostgres {
 	return &CommentsRepoPostgres{db: db, log: log}
 }
 
-func (repo *CommentsRepoPostgres) GetComments() ([]*models.Comments, error) {
-	repo.log.Info("Getting all comments from the database")
+func (repo *CommentsRepoPostgres) GetComments(ctx context.Context) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments from the database")
 	comments := []*models.Comments{}
-	err := repo.db.Select(&comments, "SELECT * FROM comments")
+	err := repo.db.SelectContext(ctx, &comments, "SELECT * FROM comments")
 	if err != nil {
 		return nil, err
 	}
 	return comments, nil
 }
 
-func (repo *CommentsRepoPostgres) GetCommentById(id int) (*models.Comments, error) {
-	repo.log.Info("Getting comment by id from the database")
+func (repo *CommentsRepoPostgres) GetCommentsPage(ctx context.Context, page models.Page) ([]*models.Comments, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments from the database")
+	comments := []*models.Comments{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &comments, "comments", "c_id", page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+func (repo *CommentsRepoPostgres) GetCommentById(ctx context.Context, id int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comment by id from the database")
 	comment := &models.Comments{}
-	err := repo.db.Get(comment, "SELECT * FROM comments WHERE c_id=$1", id)
+	err := repo.db.GetContext(ctx, comment, "SELECT * FROM comments WHERE c_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return comment, nil
 }
 
-func (repo *CommentsRepoPostgres) GetCommentsBySerialId(idSerial int) ([]*models.Comments, error) {
-	repo.log.Info("Getting comments by serial from the database")
+func (repo *CommentsRepoPostgres) GetCommentsBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by serial from the database")
+	comments := []*models.Comments{}
+	err := repo.db.SelectContext(ctx, &comments, "SELECT * FROM comments WHERE c_idSerial=$1", idSerial)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+var commentsOrder = map[string]string{
+	models.CommentsByNewest:  "c_id DESC",
+	models.CommentsByHelpful: "c_up - c_down DESC, c_id DESC",
+}
+
+func (repo *CommentsRepoPostgres) GetCommentsBySerialIdPage(ctx context.Context, idSerial int, sort string, page models.Page) ([]*models.Comments, int, error) {
+	if !models.ValidCommentsOrder(sort) || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of comments by serial id from the database")
+	comments := []*models.Comments{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &comments, "comments WHERE c_idSerial=$1 AND c_idParent=0", commentsOrder[sort], page, idSerial)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+func (repo *CommentsRepoPostgres) GetRepliesBySerialId(ctx context.Context, idSerial int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting replies by serial from the database")
 	comments := []*models.Comments{}
-	err := repo.db.Select(&comments, "SELECT * FROM comments WHERE c_idSerial=$1", idSerial)
+	err := repo.db.SelectContext(ctx, &comments, "SELECT * FROM comments WHERE c_idSerial=$1 AND c_idParent<>0 ORDER BY c_id", idSerial)
 	if err != nil {
 		return nil, err
 	}
 	return comments, nil
 }
 
-func (repo *CommentsRepoPostgres) GetCommentsByUserId(idUser int) ([]*models.Comments, error) {
-	repo.log.Info("Getting comments by user from the database")
+func (repo *CommentsRepoPostgres) GetCommentsByUserId(ctx context.Context, idUser int) ([]*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by user from the database")
 	comments := []*models.Comments{}
-	err := repo.db.Select(&comments, "SELECT * FROM comments WHERE c_idUser=$1", idUser)
+	err := repo.db.SelectContext(ctx, &comments, "SELECT * FROM comments WHERE c_idUser=$1", idUser)
 	if err != nil {
 		return nil, err
 	}
 	return comments, nil
 }
 
-func (repo *CommentsRepoPostgres) GetCommentsBySerialIdUserId(idSerial, idUser int) (*models.Comments, error) {
-	repo.log.Info("Getting comments by serial and user from the database")
+func (repo *CommentsRepoPostgres) GetCommentsBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Comments, error) {
+	repo.log.WithContext(ctx).Info("Getting comments by serial and user from the database")
 	comment := &models.Comments{}
-	err := repo.db.Get(comment, "SELECT * FROM comments WHERE c_idSerial=$1 AND c_idUser=$2", idSerial, idUser)
+	err := repo.db.GetContext(ctx, comment, "SELECT * FROM comments WHERE c_idSerial=$1 AND c_idUser=$2 AND c_idParent=0", idSerial, idUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return comment, nil
 }
 
-func (repo *CommentsRepoPostgres) CreateComment(comment *models.Comments) error {
+func (repo *CommentsRepoPostgres) CreateComment(ctx context.Context, comment *models.Comments) error {
 	if !comment.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating comment in the database")
-	err := repo.db.QueryRow("INSERT INTO comments (c_text, c_date, c_idUser, c_idSerial) VALUES ($1, $2, $3, $4) RETURNING c_id",
-		comment.GetText(), comment.GetDate(), comment.GetIdUser(), comment.GetIdSerial()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating comment in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO comments (c_text, c_date, c_idUser, c_idSerial, c_idParent, c_up, c_down, c_hidden) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING c_id",
+		comment.GetText(), comment.GetDate(), comment.GetIdUser(), comment.GetIdSerial(), comment.GetIdParent(), comment.GetUp(), comment.GetDown(), comment.GetHidden()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -83,25 +135,44 @@ func (repo *CommentsRepoPostgres) CreateComment(comment *models.Comments) error
 	return nil
 }
 
-func (repo *CommentsRepoPostgres) UpdateComment(comment *models.Comments) error {
+func (repo *CommentsRepoPostgres) UpdateComment(ctx context.Context, comment *models.Comments) error {
 	if !comment.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating comment in the database")
-	_, err := repo.db.Exec("UPDATE comments SET c_text=$1, c_date=$2, c_idUser=$3 WHERE c_id=$4",
-		comment.GetText(), comment.GetDate(), comment.GetIdUser(), comment.GetId())
+	repo.log.WithContext(ctx).Info("Updating comment in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE comments SET c_text=$1, c_date=$2, c_idUser=$3, c_idSerial=$4, c_idParent=$5, c_up=$6, c_down=$7, c_hidden=$8 WHERE c_id=$9",
+		comment.GetText(), comment.GetDate(), comment.GetIdUser(), comment.GetIdSerial(), comment.GetIdParent(), comment.GetUp(), comment.GetDown(), comment.GetHidden(), comment.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *CommentsRepoPostgres) DeleteComment(id int) error {
-	repo.log.Info("Deleting comment from the database")
-	_, err := repo.db.Exec("DELETE FROM comments WHERE c_id=$1", id)
+// LockComment locks the row of the comment until the end of the transaction,
+// so the concurrent updates of the comment wait for it.
+func (repo *CommentsRepoPostgres) LockComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking comment in the database")
+	var locked int
+	err := repo.db.GetContext(ctx, &locked, "SELECT c_id FROM comments WHERE c_id=$1 FOR UPDATE", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrNotFound
+	}
+	return err
+}
+
+func (repo *CommentsRepoPostgres) DeleteComment(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comments WHERE c_id=$1", id)
 	if err != nil {
 		return err
 	}
@@ -109,9 +180,9 @@ func (repo *CommentsRepoPostgres) DeleteComment(id int) error {
 	return nil
 }
 
-func (repo *CommentsRepoPostgres) CheckComment(idUser, idSerial int) bool {
-	repo.log.Info("Checking if comment exists in the database")
+func (repo *CommentsRepoPostgres) CheckComment(ctx context.Context, idUser, idSerial int) bool {
+	repo.log.WithContext(ctx).Info("Checking if comment exists in the database")
 	var id int
-	err := repo.db.Get(&id, "SELECT c_id FROM comments WHERE c_idUser=$1 AND c_idSerial=$2", idUser, idSerial)
+	err := repo.db.GetContext(ctx, &id, "SELECT c_id FROM comments WHERE c_idUser=$1 AND c_idSerial=$2 AND c_idParent=0 LIMIT 1", idUser, idSerial)
 	return err == nil
 }
@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	mem "app/internal/repositories/comments/memory"
 	mg "app/internal/repositories/comments/mongo"
 	pg "app/internal/repositories/comments/postgres"
+	"app/internal/repositories/memdb"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
@@ -14,8 +16,14 @@ func NewCommentsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoComment
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewCommentsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewCommentsRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewCommentsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewCommentsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewCommentsRepoMemory(db, log)
 	default:
 		return nil
 	}
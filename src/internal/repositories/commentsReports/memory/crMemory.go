@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CommentsReportsRepoMemory struct {
+	table *memdb.Table[models.CommentsReports, *models.CommentsReports]
+	log   *logrus.Logger
+}
+
+func NewCommentsReportsRepoMemory(db *memdb.DB, log *logrus.Logger) *CommentsReportsRepoMemory {
+	return &CommentsReportsRepoMemory{table: memdb.NewTable[models.CommentsReports](db, "comments_reports"), log: log}
+}
+
+func (repo *CommentsReportsRepoMemory) GetCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments reports from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *CommentsReportsRepoMemory) GetCommentReportById(ctx context.Context, id int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by id from the database")
+	report, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoMemory) GetCommentReportByIds(ctx context.Context, idComment, idUser int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by comment id and user id from the database")
+	report, ok := repo.table.First(func(row *models.CommentsReports) bool {
+		return row.GetIdComment() == idComment && row.GetIdUser() == idUser
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoMemory) GetCommentsReportsByUserId(ctx context.Context, idUser int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by user id from the database")
+	return repo.table.Select(func(row *models.CommentsReports) bool {
+		return row.GetIdUser() == idUser
+	}), nil
+}
+
+func (repo *CommentsReportsRepoMemory) GetCommentsReportsByCommentId(ctx context.Context, idComment int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by comment id from the database")
+	return repo.table.Select(func(row *models.CommentsReports) bool {
+		return row.GetIdComment() == idComment
+	}), nil
+}
+
+func (repo *CommentsReportsRepoMemory) GetOpenCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting open comments reports from the database")
+	return repo.table.Select(func(row *models.CommentsReports) bool {
+		return row.GetState() == models.ReportOpen
+	}), nil
+}
+
+func (repo *CommentsReportsRepoMemory) CreateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comment report in the database")
+	repo.table.Insert(report)
+	return nil
+}
+
+func (repo *CommentsReportsRepoMemory) UpdateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment report in the database")
+	if !repo.table.Update(report) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *CommentsReportsRepoMemory) DeleteCommentReport(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment report from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *CommentsReportsRepoMemory) DeleteCommentReportsByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting reports of comment from the database")
+	repo.table.Delete(func(row *models.CommentsReports) bool {
+		return row.GetIdComment() == idComment
+	})
+	return nil
+}
@@ -0,0 +1,237 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentsReportsRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewCommentsReportsRepoMongo(client *mongo.Client, log *logrus.Logger) *CommentsReportsRepoMongo {
+	db := client.Database("mydb")
+	return &CommentsReportsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *CommentsReportsRepoMongo) GetCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments reports from the database")
+	reports := []*models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var report models.CommentsReports
+		if err = cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoMongo) GetCommentReportById(ctx context.Context, id int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by id from the database")
+	report := &models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"cr_id": id}).Decode(report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoMongo) GetCommentReportByIds(ctx context.Context, idComment, idUser int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by comment and user from the database")
+	report := &models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"cr_idcomment": idComment, "cr_iduser": idUser}).Decode(report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoMongo) GetCommentsReportsByUserId(ctx context.Context, idUser int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by user from the database")
+	reports := []*models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"cr_iduser": idUser})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var report models.CommentsReports
+		if err = cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoMongo) GetCommentsReportsByCommentId(ctx context.Context, idComment int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by comment from the database")
+	reports := []*models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"cr_idcomment": idComment})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var report models.CommentsReports
+		if err = cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoMongo) GetOpenCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting open comments reports from the database")
+	reports := []*models.CommentsReports{}
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "cr_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"cr_state": models.ReportOpen}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var report models.CommentsReports
+		if err = cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoMongo) CreateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comment report in the database")
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "comments_reports", "cr_id")
+	if err != nil {
+		return err
+	}
+	report.SetId(id)
+
+	_, err = collection.InsertOne(ctx, report)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoMongo) UpdateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment report in the database")
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"cr_id": report.GetId()}, report)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoMongo) DeleteCommentReport(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment report from the database")
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"cr_id": id})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoMongo) DeleteCommentReportsByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting reports of comment from the database")
+	collection := repo.db.Collection("comments_reports")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, bson.M{"cr_idcomment": idComment})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
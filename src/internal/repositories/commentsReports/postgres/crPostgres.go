@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CommentsReportsRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewCommentsReportsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *CommentsReportsRepoPostgres {
+	return &CommentsReportsRepoPostgres{db: db, log: log}
+}
+
+func (repo *CommentsReportsRepoPostgres) GetCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments reports from the database")
+	reports := []*models.CommentsReports{}
+	err := repo.db.SelectContext(ctx, &reports, "SELECT * FROM comments_reports")
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) GetCommentReportById(ctx context.Context, id int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by id from the database")
+	report := &models.CommentsReports{}
+	err := repo.db.GetContext(ctx, report, "SELECT * FROM comments_reports WHERE cr_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) GetCommentReportByIds(ctx context.Context, idComment, idUser int) (*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comment report by comment and user from the database")
+	report := &models.CommentsReports{}
+	err := repo.db.GetContext(ctx, report, "SELECT * FROM comments_reports WHERE cr_idComment=$1 AND cr_idUser=$2", idComment, idUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) GetCommentsReportsByUserId(ctx context.Context, idUser int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by user from the database")
+	reports := []*models.CommentsReports{}
+	err := repo.db.SelectContext(ctx, &reports, "SELECT * FROM comments_reports WHERE cr_idUser=$1", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) GetCommentsReportsByCommentId(ctx context.Context, idComment int) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting comments reports by comment from the database")
+	reports := []*models.CommentsReports{}
+	err := repo.db.SelectContext(ctx, &reports, "SELECT * FROM comments_reports WHERE cr_idComment=$1", idComment)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) GetOpenCommentsReports(ctx context.Context) ([]*models.CommentsReports, error) {
+	repo.log.WithContext(ctx).Info("Getting open comments reports from the database")
+	reports := []*models.CommentsReports{}
+	err := repo.db.SelectContext(ctx, &reports, "SELECT * FROM comments_reports WHERE cr_state=$1 ORDER BY cr_id", models.ReportOpen)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *CommentsReportsRepoPostgres) CreateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating comment report in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO comments_reports (cr_idComment, cr_idUser, cr_reason, cr_date, cr_state) VALUES ($1, $2, $3, $4, $5) RETURNING cr_id",
+		report.GetIdComment(), report.GetIdUser(), report.GetReason(), report.GetDate(), report.GetState()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	report.SetId(int(id))
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoPostgres) UpdateCommentReport(ctx context.Context, report *models.CommentsReports) error {
+	if !report.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment report in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE comments_reports SET cr_idComment=$1, cr_idUser=$2, cr_reason=$3, cr_date=$4, cr_state=$5 WHERE cr_id=$6",
+		report.GetIdComment(), report.GetIdUser(), report.GetReason(), report.GetDate(), report.GetState(), report.GetId())
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoPostgres) DeleteCommentReport(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment report from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comments_reports WHERE cr_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsReportsRepoPostgres) DeleteCommentReportsByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting reports of comment from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comments_reports WHERE cr_idComment=$1", idComment)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
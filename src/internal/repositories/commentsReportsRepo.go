@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	mem "app/internal/repositories/commentsReports/memory"
+	mg "app/internal/repositories/commentsReports/mongo"
+	pg "app/internal/repositories/commentsReports/postgres"
+	"app/internal/repositories/memdb"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewCommentsReportsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoCommentsReports {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewCommentsReportsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewCommentsReportsRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewCommentsReportsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewCommentsReportsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewCommentsReportsRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
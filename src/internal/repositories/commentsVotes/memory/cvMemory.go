@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CommentsVotesRepoMemory struct {
+	table *memdb.Table[models.CommentsVotes, *models.CommentsVotes]
+	log   *logrus.Logger
+}
+
+func NewCommentsVotesRepoMemory(db *memdb.DB, log *logrus.Logger) *CommentsVotesRepoMemory {
+	return &CommentsVotesRepoMemory{table: memdb.NewTable[models.CommentsVotes](db, "comments_votes"), log: log}
+}
+
+func (repo *CommentsVotesRepoMemory) GetCommentsVotes(ctx context.Context) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments votes from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *CommentsVotesRepoMemory) GetCommentVoteById(ctx context.Context, id int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by id from the database")
+	vote, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoMemory) GetCommentVoteByIds(ctx context.Context, idComment, idUser int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by comment id and user id from the database")
+	vote, ok := repo.table.First(func(row *models.CommentsVotes) bool {
+		return row.GetIdComment() == idComment && row.GetIdUser() == idUser
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoMemory) GetCommentsVotesByUserId(ctx context.Context, idUser int) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comments votes by user id from the database")
+	return repo.table.Select(func(row *models.CommentsVotes) bool {
+		return row.GetIdUser() == idUser
+	}), nil
+}
+
+func (repo *CommentsVotesRepoMemory) CountCommentVotes(ctx context.Context, idComment int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Counting votes of comment in the database")
+	up, down := 0, 0
+	for _, vote := range repo.table.Select(func(row *models.CommentsVotes) bool {
+		return row.GetIdComment() == idComment
+	}) {
+		if vote.GetValue() > 0 {
+			up++
+		} else {
+			down++
+		}
+	}
+	return up, down, nil
+}
+
+func (repo *CommentsVotesRepoMemory) CreateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comment vote in the database")
+	repo.table.Insert(vote)
+	return nil
+}
+
+func (repo *CommentsVotesRepoMemory) UpdateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment vote in the database")
+	if !repo.table.Update(vote) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *CommentsVotesRepoMemory) DeleteCommentVote(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment vote from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *CommentsVotesRepoMemory) DeleteCommentVotesByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting votes of comment from the database")
+	repo.table.Delete(func(row *models.CommentsVotes) bool {
+		return row.GetIdComment() == idComment
+	})
+	return nil
+}
@@ -0,0 +1,200 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CommentsVotesRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewCommentsVotesRepoMongo(client *mongo.Client, log *logrus.Logger) *CommentsVotesRepoMongo {
+	db := client.Database("mydb")
+	return &CommentsVotesRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *CommentsVotesRepoMongo) GetCommentsVotes(ctx context.Context) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments votes from the database")
+	votes := []*models.CommentsVotes{}
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var vote models.CommentsVotes
+		if err = cursor.Decode(&vote); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &vote)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (repo *CommentsVotesRepoMongo) GetCommentVoteById(ctx context.Context, id int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by id from the database")
+	vote := &models.CommentsVotes{}
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"cv_id": id}).Decode(vote)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoMongo) GetCommentVoteByIds(ctx context.Context, idComment, idUser int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by comment and user from the database")
+	vote := &models.CommentsVotes{}
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"cv_idcomment": idComment, "cv_iduser": idUser}).Decode(vote)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoMongo) GetCommentsVotesByUserId(ctx context.Context, idUser int) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comments votes by user from the database")
+	votes := []*models.CommentsVotes{}
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"cv_iduser": idUser})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var vote models.CommentsVotes
+		if err = cursor.Decode(&vote); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &vote)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (repo *CommentsVotesRepoMongo) CountCommentVotes(ctx context.Context, idComment int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Counting votes of comment in the database")
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	up, err := collection.CountDocuments(ctx, bson.M{"cv_idcomment": idComment, "cv_value": bson.M{"$gt": 0}})
+	if err != nil {
+		return 0, 0, err
+	}
+	down, err := collection.CountDocuments(ctx, bson.M{"cv_idcomment": idComment, "cv_value": bson.M{"$lt": 0}})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(up), int(down), nil
+}
+
+func (repo *CommentsVotesRepoMongo) CreateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comment vote in the database")
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "comments_votes", "cv_id")
+	if err != nil {
+		return err
+	}
+	vote.SetId(id)
+
+	_, err = collection.InsertOne(ctx, vote)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoMongo) UpdateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment vote in the database")
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"cv_id": vote.GetId()}, vote)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoMongo) DeleteCommentVote(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment vote from the database")
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"cv_id": id})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoMongo) DeleteCommentVotesByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting votes of comment from the database")
+	collection := repo.db.Collection("comments_votes")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, bson.M{"cv_idcomment": idComment})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
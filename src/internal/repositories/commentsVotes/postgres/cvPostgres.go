@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CommentsVotesRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewCommentsVotesRepoPostgres(db pgdb.Querier, log *logrus.Logger) *CommentsVotesRepoPostgres {
+	return &CommentsVotesRepoPostgres{db: db, log: log}
+}
+
+func (repo *CommentsVotesRepoPostgres) GetCommentsVotes(ctx context.Context) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting all comments votes from the database")
+	votes := []*models.CommentsVotes{}
+	err := repo.db.SelectContext(ctx, &votes, "SELECT * FROM comments_votes")
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (repo *CommentsVotesRepoPostgres) GetCommentVoteById(ctx context.Context, id int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by id from the database")
+	vote := &models.CommentsVotes{}
+	err := repo.db.GetContext(ctx, vote, "SELECT * FROM comments_votes WHERE cv_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoPostgres) GetCommentVoteByIds(ctx context.Context, idComment, idUser int) (*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comment vote by comment and user from the database")
+	vote := &models.CommentsVotes{}
+	err := repo.db.GetContext(ctx, vote, "SELECT * FROM comments_votes WHERE cv_idComment=$1 AND cv_idUser=$2", idComment, idUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (repo *CommentsVotesRepoPostgres) GetCommentsVotesByUserId(ctx context.Context, idUser int) ([]*models.CommentsVotes, error) {
+	repo.log.WithContext(ctx).Info("Getting comments votes by user from the database")
+	votes := []*models.CommentsVotes{}
+	err := repo.db.SelectContext(ctx, &votes, "SELECT * FROM comments_votes WHERE cv_idUser=$1", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (repo *CommentsVotesRepoPostgres) CountCommentVotes(ctx context.Context, idComment int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Counting votes of comment in the database")
+	var count struct {
+		Up   int `db:"up"`
+		Down int `db:"down"`
+	}
+	err := repo.db.GetContext(ctx, &count, "SELECT COUNT(*) FILTER (WHERE cv_value > 0) AS up, COUNT(*) FILTER (WHERE cv_value < 0) AS down FROM comments_votes WHERE cv_idComment=$1", idComment)
+	if err != nil {
+		return 0, 0, err
+	}
+	return count.Up, count.Down, nil
+}
+
+func (repo *CommentsVotesRepoPostgres) CreateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating comment vote in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO comments_votes (cv_idComment, cv_idUser, cv_value) VALUES ($1, $2, $3) RETURNING cv_id",
+		vote.GetIdComment(), vote.GetIdUser(), vote.GetValue()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	vote.SetId(int(id))
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoPostgres) UpdateCommentVote(ctx context.Context, vote *models.CommentsVotes) error {
+	if !vote.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating comment vote in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE comments_votes SET cv_idComment=$1, cv_idUser=$2, cv_value=$3 WHERE cv_id=$4",
+		vote.GetIdComment(), vote.GetIdUser(), vote.GetValue(), vote.GetId())
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoPostgres) DeleteCommentVote(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comment vote from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comments_votes WHERE cv_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *CommentsVotesRepoPostgres) DeleteCommentVotesByCommentId(ctx context.Context, idComment int) error {
+	repo.log.WithContext(ctx).Info("Deleting votes of comment from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comments_votes WHERE cv_idComment=$1", idComment)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
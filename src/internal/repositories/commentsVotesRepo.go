@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	mem "app/internal/repositories/commentsVotes/memory"
+	mg "app/internal/repositories/commentsVotes/mongo"
+	pg "app/internal/repositories/commentsVotes/postgres"
+	"app/internal/repositories/memdb"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewCommentsVotesRepo(db interface{}, log *logrus.Logger) interfaces.IRepoCommentsVotes {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewCommentsVotesRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewCommentsVotesRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewCommentsVotesRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewCommentsVotesRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewCommentsVotesRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ComparisonsRepoMemory struct {
+	table *memdb.Table[models.Comparisons, *models.Comparisons]
+	log   *logrus.Logger
+}
+
+func NewComparisonsRepoMemory(db *memdb.DB, log *logrus.Logger) *ComparisonsRepoMemory {
+	return &ComparisonsRepoMemory{table: memdb.NewTable[models.Comparisons](db, "comparisons"), log: log}
+}
+
+func (repo *ComparisonsRepoMemory) GetComparisonById(ctx context.Context, id int) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by id from the database")
+	comparison, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return comparison, nil
+}
+
+func (repo *ComparisonsRepoMemory) GetComparisonBySlug(ctx context.Context, slug string) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by slug from the database")
+	comparison, ok := repo.table.First(func(row *models.Comparisons) bool {
+		return row.GetSlug() == slug
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return comparison, nil
+}
+
+// GetComparisonsByUserId returns the comparisons of the user, the earlier
+// saved first.
+func (repo *ComparisonsRepoMemory) GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparisons by user id from the database")
+	return repo.table.Select(func(row *models.Comparisons) bool {
+		return row.GetIdUser() == idUser
+	}), nil
+}
+
+func (repo *ComparisonsRepoMemory) CreateComparison(ctx context.Context, comparison *models.Comparisons) error {
+	if !comparison.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comparison in the database")
+	repo.table.Insert(comparison)
+	return nil
+}
+
+func (repo *ComparisonsRepoMemory) DeleteComparison(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparison from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *ComparisonsRepoMemory) DeleteComparisonsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparisons by user id from the database")
+	repo.table.Delete(func(row *models.Comparisons) bool {
+		return row.GetIdUser() == idUser
+	})
+	return nil
+}
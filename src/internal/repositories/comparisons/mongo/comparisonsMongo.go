@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ComparisonsRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewComparisonsRepoMongo(client *mongo.Client, log *logrus.Logger) *ComparisonsRepoMongo {
+	db := client.Database("mydb")
+	return &ComparisonsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *ComparisonsRepoMongo) getComparison(ctx context.Context, filter bson.M) (*models.Comparisons, error) {
+	collection := repo.db.Collection("comparisons")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var comparison models.Comparisons
+	err := collection.FindOne(ctx, filter).Decode(&comparison)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
+func (repo *ComparisonsRepoMongo) GetComparisonById(ctx context.Context, id int) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by id from the database")
+	return repo.getComparison(ctx, bson.M{"cm_id": id})
+}
+
+func (repo *ComparisonsRepoMongo) GetComparisonBySlug(ctx context.Context, slug string) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by slug from the database")
+	return repo.getComparison(ctx, bson.M{"cm_slug": slug})
+}
+
+// GetComparisonsByUserId returns the comparisons of the user, the earlier
+// saved first.
+func (repo *ComparisonsRepoMongo) GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparisons by user id from the database")
+	collection := repo.db.Collection("comparisons")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "cm_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"cm_iduser": idUser}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	comparisons := []*models.Comparisons{}
+	for cursor.Next(ctx) {
+		var comparison models.Comparisons
+		if err := cursor.Decode(&comparison); err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, &comparison)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return comparisons, nil
+}
+
+func (repo *ComparisonsRepoMongo) CreateComparison(ctx context.Context, comparison *models.Comparisons) error {
+	if !comparison.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating comparison in the database")
+	collection := repo.db.Collection("comparisons")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "comparisons", "cm_id")
+	if err != nil {
+		return err
+	}
+	comparison.SetId(id)
+
+	_, err = collection.InsertOne(ctx, comparison)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *ComparisonsRepoMongo) DeleteComparison(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparison from the database")
+	collection := repo.db.Collection("comparisons")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"cm_id": id})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repo *ComparisonsRepoMongo) DeleteComparisonsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparisons by user id from the database")
+	collection := repo.db.Collection("comparisons")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, bson.M{"cm_iduser": idUser})
+	if err != nil {
+		return err
+	}
+	return nil
+}
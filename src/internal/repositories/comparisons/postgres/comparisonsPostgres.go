@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ComparisonsRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewComparisonsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *ComparisonsRepoPostgres {
+	return &ComparisonsRepoPostgres{db: db, log: log}
+}
+
+func (repo *ComparisonsRepoPostgres) GetComparisonById(ctx context.Context, id int) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by id from the database")
+	comparison := &models.Comparisons{}
+	err := repo.db.GetContext(ctx, comparison, "SELECT * FROM comparisons WHERE cm_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+func (repo *ComparisonsRepoPostgres) GetComparisonBySlug(ctx context.Context, slug string) (*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparison by slug from the database")
+	comparison := &models.Comparisons{}
+	err := repo.db.GetContext(ctx, comparison, "SELECT * FROM comparisons WHERE cm_slug=$1", slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// GetComparisonsByUserId returns the comparisons of the user, the earlier
+// saved first.
+func (repo *ComparisonsRepoPostgres) GetComparisonsByUserId(ctx context.Context, idUser int) ([]*models.Comparisons, error) {
+	repo.log.WithContext(ctx).Info("Getting comparisons by user id from the database")
+	comparisons := []*models.Comparisons{}
+	err := repo.db.SelectContext(ctx, &comparisons, "SELECT * FROM comparisons WHERE cm_idUser=$1 ORDER BY cm_id", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return comparisons, nil
+}
+
+func (repo *ComparisonsRepoPostgres) CreateComparison(ctx context.Context, comparison *models.Comparisons) error {
+	if !comparison.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating comparison in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO comparisons (cm_idUser, cm_slug, cm_serials, cm_date) VALUES ($1, $2, $3, $4) RETURNING cm_id",
+		comparison.GetIdUser(), comparison.GetSlug(), comparison.GetSerials(), comparison.GetDate()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	comparison.SetId(int(id))
+
+	return nil
+}
+
+func (repo *ComparisonsRepoPostgres) DeleteComparison(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparison from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comparisons WHERE cm_id=$1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repo *ComparisonsRepoPostgres) DeleteComparisonsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting comparisons by user id from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM comparisons WHERE cm_idUser=$1", idUser)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	mem "app/internal/repositories/comparisons/memory"
+	mg "app/internal/repositories/comparisons/mongo"
+	pg "app/internal/repositories/comparisons/postgres"
+	"app/internal/repositories/memdb"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewComparisonsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoComparisons {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewComparisonsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewComparisonsRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewComparisonsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewComparisonsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewComparisonsRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
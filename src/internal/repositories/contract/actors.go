@@ -0,0 +1,55 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validActor() *models.Actors {
+	return &models.Actors{A_name: "Брайан", A_surname: "Крэнстон", A_gender: "м", A_bdate: "07.03.1956"}
+}
+
+var actorsCrud = crud[interfaces.IRepoActors, models.Actors, *models.Actors]{
+	create: interfaces.IRepoActors.CreateActor,
+	get:    interfaces.IRepoActors.GetActorById,
+	update: interfaces.IRepoActors.UpdateActor,
+	delete: interfaces.IRepoActors.DeleteActor,
+	list:   interfaces.IRepoActors.GetActors,
+	valid: func(t *testing.T, db interface{}) *models.Actors {
+		return validActor()
+	},
+	change: func(t *testing.T, db interface{}, actor *models.Actors) {
+		actor.SetName("Аарон")
+		actor.SetSurname("Пол")
+		actor.SetBdate("27.08.1979")
+	},
+	invalidate: func(actor *models.Actors) {
+		actor.SetName("")
+	},
+}
+
+// Actors runs the IRepoActors contract.
+func Actors(t *testing.T, open Open) {
+	run(t, open, repositories.NewActorsRepo, append(actorsCrud.tests(),
+		testCase[interfaces.IRepoActors]{"check finds actor by its fields", func(t *testing.T, db interface{}, repo interfaces.IRepoActors) {
+			actor := validActor()
+			require.NoError(t, repo.CreateActor(ctx, actor))
+
+			found := validActor()
+			assert.True(t, repo.CheckActor(ctx, found))
+			assert.Equal(t, actor, found)
+		}},
+		testCase[interfaces.IRepoActors]{"check of missing actor", func(t *testing.T, db interface{}, repo interfaces.IRepoActors) {
+			require.NoError(t, repo.CreateActor(ctx, validActor()))
+
+			other := validActor()
+			other.SetBdate("08.03.1956")
+			assert.False(t, repo.CheckActor(ctx, other))
+		}},
+	))
+}
@@ -0,0 +1,179 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validComment(t *testing.T, db interface{}) *models.Comments {
+	return &models.Comments{
+		C_text:     "Лучший сериал",
+		C_date:     "01.02.2024",
+		C_idUser:   newUser(t, db).GetId(),
+		C_idSerial: newSerial(t, db).GetId(),
+	}
+}
+
+var commentsCrud = crud[interfaces.IRepoComments, models.Comments, *models.Comments]{
+	create: interfaces.IRepoComments.CreateComment,
+	get:    interfaces.IRepoComments.GetCommentById,
+	update: interfaces.IRepoComments.UpdateComment,
+	delete: interfaces.IRepoComments.DeleteComment,
+	list:   interfaces.IRepoComments.GetComments,
+	page:   interfaces.IRepoComments.GetCommentsPage,
+	valid:  validComment,
+	change: func(t *testing.T, db interface{}, comment *models.Comments) {
+		comment.C_text = "Худший сериал"
+		comment.C_date = "03.04.2024"
+		comment.C_idUser = newUser(t, db).GetId()
+		comment.C_idSerial = newSerial(t, db).GetId()
+		comment.C_idParent = newComment(t, db).GetId()
+		comment.SetVotes(2, 1)
+		comment.SetHidden(true)
+	},
+	invalidate: func(comment *models.Comments) {
+		comment.C_text = ""
+	},
+}
+
+// Comments runs the IRepoComments contract.
+func Comments(t *testing.T, open Open) {
+	run(t, open, repositories.NewCommentsRepo, append(commentsCrud.tests(),
+		testCase[interfaces.IRepoComments]{"get by serial and by user", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			first := validComment(t, db)
+			second := validComment(t, db)
+			second.C_idUser = first.C_idUser
+			third := validComment(t, db)
+			third.C_idSerial = first.C_idSerial
+			for _, comment := range []*models.Comments{first, second, third} {
+				require.NoError(t, repo.CreateComment(ctx, comment))
+			}
+
+			bySerial, err := repo.GetCommentsBySerialId(ctx, first.C_idSerial)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.Comments{first, third}, bySerial)
+
+			byUser, err := repo.GetCommentsByUserId(ctx, first.C_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.Comments{first, second}, byUser)
+
+			none, err := repo.GetCommentsBySerialId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoComments]{"page by serial", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			first := validComment(t, db)
+			require.NoError(t, repo.CreateComment(ctx, first))
+			require.NoError(t, repo.CreateComment(ctx, validComment(t, db)))
+			rest := []*models.Comments{}
+			for i := 0; i < 2; i++ {
+				comment := validComment(t, db)
+				comment.C_idSerial = first.C_idSerial
+				require.NoError(t, repo.CreateComment(ctx, comment))
+				rest = append(rest, comment)
+			}
+			reply := validComment(t, db)
+			reply.C_idSerial = first.C_idSerial
+			reply.C_idParent = first.C_id
+			require.NoError(t, repo.CreateComment(ctx, reply))
+
+			got, total, err := repo.GetCommentsBySerialIdPage(ctx, first.C_idSerial, models.CommentsByNewest, models.NewPage(1, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Comments{rest[1], rest[0]}, got)
+			assert.Equal(t, 3, total)
+
+			got, total, err = repo.GetCommentsBySerialIdPage(ctx, first.C_idSerial, models.CommentsByNewest, models.NewPage(2, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Comments{first}, got)
+			assert.Equal(t, 3, total)
+
+			_, _, err = repo.GetCommentsBySerialIdPage(ctx, first.C_idSerial, models.CommentsByNewest, models.NewPage(0, 2))
+			assert.ErrorIs(t, err, models.ErrInvalidModel)
+			_, _, err = repo.GetCommentsBySerialIdPage(ctx, first.C_idSerial, "oldest", models.NewPage(1, 2))
+			assert.ErrorIs(t, err, models.ErrInvalidModel)
+		}},
+		testCase[interfaces.IRepoComments]{"page by serial by helpfulness", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			idSerial := newSerial(t, db).GetId()
+			comments := []*models.Comments{}
+			for _, votes := range [][2]int{{1, 0}, {5, 2}, {0, 3}, {2, 1}} {
+				comment := validComment(t, db)
+				comment.C_idSerial = idSerial
+				comment.SetVotes(votes[0], votes[1])
+				require.NoError(t, repo.CreateComment(ctx, comment))
+				comments = append(comments, comment)
+			}
+
+			got, total, err := repo.GetCommentsBySerialIdPage(ctx, idSerial, models.CommentsByHelpful, models.NewPage(1, 10))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Comments{comments[1], comments[3], comments[0], comments[2]}, got)
+			assert.Equal(t, 4, total)
+		}},
+		testCase[interfaces.IRepoComments]{"replies by serial", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			review := validComment(t, db)
+			require.NoError(t, repo.CreateComment(ctx, review))
+			replies := []*models.Comments{}
+			parent := review
+			for i := 0; i < 2; i++ {
+				reply := validComment(t, db)
+				reply.C_idSerial = review.C_idSerial
+				reply.C_idParent = parent.C_id
+				require.NoError(t, repo.CreateComment(ctx, reply))
+				replies = append(replies, reply)
+				parent = reply
+			}
+			other := validComment(t, db)
+			other.C_idParent = review.C_id
+			require.NoError(t, repo.CreateComment(ctx, other))
+
+			got, err := repo.GetRepliesBySerialId(ctx, review.C_idSerial)
+			require.NoError(t, err)
+			assert.Equal(t, replies, got)
+
+			none, err := repo.GetRepliesBySerialId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoComments]{"get by serial and user", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			comment := validComment(t, db)
+			require.NoError(t, repo.CreateComment(ctx, comment))
+
+			got, err := repo.GetCommentsBySerialIdUserId(ctx, comment.C_idSerial, comment.C_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, comment, got)
+
+			_, err = repo.GetCommentsBySerialIdUserId(ctx, comment.C_idUser, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoComments]{"lock", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			comment := validComment(t, db)
+			require.NoError(t, repo.CreateComment(ctx, comment))
+
+			require.NoError(t, repo.LockComment(ctx, comment.GetId()))
+			got, err := repo.GetCommentById(ctx, comment.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, comment, got)
+
+			assert.ErrorIs(t, repo.LockComment(ctx, missingId), models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoComments]{"check comment", func(t *testing.T, db interface{}, repo interfaces.IRepoComments) {
+			comment := validComment(t, db)
+			require.NoError(t, repo.CreateComment(ctx, comment))
+
+			assert.True(t, repo.CheckComment(ctx, comment.C_idUser, comment.C_idSerial))
+			assert.False(t, repo.CheckComment(ctx, comment.C_idSerial, missingId))
+			assert.False(t, repo.CheckComment(ctx, missingId, comment.C_idSerial))
+
+			reply := validComment(t, db)
+			reply.C_idParent = comment.C_id
+			require.NoError(t, repo.CreateComment(ctx, reply))
+			assert.False(t, repo.CheckComment(ctx, reply.C_idUser, reply.C_idSerial))
+			_, err := repo.GetCommentsBySerialIdUserId(ctx, reply.C_idSerial, reply.C_idUser)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+	))
+}
@@ -0,0 +1,101 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCommentReport(t *testing.T, db interface{}) *models.CommentsReports {
+	return &models.CommentsReports{
+		Cr_idComment: newComment(t, db).GetId(),
+		Cr_idUser:    newUser(t, db).GetId(),
+		Cr_reason:    "Спойлер",
+		Cr_date:      "01.02.2024",
+		Cr_state:     models.ReportOpen,
+	}
+}
+
+var commentsReportsCrud = crud[interfaces.IRepoCommentsReports, models.CommentsReports, *models.CommentsReports]{
+	create: interfaces.IRepoCommentsReports.CreateCommentReport,
+	get:    interfaces.IRepoCommentsReports.GetCommentReportById,
+	update: interfaces.IRepoCommentsReports.UpdateCommentReport,
+	delete: interfaces.IRepoCommentsReports.DeleteCommentReport,
+	list:   interfaces.IRepoCommentsReports.GetCommentsReports,
+	valid:  validCommentReport,
+	change: func(t *testing.T, db interface{}, report *models.CommentsReports) {
+		report.Cr_reason = "Оскорбления"
+		report.Cr_date = "03.04.2024"
+		report.Cr_state = models.ReportClosed
+	},
+	invalidate: func(report *models.CommentsReports) {
+		report.Cr_state = "unknown"
+	},
+}
+
+// CommentsReports runs the IRepoCommentsReports contract.
+func CommentsReports(t *testing.T, open Open) {
+	run(t, open, repositories.NewCommentsReportsRepo, append(commentsReportsCrud.tests(),
+		testCase[interfaces.IRepoCommentsReports]{"get by user, by comment and by comment and user", func(t *testing.T, db interface{}, repo interfaces.IRepoCommentsReports) {
+			first := validCommentReport(t, db)
+			second := validCommentReport(t, db)
+			second.Cr_idUser = first.Cr_idUser
+			third := validCommentReport(t, db)
+			third.Cr_idComment = first.Cr_idComment
+			for _, report := range []*models.CommentsReports{first, second, third} {
+				require.NoError(t, repo.CreateCommentReport(ctx, report))
+			}
+
+			byUser, err := repo.GetCommentsReportsByUserId(ctx, first.Cr_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.CommentsReports{first, second}, byUser)
+
+			byComment, err := repo.GetCommentsReportsByCommentId(ctx, first.Cr_idComment)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.CommentsReports{first, third}, byComment)
+
+			got, err := repo.GetCommentReportByIds(ctx, third.Cr_idComment, third.Cr_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, third, got)
+
+			_, err = repo.GetCommentReportByIds(ctx, second.Cr_idComment, third.Cr_idUser)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoCommentsReports]{"open reports oldest first", func(t *testing.T, db interface{}, repo interfaces.IRepoCommentsReports) {
+			reports := []*models.CommentsReports{}
+			for i := 0; i < 3; i++ {
+				report := validCommentReport(t, db)
+				require.NoError(t, repo.CreateCommentReport(ctx, report))
+				reports = append(reports, report)
+			}
+			reports[1].Cr_state = models.ReportClosed
+			require.NoError(t, repo.UpdateCommentReport(ctx, reports[1]))
+
+			open, err := repo.GetOpenCommentsReports(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.CommentsReports{reports[0], reports[2]}, open)
+		}},
+		testCase[interfaces.IRepoCommentsReports]{"delete by comment", func(t *testing.T, db interface{}, repo interfaces.IRepoCommentsReports) {
+			first := validCommentReport(t, db)
+			require.NoError(t, repo.CreateCommentReport(ctx, first))
+			second := validCommentReport(t, db)
+			second.Cr_idComment = first.Cr_idComment
+			require.NoError(t, repo.CreateCommentReport(ctx, second))
+			other := validCommentReport(t, db)
+			require.NoError(t, repo.CreateCommentReport(ctx, other))
+
+			require.NoError(t, repo.DeleteCommentReportsByCommentId(ctx, first.Cr_idComment))
+			byComment, err := repo.GetCommentsReportsByCommentId(ctx, first.Cr_idComment)
+			require.NoError(t, err)
+			assert.Empty(t, byComment)
+
+			got, err := repo.GetCommentReportById(ctx, other.Cr_id)
+			require.NoError(t, err)
+			assert.Equal(t, other, got)
+		}},
+	))
+}
@@ -0,0 +1,88 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCommentVote(t *testing.T, db interface{}) *models.CommentsVotes {
+	return &models.CommentsVotes{
+		Cv_idComment: newComment(t, db).GetId(),
+		Cv_idUser:    newUser(t, db).GetId(),
+		Cv_value:     1,
+	}
+}
+
+var commentsVotesCrud = crud[interfaces.IRepoCommentsVotes, models.CommentsVotes, *models.CommentsVotes]{
+	create: interfaces.IRepoCommentsVotes.CreateCommentVote,
+	get:    interfaces.IRepoCommentsVotes.GetCommentVoteById,
+	update: interfaces.IRepoCommentsVotes.UpdateCommentVote,
+	delete: interfaces.IRepoCommentsVotes.DeleteCommentVote,
+	list:   interfaces.IRepoCommentsVotes.GetCommentsVotes,
+	valid:  validCommentVote,
+	change: func(t *testing.T, db interface{}, vote *models.CommentsVotes) {
+		vote.Cv_value = -1
+	},
+	invalidate: func(vote *models.CommentsVotes) {
+		vote.Cv_value = 0
+	},
+}
+
+// CommentsVotes runs the IRepoCommentsVotes contract.
+func CommentsVotes(t *testing.T, open Open) {
+	run(t, open, repositories.NewCommentsVotesRepo, append(commentsVotesCrud.tests(),
+		testCase[interfaces.IRepoCommentsVotes]{"get by user and by comment and user", func(t *testing.T, db interface{}, repo interfaces.IRepoCommentsVotes) {
+			first := validCommentVote(t, db)
+			second := validCommentVote(t, db)
+			second.Cv_idUser = first.Cv_idUser
+			third := validCommentVote(t, db)
+			third.Cv_idComment = first.Cv_idComment
+			for _, vote := range []*models.CommentsVotes{first, second, third} {
+				require.NoError(t, repo.CreateCommentVote(ctx, vote))
+			}
+
+			byUser, err := repo.GetCommentsVotesByUserId(ctx, first.Cv_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.CommentsVotes{first, second}, byUser)
+
+			got, err := repo.GetCommentVoteByIds(ctx, third.Cv_idComment, third.Cv_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, third, got)
+
+			_, err = repo.GetCommentVoteByIds(ctx, second.Cv_idComment, third.Cv_idUser)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoCommentsVotes]{"count and delete by comment", func(t *testing.T, db interface{}, repo interfaces.IRepoCommentsVotes) {
+			first := validCommentVote(t, db)
+			require.NoError(t, repo.CreateCommentVote(ctx, first))
+			other := validCommentVote(t, db)
+			require.NoError(t, repo.CreateCommentVote(ctx, other))
+			for _, value := range []int{1, -1, -1} {
+				vote := validCommentVote(t, db)
+				vote.Cv_idComment = first.Cv_idComment
+				vote.Cv_value = value
+				require.NoError(t, repo.CreateCommentVote(ctx, vote))
+			}
+
+			up, down, err := repo.CountCommentVotes(ctx, first.Cv_idComment)
+			require.NoError(t, err)
+			assert.Equal(t, 2, up)
+			assert.Equal(t, 2, down)
+
+			require.NoError(t, repo.DeleteCommentVotesByCommentId(ctx, first.Cv_idComment))
+			up, down, err = repo.CountCommentVotes(ctx, first.Cv_idComment)
+			require.NoError(t, err)
+			assert.Zero(t, up)
+			assert.Zero(t, down)
+
+			got, err := repo.GetCommentVoteById(ctx, other.Cv_id)
+			require.NoError(t, err)
+			assert.Equal(t, other, got)
+		}},
+	))
+}
@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validComparison(t *testing.T, db interface{}, idUser int, slug string) *models.Comparisons {
+	comparison := &models.Comparisons{Cm_idUser: idUser, Cm_slug: slug, Cm_date: "2024-05-01"}
+	comparison.SetSerialIds([]int{newSerial(t, db).GetId(), newSerial(t, db).GetId()})
+	return comparison
+}
+
+// Comparisons runs the IRepoComparisons contract. Saved comparisons are never
+// updated, so the generic CRUD cases do not apply.
+func Comparisons(t *testing.T, open Open) {
+	run(t, open, repositories.NewComparisonsRepo, []testCase[interfaces.IRepoComparisons]{
+		{"create assigns id and get returns it by id, slug and user", func(t *testing.T, db interface{}, repo interfaces.IRepoComparisons) {
+			idUser := newUser(t, db).GetId()
+			first := validComparison(t, db, idUser, "0123456789abcdef")
+			second := validComparison(t, db, idUser, "fedcba9876543210")
+			require.NoError(t, repo.CreateComparison(ctx, first))
+			require.NoError(t, repo.CreateComparison(ctx, second))
+			assert.NotZero(t, first.GetId())
+			assert.NotEqual(t, first.GetId(), second.GetId())
+			require.NoError(t, repo.CreateComparison(ctx, validComparison(t, db, newUser(t, db).GetId(), "00000000000000ff")))
+
+			got, err := repo.GetComparisonById(ctx, first.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, first, got)
+			got, err = repo.GetComparisonBySlug(ctx, second.GetSlug())
+			require.NoError(t, err)
+			assert.Equal(t, second, got)
+			all, err := repo.GetComparisonsByUserId(ctx, idUser)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Comparisons{first, second}, all)
+
+			_, err = repo.GetComparisonById(ctx, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			_, err = repo.GetComparisonBySlug(ctx, "missing")
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		{"create rejects invalid model", func(t *testing.T, db interface{}, repo interfaces.IRepoComparisons) {
+			comparison := validComparison(t, db, newUser(t, db).GetId(), "0123456789abcdef")
+			comparison.Cm_serials = "1"
+			assert.ErrorIs(t, repo.CreateComparison(ctx, comparison), models.ErrInvalidModel)
+			comparison.Cm_serials = "1,1"
+			assert.ErrorIs(t, repo.CreateComparison(ctx, comparison), models.ErrInvalidModel)
+			comparison = validComparison(t, db, newUser(t, db).GetId(), "")
+			assert.ErrorIs(t, repo.CreateComparison(ctx, comparison), models.ErrInvalidModel)
+		}},
+		{"delete and delete by user keep the others", func(t *testing.T, db interface{}, repo interfaces.IRepoComparisons) {
+			idUser, idOther := newUser(t, db).GetId(), newUser(t, db).GetId()
+			first := validComparison(t, db, idUser, "0123456789abcdef")
+			require.NoError(t, repo.CreateComparison(ctx, first))
+			require.NoError(t, repo.CreateComparison(ctx, validComparison(t, db, idUser, "fedcba9876543210")))
+			other := validComparison(t, db, idOther, "00000000000000ff")
+			require.NoError(t, repo.CreateComparison(ctx, other))
+
+			require.NoError(t, repo.DeleteComparison(ctx, first.GetId()))
+			_, err := repo.GetComparisonById(ctx, first.GetId())
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			require.NoError(t, repo.DeleteComparisonsByUserId(ctx, idUser))
+			got, err := repo.GetComparisonsByUserId(ctx, idUser)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+			got, err = repo.GetComparisonsByUserId(ctx, idOther)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Comparisons{other}, got)
+		}},
+	})
+}
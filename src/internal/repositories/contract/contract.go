@@ -0,0 +1,300 @@
+// Package contract holds the behaviour every repository backend must share.
+// Each IRepo* interface has its own table-driven suite; a backend plugs into
+// the suites with an Open function returning a handle accepted by the
+// repositories.NewXRepo constructors.
+//
+// The contract in short:
+//   - Create validates the model, returns models.ErrInvalidModel for an
+//     invalid one and assigns a new id to a valid one;
+//   - getting a missing row by id (or by another unique key) returns
+//     models.ErrNotFound;
+//   - Update validates the model and returns models.ErrNotFound when the row
+//     does not exist;
+//   - Delete of a missing row is not an error;
+//   - lists are returned in no particular order, an empty list is not an error;
+//   - pages of lists are ordered by id unless stated otherwise, report the
+//     length of the whole list and return models.ErrInvalidModel for an
+//     invalid models.Page; a page past the end is empty;
+//   - dates are read back in the "02.01.2006" format they were written in.
+package contract
+
+import (
+	"app/internal/models"
+	"app/internal/repositories"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ctx is the context of the repository calls made by the suites.
+var ctx = context.Background()
+
+// Open returns the handle of an empty storage for one test.
+type Open func(t *testing.T) interface{}
+
+// RunAll runs the suites of all repository interfaces.
+func RunAll(t *testing.T, open Open) {
+	t.Run("Actors", func(t *testing.T) { Actors(t, open) })
+	t.Run("Comments", func(t *testing.T) { Comments(t, open) })
+	t.Run("CommentsReports", func(t *testing.T) { CommentsReports(t, open) })
+	t.Run("CommentsVotes", func(t *testing.T) { CommentsVotes(t, open) })
+	t.Run("Comparisons", func(t *testing.T) { Comparisons(t, open) })
+	t.Run("Episodes", func(t *testing.T) { Episodes(t, open) })
+	t.Run("EpisodesUsers", func(t *testing.T) { EpisodesUsers(t, open) })
+	t.Run("Favourites", func(t *testing.T) { Favourites(t, open) })
+	t.Run("Moderations", func(t *testing.T) { Moderations(t, open) })
+	t.Run("Producers", func(t *testing.T) { Producers(t, open) })
+	t.Run("Ratings", func(t *testing.T) { Ratings(t, open) })
+	t.Run("Recommendations", func(t *testing.T) { Recommendations(t, open) })
+	t.Run("Seasons", func(t *testing.T) { Seasons(t, open) })
+	t.Run("Serials", func(t *testing.T) { Serials(t, open) })
+	t.Run("SerialsActors", func(t *testing.T) { SerialsActors(t, open) })
+	t.Run("SerialsFavourites", func(t *testing.T) { SerialsFavourites(t, open) })
+	t.Run("SerialsUsers", func(t *testing.T) { SerialsUsers(t, open) })
+	t.Run("Statistic", func(t *testing.T) { Statistic(t, open) })
+	t.Run("Users", func(t *testing.T) { Users(t, open) })
+	t.Run("UnitOfWork", func(t *testing.T) { UnitOfWork(t, open) })
+}
+
+type testCase[R any] struct {
+	name string
+	run  func(t *testing.T, db interface{}, repo R)
+}
+
+// run executes every case on a fresh storage.
+func run[R comparable](t *testing.T, open Open, newRepo func(db interface{}, log *logrus.Logger) R, tests []testCase[R]) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := open(t)
+			var zero R
+			repo := newRepo(db, discardLog())
+			require.NotEqual(t, zero, repo, "no repository for %T", db)
+			tt.run(t, db, repo)
+		})
+	}
+}
+
+func discardLog() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+// Model is the constraint satisfied by pointers to the stored models.
+type Model[T any] interface {
+	*T
+	GetId() int
+	SetId(id int)
+}
+
+// crud describes the create/get/update/delete/list methods of one interface
+// so that the cases they share are written once.
+type crud[R any, T any, P Model[T]] struct {
+	create func(repo R, ctx context.Context, model P) error
+	get    func(repo R, ctx context.Context, id int) (P, error)
+	update func(repo R, ctx context.Context, model P) error
+	// delete is nil for the interfaces without a delete by id.
+	delete func(repo R, ctx context.Context, id int) error
+	list   func(repo R, ctx context.Context) ([]P, error)
+	// page is nil for the interfaces without a paged list.
+	page func(repo R, ctx context.Context, page models.Page) ([]P, int, error)
+	// valid returns a new valid model, creating the rows it refers to.
+	valid func(t *testing.T, db interface{}) P
+	// change modifies a valid model keeping it valid.
+	change func(t *testing.T, db interface{}, model P)
+	// invalidate makes a valid model invalid.
+	invalidate func(model P)
+}
+
+func (c crud[R, T, P]) tests() []testCase[R] {
+	tests := []testCase[R]{
+		{"create assigns id and get returns the model", func(t *testing.T, db interface{}, repo R) {
+			model := c.valid(t, db)
+			require.NoError(t, c.create(repo, ctx, model))
+			assert.Positive(t, model.GetId())
+
+			got, err := c.get(repo, ctx, model.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, model, got)
+		}},
+		{"create assigns distinct ids", func(t *testing.T, db interface{}, repo R) {
+			first, second := c.valid(t, db), c.valid(t, db)
+			require.NoError(t, c.create(repo, ctx, first))
+			require.NoError(t, c.create(repo, ctx, second))
+			assert.NotEqual(t, first.GetId(), second.GetId())
+		}},
+		{"create of invalid model", func(t *testing.T, db interface{}, repo R) {
+			model := c.valid(t, db)
+			before, err := c.list(repo, ctx)
+			require.NoError(t, err)
+
+			c.invalidate(model)
+			assert.ErrorIs(t, c.create(repo, ctx, model), models.ErrInvalidModel)
+
+			after, err := c.list(repo, ctx)
+			require.NoError(t, err)
+			assert.Len(t, after, len(before))
+		}},
+		{"get of missing model", func(t *testing.T, db interface{}, repo R) {
+			got, err := c.get(repo, ctx, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			assert.Nil(t, got)
+		}},
+		{"update changes the stored model", func(t *testing.T, db interface{}, repo R) {
+			model := c.valid(t, db)
+			require.NoError(t, c.create(repo, ctx, model))
+			c.change(t, db, model)
+			require.NoError(t, c.update(repo, ctx, model))
+
+			got, err := c.get(repo, ctx, model.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, model, got)
+		}},
+		{"update of invalid model", func(t *testing.T, db interface{}, repo R) {
+			model := c.valid(t, db)
+			require.NoError(t, c.create(repo, ctx, model))
+			want := *model
+			c.invalidate(model)
+			assert.ErrorIs(t, c.update(repo, ctx, model), models.ErrInvalidModel)
+
+			got, err := c.get(repo, ctx, model.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, P(&want), got)
+		}},
+		{"update of missing model", func(t *testing.T, db interface{}, repo R) {
+			model := c.valid(t, db)
+			model.SetId(missingId)
+			assert.ErrorIs(t, c.update(repo, ctx, model), models.ErrNotFound)
+		}},
+		{"list returns created models", func(t *testing.T, db interface{}, repo R) {
+			first, second := c.valid(t, db), c.valid(t, db)
+			before, err := c.list(repo, ctx)
+			require.NoError(t, err)
+
+			require.NoError(t, c.create(repo, ctx, first))
+			require.NoError(t, c.create(repo, ctx, second))
+
+			got, err := c.list(repo, ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, append(before, first, second), got)
+		}},
+	}
+	if c.page != nil {
+		tests = append(tests,
+			testCase[R]{"page splits the list", func(t *testing.T, db interface{}, repo R) {
+				created := []P{}
+				for i := 0; i < 5; i++ {
+					model := c.valid(t, db)
+					require.NoError(t, c.create(repo, ctx, model))
+					created = append(created, model)
+				}
+				tests := []struct {
+					page models.Page
+					want []P
+				}{
+					{models.NewPage(1, 2), created[0:2]},
+					{models.NewPage(2, 2), created[2:4]},
+					{models.NewPage(3, 2), created[4:5]},
+					{models.NewPage(1, 5), created},
+				}
+				for _, tt := range tests {
+					got, total, err := c.page(repo, ctx, tt.page)
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, got, "page %d", tt.page.Num)
+					assert.Equal(t, 5, total)
+				}
+
+				got, total, err := c.page(repo, ctx, models.NewPage(4, 2))
+				require.NoError(t, err)
+				assert.Empty(t, got)
+				assert.Equal(t, 5, total)
+			}},
+			testCase[R]{"page of invalid page", func(t *testing.T, db interface{}, repo R) {
+				for _, page := range []models.Page{
+					models.NewPage(0, 2),
+					models.NewPage(1, 0),
+					models.NewPage(1, models.MaxPageSize+1),
+				} {
+					got, _, err := c.page(repo, ctx, page)
+					assert.ErrorIs(t, err, models.ErrInvalidModel)
+					assert.Nil(t, got)
+				}
+			}},
+		)
+	}
+	if c.delete != nil {
+		tests = append(tests,
+			testCase[R]{"delete removes the model", func(t *testing.T, db interface{}, repo R) {
+				model := c.valid(t, db)
+				require.NoError(t, c.create(repo, ctx, model))
+				require.NoError(t, c.delete(repo, ctx, model.GetId()))
+
+				_, err := c.get(repo, ctx, model.GetId())
+				assert.ErrorIs(t, err, models.ErrNotFound)
+			}},
+			testCase[R]{"delete of missing model", func(t *testing.T, db interface{}, repo R) {
+				assert.NoError(t, c.delete(repo, ctx, missingId))
+			}},
+		)
+	}
+	return tests
+}
+
+// missingId is an id no test creates a row with.
+const missingId = 1 << 30
+
+// Fixtures creating the rows referred to by the models under test.
+
+func newProducer(t *testing.T, db interface{}) *models.Producers {
+	producer := &models.Producers{P_name: "Джеймс", P_surname: "Кэмерон"}
+	require.NoError(t, repositories.NewProducersRepo(db, discardLog()).CreateProducer(ctx, producer))
+	return producer
+}
+
+func newSerial(t *testing.T, db interface{}) *models.Serial {
+	serial := validSerial(t, db)
+	require.NoError(t, repositories.NewSerialsRepo(db, discardLog()).CreateSerial(ctx, serial))
+	return serial
+}
+
+func newSeason(t *testing.T, db interface{}) *models.Seasons {
+	season := validSeason(t, db)
+	require.NoError(t, repositories.NewSeasonsRepo(db, discardLog()).CreateSeason(ctx, season))
+	return season
+}
+
+func newEpisode(t *testing.T, db interface{}) *models.Episodes {
+	episode := validEpisode(t, db)
+	require.NoError(t, repositories.NewEpisodesRepo(db, discardLog()).CreateEpisode(ctx, episode))
+	return episode
+}
+
+func newComment(t *testing.T, db interface{}) *models.Comments {
+	comment := validComment(t, db)
+	require.NoError(t, repositories.NewCommentsRepo(db, discardLog()).CreateComment(ctx, comment))
+	return comment
+}
+
+func newActor(t *testing.T, db interface{}) *models.Actors {
+	actor := validActor()
+	require.NoError(t, repositories.NewActorsRepo(db, discardLog()).CreateActor(ctx, actor))
+	return actor
+}
+
+func newFavourite(t *testing.T, db interface{}) *models.Favourites {
+	favourite := &models.Favourites{F_name: "Избранное", F_position: 1, F_visibility: models.ListPrivate}
+	id, err := repositories.NewFavouritesRepo(db, discardLog()).CreateFavourite(ctx, favourite)
+	require.NoError(t, err)
+	favourite.SetId(id)
+	return favourite
+}
+
+func newUser(t *testing.T, db interface{}) *models.Users {
+	user := validUser(t, db)
+	require.NoError(t, repositories.NewUsersRepo(db, discardLog()).CreateUser(ctx, user))
+	return user
+}
@@ -0,0 +1,72 @@
+package contract_test
+
+import (
+	"app/internal/migrations"
+	"app/internal/repositories"
+	"app/internal/repositories/contract"
+	"app/internal/repositories/memdb"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The Postgres and Mongo backends are tested only when the environment points
+// to a disposable database: every test erases all the data stored there.
+//
+//	TEST_POSTGRES_URL="user=postgres dbname=serials_test sslmode=disable" go test ./internal/repositories/contract
+//	TEST_MONGO_URL="mongodb://localhost:27017" go test ./internal/repositories/contract
+
+var tables = []string{"comparisons", "recommendations", "episodes_users", "ratings", "comments_votes", "comments_reports", "comments", "moderations", "serials_users", "serials_favourites", "serials_actors", "episodes", "seasons",
+	"serials", "users", "favourites", "actors", "producers", "statistic"}
+
+func TestMemory(t *testing.T) {
+	contract.RunAll(t, func(t *testing.T) interface{} {
+		return memdb.New()
+	})
+}
+
+func TestPostgres(t *testing.T) {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+	db, err := sqlx.Connect("postgres", url)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = migrations.NewMigrator(db, logrus.New()).Up()
+	require.NoError(t, err)
+
+	contract.RunAll(t, func(t *testing.T) interface{} {
+		for _, table := range tables {
+			_, err := db.Exec("TRUNCATE " + table + " RESTART IDENTITY CASCADE")
+			require.NoError(t, err)
+		}
+		return db
+	})
+}
+
+func TestMongo(t *testing.T) {
+	url := os.Getenv("TEST_MONGO_URL")
+	if url == "" {
+		t.Skip("TEST_MONGO_URL is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(url))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	contract.RunAll(t, func(t *testing.T) interface{} {
+		require.NoError(t, client.Database("mydb").Drop(context.Background()))
+		require.NoError(t, repositories.SetupMongo(context.Background(), client, logrus.New()))
+		return client
+	})
+}
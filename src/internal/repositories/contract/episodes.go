@@ -0,0 +1,63 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEpisode(t *testing.T, db interface{}) *models.Episodes {
+	return &models.Episodes{
+		E_name:     "Пилот",
+		E_date:     "20.01.2008",
+		E_idSeason: newSeason(t, db).GetId(),
+		E_num:      1,
+		E_duration: "00:58:00",
+	}
+}
+
+var episodesCrud = crud[interfaces.IRepoEpisodes, models.Episodes, *models.Episodes]{
+	create: interfaces.IRepoEpisodes.CreateEpisode,
+	get:    interfaces.IRepoEpisodes.GetEpisodeById,
+	update: interfaces.IRepoEpisodes.UpdateEpisode,
+	delete: interfaces.IRepoEpisodes.DeleteEpisode,
+	list:   interfaces.IRepoEpisodes.GetEpisodes,
+	valid:  validEpisode,
+	change: func(t *testing.T, db interface{}, episode *models.Episodes) {
+		episode.E_name = "Кот в мешке"
+		episode.E_date = "27.01.2008"
+		episode.E_idSeason = newSeason(t, db).GetId()
+		episode.E_num = 2
+		episode.E_duration = "00:48:00"
+	},
+	invalidate: func(episode *models.Episodes) {
+		episode.E_duration = "48 минут"
+	},
+}
+
+// Episodes runs the IRepoEpisodes contract.
+func Episodes(t *testing.T, open Open) {
+	run(t, open, repositories.NewEpisodesRepo, append(episodesCrud.tests(),
+		testCase[interfaces.IRepoEpisodes]{"get by season", func(t *testing.T, db interface{}, repo interfaces.IRepoEpisodes) {
+			first, other := validEpisode(t, db), validEpisode(t, db)
+			second := validEpisode(t, db)
+			second.E_idSeason = first.E_idSeason
+			second.E_num = 2
+			for _, episode := range []*models.Episodes{first, second, other} {
+				require.NoError(t, repo.CreateEpisode(ctx, episode))
+			}
+
+			got, err := repo.GetEpisodesBySeasonId(ctx, first.E_idSeason)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.Episodes{first, second}, got)
+
+			none, err := repo.GetEpisodesBySeasonId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+	))
+}
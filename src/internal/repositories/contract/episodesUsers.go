@@ -0,0 +1,88 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEpisodeUser(t *testing.T, db interface{}) *models.EpisodesUsers {
+	episode := newEpisode(t, db)
+	season, err := repositories.NewSeasonsRepo(db, discardLog()).GetSeasonById(ctx, episode.GetIdSeason())
+	require.NoError(t, err)
+	return &models.EpisodesUsers{
+		Eu_idEpisode: episode.GetId(),
+		Eu_idSerial:  season.GetIdSerial(),
+		Eu_idUser:    newUser(t, db).GetId(),
+	}
+}
+
+var episodesUsersCrud = crud[interfaces.IRepoEpisodesUsers, models.EpisodesUsers, *models.EpisodesUsers]{
+	create: interfaces.IRepoEpisodesUsers.CreateEpisodesUsers,
+	get:    interfaces.IRepoEpisodesUsers.GetEpisodesUsersById,
+	update: interfaces.IRepoEpisodesUsers.UpdateEpisodesUsers,
+	delete: interfaces.IRepoEpisodesUsers.DeleteEpisodesUsers,
+	list:   interfaces.IRepoEpisodesUsers.GetEpisodesUsers,
+	valid:  validEpisodeUser,
+	change: func(t *testing.T, db interface{}, episodeUser *models.EpisodesUsers) {
+		episodeUser.Eu_idUser = newUser(t, db).GetId()
+	},
+	invalidate: func(episodeUser *models.EpisodesUsers) {
+		episodeUser.Eu_idEpisode = 0
+	},
+}
+
+// EpisodesUsers runs the IRepoEpisodesUsers contract.
+func EpisodesUsers(t *testing.T, open Open) {
+	run(t, open, repositories.NewEpisodesUsersRepo, append(episodesUsersCrud.tests(),
+		testCase[interfaces.IRepoEpisodesUsers]{"get by serial and user", func(t *testing.T, db interface{}, repo interfaces.IRepoEpisodesUsers) {
+			first := validEpisodeUser(t, db)
+			second := validEpisodeUser(t, db)
+			second.Eu_idSerial = first.Eu_idSerial
+			second.Eu_idUser = first.Eu_idUser
+			otherUser := validEpisodeUser(t, db)
+			otherUser.Eu_idSerial = first.Eu_idSerial
+			otherSerial := validEpisodeUser(t, db)
+			otherSerial.Eu_idUser = first.Eu_idUser
+			for _, episodeUser := range []*models.EpisodesUsers{first, second, otherUser, otherSerial} {
+				require.NoError(t, repo.CreateEpisodesUsers(ctx, episodeUser))
+			}
+
+			got, err := repo.GetEpisodesBySerialIdUserId(ctx, first.Eu_idSerial, first.Eu_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.EpisodesUsers{first, second}, got)
+
+			none, err := repo.GetEpisodesBySerialIdUserId(ctx, missingId, first.Eu_idUser)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoEpisodesUsers]{"get by episode and user ids", func(t *testing.T, db interface{}, repo interfaces.IRepoEpisodesUsers) {
+			episodeUser := validEpisodeUser(t, db)
+			require.NoError(t, repo.CreateEpisodesUsers(ctx, episodeUser))
+
+			got, err := repo.GetEpisodeUserByIds(ctx, episodeUser.Eu_idEpisode, episodeUser.Eu_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, episodeUser, got)
+
+			_, err = repo.GetEpisodeUserByIds(ctx, episodeUser.Eu_idEpisode, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoEpisodesUsers]{"delete by user", func(t *testing.T, db interface{}, repo interfaces.IRepoEpisodesUsers) {
+			removed, kept := validEpisodeUser(t, db), validEpisodeUser(t, db)
+			require.NoError(t, repo.CreateEpisodesUsers(ctx, removed))
+			require.NoError(t, repo.CreateEpisodesUsers(ctx, kept))
+
+			require.NoError(t, repo.DeleteEpisodesByUserId(ctx, removed.Eu_idUser))
+			_, err := repo.GetEpisodesUsersById(ctx, removed.GetId())
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			_, err = repo.GetEpisodesUsersById(ctx, kept.GetId())
+			assert.NoError(t, err)
+
+			assert.NoError(t, repo.DeleteEpisodesByUserId(ctx, removed.Eu_idUser))
+		}},
+	))
+}
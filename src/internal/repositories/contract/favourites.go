@@ -0,0 +1,84 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var favouritesCrud = crud[interfaces.IRepoFavourites, models.Favourites, *models.Favourites]{
+	create: func(repo interfaces.IRepoFavourites, ctx context.Context, favourite *models.Favourites) error {
+		_, err := repo.CreateFavourite(ctx, favourite)
+		return err
+	},
+	get:    interfaces.IRepoFavourites.GetFavouriteById,
+	update: interfaces.IRepoFavourites.UpdateFavourite,
+	delete: interfaces.IRepoFavourites.DeleteFavourite,
+	list:   interfaces.IRepoFavourites.GetFavourites,
+	valid: func(t *testing.T, db interface{}) *models.Favourites {
+		return &models.Favourites{F_idUser: 1, F_name: "Смотрю", F_position: 1, F_visibility: models.ListPrivate}
+	},
+	change: func(t *testing.T, db interface{}, favourite *models.Favourites) {
+		favourite.F_name = "Брошено"
+		favourite.F_note = "не понравилось"
+		favourite.F_position = 2
+		favourite.F_cntSerials = 3
+		favourite.F_visibility = models.ListPublic
+		favourite.F_slug = "0123456789abcdef"
+	},
+	invalidate: func(favourite *models.Favourites) {
+		favourite.F_cntSerials = -1
+	},
+}
+
+// Favourites runs the IRepoFavourites contract.
+func Favourites(t *testing.T, open Open) {
+	run(t, open, repositories.NewFavouritesRepo, append(favouritesCrud.tests(),
+		testCase[interfaces.IRepoFavourites]{"create returns the assigned id", func(t *testing.T, db interface{}, repo interfaces.IRepoFavourites) {
+			favourite := &models.Favourites{F_name: "Избранное", F_cntSerials: 2, F_visibility: models.ListPrivate}
+			id, err := repo.CreateFavourite(ctx, favourite)
+			require.NoError(t, err)
+			assert.Equal(t, favourite.GetId(), id)
+		}},
+		testCase[interfaces.IRepoFavourites]{"get by user in order", func(t *testing.T, db interface{}, repo interfaces.IRepoFavourites) {
+			third := &models.Favourites{F_idUser: 1, F_name: "Брошено", F_position: 3, F_visibility: models.ListPrivate}
+			first := &models.Favourites{F_idUser: 1, F_name: "Избранное", F_position: 1, F_visibility: models.ListPrivate}
+			other := &models.Favourites{F_idUser: 2, F_name: "Избранное", F_position: 1, F_visibility: models.ListPrivate}
+			second := &models.Favourites{F_idUser: 1, F_name: "Смотрю", F_position: 2, F_visibility: models.ListPrivate}
+			for _, favourite := range []*models.Favourites{third, first, other, second} {
+				_, err := repo.CreateFavourite(ctx, favourite)
+				require.NoError(t, err)
+			}
+
+			lists, err := repo.GetFavouritesByUserId(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Favourites{first, second, third}, lists)
+
+			none, err := repo.GetFavouritesByUserId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoFavourites]{"get by slug", func(t *testing.T, db interface{}, repo interfaces.IRepoFavourites) {
+			private := &models.Favourites{F_idUser: 1, F_name: "Смотрю", F_visibility: models.ListPrivate}
+			shared := &models.Favourites{F_idUser: 1, F_name: "Брошено", F_visibility: models.ListUnlisted, F_slug: "0123456789abcdef"}
+			for _, favourite := range []*models.Favourites{private, shared} {
+				_, err := repo.CreateFavourite(ctx, favourite)
+				require.NoError(t, err)
+			}
+
+			found, err := repo.GetFavouriteBySlug(ctx, shared.F_slug)
+			require.NoError(t, err)
+			assert.Equal(t, shared, found)
+
+			_, err = repo.GetFavouriteBySlug(ctx, "")
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			_, err = repo.GetFavouriteBySlug(ctx, "missing")
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+	))
+}
@@ -0,0 +1,72 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validModeration(t *testing.T, db interface{}) *models.Moderations {
+	return &models.Moderations{
+		Md_idComment:   newComment(t, db).GetId(),
+		Md_idModerator: newUser(t, db).GetId(),
+		Md_action:      models.ModerationHide,
+		Md_text:        "Лучший сериал",
+		Md_date:        "2024-02-01 10:00:00",
+	}
+}
+
+// Moderations runs the IRepoModerations contract. The decisions are never
+// changed, so the generic CRUD cases do not apply.
+func Moderations(t *testing.T, open Open) {
+	run(t, open, repositories.NewModerationsRepo, []testCase[interfaces.IRepoModerations]{
+		{"create assigns id and get returns the model", func(t *testing.T, db interface{}, repo interfaces.IRepoModerations) {
+			moderation := validModeration(t, db)
+			require.NoError(t, repo.CreateModeration(ctx, moderation))
+			assert.NotZero(t, moderation.GetId())
+
+			got, err := repo.GetModerationById(ctx, moderation.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, moderation, got)
+		}},
+		{"create rejects invalid model", func(t *testing.T, db interface{}, repo interfaces.IRepoModerations) {
+			moderation := validModeration(t, db)
+			moderation.Md_action = "ban"
+			assert.ErrorIs(t, repo.CreateModeration(ctx, moderation), models.ErrInvalidModel)
+		}},
+		{"get missing returns not found", func(t *testing.T, db interface{}, repo interfaces.IRepoModerations) {
+			got, err := repo.GetModerationById(ctx, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			assert.Nil(t, got)
+		}},
+		{"list and page newest first", func(t *testing.T, db interface{}, repo interfaces.IRepoModerations) {
+			moderations := []*models.Moderations{}
+			for _, action := range []string{models.ModerationHide, models.ModerationDelete, models.ModerationDismiss} {
+				moderation := validModeration(t, db)
+				moderation.Md_action = action
+				require.NoError(t, repo.CreateModeration(ctx, moderation))
+				moderations = append(moderations, moderation)
+			}
+
+			all, err := repo.GetModerations(ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, moderations, all)
+
+			page, total, err := repo.GetModerationsPage(ctx, models.NewPage(1, 2))
+			require.NoError(t, err)
+			assert.Equal(t, 3, total)
+			assert.Equal(t, []*models.Moderations{moderations[2], moderations[1]}, page)
+
+			page, _, err = repo.GetModerationsPage(ctx, models.NewPage(2, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Moderations{moderations[0]}, page)
+
+			_, _, err = repo.GetModerationsPage(ctx, models.Page{})
+			assert.ErrorIs(t, err, models.ErrInvalidModel)
+		}},
+	})
+}
@@ -0,0 +1,31 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+)
+
+var producersCrud = crud[interfaces.IRepoProducers, models.Producers, *models.Producers]{
+	create: interfaces.IRepoProducers.CreateProducer,
+	get:    interfaces.IRepoProducers.GetProducerById,
+	update: interfaces.IRepoProducers.UpdateProducer,
+	delete: interfaces.IRepoProducers.DeleteProducer,
+	list:   interfaces.IRepoProducers.GetProducers,
+	valid: func(t *testing.T, db interface{}) *models.Producers {
+		return &models.Producers{P_name: "Винс", P_surname: "Гиллиган"}
+	},
+	change: func(t *testing.T, db interface{}, producer *models.Producers) {
+		producer.P_name = "Питер"
+		producer.P_surname = "Гулд"
+	},
+	invalidate: func(producer *models.Producers) {
+		producer.P_surname = ""
+	},
+}
+
+// Producers runs the IRepoProducers contract.
+func Producers(t *testing.T, open Open) {
+	run(t, open, repositories.NewProducersRepo, producersCrud.tests())
+}
@@ -0,0 +1,80 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRating(t *testing.T, db interface{}) *models.Ratings {
+	return &models.Ratings{
+		R_idUser:   newUser(t, db).GetId(),
+		R_idSerial: newSerial(t, db).GetId(),
+		R_score:    8,
+	}
+}
+
+var ratingsCrud = crud[interfaces.IRepoRatings, models.Ratings, *models.Ratings]{
+	create: interfaces.IRepoRatings.CreateRating,
+	get:    interfaces.IRepoRatings.GetRatingById,
+	update: interfaces.IRepoRatings.UpdateRating,
+	delete: interfaces.IRepoRatings.DeleteRating,
+	list:   interfaces.IRepoRatings.GetRatings,
+	valid:  validRating,
+	change: func(t *testing.T, db interface{}, rating *models.Ratings) {
+		rating.R_score = models.MaxScore
+	},
+	invalidate: func(rating *models.Ratings) {
+		rating.R_score = models.MinScore - 1
+	},
+}
+
+// Ratings runs the IRepoRatings contract.
+func Ratings(t *testing.T, open Open) {
+	run(t, open, repositories.NewRatingsRepo, append(ratingsCrud.tests(),
+		testCase[interfaces.IRepoRatings]{"get by user and by serial and user", func(t *testing.T, db interface{}, repo interfaces.IRepoRatings) {
+			first := validRating(t, db)
+			second := validRating(t, db)
+			second.R_idUser = first.R_idUser
+			third := validRating(t, db)
+			third.R_idSerial = first.R_idSerial
+			for _, rating := range []*models.Ratings{first, second, third} {
+				require.NoError(t, repo.CreateRating(ctx, rating))
+			}
+
+			byUser, err := repo.GetRatingsByUserId(ctx, first.R_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.Ratings{first, second}, byUser)
+
+			got, err := repo.GetRatingBySerialIdUserId(ctx, third.R_idSerial, third.R_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, third, got)
+
+			_, err = repo.GetRatingBySerialIdUserId(ctx, second.R_idSerial, third.R_idUser)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoRatings]{"sum by serial", func(t *testing.T, db interface{}, repo interfaces.IRepoRatings) {
+			first := validRating(t, db)
+			require.NoError(t, repo.CreateRating(ctx, first))
+			require.NoError(t, repo.CreateRating(ctx, validRating(t, db)))
+			second := validRating(t, db)
+			second.R_idSerial = first.R_idSerial
+			second.R_score = 3
+			require.NoError(t, repo.CreateRating(ctx, second))
+
+			sum, votes, err := repo.SumRatings(ctx, first.R_idSerial)
+			require.NoError(t, err)
+			assert.Equal(t, 11, sum)
+			assert.Equal(t, 2, votes)
+
+			sum, votes, err = repo.SumRatings(ctx, missingId)
+			require.NoError(t, err)
+			assert.Zero(t, sum)
+			assert.Zero(t, votes)
+		}},
+	))
+}
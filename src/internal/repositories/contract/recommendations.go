@@ -0,0 +1,65 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRecommendation(t *testing.T, db interface{}, idUser, position int) *models.Recommendations {
+	return &models.Recommendations{
+		Rc_idUser:   idUser,
+		Rc_idSerial: newSerial(t, db).GetId(),
+		Rc_score:    1.5,
+		Rc_source:   models.RecommendByUsers,
+		Rc_position: position,
+	}
+}
+
+// Recommendations runs the IRepoRecommendations contract. The rankings are
+// replaced as a whole, so the generic CRUD cases do not apply.
+func Recommendations(t *testing.T, open Open) {
+	run(t, open, repositories.NewRecommendationsRepo, []testCase[interfaces.IRepoRecommendations]{
+		{"create assigns id and get by user returns them in order", func(t *testing.T, db interface{}, repo interfaces.IRepoRecommendations) {
+			idUser := newUser(t, db).GetId()
+			second := validRecommendation(t, db, idUser, 2)
+			first := validRecommendation(t, db, idUser, 1)
+			first.Rc_source = models.RecommendPopular
+			require.NoError(t, repo.CreateRecommendation(ctx, second))
+			require.NoError(t, repo.CreateRecommendation(ctx, first))
+			assert.NotZero(t, second.GetId())
+			assert.NotEqual(t, second.GetId(), first.GetId())
+			require.NoError(t, repo.CreateRecommendation(ctx, validRecommendation(t, db, newUser(t, db).GetId(), 1)))
+
+			got, err := repo.GetRecommendationsByUserId(ctx, idUser)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Recommendations{first, second}, got)
+		}},
+		{"create rejects invalid model", func(t *testing.T, db interface{}, repo interfaces.IRepoRecommendations) {
+			recommendation := validRecommendation(t, db, newUser(t, db).GetId(), 1)
+			recommendation.Rc_source = "random"
+			assert.ErrorIs(t, repo.CreateRecommendation(ctx, recommendation), models.ErrInvalidModel)
+			recommendation = validRecommendation(t, db, newUser(t, db).GetId(), 0)
+			assert.ErrorIs(t, repo.CreateRecommendation(ctx, recommendation), models.ErrInvalidModel)
+		}},
+		{"delete by user keeps the others", func(t *testing.T, db interface{}, repo interfaces.IRepoRecommendations) {
+			idUser, idOther := newUser(t, db).GetId(), newUser(t, db).GetId()
+			require.NoError(t, repo.CreateRecommendation(ctx, validRecommendation(t, db, idUser, 1)))
+			other := validRecommendation(t, db, idOther, 1)
+			require.NoError(t, repo.CreateRecommendation(ctx, other))
+
+			require.NoError(t, repo.DeleteRecommendationsByUserId(ctx, idUser))
+			got, err := repo.GetRecommendationsByUserId(ctx, idUser)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+			got, err = repo.GetRecommendationsByUserId(ctx, idOther)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Recommendations{other}, got)
+			require.NoError(t, repo.DeleteRecommendationsByUserId(ctx, missingId))
+		}},
+	})
+}
@@ -0,0 +1,62 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSeason(t *testing.T, db interface{}) *models.Seasons {
+	return &models.Seasons{
+		Ss_name:     "Сезон 1",
+		Ss_date:     "20.01.2008",
+		Ss_idSerial: newSerial(t, db).GetId(),
+		Ss_num:      1,
+	}
+}
+
+var seasonsCrud = crud[interfaces.IRepoSeasons, models.Seasons, *models.Seasons]{
+	create: interfaces.IRepoSeasons.CreateSeason,
+	get:    interfaces.IRepoSeasons.GetSeasonById,
+	update: interfaces.IRepoSeasons.UpdateSeason,
+	delete: interfaces.IRepoSeasons.DeleteSeason,
+	list:   interfaces.IRepoSeasons.GetSeasons,
+	valid:  validSeason,
+	change: func(t *testing.T, db interface{}, season *models.Seasons) {
+		season.Ss_name = "Сезон 2"
+		season.Ss_date = "08.03.2009"
+		season.Ss_idSerial = newSerial(t, db).GetId()
+		season.Ss_num = 2
+		season.Ss_cntEpisodes = 13
+	},
+	invalidate: func(season *models.Seasons) {
+		season.Ss_date = ""
+	},
+}
+
+// Seasons runs the IRepoSeasons contract.
+func Seasons(t *testing.T, open Open) {
+	run(t, open, repositories.NewSeasonsRepo, append(seasonsCrud.tests(),
+		testCase[interfaces.IRepoSeasons]{"get by serial", func(t *testing.T, db interface{}, repo interfaces.IRepoSeasons) {
+			first, other := validSeason(t, db), validSeason(t, db)
+			second := validSeason(t, db)
+			second.Ss_idSerial = first.Ss_idSerial
+			second.Ss_num = 2
+			for _, season := range []*models.Seasons{first, second, other} {
+				require.NoError(t, repo.CreateSeason(ctx, season))
+			}
+
+			got, err := repo.GetSeasonsBySerialId(ctx, first.Ss_idSerial)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.Seasons{first, second}, got)
+
+			none, err := repo.GetSeasonsBySerialId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+	))
+}
@@ -0,0 +1,376 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSerial(t *testing.T, db interface{}) *models.Serial {
+	return &models.Serial{
+		S_name:        "Во все тяжкие",
+		S_description: "Учитель химии становится наркобароном",
+		S_genre:       "драма",
+		S_state:       "завершен",
+		S_idProducer:  newProducer(t, db).GetId(),
+		S_year:        2008,
+		S_rating:      9.5,
+		S_img:         "breaking_bad.jpg",
+		S_duration:    "00:00:00",
+	}
+}
+
+var serialsCrud = crud[interfaces.IRepoSerials, models.Serial, *models.Serial]{
+	create: interfaces.IRepoSerials.CreateSerial,
+	get:    interfaces.IRepoSerials.GetSerialById,
+	update: interfaces.IRepoSerials.UpdateSerial,
+	delete: interfaces.IRepoSerials.DeleteSerial,
+	list:   interfaces.IRepoSerials.GetSerials,
+	page: func(repo interfaces.IRepoSerials, ctx context.Context, page models.Page) ([]*models.Serial, int, error) {
+		return repo.SearchSerials(ctx, &models.SerialsQuery{}, page)
+	},
+	valid: validSerial,
+	change: func(t *testing.T, db interface{}, serial *models.Serial) {
+		serial.S_name = "Лучше звоните Солу"
+		serial.S_description = "Адвокат Джимми Макгилл"
+		serial.S_genre = "криминал"
+		serial.S_state = "выходит"
+		serial.S_idProducer = newProducer(t, db).GetId()
+		serial.S_year = 2015
+		serial.S_seasons = 6
+		serial.S_rating = 8.75
+		serial.S_votes = 4
+		serial.S_img = "better_call_saul.jpg"
+		serial.S_duration = "48:30:00"
+	},
+	invalidate: func(serial *models.Serial) {
+		serial.S_year = 0
+	},
+}
+
+// Serials runs the IRepoSerials contract.
+func Serials(t *testing.T, open Open) {
+	run(t, open, repositories.NewSerialsRepo, append(serialsCrud.tests(),
+		testCase[interfaces.IRepoSerials]{"get by title", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			first, second, other := validSerial(t, db), validSerial(t, db), validSerial(t, db)
+			first.S_name = "Тьма"
+			second.S_name = "Тёмные начала (1+1)"
+			other.S_name = "Шерлок (Sherlock)"
+			for _, serial := range []*models.Serial{first, second, other} {
+				require.NoError(t, repo.CreateSerial(ctx, serial))
+			}
+
+			tests := []struct {
+				title string
+				want  []*models.Serial
+			}{
+				{"Тьма", []*models.Serial{first}},
+				{"ерло", []*models.Serial{other}},
+				{"SHERLOCK", []*models.Serial{other}},
+				{"(1+1)", []*models.Serial{second}},
+				{"", []*models.Serial{first, second, other}},
+				{"Доктор Кто", nil},
+			}
+			for _, tt := range tests {
+				got, err := repo.GetSerialsByTitle(ctx, tt.title)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got, tt.title)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"lock", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			serial := validSerial(t, db)
+			require.NoError(t, repo.CreateSerial(ctx, serial))
+
+			require.NoError(t, repo.LockSerial(ctx, serial.GetId()))
+			got, err := repo.GetSerialById(ctx, serial.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, serial, got)
+
+			assert.ErrorIs(t, repo.LockSerial(ctx, missingId), models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoSerials]{"search filters", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newSearchFixture(t, db, repo)
+			a, b, c, d := f.serials[0], f.serials[1], f.serials[2], f.serials[3]
+
+			tests := []struct {
+				name  string
+				query models.SerialsQuery
+				want  []*models.Serial
+			}{
+				{"empty", models.SerialsQuery{}, []*models.Serial{a, b, c, d}},
+				{"title", models.SerialsQuery{Title: "ТЁМ"}, []*models.Serial{c}},
+				{"title with wildcards", models.SerialsQuery{Title: "%"}, []*models.Serial{}},
+				{"genre", models.SerialsQuery{Genre: "фэнтези"}, []*models.Serial{c, d}},
+				{"state", models.SerialsQuery{State: "завершен"}, []*models.Serial{a, b}},
+				{"year from", models.SerialsQuery{YearFrom: 2016}, []*models.Serial{a, c, d}},
+				{"year to", models.SerialsQuery{YearTo: 2016}, []*models.Serial{b, d}},
+				{"year range", models.SerialsQuery{YearFrom: 2010, YearTo: 2016}, []*models.Serial{b, d}},
+				{"min rating", models.SerialsQuery{MinRating: 8.5}, []*models.Serial{a, b, d}},
+				{"producer", models.SerialsQuery{IdProducer: f.producer}, []*models.Serial{a, c}},
+				{"actor", models.SerialsQuery{IdActor: f.actors[0]}, []*models.Serial{a, c}},
+				{"other actor", models.SerialsQuery{IdActor: f.actors[1]}, []*models.Serial{b, d}},
+				{"missing actor", models.SerialsQuery{IdActor: missingId}, []*models.Serial{}},
+				{"all filters", models.SerialsQuery{Genre: "фэнтези", MinRating: 8, YearTo: 2019, IdActor: f.actors[1]}, []*models.Serial{d}},
+			}
+			for _, tt := range tests {
+				got, total, err := repo.SearchSerials(ctx, &tt.query, models.NewPage(1, models.MaxPageSize))
+				require.NoError(t, err, tt.name)
+				assert.Equal(t, tt.want, got, tt.name)
+				assert.Equal(t, len(tt.want), total, tt.name)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"search order", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newSearchFixture(t, db, repo)
+			a, b, c, d := f.serials[0], f.serials[1], f.serials[2], f.serials[3]
+
+			tests := []struct {
+				name  string
+				query models.SerialsQuery
+				want  []*models.Serial
+			}{
+				{"id desc", models.SerialsQuery{Desc: true}, []*models.Serial{d, c, b, a}},
+				{"rating", models.SerialsQuery{Sort: models.SortByRating}, []*models.Serial{c, a, d, b}},
+				{"rating desc", models.SerialsQuery{Sort: models.SortByRating, Desc: true}, []*models.Serial{b, d, a, c}},
+				{"year", models.SerialsQuery{Sort: models.SortByYear}, []*models.Serial{b, d, a, c}},
+				{"name", models.SerialsQuery{Sort: models.SortByName}, []*models.Serial{d, a, c, b}},
+				{"name desc", models.SerialsQuery{Sort: models.SortByName, Desc: true}, []*models.Serial{b, c, a, d}},
+				{"filtered", models.SerialsQuery{Genre: "фэнтези", Sort: models.SortByRating, Desc: true}, []*models.Serial{d, c}},
+			}
+			for _, tt := range tests {
+				got, total, err := repo.SearchSerials(ctx, &tt.query, models.NewPage(1, models.MaxPageSize))
+				require.NoError(t, err, tt.name)
+				assert.Equal(t, tt.want, got, tt.name)
+				assert.Equal(t, len(tt.want), total, tt.name)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"search page", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newSearchFixture(t, db, repo)
+			a, c, d := f.serials[0], f.serials[2], f.serials[3]
+			query := &models.SerialsQuery{MinRating: 7.5, Sort: models.SortByRating}
+
+			got, total, err := repo.SearchSerials(ctx, query, models.NewPage(1, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Serial{c, a}, got)
+			assert.Equal(t, 4, total)
+
+			got, total, err = repo.SearchSerials(ctx, query, models.NewPage(2, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Serial{d, f.serials[1]}, got)
+			assert.Equal(t, 4, total)
+		}},
+		testCase[interfaces.IRepoSerials]{"full-text search", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newTextFixture(t, db, repo)
+
+			tests := []struct {
+				text string
+				want []*models.Serial
+			}{
+				{"Лондон", []*models.Serial{f.london, f.sherlock}},
+				{"детей", []*models.Serial{f.dark}},
+				{"ДЕТЕЙ", []*models.Serial{f.dark}},
+				{"Камбербэтч", []*models.Serial{f.sherlock}},
+				{"Моффат", []*models.Serial{f.sherlock}},
+				{"детектив Камбербэтч", []*models.Serial{f.sherlock}},
+				{"детектив детей", []*models.Serial{}},
+				{"Доктор", []*models.Serial{}},
+			}
+			for _, tt := range tests {
+				hits, total, err := repo.FullTextSearch(ctx, tt.text, models.NewPage(1, models.DefaultPageSize))
+				require.NoError(t, err, tt.text)
+				got := []*models.Serial{}
+				for _, hit := range hits {
+					got = append(got, hit.Serial)
+				}
+				assert.Equal(t, tt.want, got, tt.text)
+				assert.Equal(t, len(tt.want), total, tt.text)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"full-text search in english", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newTextFixture(t, db, repo)
+			serial := &models.Serial{S_name: "Sherlock", S_description: "Detectives investigate crimes in London", S_genre: "детектив",
+				S_state: "завершен", S_year: 2010, S_rating: 9, S_idProducer: f.producer, S_img: "serial.jpg", S_duration: "00:00:00"}
+			require.NoError(t, repo.CreateSerial(ctx, serial))
+
+			hits, total, err := repo.FullTextSearch(ctx, "detective", models.NewPage(1, models.DefaultPageSize))
+			require.NoError(t, err)
+			require.Len(t, hits, 1)
+			assert.Equal(t, serial, hits[0].Serial)
+			assert.Equal(t, 1, total)
+			matches := []string{}
+			for _, fragment := range hits[0].Snippet {
+				if fragment.Match {
+					matches = append(matches, fragment.Text)
+				}
+			}
+			assert.Equal(t, []string{"Detectives"}, matches)
+		}},
+		testCase[interfaces.IRepoSerials]{"full-text search snippet and page", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newTextFixture(t, db, repo)
+
+			hits, total, err := repo.FullTextSearch(ctx, "детей", models.NewPage(1, 1))
+			require.NoError(t, err)
+			require.Len(t, hits, 1)
+			assert.Equal(t, 1, total)
+			matches := []string{}
+			for _, fragment := range hits[0].Snippet {
+				if fragment.Match {
+					matches = append(matches, fragment.Text)
+				}
+			}
+			assert.Equal(t, []string{"детей"}, matches)
+
+			hits, total, err = repo.FullTextSearch(ctx, "Лондон", models.NewPage(2, 1))
+			require.NoError(t, err)
+			require.Len(t, hits, 1)
+			assert.Equal(t, f.sherlock, hits[0].Serial)
+			assert.Equal(t, 2, total)
+		}},
+		testCase[interfaces.IRepoSerials]{"full-text search of invalid query", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			for _, text := range []string{"", " ,.! "} {
+				hits, _, err := repo.FullTextSearch(ctx, text, models.NewPage(1, models.DefaultPageSize))
+				assert.ErrorIs(t, err, models.ErrInvalidModel, text)
+				assert.Nil(t, hits)
+			}
+			_, _, err := repo.FullTextSearch(ctx, "Лондон", models.NewPage(0, 1))
+			assert.ErrorIs(t, err, models.ErrInvalidModel)
+		}},
+		testCase[interfaces.IRepoSerials]{"suggest", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newTextFixture(t, db, repo)
+			serial := func(s *models.Serial) *models.Suggestion {
+				return &models.Suggestion{Kind: models.SuggestSerial, Id: s.GetId(), Text: s.GetName()}
+			}
+
+			tests := []struct {
+				prefix string
+				want   []*models.Suggestion
+			}{
+				{"шер", []*models.Suggestion{serial(f.sherlock)}},
+				{"ЛОН", []*models.Suggestion{serial(f.london)}},
+				{"Шрелок", []*models.Suggestion{serial(f.sherlock)}},
+				{"Камб", []*models.Suggestion{{Kind: models.SuggestActor, Id: f.actor, Text: "Бенедикт Камбербэтч"}}},
+				{"бенедикт камб", []*models.Suggestion{{Kind: models.SuggestActor, Id: f.actor, Text: "Бенедикт Камбербэтч"}}},
+				{"мофат", []*models.Suggestion{{Kind: models.SuggestProducer, Id: f.producer, Text: "Стивен Моффат"}}},
+				{"шрл", []*models.Suggestion{}},
+				{"доктор", []*models.Suggestion{}},
+			}
+			for _, tt := range tests {
+				got, err := repo.Suggest(ctx, models.NewSuggestQuery(tt.prefix, models.DefaultSuggestions))
+				require.NoError(t, err, tt.prefix)
+				assert.Equal(t, tt.want, got, tt.prefix)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"suggest order and limit", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			f := newTextFixture(t, db, repo)
+			lost := &models.Serial{S_name: "Потерянный Лондон", S_description: "Описание", S_genre: "драма", S_state: "завершен",
+				S_year: 2010, S_rating: 8, S_idProducer: f.producer, S_img: "serial.jpg", S_duration: "00:00:00"}
+			require.NoError(t, repo.CreateSerial(ctx, lost))
+
+			got, err := repo.Suggest(ctx, models.NewSuggestQuery("лондн", models.DefaultSuggestions))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Suggestion{
+				{Kind: models.SuggestSerial, Id: f.london.GetId(), Text: "Лондон"},
+				{Kind: models.SuggestSerial, Id: lost.GetId(), Text: "Потерянный Лондон"},
+			}, got)
+
+			got, err = repo.Suggest(ctx, models.NewSuggestQuery("лондон", 1))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Suggestion{{Kind: models.SuggestSerial, Id: f.london.GetId(), Text: "Лондон"}}, got)
+		}},
+		testCase[interfaces.IRepoSerials]{"suggest of invalid query", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			for _, query := range []*models.SuggestQuery{
+				models.NewSuggestQuery(" ", models.DefaultSuggestions),
+				models.NewSuggestQuery("шер", 0),
+				models.NewSuggestQuery("шер", models.MaxSuggestions+1),
+			} {
+				got, err := repo.Suggest(ctx, query)
+				assert.ErrorIs(t, err, models.ErrInvalidModel)
+				assert.Nil(t, got)
+			}
+		}},
+		testCase[interfaces.IRepoSerials]{"search of invalid query", func(t *testing.T, db interface{}, repo interfaces.IRepoSerials) {
+			for _, query := range []*models.SerialsQuery{
+				{YearFrom: 2020, YearTo: 2010},
+				{MinRating: -1},
+				{Sort: "duration"},
+			} {
+				got, _, err := repo.SearchSerials(ctx, query, models.NewPage(1, models.DefaultPageSize))
+				assert.ErrorIs(t, err, models.ErrInvalidModel)
+				assert.Nil(t, got)
+			}
+		}},
+	))
+}
+
+type searchFixture struct {
+	serials  []*models.Serial
+	producer int
+	actors   []int
+}
+
+// newSearchFixture creates four serials, the first and the third of the same
+// producer, and two actors: the first plays in the first and the third serial,
+// the second in the second and the fourth.
+func newSearchFixture(t *testing.T, db interface{}, repo interfaces.IRepoSerials) *searchFixture {
+	f := &searchFixture{producer: newProducer(t, db).GetId()}
+	other := newProducer(t, db).GetId()
+	for _, serial := range []*models.Serial{
+		{S_name: "Тьма", S_genre: "драма", S_state: "завершен", S_year: 2017, S_rating: 8.5, S_idProducer: f.producer},
+		{S_name: "Шерлок", S_genre: "детектив", S_state: "завершен", S_year: 2010, S_rating: 9, S_idProducer: other},
+		{S_name: "Тёмные начала", S_genre: "фэнтези", S_state: "выходит", S_year: 2019, S_rating: 7.5, S_idProducer: f.producer},
+		{S_name: "Очень странные дела", S_genre: "фэнтези", S_state: "выходит", S_year: 2016, S_rating: 8.5, S_idProducer: other},
+	} {
+		serial.S_description = "Описание"
+		serial.S_img = "serial.jpg"
+		serial.S_duration = "00:00:00"
+		require.NoError(t, repo.CreateSerial(ctx, serial))
+		f.serials = append(f.serials, serial)
+	}
+
+	saRepo := repositories.NewSerialsActorsRepo(db, discardLog())
+	for i := 0; i < 2; i++ {
+		actor := newActor(t, db).GetId()
+		f.actors = append(f.actors, actor)
+		for _, serial := range []*models.Serial{f.serials[i], f.serials[i+2]} {
+			require.NoError(t, saRepo.CreateSerialsActors(ctx, &models.SerialsActors{Sa_idSerial: serial.GetId(), Sa_idActor: actor, Sa_role: models.CastMain}))
+		}
+	}
+	return f
+}
+
+type textFixture struct {
+	sherlock, dark, london *models.Serial
+	producer, actor        int
+}
+
+// newTextFixture creates three serials: "Шерлок" of the producer Стивен Моффат
+// with the actor Бенедикт Камбербэтч, set in London, and two more of another
+// producer, one of them named "Лондон".
+func newTextFixture(t *testing.T, db interface{}, repo interfaces.IRepoSerials) *textFixture {
+	moffat := &models.Producers{P_name: "Стивен", P_surname: "Моффат"}
+	require.NoError(t, repositories.NewProducersRepo(db, discardLog()).CreateProducer(ctx, moffat))
+	other := newProducer(t, db).GetId()
+	actor := &models.Actors{A_name: "Бенедикт", A_surname: "Камбербэтч", A_gender: "м", A_bdate: "19.07.1976"}
+	require.NoError(t, repositories.NewActorsRepo(db, discardLog()).CreateActor(ctx, actor))
+
+	create := func(name, description string, producer int) *models.Serial {
+		serial := &models.Serial{S_name: name, S_description: description, S_genre: "драма", S_state: "завершен",
+			S_year: 2010, S_rating: 8, S_idProducer: producer, S_img: "serial.jpg", S_duration: "00:00:00"}
+		require.NoError(t, repo.CreateSerial(ctx, serial))
+		return serial
+	}
+	f := &textFixture{
+		sherlock: create("Шерлок", "Детектив расследует преступления в Лондоне", moffat.GetId()),
+		dark:     create("Тьма", "Исчезновение детей в маленьком немецком городке", other),
+		london:   create("Лондон", "Сериал о столице Британии", other),
+		producer: moffat.GetId(),
+		actor:    actor.GetId(),
+	}
+	sa := &models.SerialsActors{Sa_idSerial: f.sherlock.GetId(), Sa_idActor: actor.GetId(), Sa_role: models.CastMain}
+	require.NoError(t, repositories.NewSerialsActorsRepo(db, discardLog()).CreateSerialsActors(ctx, sa))
+	return f
+}
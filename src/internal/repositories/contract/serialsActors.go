@@ -0,0 +1,130 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/memdb"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func validSerialActor(t *testing.T, db interface{}) *models.SerialsActors {
+	return &models.SerialsActors{
+		Sa_idSerial:   newSerial(t, db).GetId(),
+		Sa_idActor:    newActor(t, db).GetId(),
+		Sa_character:  "Шерлок Холмс",
+		Sa_role:       models.CastMain,
+		Sa_billing:    1,
+		Sa_seasonFrom: 1,
+	}
+}
+
+// storeBareSerialActor stores the link of the actor to the serial the way it
+// was stored before the roles were added and returns its id.
+func storeBareSerialActor(t *testing.T, db interface{}, idSerial, idActor int) int {
+	switch db := db.(type) {
+	case *memdb.DB:
+		serialActor := &models.SerialsActors{Sa_idSerial: idSerial, Sa_idActor: idActor}
+		memdb.NewTable[models.SerialsActors](db, "serials_actors").Insert(serialActor)
+		return serialActor.GetId()
+	case *sqlx.DB:
+		var id int
+		require.NoError(t, db.Get(&id, "INSERT INTO serials_actors (sa_idSerial, sa_idActor) VALUES ($1, $2) RETURNING sa_id", idSerial, idActor))
+		return id
+	case *mongo.Client:
+		mydb := db.Database("mydb")
+		id, err := counters.NewCountersRepoMongo(mydb).NextId(ctx, "serials_actors", "sa_id")
+		require.NoError(t, err)
+		_, err = mydb.Collection("serials_actors").InsertOne(ctx, bson.M{"sa_id": id, "sa_idserial": idSerial, "sa_idactor": idActor})
+		require.NoError(t, err)
+		return id
+	}
+	t.Fatalf("unknown database %T", db)
+	return 0
+}
+
+var serialsActorsCrud = crud[interfaces.IRepoSerialsActors, models.SerialsActors, *models.SerialsActors]{
+	create: interfaces.IRepoSerialsActors.CreateSerialsActors,
+	get:    interfaces.IRepoSerialsActors.GetSerialsActorsById,
+	update: interfaces.IRepoSerialsActors.UpdateSerialsActors,
+	delete: interfaces.IRepoSerialsActors.DeleteSerialsActors,
+	list:   interfaces.IRepoSerialsActors.GetSerialsActors,
+	valid:  validSerialActor,
+	change: func(t *testing.T, db interface{}, serialActor *models.SerialsActors) {
+		serialActor.Sa_idSerial = newSerial(t, db).GetId()
+		serialActor.Sa_idActor = newActor(t, db).GetId()
+		serialActor.Sa_character = "Джим Мориарти"
+		serialActor.Sa_role = models.CastRecurring
+		serialActor.Sa_billing = 0
+		serialActor.Sa_seasonTo = 3
+	},
+	invalidate: func(serialActor *models.SerialsActors) {
+		serialActor.Sa_idActor = 0
+	},
+}
+
+// SerialsActors runs the IRepoSerialsActors contract.
+func SerialsActors(t *testing.T, open Open) {
+	run(t, open, repositories.NewSerialsActorsRepo, append(serialsActorsCrud.tests(),
+		testCase[interfaces.IRepoSerialsActors]{"get by serial and by actor", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsActors) {
+			first := validSerialActor(t, db)
+			second := validSerialActor(t, db)
+			second.Sa_idSerial = first.Sa_idSerial
+			third := validSerialActor(t, db)
+			third.Sa_idActor = first.Sa_idActor
+			for _, serialActor := range []*models.SerialsActors{first, second, third} {
+				require.NoError(t, repo.CreateSerialsActors(ctx, serialActor))
+			}
+
+			bySerial, err := repo.GetActorsBySerialId(ctx, first.Sa_idSerial)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsActors{first, second}, bySerial)
+
+			byActor, err := repo.GetSerialsByActorId(ctx, first.Sa_idActor)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsActors{first, third}, byActor)
+
+			none, err := repo.GetSerialsByActorId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoSerialsActors]{"stored without role", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsActors) {
+			serial, actor := newSerial(t, db).GetId(), newActor(t, db).GetId()
+			id := storeBareSerialActor(t, db, serial, actor)
+			want := &models.SerialsActors{Sa_id: id, Sa_idSerial: serial, Sa_idActor: actor, Sa_role: models.CastMain}
+
+			got, err := repo.GetSerialsActorsById(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			assert.True(t, got.Validate())
+
+			bySerial, err := repo.GetActorsBySerialId(ctx, serial)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.SerialsActors{want}, bySerial)
+
+			byActor, err := repo.GetSerialsByActorId(ctx, actor)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.SerialsActors{want}, byActor)
+
+			all, err := repo.GetSerialsActors(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.SerialsActors{want}, all)
+		}},
+		testCase[interfaces.IRepoSerialsActors]{"invalid role and seasons", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsActors) {
+			serialActor := validSerialActor(t, db)
+			serialActor.Sa_role = "cameo"
+			assert.ErrorIs(t, repo.CreateSerialsActors(ctx, serialActor), models.ErrInvalidModel)
+
+			serialActor = validSerialActor(t, db)
+			serialActor.Sa_seasonFrom, serialActor.Sa_seasonTo = 3, 2
+			assert.ErrorIs(t, repo.CreateSerialsActors(ctx, serialActor), models.ErrInvalidModel)
+		}},
+	))
+}
@@ -0,0 +1,80 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSerialFavourite(t *testing.T, db interface{}) *models.SerialsFavourites {
+	return &models.SerialsFavourites{Sf_idSerial: newSerial(t, db).GetId(), Sf_idFavourite: newFavourite(t, db).GetId(), Sf_date: "2024-03-01"}
+}
+
+var serialsFavouritesCrud = crud[interfaces.IRepoSerialsFavourites, models.SerialsFavourites, *models.SerialsFavourites]{
+	create: interfaces.IRepoSerialsFavourites.CreateSerialsFavourites,
+	get:    interfaces.IRepoSerialsFavourites.GetSerialsFavouritesById,
+	update: interfaces.IRepoSerialsFavourites.UpdateSerialsFavourites,
+	delete: interfaces.IRepoSerialsFavourites.DeleteSerialsFavourites,
+	list:   interfaces.IRepoSerialsFavourites.GetSerialsFavourites,
+	valid:  validSerialFavourite,
+	change: func(t *testing.T, db interface{}, serialFavourite *models.SerialsFavourites) {
+		serialFavourite.Sf_idSerial = newSerial(t, db).GetId()
+		serialFavourite.Sf_idFavourite = newFavourite(t, db).GetId()
+		serialFavourite.Sf_date = "2024-03-02"
+	},
+	invalidate: func(serialFavourite *models.SerialsFavourites) {
+		serialFavourite.Sf_idSerial = 0
+	},
+}
+
+// SerialsFavourites runs the IRepoSerialsFavourites contract.
+func SerialsFavourites(t *testing.T, open Open) {
+	run(t, open, repositories.NewSerialsFavouritesRepo, append(serialsFavouritesCrud.tests(),
+		testCase[interfaces.IRepoSerialsFavourites]{"get by serial and by favourite", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsFavourites) {
+			first := validSerialFavourite(t, db)
+			second := validSerialFavourite(t, db)
+			second.Sf_idFavourite = first.Sf_idFavourite
+			third := validSerialFavourite(t, db)
+			third.Sf_idSerial = first.Sf_idSerial
+			for _, serialFavourite := range []*models.SerialsFavourites{first, second, third} {
+				require.NoError(t, repo.CreateSerialsFavourites(ctx, serialFavourite))
+			}
+
+			byFavourite, err := repo.GetSerialsByFavouriteId(ctx, first.Sf_idFavourite)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsFavourites{first, second}, byFavourite)
+
+			bySerial, err := repo.GetFavouritesBySerialId(ctx, first.Sf_idSerial)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsFavourites{first, third}, bySerial)
+
+			none, err := repo.GetFavouritesBySerialId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoSerialsFavourites]{"check serial in favourite", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsFavourites) {
+			serialFavourite := validSerialFavourite(t, db)
+			require.NoError(t, repo.CreateSerialsFavourites(ctx, serialFavourite))
+
+			assert.True(t, repo.CheckSerialInFavourite(ctx, &models.SerialsFavourites{
+				Sf_idSerial: serialFavourite.Sf_idSerial, Sf_idFavourite: serialFavourite.Sf_idFavourite}))
+			assert.False(t, repo.CheckSerialInFavourite(ctx, &models.SerialsFavourites{
+				Sf_idSerial: serialFavourite.Sf_idFavourite, Sf_idFavourite: missingId}))
+		}},
+		testCase[interfaces.IRepoSerialsFavourites]{"delete serial from favourite", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsFavourites) {
+			removed, kept := validSerialFavourite(t, db), validSerialFavourite(t, db)
+			require.NoError(t, repo.CreateSerialsFavourites(ctx, removed))
+			require.NoError(t, repo.CreateSerialsFavourites(ctx, kept))
+
+			require.NoError(t, repo.DeleteSerialById(ctx, removed.Sf_idFavourite, removed.Sf_idSerial))
+			assert.False(t, repo.CheckSerialInFavourite(ctx, removed))
+			assert.True(t, repo.CheckSerialInFavourite(ctx, kept))
+
+			assert.NoError(t, repo.DeleteSerialById(ctx, removed.Sf_idFavourite, removed.Sf_idSerial))
+		}},
+	))
+}
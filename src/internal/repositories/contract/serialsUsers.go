@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSerialUser(t *testing.T, db interface{}) *models.SerialsUsers {
+	return &models.SerialsUsers{
+		Su_idSerial: newSerial(t, db).GetId(),
+		Su_idUser:   newUser(t, db).GetId(),
+		Su_lastSeen: "05.05.2024",
+	}
+}
+
+var serialsUsersCrud = crud[interfaces.IRepoSerialsUsers, models.SerialsUsers, *models.SerialsUsers]{
+	create: interfaces.IRepoSerialsUsers.CreateSerialsUsers,
+	get:    interfaces.IRepoSerialsUsers.GetSerialsUsersById,
+	update: interfaces.IRepoSerialsUsers.UpdateSerialsUsers,
+	delete: interfaces.IRepoSerialsUsers.DeleteSerialsUsers,
+	list:   interfaces.IRepoSerialsUsers.GetSerialsUsers,
+	page:   interfaces.IRepoSerialsUsers.GetSerialsUsersPage,
+	valid:  validSerialUser,
+	change: func(t *testing.T, db interface{}, serialUser *models.SerialsUsers) {
+		serialUser.Su_idSerial = newSerial(t, db).GetId()
+		serialUser.Su_idUser = newUser(t, db).GetId()
+		serialUser.Su_lastSeen = "06.06.2024"
+	},
+	invalidate: func(serialUser *models.SerialsUsers) {
+		serialUser.Su_lastSeen = ""
+	},
+}
+
+// SerialsUsers runs the IRepoSerialsUsers contract.
+func SerialsUsers(t *testing.T, open Open) {
+	run(t, open, repositories.NewSerialsUsersRepo, append(serialsUsersCrud.tests(),
+		testCase[interfaces.IRepoSerialsUsers]{"get by serial and by user", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsUsers) {
+			first := validSerialUser(t, db)
+			second := validSerialUser(t, db)
+			second.Su_idUser = first.Su_idUser
+			third := validSerialUser(t, db)
+			third.Su_idSerial = first.Su_idSerial
+			for _, serialUser := range []*models.SerialsUsers{first, second, third} {
+				require.NoError(t, repo.CreateSerialsUsers(ctx, serialUser))
+			}
+
+			byUser, err := repo.GetSerialsByUserId(ctx, first.Su_idUser)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsUsers{first, second}, byUser)
+
+			bySerial, err := repo.GetUsersBySerialId(ctx, first.Su_idSerial)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*models.SerialsUsers{first, third}, bySerial)
+
+			none, err := repo.GetUsersBySerialId(ctx, missingId)
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		}},
+		testCase[interfaces.IRepoSerialsUsers]{"page by user", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsUsers) {
+			first := validSerialUser(t, db)
+			require.NoError(t, repo.CreateSerialsUsers(ctx, first))
+			require.NoError(t, repo.CreateSerialsUsers(ctx, validSerialUser(t, db)))
+			rest := []*models.SerialsUsers{}
+			for i := 0; i < 2; i++ {
+				su := validSerialUser(t, db)
+				su.Su_idUser = first.Su_idUser
+				require.NoError(t, repo.CreateSerialsUsers(ctx, su))
+				rest = append(rest, su)
+			}
+
+			got, total, err := repo.GetSerialsByUserIdPage(ctx, first.Su_idUser, models.NewPage(1, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.SerialsUsers{first, rest[0]}, got)
+			assert.Equal(t, 3, total)
+
+			got, total, err = repo.GetSerialsByUserIdPage(ctx, first.Su_idUser, models.NewPage(2, 2))
+			require.NoError(t, err)
+			assert.Equal(t, []*models.SerialsUsers{rest[1]}, got)
+			assert.Equal(t, 3, total)
+
+			_, _, err = repo.GetSerialsByUserIdPage(ctx, first.Su_idUser, models.NewPage(0, 2))
+			assert.ErrorIs(t, err, models.ErrInvalidModel)
+		}},
+		testCase[interfaces.IRepoSerialsUsers]{"get by serial and user ids", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsUsers) {
+			serialUser := validSerialUser(t, db)
+			require.NoError(t, repo.CreateSerialsUsers(ctx, serialUser))
+
+			got, err := repo.GetSerialUserByIds(ctx, serialUser.Su_idSerial, serialUser.Su_idUser)
+			require.NoError(t, err)
+			assert.Equal(t, serialUser, got)
+
+			_, err = repo.GetSerialUserByIds(ctx, serialUser.Su_idUser, missingId)
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoSerialsUsers]{"delete by user", func(t *testing.T, db interface{}, repo interfaces.IRepoSerialsUsers) {
+			removed, kept := validSerialUser(t, db), validSerialUser(t, db)
+			require.NoError(t, repo.CreateSerialsUsers(ctx, removed))
+			require.NoError(t, repo.CreateSerialsUsers(ctx, kept))
+
+			require.NoError(t, repo.DeleteSerialsByUserId(ctx, removed.Su_idUser))
+			_, err := repo.GetSerialsUsersById(ctx, removed.GetId())
+			assert.ErrorIs(t, err, models.ErrNotFound)
+			_, err = repo.GetSerialsUsersById(ctx, kept.GetId())
+			assert.NoError(t, err)
+
+			assert.NoError(t, repo.DeleteSerialsByUserId(ctx, removed.Su_idUser))
+		}},
+	))
+}
@@ -0,0 +1,53 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Statistic runs the IRepoStatistic contract. The statistic is a single row
+// created empty on first use.
+func Statistic(t *testing.T, open Open) {
+	run(t, open, repositories.NewStatisticRepo, []testCase[interfaces.IRepoStatistic]{
+		{"get creates empty statistic", func(t *testing.T, db interface{}, repo interfaces.IRepoStatistic) {
+			stat, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			assert.Positive(t, stat.GetId())
+			assert.Equal(t, &models.Statistic{St_id: stat.GetId()}, stat)
+
+			again, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, stat, again)
+		}},
+		{"update changes the statistic", func(t *testing.T, db interface{}, repo interfaces.IRepoStatistic) {
+			stat, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			stat.St_gender_male = 3
+			stat.St_gender_female = 2
+			stat.St_role_user = 4
+			stat.St_role_admin = 1
+			stat.St_age_19_30 = 5
+			require.NoError(t, repo.UpdateStatistic(ctx, stat))
+
+			got, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, stat, got)
+		}},
+		{"update of invalid statistic", func(t *testing.T, db interface{}, repo interfaces.IRepoStatistic) {
+			stat, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			stat.St_role_user = -1
+			assert.ErrorIs(t, repo.UpdateStatistic(ctx, stat), models.ErrInvalidModel)
+		}},
+		{"update of missing statistic", func(t *testing.T, db interface{}, repo interfaces.IRepoStatistic) {
+			_, err := repo.GetStatistic(ctx)
+			require.NoError(t, err)
+			assert.ErrorIs(t, repo.UpdateStatistic(ctx, &models.Statistic{St_id: missingId}), models.ErrNotFound)
+		}},
+	})
+}
@@ -0,0 +1,73 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UnitOfWork runs the IUnitOfWork contract: the changes made through the
+// repositories of a transaction are kept if its function succeeds and
+// discarded if it fails.
+func UnitOfWork(t *testing.T, open Open) {
+	errAbort := errors.New("abort")
+
+	run(t, open, repositories.NewUnitOfWork, []testCase[interfaces.IUnitOfWork]{
+		{"commit keeps the changes", func(t *testing.T, db interface{}, uow interfaces.IUnitOfWork) {
+			producer := &models.Producers{P_name: "Джеймс", P_surname: "Кэмерон"}
+			err := uow.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+				if err := tx.Producers().CreateProducer(ctx, producer); err != nil {
+					return err
+				}
+				stat, err := tx.Statistic().GetStatistic(ctx)
+				if err != nil {
+					return err
+				}
+				stat.St_role_user = 1
+				return tx.Statistic().UpdateStatistic(ctx, stat)
+			})
+			require.NoError(t, err)
+
+			got, err := repositories.NewProducersRepo(db, discardLog()).GetProducerById(ctx, producer.GetId())
+			require.NoError(t, err)
+			assert.Equal(t, producer, got)
+			stat, err := repositories.NewStatisticRepo(db, discardLog()).GetStatistic(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, stat.St_role_user)
+		}},
+		{"error discards the changes", func(t *testing.T, db interface{}, uow interfaces.IUnitOfWork) {
+			kept := newProducer(t, db)
+			err := uow.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+				if err := tx.Producers().CreateProducer(ctx, &models.Producers{P_name: "Дэвид", P_surname: "Финчер"}); err != nil {
+					return err
+				}
+				if err := tx.Producers().DeleteProducer(ctx, kept.GetId()); err != nil {
+					return err
+				}
+				return errAbort
+			})
+			assert.ErrorIs(t, err, errAbort)
+
+			producers, err := repositories.NewProducersRepo(db, discardLog()).GetProducers(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []*models.Producers{kept}, producers)
+		}},
+		{"changes are visible inside the transaction", func(t *testing.T, db interface{}, uow interfaces.IUnitOfWork) {
+			err := uow.Do(ctx, func(ctx context.Context, tx interfaces.ITx) error {
+				producer := &models.Producers{P_name: "Джеймс", P_surname: "Кэмерон"}
+				require.NoError(t, tx.Producers().CreateProducer(ctx, producer))
+				got, err := tx.Producers().GetProducerById(ctx, producer.GetId())
+				require.NoError(t, err)
+				assert.Equal(t, producer, got)
+				return errAbort
+			})
+			assert.ErrorIs(t, err, errAbort)
+		}},
+	})
+}
@@ -0,0 +1,76 @@
+package contract
+
+import (
+	"app/internal/interfaces"
+	"app/internal/models"
+	"app/internal/repositories"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logins makes the logins of the created users unique.
+var logins atomic.Int64
+
+func validUser(t *testing.T, db interface{}) *models.Users {
+	return &models.Users{
+		U_login:        fmt.Sprintf("user%d", logins.Add(1)),
+		U_password:     "password",
+		U_role:         "user",
+		U_name:         "Иван",
+		U_surname:      "Иванов",
+		U_gender:       "м",
+		U_bdate:        "15.06.1990",
+		U_idFavourites: newFavourite(t, db).GetId(),
+	}
+}
+
+var usersCrud = crud[interfaces.IRepoUsers, models.Users, *models.Users]{
+	create: interfaces.IRepoUsers.CreateUser,
+	get:    interfaces.IRepoUsers.GetUserById,
+	update: interfaces.IRepoUsers.UpdateUser,
+	delete: interfaces.IRepoUsers.DeleteUser,
+	list:   interfaces.IRepoUsers.GetUsers,
+	page:   interfaces.IRepoUsers.GetUsersPage,
+	valid:  validUser,
+	change: func(t *testing.T, db interface{}, user *models.Users) {
+		user.U_login = fmt.Sprintf("user%d", logins.Add(1))
+		user.U_password = "secret"
+		user.U_role = "admin"
+		user.U_name = "Мария"
+		user.U_surname = "Петрова"
+		user.U_gender = "ж"
+		user.U_bdate = "31.12.2001"
+		user.U_idFavourites = newFavourite(t, db).GetId()
+	},
+	invalidate: func(user *models.Users) {
+		user.U_login = ""
+	},
+}
+
+// Users runs the IRepoUsers contract.
+func Users(t *testing.T, open Open) {
+	run(t, open, repositories.NewUsersRepo, append(usersCrud.tests(),
+		testCase[interfaces.IRepoUsers]{"get by login", func(t *testing.T, db interface{}, repo interfaces.IRepoUsers) {
+			user := validUser(t, db)
+			require.NoError(t, repo.CreateUser(ctx, user))
+
+			got, err := repo.GetUserByLogin(ctx, user.U_login)
+			require.NoError(t, err)
+			assert.Equal(t, user, got)
+
+			_, err = repo.GetUserByLogin(ctx, user.U_login+"_")
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		}},
+		testCase[interfaces.IRepoUsers]{"check user", func(t *testing.T, db interface{}, repo interfaces.IRepoUsers) {
+			user := validUser(t, db)
+			require.NoError(t, repo.CreateUser(ctx, user))
+
+			assert.True(t, repo.CheckUser(ctx, user.U_login))
+			assert.False(t, repo.CheckUser(ctx, user.U_login+"_"))
+		}},
+	))
+}
@@ -0,0 +1,68 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CountersRepoMongo issues sequential integer ids for the documents of the
+// other collections. Every collection has its own counter document
+// {_id: <collection>, seq: <last issued id>} in the "counters" collection.
+type CountersRepoMongo struct {
+	db *mongo.Database
+}
+
+func NewCountersRepoMongo(db *mongo.Database) *CountersRepoMongo {
+	return &CountersRepoMongo{db: db}
+}
+
+// NextId returns the next id for the collection. idField is the name of the
+// id field of the collection documents: the first call seeds the counter with
+// the largest id already stored there.
+func (repo *CountersRepoMongo) NextId(ctx context.Context, collection string, idField string) (int, error) {
+	counters := repo.db.Collection("counters")
+	var counter struct {
+		Seq int `bson:"seq"`
+	}
+
+	err := counters.FindOneAndUpdate(ctx, bson.M{"_id": collection}, bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&counter)
+	if err == nil {
+		return counter.Seq, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, err
+	}
+
+	var last bson.M
+	maxId := 0
+	err = repo.db.Collection(collection).FindOne(ctx, bson.M{},
+		options.FindOne().SetSort(bson.M{idField: -1}).SetProjection(bson.M{idField: 1})).Decode(&last)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, err
+	}
+	if err == nil {
+		switch v := last[idField].(type) {
+		case int32:
+			maxId = int(v)
+		case int64:
+			maxId = int(v)
+		}
+	}
+
+	_, err = counters.UpdateOne(ctx, bson.M{"_id": collection}, bson.M{"$max": bson.M{"seq": maxId}},
+		options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return 0, err
+	}
+	err = counters.FindOneAndUpdate(ctx, bson.M{"_id": collection}, bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
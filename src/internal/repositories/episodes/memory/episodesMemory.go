@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type EpisodesRepoMemory struct {
+	table *memdb.Table[models.Episodes, *models.Episodes]
+	log   *logrus.Logger
+}
+
+func NewEpisodesRepoMemory(db *memdb.DB, log *logrus.Logger) *EpisodesRepoMemory {
+	return &EpisodesRepoMemory{table: memdb.NewTable[models.Episodes](db, "episodes"), log: log}
+}
+
+func (repo *EpisodesRepoMemory) GetEpisodes(ctx context.Context) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *EpisodesRepoMemory) GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episode by id from the database")
+	episode, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return episode, nil
+}
+
+func (repo *EpisodesRepoMemory) GetEpisodesBySeasonId(ctx context.Context, id int) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes by season id from the database")
+	return repo.table.Select(func(row *models.Episodes) bool {
+		return row.GetIdSeason() == id
+	}), nil
+}
+
+func (repo *EpisodesRepoMemory) CreateEpisode(ctx context.Context, episode *models.Episodes) error {
+	if !episode.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating episode in the database")
+	repo.table.Insert(episode)
+	return nil
+}
+
+func (repo *EpisodesRepoMemory) UpdateEpisode(ctx context.Context, episode *models.Episodes) error {
+	if !episode.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating episode in the database")
+	if !repo.table.Update(episode) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *EpisodesRepoMemory) DeleteEpisode(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episode from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
@@ -2,29 +2,33 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type EpisodesRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewEpisodesRepoMongo(client *mongo.Client, log *logrus.Logger) *EpisodesRepoMongo {
 	db := client.Database("mydb")
-	return &EpisodesRepoMongo{db: db, log: log}
+	return &EpisodesRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
 func (repo *EpisodesRepoMongo) FormatDate(episode *models.Episodes) {
 	date := episode.GetDate()
-	d1, _ := time.Parse("2006-01-02T00:00:00Z", date)
+	d1, err := time.Parse("2006-01-02T00:00:00Z", date)
+	if err != nil {
+		return
+	}
 	d2 := d1.Format("02.01.2006")
 	episode.SetDate(d2)
 }
@@ -35,11 +39,11 @@ func (repo *EpisodesRepoMongo) FormatDateList(episodes []*models.Episodes) {
 	}
 }
 
-func (repo *EpisodesRepoMongo) GetEpisodes() ([]*models.Episodes, error) {
-	repo.log.Info("Getting all episodes from the database")
+func (repo *EpisodesRepoMongo) GetEpisodes(ctx context.Context) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes from the database")
 	episodes := []*models.Episodes{}
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -62,18 +66,17 @@ func (repo *EpisodesRepoMongo) GetEpisodes() ([]*models.Episodes, error) {
 	return episodes, nil
 }
 
-func (repo *EpisodesRepoMongo) GetEpisodeById(id int) (*models.Episodes, error) {
-	repo.log.Info("Getting episode by id from the database")
+func (repo *EpisodesRepoMongo) GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episode by id from the database")
 	episode := &models.Episodes{}
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
+	err := collection.FindOne(ctx, bson.M{"e_id": id}).Decode(episode)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
 	}
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(episode)
 	if err != nil {
 		return nil, err
 	}
@@ -81,14 +84,14 @@ func (repo *EpisodesRepoMongo) GetEpisodeById(id int) (*models.Episodes, error)
 	return episode, nil
 }
 
-func (repo *EpisodesRepoMongo) GetEpisodesBySeasonId(idSeason int) ([]*models.Episodes, error) {
-	repo.log.Info("Getting episodes by season id from the database")
+func (repo *EpisodesRepoMongo) GetEpisodesBySeasonId(ctx context.Context, idSeason int) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes by season id from the database")
 	episodes := []*models.Episodes{}
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"e_idSeason": idSeason})
+	cursor, err := collection.Find(ctx, bson.M{"e_idseason": idSeason})
 	if err != nil {
 		return nil, err
 	}
@@ -108,77 +111,58 @@ func (repo *EpisodesRepoMongo) GetEpisodesBySeasonId(idSeason int) ([]*models.Ep
 	return episodes, nil
 }
 
-func (repo *EpisodesRepoMongo) CreateEpisode(episode *models.Episodes) error {
+func (repo *EpisodesRepoMongo) CreateEpisode(ctx context.Context, episode *models.Episodes) error {
 	if !episode.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating episode in the database")
+	repo.log.WithContext(ctx).Info("Creating episode in the database")
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"e_name":     episode.GetName(),
-		"e_date":     episode.GetDate(),
-		"e_idSeason": episode.GetIdSeason(),
-		"e_num":      episode.GetNum(),
-		"e_duration": episode.GetDuration(),
-	})
+	id, err := repo.counters.NextId(ctx, "episodes", "e_id")
+	if err != nil {
+		return err
+	}
+	episode.SetId(id)
+
+	_, err = collection.InsertOne(ctx, episode)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	episode.SetId(res)
 
 	return nil
 }
 
-func (repo *EpisodesRepoMongo) UpdateEpisode(episode *models.Episodes) error {
+func (repo *EpisodesRepoMongo) UpdateEpisode(ctx context.Context, episode *models.Episodes) error {
 	if !episode.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating episode in the database")
+	repo.log.WithContext(ctx).Info("Updating episode in the database")
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(episode.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"e_id": episode.GetId()}, episode)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"e_name":     episode.GetName(),
-			"e_date":     episode.GetDate(),
-			"e_idSeason": episode.GetIdSeason(),
-			"e_num":      episode.GetNum(),
-			"e_duration": episode.GetDuration(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *EpisodesRepoMongo) DeleteEpisode(id int) error {
-	repo.log.Info("Deleting episode from the database")
+func (repo *EpisodesRepoMongo) DeleteEpisode(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episode from the database")
 	collection := repo.db.Collection("episodes")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"e_id": id})
 	if err != nil {
 		return err
 	}
@@ -2,18 +2,21 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type EpisodesRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewEpisodesRepoPostgres(db *sqlx.DB, log *logrus.Logger) *EpisodesRepoPostgres {
+func NewEpisodesRepoPostgres(db pgdb.Querier, log *logrus.Logger) *EpisodesRepoPostgres {
 	return &EpisodesRepoPostgres{db: db, log: log}
 }
 
@@ -24,16 +27,26 @@ func (repo *EpisodesRepoPostgres) FormatDate(episode *models.Episodes) {
 	episode.SetDate(d2)
 }
 
+// DbDate converts a date in the "02.01.2006" format used by the models
+// into the ISO form expected by the database. Other values are passed as is.
+func (repo *EpisodesRepoPostgres) DbDate(date string) string {
+	d, err := time.Parse("02.01.2006", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("2006-01-02")
+}
+
 func (repo *EpisodesRepoPostgres) FormatDateList(episodes []*models.Episodes) {
 	for _, episode := range episodes {
 		repo.FormatDate(episode)
 	}
 }
 
-func (repo *EpisodesRepoPostgres) GetEpisodes() ([]*models.Episodes, error) {
-	repo.log.Info("Getting all episodes from the database")
+func (repo *EpisodesRepoPostgres) GetEpisodes(ctx context.Context) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes from the database")
 	episodes := []*models.Episodes{}
-	err := repo.db.Select(&episodes, "SELECT * FROM episodes")
+	err := repo.db.SelectContext(ctx, &episodes, "SELECT * FROM episodes")
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +54,13 @@ func (repo *EpisodesRepoPostgres) GetEpisodes() ([]*models.Episodes, error) {
 	return episodes, nil
 }
 
-func (repo *EpisodesRepoPostgres) GetEpisodeById(id int) (*models.Episodes, error) {
-	repo.log.Info("Getting episode by id from the database")
+func (repo *EpisodesRepoPostgres) GetEpisodeById(ctx context.Context, id int) (*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episode by id from the database")
 	episode := &models.Episodes{}
-	err := repo.db.Get(episode, "SELECT * FROM episodes WHERE id=$1", id)
+	err := repo.db.GetContext(ctx, episode, "SELECT * FROM episodes WHERE e_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +68,10 @@ func (repo *EpisodesRepoPostgres) GetEpisodeById(id int) (*models.Episodes, erro
 	return episode, nil
 }
 
-func (repo *EpisodesRepoPostgres) GetEpisodesBySeasonId(id int) ([]*models.Episodes, error) {
-	repo.log.Info("Getting episodes by season id from the database")
+func (repo *EpisodesRepoPostgres) GetEpisodesBySeasonId(ctx context.Context, id int) ([]*models.Episodes, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes by season id from the database")
 	episodes := []*models.Episodes{}
-	err := repo.db.Select(&episodes, "SELECT * FROM episodes WHERE e_idSeason=$1", id)
+	err := repo.db.SelectContext(ctx, &episodes, "SELECT * FROM episodes WHERE e_idSeason=$1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +79,15 @@ func (repo *EpisodesRepoPostgres) GetEpisodesBySeasonId(id int) ([]*models.Episo
 	return episodes, nil
 }
 
-func (repo *EpisodesRepoPostgres) CreateEpisode(episode *models.Episodes) error {
+func (repo *EpisodesRepoPostgres) CreateEpisode(ctx context.Context, episode *models.Episodes) error {
 	if !episode.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating episode in the database")
-	err := repo.db.QueryRow("INSERT INTO episodes (e_name, e_date, e_idSeason, e_num, e_duration) VALUES ($1, $2, $3, $4, $5) RETURNING e_id",
-		episode.GetName(), episode.GetDate(), episode.GetIdSeason(), episode.GetNum(), episode.GetDuration()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating episode in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO episodes (e_name, e_date, e_idSeason, e_num, e_duration) VALUES ($1, $2, $3, $4, $5) RETURNING e_id",
+		episode.GetName(), repo.DbDate(episode.GetDate()), episode.GetIdSeason(), episode.GetNum(), episode.GetDuration()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -80,25 +96,32 @@ func (repo *EpisodesRepoPostgres) CreateEpisode(episode *models.Episodes) error
 	return nil
 }
 
-func (repo *EpisodesRepoPostgres) UpdateEpisode(episode *models.Episodes) error {
+func (repo *EpisodesRepoPostgres) UpdateEpisode(ctx context.Context, episode *models.Episodes) error {
 	if !episode.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating episode in the database")
-	_, err := repo.db.Exec("UPDATE episodes SET e_name=$1, e_date=$2, e_idSeason=$3, e_num=$4, e_duration=$5 WHERE e_id=$6",
-		episode.GetName(), episode.GetDate(), episode.GetIdSeason(), episode.GetNum(), episode.GetDuration(), episode.GetId())
+	repo.log.WithContext(ctx).Info("Updating episode in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE episodes SET e_name=$1, e_date=$2, e_idSeason=$3, e_num=$4, e_duration=$5 WHERE e_id=$6",
+		episode.GetName(), repo.DbDate(episode.GetDate()), episode.GetIdSeason(), episode.GetNum(), episode.GetDuration(), episode.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *EpisodesRepoPostgres) DeleteEpisode(id int) error {
-	repo.log.Info("Deleting episode from the database")
-	_, err := repo.db.Exec("DELETE FROM episodes WHERE e_id=$1", id)
+func (repo *EpisodesRepoPostgres) DeleteEpisode(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episode from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM episodes WHERE e_id=$1", id)
 	if err != nil {
 		return err
 	}
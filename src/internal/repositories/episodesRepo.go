@@ -3,8 +3,10 @@ package repositories
 import (
 	"app/internal/interfaces"
 
+	mem "app/internal/repositories/episodes/memory"
 	mg "app/internal/repositories/episodes/mongo"
 	pg "app/internal/repositories/episodes/postgres"
+	"app/internal/repositories/memdb"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
@@ -15,8 +17,14 @@ func NewEpisodesRepo(db interface{}, log *logrus.Logger) interfaces.IRepoEpisode
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewEpisodesRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewEpisodesRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewEpisodesRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewEpisodesRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewEpisodesRepoMemory(db, log)
 	default:
 		return nil
 	}
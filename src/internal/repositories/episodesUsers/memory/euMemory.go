@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type EpisodesUsersRepoMemory struct {
+	table *memdb.Table[models.EpisodesUsers, *models.EpisodesUsers]
+	log   *logrus.Logger
+}
+
+func NewEpisodesUsersRepoMemory(db *memdb.DB, log *logrus.Logger) *EpisodesUsersRepoMemory {
+	return &EpisodesUsersRepoMemory{table: memdb.NewTable[models.EpisodesUsers](db, "episodes_users"), log: log}
+}
+
+func (repo *EpisodesUsersRepoMemory) GetEpisodesUsers(ctx context.Context) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes_users from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *EpisodesUsersRepoMemory) GetEpisodesUsersById(ctx context.Context, id int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by id from the database")
+	episodeUser, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoMemory) GetEpisodesBySerialIdUserId(ctx context.Context, serialId, userId int) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by serial id and user id from the database")
+	return repo.table.Select(func(row *models.EpisodesUsers) bool {
+		return row.GetIdUser() == userId && row.GetIdSerial() == serialId
+	}), nil
+}
+
+func (repo *EpisodesUsersRepoMemory) GetEpisodeUserByIds(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by episode id and user id from the database")
+	episodeUser, ok := repo.table.First(func(row *models.EpisodesUsers) bool {
+		return row.GetIdEpisode() == episodeId && row.GetIdUser() == userId
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoMemory) CreateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating episodes_users in the database")
+	repo.table.Insert(episodeUser)
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMemory) UpdateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating episodes_users in the database")
+	if !repo.table.Update(episodeUser) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMemory) DeleteEpisodesUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMemory) DeleteEpisodesByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users by user id from the database")
+	repo.table.Delete(func(row *models.EpisodesUsers) bool {
+		return row.GetIdUser() == id
+	})
+	return nil
+}
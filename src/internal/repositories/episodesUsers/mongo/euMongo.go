@@ -0,0 +1,180 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type EpisodesUsersRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewEpisodesUsersRepoMongo(client *mongo.Client, log *logrus.Logger) *EpisodesUsersRepoMongo {
+	db := client.Database("mydb")
+	return &EpisodesUsersRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *EpisodesUsersRepoMongo) GetEpisodesUsers(ctx context.Context) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes_users from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	episodesUsers := []*models.EpisodesUsers{}
+	for cursor.Next(ctx) {
+		var episodeUser models.EpisodesUsers
+		if err := cursor.Decode(&episodeUser); err != nil {
+			return nil, err
+		}
+		episodesUsers = append(episodesUsers, &episodeUser)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return episodesUsers, nil
+}
+
+func (repo *EpisodesUsersRepoMongo) GetEpisodesUsersById(ctx context.Context, id int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by id from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var episodeUser models.EpisodesUsers
+	err := collection.FindOne(ctx, bson.M{"eu_id": id}).Decode(&episodeUser)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoMongo) GetEpisodesBySerialIdUserId(ctx context.Context, serialId, userId int) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by serial id and user id from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"eu_iduser": userId, "eu_idserial": serialId})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	episodesUsers := []*models.EpisodesUsers{}
+	for cursor.Next(ctx) {
+		var episodeUser models.EpisodesUsers
+		if err := cursor.Decode(&episodeUser); err != nil {
+			return nil, err
+		}
+		episodesUsers = append(episodesUsers, &episodeUser)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return episodesUsers, nil
+}
+
+func (repo *EpisodesUsersRepoMongo) GetEpisodeUserByIds(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by episode id and user id from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var episodeUser models.EpisodesUsers
+	err := collection.FindOne(ctx, bson.M{"eu_idepisode": episodeId, "eu_iduser": userId}).Decode(&episodeUser)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoMongo) CreateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating episodes_users in the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "episodes_users", "eu_id")
+	if err != nil {
+		return err
+	}
+	episodeUser.SetId(id)
+
+	_, err = collection.InsertOne(ctx, episodeUser)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMongo) UpdateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating episodes_users in the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"eu_id": episodeUser.GetId()}, episodeUser)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMongo) DeleteEpisodesUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"eu_id": id})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repo *EpisodesUsersRepoMongo) DeleteEpisodesByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users by user id from the database")
+	collection := repo.db.Collection("episodes_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, bson.M{"eu_iduser": id})
+	if err != nil {
+		return err
+	}
+	return nil
+}
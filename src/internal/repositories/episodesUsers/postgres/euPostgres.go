@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type EpisodesUsersRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewEpisodesUsersRepoPostgres(db pgdb.Querier, log *logrus.Logger) *EpisodesUsersRepoPostgres {
+	return &EpisodesUsersRepoPostgres{db: db, log: log}
+}
+
+func (repo *EpisodesUsersRepoPostgres) GetEpisodesUsers(ctx context.Context) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all episodes_users from the database")
+	episodesUsers := []*models.EpisodesUsers{}
+	err := repo.db.SelectContext(ctx, &episodesUsers, "SELECT * FROM episodes_users")
+	if err != nil {
+		return nil, err
+	}
+	return episodesUsers, nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) GetEpisodesUsersById(ctx context.Context, id int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by id from the database")
+	episodeUser := &models.EpisodesUsers{}
+	err := repo.db.GetContext(ctx, episodeUser, "SELECT * FROM episodes_users WHERE eu_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) GetEpisodesBySerialIdUserId(ctx context.Context, serialId, userId int) ([]*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by serial id and user id from the database")
+	episodesUsers := []*models.EpisodesUsers{}
+	err := repo.db.SelectContext(ctx, &episodesUsers, "SELECT * FROM episodes_users WHERE eu_idUser=$1 AND eu_idSerial=$2", userId, serialId)
+	if err != nil {
+		return nil, err
+	}
+	return episodesUsers, nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) GetEpisodeUserByIds(ctx context.Context, episodeId, userId int) (*models.EpisodesUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting episodes_users by episode id and user id from the database")
+	episodeUser := &models.EpisodesUsers{}
+	err := repo.db.GetContext(ctx, episodeUser, "SELECT * FROM episodes_users WHERE eu_idEpisode=$1 AND eu_idUser=$2", episodeId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return episodeUser, nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) CreateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating episodes_users in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO episodes_users (eu_idEpisode, eu_idSerial, eu_idUser) VALUES ($1, $2, $3) RETURNING eu_id",
+		episodeUser.GetIdEpisode(), episodeUser.GetIdSerial(), episodeUser.GetIdUser()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	episodeUser.SetId(int(id))
+
+	return nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) UpdateEpisodesUsers(ctx context.Context, episodeUser *models.EpisodesUsers) error {
+	if !episodeUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating episodes_users in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE episodes_users SET eu_idEpisode=$1, eu_idSerial=$2, eu_idUser=$3 WHERE eu_id=$4",
+		episodeUser.GetIdEpisode(), episodeUser.GetIdSerial(), episodeUser.GetIdUser(), episodeUser.GetId())
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) DeleteEpisodesUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM episodes_users WHERE eu_id=$1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repo *EpisodesUsersRepoPostgres) DeleteEpisodesByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting episodes_users by user id from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM episodes_users WHERE eu_idUser=$1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	mem "app/internal/repositories/episodesUsers/memory"
+	mg "app/internal/repositories/episodesUsers/mongo"
+	pg "app/internal/repositories/episodesUsers/postgres"
+	"app/internal/repositories/memdb"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewEpisodesUsersRepo(db interface{}, log *logrus.Logger) interfaces.IRepoEpisodesUsers {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewEpisodesUsersRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewEpisodesUsersRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewEpisodesUsersRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewEpisodesUsersRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewEpisodesUsersRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
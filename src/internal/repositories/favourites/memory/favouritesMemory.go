@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+type FavouritesRepoMemory struct {
+	table *memdb.Table[models.Favourites, *models.Favourites]
+	log   *logrus.Logger
+}
+
+func NewFavouritesRepoMemory(db *memdb.DB, log *logrus.Logger) *FavouritesRepoMemory {
+	return &FavouritesRepoMemory{table: memdb.NewTable[models.Favourites](db, "favourites"), log: log}
+}
+
+func (repo *FavouritesRepoMemory) GetFavourites(ctx context.Context) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all favourites from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *FavouritesRepoMemory) GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourite by id from the database")
+	favourite, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return favourite, nil
+}
+
+// GetFavouritesByUserId returns the lists of the user in their order.
+func (repo *FavouritesRepoMemory) GetFavouritesByUserId(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourites by user id from the database")
+	favourites := repo.table.Select(func(row *models.Favourites) bool {
+		return row.GetIdUser() == idUser
+	})
+	sort.SliceStable(favourites, func(i, j int) bool {
+		return favourites[i].GetPosition() < favourites[j].GetPosition()
+	})
+	return favourites, nil
+}
+
+// GetFavouriteBySlug returns the list with the slug, lists without a slug are
+// never found.
+func (repo *FavouritesRepoMemory) GetFavouriteBySlug(ctx context.Context, slug string) (*models.Favourites, error) {
+	if slug == "" {
+		return nil, models.ErrNotFound
+	}
+	repo.log.WithContext(ctx).Info("Getting favourite by slug from the database")
+	favourite, ok := repo.table.First(func(row *models.Favourites) bool {
+		return row.GetSlug() == slug
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return favourite, nil
+}
+
+func (repo *FavouritesRepoMemory) CreateFavourite(ctx context.Context, favourite *models.Favourites) (int, error) {
+	if !favourite.Validate() {
+		return 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating favourite in the database")
+	repo.table.Insert(favourite)
+	return favourite.GetId(), nil
+}
+
+func (repo *FavouritesRepoMemory) UpdateFavourite(ctx context.Context, favourite *models.Favourites) error {
+	if !favourite.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating favourite in the database")
+	if !repo.table.Update(favourite) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *FavouritesRepoMemory) DeleteFavourite(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting favourite from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
@@ -2,30 +2,32 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type FavouritesRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewFavouritesRepoMongo(client *mongo.Client, log *logrus.Logger) *FavouritesRepoMongo {
 	db := client.Database("mydb")
-	return &FavouritesRepoMongo{db: db, log: log}
+	return &FavouritesRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *FavouritesRepoMongo) GetFavourites() ([]*models.Favourites, error) {
-	repo.log.Info("Getting all favourites from the database")
+func (repo *FavouritesRepoMongo) GetFavourites(ctx context.Context) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all favourites from the database")
 	collection := repo.db.Collection("favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -34,7 +36,7 @@ func (repo *FavouritesRepoMongo) GetFavourites() ([]*models.Favourites, error) {
 	}
 	defer cursor.Close(ctx)
 
-	var favourites []*models.Favourites
+	favourites := []*models.Favourites{}
 	for cursor.Next(ctx) {
 		var favourite models.Favourites
 		if err := cursor.Decode(&favourite); err != nil {
@@ -48,88 +50,125 @@ func (repo *FavouritesRepoMongo) GetFavourites() ([]*models.Favourites, error) {
 	return favourites, nil
 }
 
-func (repo *FavouritesRepoMongo) GetFavouriteById(id int) (*models.Favourites, error) {
-	repo.log.Info("Getting favourite by id from the database")
+func (repo *FavouritesRepoMongo) GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourite by id from the database")
 	collection := repo.db.Collection("favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
+	var favourite models.Favourites
+	err := collection.FindOne(ctx, bson.M{"f_id": id}).Decode(&favourite)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &favourite, nil
+}
+
+// GetFavouritesByUserId returns the lists of the user in their order.
+func (repo *FavouritesRepoMongo) GetFavouritesByUserId(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourites by user id from the database")
+	collection := repo.db.Collection("favourites")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "f_position", Value: 1}, {Key: "f_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"f_iduser": idUser}, opts)
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
+
+	favourites := []*models.Favourites{}
+	for cursor.Next(ctx) {
+		var favourite models.Favourites
+		if err := cursor.Decode(&favourite); err != nil {
+			return nil, err
+		}
+		favourites = append(favourites, &favourite)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return favourites, nil
+}
+
+// GetFavouriteBySlug returns the list with the slug, lists without a slug are
+// never found.
+func (repo *FavouritesRepoMongo) GetFavouriteBySlug(ctx context.Context, slug string) (*models.Favourites, error) {
+	if slug == "" {
+		return nil, models.ErrNotFound
+	}
+	repo.log.WithContext(ctx).Info("Getting favourite by slug from the database")
+	collection := repo.db.Collection("favourites")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
 	var favourite models.Favourites
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&favourite)
+	err := collection.FindOne(ctx, bson.M{"f_slug": slug}).Decode(&favourite)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &favourite, nil
 }
 
-func (repo *FavouritesRepoMongo) CreateFavourite(favourite *models.Favourites) (int, error) {
+func (repo *FavouritesRepoMongo) CreateFavourite(ctx context.Context, favourite *models.Favourites) (int, error) {
 	if !favourite.Validate() {
 		return 0, models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating favourite in the database")
+	repo.log.WithContext(ctx).Info("Creating favourite in the database")
 	collection := repo.db.Collection("favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"f_cntSerials": favourite.GetCntSerials(),
-	})
+	id, err := repo.counters.NextId(ctx, "favourites", "f_id")
+	if err != nil {
+		return 0, err
+	}
+	favourite.SetId(id)
+
+	_, err = collection.InsertOne(ctx, favourite)
 	if err != nil {
 		return 0, err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	favourite.SetId(res)
 
 	return favourite.GetId(), nil
 }
 
-func (repo *FavouritesRepoMongo) UpdateFavourite(favourite *models.Favourites) error {
+func (repo *FavouritesRepoMongo) UpdateFavourite(ctx context.Context, favourite *models.Favourites) error {
 	if !favourite.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating favourite in the database")
+	repo.log.WithContext(ctx).Info("Updating favourite in the database")
 	collection := repo.db.Collection("favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(favourite.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"f_id": favourite.GetId()}, favourite)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"f_cntSerials": favourite.GetCntSerials(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *FavouritesRepoMongo) DeleteFavourite(id int) error {
-	repo.log.Info("Deleting favourite from the database")
+func (repo *FavouritesRepoMongo) DeleteFavourite(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting favourite from the database")
 	collection := repo.db.Collection("favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"f_id": id})
 	if err != nil {
 		return err
 	}
@@ -2,49 +2,84 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type FavouritesRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewFavouritesRepoPostgres(db *sqlx.DB, log *logrus.Logger) *FavouritesRepoPostgres {
+func NewFavouritesRepoPostgres(db pgdb.Querier, log *logrus.Logger) *FavouritesRepoPostgres {
 	return &FavouritesRepoPostgres{db: db, log: log}
 }
 
-func (repo *FavouritesRepoPostgres) GetFavourites() ([]*models.Favourites, error) {
-	repo.log.Info("Getting all favourites from the database")
+func (repo *FavouritesRepoPostgres) GetFavourites(ctx context.Context) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all favourites from the database")
 	favourites := []*models.Favourites{}
-	err := repo.db.Select(&favourites, "SELECT * FROM favourites")
+	err := repo.db.SelectContext(ctx, &favourites, "SELECT * FROM favourites")
 	if err != nil {
 		return nil, err
 	}
 	return favourites, nil
 }
 
-func (repo *FavouritesRepoPostgres) GetFavouriteById(id int) (*models.Favourites, error) {
-	repo.log.Info("Getting favourite by id from the database")
+func (repo *FavouritesRepoPostgres) GetFavouriteById(ctx context.Context, id int) (*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourite by id from the database")
 	favourite := &models.Favourites{}
-	err := repo.db.Get(favourite, "SELECT * FROM favourites WHERE f_id=$1", id)
+	err := repo.db.GetContext(ctx, favourite, "SELECT * FROM favourites WHERE f_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return favourite, nil
 }
 
-func (repo *FavouritesRepoPostgres) CreateFavourite(favourite *models.Favourites) (int, error) {
+// GetFavouritesByUserId returns the lists of the user in their order.
+func (repo *FavouritesRepoPostgres) GetFavouritesByUserId(ctx context.Context, idUser int) ([]*models.Favourites, error) {
+	repo.log.WithContext(ctx).Info("Getting favourites by user id from the database")
+	favourites := []*models.Favourites{}
+	err := repo.db.SelectContext(ctx, &favourites, "SELECT * FROM favourites WHERE f_idUser=$1 ORDER BY f_position, f_id", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return favourites, nil
+}
+
+// GetFavouriteBySlug returns the list with the slug, lists without a slug are
+// never found.
+func (repo *FavouritesRepoPostgres) GetFavouriteBySlug(ctx context.Context, slug string) (*models.Favourites, error) {
+	if slug == "" {
+		return nil, models.ErrNotFound
+	}
+	repo.log.WithContext(ctx).Info("Getting favourite by slug from the database")
+	favourite := &models.Favourites{}
+	err := repo.db.GetContext(ctx, favourite, "SELECT * FROM favourites WHERE f_slug=$1", slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return favourite, nil
+}
+
+func (repo *FavouritesRepoPostgres) CreateFavourite(ctx context.Context, favourite *models.Favourites) (int, error) {
 	if !favourite.Validate() {
 		return -1, models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating favourite in the database")
-	err := repo.db.QueryRow("INSERT INTO favourites (f_cntSerials) VALUES ($1) RETURNING f_id",
-		favourite.GetCntSerials()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating favourite in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO favourites (f_idUser, f_name, f_note, f_position, f_cntSerials, f_visibility, f_slug) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING f_id",
+		favourite.GetIdUser(), favourite.GetName(), favourite.GetNote(), favourite.GetPosition(), favourite.GetCntSerials(), favourite.GetVisibility(), favourite.GetSlug()).Scan(&id)
 	if err != nil {
 		return -1, err
 	}
@@ -54,25 +89,32 @@ func (repo *FavouritesRepoPostgres) CreateFavourite(favourite *models.Favourites
 	return favourite.GetId(), nil
 }
 
-func (repo *FavouritesRepoPostgres) UpdateFavourite(favourite *models.Favourites) error {
+func (repo *FavouritesRepoPostgres) UpdateFavourite(ctx context.Context, favourite *models.Favourites) error {
 	if !favourite.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating favourite in the database")
-	_, err := repo.db.Exec("UPDATE favourites SET f_cntSerials=$1 WHERE f_id=$2",
-		favourite.GetCntSerials(), favourite.GetId())
+	repo.log.WithContext(ctx).Info("Updating favourite in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE favourites SET f_idUser=$1, f_name=$2, f_note=$3, f_position=$4, f_cntSerials=$5, f_visibility=$6, f_slug=$7 WHERE f_id=$8",
+		favourite.GetIdUser(), favourite.GetName(), favourite.GetNote(), favourite.GetPosition(), favourite.GetCntSerials(), favourite.GetVisibility(), favourite.GetSlug(), favourite.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *FavouritesRepoPostgres) DeleteFavourite(id int) error {
-	repo.log.Info("Deleting favourite from the database")
-	_, err := repo.db.Exec("DELETE FROM favourites WHERE f_id=$1", id)
+func (repo *FavouritesRepoPostgres) DeleteFavourite(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting favourite from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM favourites WHERE f_id=$1", id)
 	if err != nil {
 		return err
 	}
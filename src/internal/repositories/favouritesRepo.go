@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	mem "app/internal/repositories/favourites/memory"
 	mg "app/internal/repositories/favourites/mongo"
 	pg "app/internal/repositories/favourites/postgres"
+	"app/internal/repositories/memdb"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
@@ -14,8 +16,14 @@ func NewFavouritesRepo(db interface{}, log *logrus.Logger) interfaces.IRepoFavou
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewFavouritesRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewFavouritesRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewFavouritesRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewFavouritesRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewFavouritesRepoMemory(db, log)
 	default:
 		return nil
 	}
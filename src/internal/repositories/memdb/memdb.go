@@ -0,0 +1,210 @@
+package memdb
+
+import (
+	"sort"
+	"sync"
+)
+
+// DB is an in-memory database shared by the memory repositories. It keeps one
+// table per collection, every table holds copies of the stored models keyed by
+// their ids. DB is safe for concurrent use.
+type DB struct {
+	mu     sync.RWMutex
+	txMu   sync.Mutex
+	tables map[string]map[int]any
+	seq    map[string]int
+}
+
+func New() *DB {
+	return &DB{tables: map[string]map[int]any{}, seq: map[string]int{}}
+}
+
+// Tx runs fn and restores the tables to their state before the call if fn
+// returns an error or panics. Transactions are serialized with each other but
+// not with the calls made outside of them, whose writes during fn are lost on
+// rollback: Tx is only a best-effort equivalent of a database transaction.
+func (db *DB) Tx(fn func() error) error {
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	db.mu.RLock()
+	tables := make(map[string]map[int]any, len(db.tables))
+	for name, rows := range db.tables {
+		tables[name] = make(map[int]any, len(rows))
+		for id, row := range rows {
+			tables[name][id] = row
+		}
+	}
+	seq := make(map[string]int, len(db.seq))
+	for name, id := range db.seq {
+		seq[name] = id
+	}
+	db.mu.RUnlock()
+
+	committed := false
+	defer func() {
+		if !committed {
+			db.mu.Lock()
+			db.tables, db.seq = tables, seq
+			db.mu.Unlock()
+		}
+	}()
+	err := fn()
+	committed = err == nil
+	return err
+}
+
+// Model is the constraint satisfied by pointers to the models stored in a Table.
+type Model[T any] interface {
+	*T
+	GetId() int
+	SetId(id int)
+}
+
+// Table gives typed access to one table of the database.
+type Table[T any, P Model[T]] struct {
+	db   *DB
+	name string
+}
+
+func NewTable[T any, P Model[T]](db *DB, name string) *Table[T, P] {
+	return &Table[T, P]{db: db, name: name}
+}
+
+func (t *Table[T, P]) rows() map[int]any {
+	rows, ok := t.db.tables[t.name]
+	if !ok {
+		rows = map[int]any{}
+		t.db.tables[t.name] = rows
+	}
+	return rows
+}
+
+// Get returns a copy of the row with the given id.
+func (t *Table[T, P]) Get(id int) (P, bool) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	row, ok := t.db.tables[t.name][id]
+	if !ok {
+		return nil, false
+	}
+	v := row.(T)
+	return P(&v), true
+}
+
+// Select returns copies of the rows accepted by match ordered by id,
+// all rows if match is nil.
+func (t *Table[T, P]) Select(match func(P) bool) []P {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	res := []P{}
+	for _, row := range t.db.tables[t.name] {
+		v := row.(T)
+		if match == nil || match(P(&v)) {
+			res = append(res, P(&v))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].GetId() < res[j].GetId()
+	})
+	return res
+}
+
+// First returns a copy of the row with the smallest id accepted by match.
+func (t *Table[T, P]) First(match func(P) bool) (P, bool) {
+	rows := t.Select(match)
+	if len(rows) == 0 {
+		return nil, false
+	}
+	return rows[0], true
+}
+
+// FirstOrInsert returns a copy of the row with the smallest id,
+// inserting the model first if the table is empty.
+func (t *Table[T, P]) FirstOrInsert(model P) P {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	rows := t.rows()
+	if len(rows) == 0 {
+		t.db.seq[t.name]++
+		model.SetId(t.db.seq[t.name])
+		rows[model.GetId()] = *model
+	}
+	first := -1
+	for id := range rows {
+		if first == -1 || id < first {
+			first = id
+		}
+	}
+	v := rows[first].(T)
+	return P(&v)
+}
+
+// Insert assigns the next id of the table to the model and stores its copy.
+func (t *Table[T, P]) Insert(model P) {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	t.db.seq[t.name]++
+	model.SetId(t.db.seq[t.name])
+	t.rows()[model.GetId()] = *model
+}
+
+// Update replaces the stored row with the copy of the model.
+// It reports whether the row existed.
+func (t *Table[T, P]) Update(model P) bool {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	rows := t.rows()
+	if _, ok := rows[model.GetId()]; !ok {
+		return false
+	}
+	rows[model.GetId()] = *model
+	return true
+}
+
+// Delete removes the rows accepted by match and returns their number.
+func (t *Table[T, P]) Delete(match func(P) bool) int {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	rows := t.rows()
+	cnt := 0
+	for id, row := range rows {
+		v := row.(T)
+		if match(P(&v)) {
+			delete(rows, id)
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// DeleteById removes the row with the given id and reports whether it existed.
+func (t *Table[T, P]) DeleteById(id int) bool {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	rows := t.rows()
+	if _, ok := rows[id]; !ok {
+		return false
+	}
+	delete(rows, id)
+	return true
+}
+
+// Paginate returns the limit rows following the first offset ones, none for
+// a negative offset or limit.
+func Paginate[P any](rows []P, offset, limit int) []P {
+	if offset < 0 || limit < 0 || offset >= len(rows) {
+		return rows[:0]
+	}
+	if limit > len(rows)-offset {
+		return rows[offset:]
+	}
+	return rows[offset : offset+limit]
+}
@@ -0,0 +1,38 @@
+package mgdb
+
+import (
+	"app/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindPage returns the page of the documents matching filter ordered by sort
+// and the number of all the matching documents.
+func FindPage[T any](ctx context.Context, collection *mongo.Collection, filter interface{}, sort bson.D, page models.Page) ([]*T, int, error) {
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	opts := options.Find().SetSort(sort).SetSkip(int64(page.Offset())).SetLimit(int64(page.Limit()))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	res := []*T{}
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		res = append(res, &doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+	return res, int(total), nil
+}
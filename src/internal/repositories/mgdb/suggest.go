@@ -0,0 +1,66 @@
+package mgdb
+
+import (
+	"app/internal/models"
+	"context"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SuggestFilter returns the filter of the documents with the keys of their name
+// matching the query, see models.SuggestKeys. A collection stores the keys in
+// the fields <prefix>_keys and <prefix>_tails indexed by SetupSuggestKeys.
+// Every pattern is anchored at the start of a key, so the index bounds the
+// keys read by the literal start of the pattern.
+func SuggestFilter(query *models.SuggestQuery, prefix string) bson.M {
+	heads, tails := query.KeyVariants(regexp.QuoteMeta, ".")
+	anchored := func(patterns []string) bson.A {
+		res := bson.A{}
+		for _, pattern := range patterns {
+			res = append(res, primitive.Regex{Pattern: "^" + pattern})
+		}
+		return res
+	}
+	or := bson.A{bson.M{prefix + "_keys": bson.M{"$in": anchored(heads)}}}
+	if len(tails) > 0 {
+		or = append(or, bson.M{prefix + "_tails": bson.M{"$in": anchored(tails)}})
+	}
+	return bson.M{"$or": or}
+}
+
+// SetupSuggestKeys indexes the keys of the collection with the fields of the
+// prefix and fills them for the documents stored without them, their name is
+// the text of the fields joined by spaces.
+func SetupSuggestKeys(ctx context.Context, collection *mongo.Collection, prefix string, fields ...string) error {
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: prefix + "_keys", Value: 1}}},
+		{Keys: bson.D{{Key: prefix + "_tails", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{prefix + "_keys": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		parts := []string{}
+		for _, field := range fields {
+			part, _ := cursor.Current.Lookup(field).StringValueOK()
+			parts = append(parts, part)
+		}
+		keys, tails := models.SuggestKeys(strings.Join(parts, " "))
+		_, err = collection.UpdateByID(ctx, cursor.Current.Lookup("_id"),
+			bson.M{"$set": bson.M{prefix + "_keys": keys, prefix + "_tails": tails}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ModerationsRepoMemory struct {
+	table *memdb.Table[models.Moderations, *models.Moderations]
+	log   *logrus.Logger
+}
+
+func NewModerationsRepoMemory(db *memdb.DB, log *logrus.Logger) *ModerationsRepoMemory {
+	return &ModerationsRepoMemory{table: memdb.NewTable[models.Moderations](db, "moderations"), log: log}
+}
+
+func (repo *ModerationsRepoMemory) GetModerations(ctx context.Context) ([]*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting all moderations from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *ModerationsRepoMemory) GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of moderations from the database")
+	moderations := repo.table.Select(nil)
+	sort.Slice(moderations, func(i, j int) bool {
+		return moderations[i].GetId() > moderations[j].GetId()
+	})
+	return memdb.Paginate(moderations, page.Offset(), page.Limit()), len(moderations), nil
+}
+
+func (repo *ModerationsRepoMemory) GetModerationById(ctx context.Context, id int) (*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting moderation by id from the database")
+	moderation, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return moderation, nil
+}
+
+func (repo *ModerationsRepoMemory) CreateModeration(ctx context.Context, moderation *models.Moderations) error {
+	if !moderation.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating moderation in the database")
+	repo.table.Insert(moderation)
+	return nil
+}
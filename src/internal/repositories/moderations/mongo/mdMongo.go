@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ModerationsRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewModerationsRepoMongo(client *mongo.Client, log *logrus.Logger) *ModerationsRepoMongo {
+	db := client.Database("mydb")
+	return &ModerationsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *ModerationsRepoMongo) GetModerations(ctx context.Context) ([]*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting all moderations from the database")
+	moderations := []*models.Moderations{}
+	collection := repo.db.Collection("moderations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var moderation models.Moderations
+		if err = cursor.Decode(&moderation); err != nil {
+			return nil, err
+		}
+		moderations = append(moderations, &moderation)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return moderations, nil
+}
+
+func (repo *ModerationsRepoMongo) GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of moderations from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return mgdb.FindPage[models.Moderations](ctx, repo.db.Collection("moderations"), bson.M{}, bson.D{{Key: "md_id", Value: -1}}, page)
+}
+
+func (repo *ModerationsRepoMongo) GetModerationById(ctx context.Context, id int) (*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting moderation by id from the database")
+	moderation := &models.Moderations{}
+	collection := repo.db.Collection("moderations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"md_id": id}).Decode(moderation)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return moderation, nil
+}
+
+func (repo *ModerationsRepoMongo) CreateModeration(ctx context.Context, moderation *models.Moderations) error {
+	if !moderation.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating moderation in the database")
+	collection := repo.db.Collection("moderations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "moderations", "md_id")
+	if err != nil {
+		return err
+	}
+	moderation.SetId(id)
+
+	_, err = collection.InsertOne(ctx, moderation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
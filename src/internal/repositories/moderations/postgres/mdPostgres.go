@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ModerationsRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewModerationsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *ModerationsRepoPostgres {
+	return &ModerationsRepoPostgres{db: db, log: log}
+}
+
+func (repo *ModerationsRepoPostgres) GetModerations(ctx context.Context) ([]*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting all moderations from the database")
+	moderations := []*models.Moderations{}
+	err := repo.db.SelectContext(ctx, &moderations, "SELECT * FROM moderations")
+	if err != nil {
+		return nil, err
+	}
+	return moderations, nil
+}
+
+func (repo *ModerationsRepoPostgres) GetModerationsPage(ctx context.Context, page models.Page) ([]*models.Moderations, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of moderations from the database")
+	moderations := []*models.Moderations{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &moderations, "moderations", "md_id DESC", page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return moderations, total, nil
+}
+
+func (repo *ModerationsRepoPostgres) GetModerationById(ctx context.Context, id int) (*models.Moderations, error) {
+	repo.log.WithContext(ctx).Info("Getting moderation by id from the database")
+	moderation := &models.Moderations{}
+	err := repo.db.GetContext(ctx, moderation, "SELECT * FROM moderations WHERE md_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return moderation, nil
+}
+
+func (repo *ModerationsRepoPostgres) CreateModeration(ctx context.Context, moderation *models.Moderations) error {
+	if !moderation.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating moderation in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO moderations (md_idComment, md_idModerator, md_action, md_text, md_date) VALUES ($1, $2, $3, $4, $5) RETURNING md_id",
+		moderation.GetIdComment(), moderation.GetIdModerator(), moderation.GetAction(), moderation.GetText(), moderation.GetDate()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	moderation.SetId(int(id))
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/moderations/memory"
+	mg "app/internal/repositories/moderations/mongo"
+	pg "app/internal/repositories/moderations/postgres"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewModerationsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoModerations {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewModerationsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewModerationsRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewModerationsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewModerationsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewModerationsRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	actors "app/internal/repositories/actors/mongo"
+	producers "app/internal/repositories/producers/mongo"
+	serials "app/internal/repositories/serials/mongo"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SetupMongo prepares the Mongo database once at startup: it creates the
+// indexes the repositories query by and fills the fields added to the stored
+// documents since they were written. It is safe to run it again.
+func SetupMongo(ctx context.Context, client *mongo.Client, log *logrus.Logger) error {
+	log.WithContext(ctx).Info("Setting up the Mongo database")
+	err := serials.NewSerialsRepoMongo(client, log).Setup(ctx)
+	if err != nil {
+		return err
+	}
+	err = actors.NewActorsRepoMongo(client, log).Setup(ctx)
+	if err != nil {
+		return err
+	}
+	return producers.NewProducersRepoMongo(client, log).Setup(ctx)
+}
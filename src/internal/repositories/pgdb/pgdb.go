@@ -0,0 +1,48 @@
+package pgdb
+
+import (
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Querier is the part of *sqlx.DB used by the postgres repositories. It is
+// implemented by *sqlx.Tx as well, so the same repositories work inside a
+// transaction.
+type Querier interface {
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the wildcards of LIKE in s.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Contains returns the LIKE pattern matching the strings that contain s.
+func Contains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// SelectPage selects the page of the rows of "SELECT * FROM <from>" ordered by
+// order into dest and returns the number of all the rows. from is the table
+// with an optional WHERE clause, args are its parameters.
+func SelectPage(ctx context.Context, db Querier, dest interface{}, from string, order string, page models.Page, args ...interface{}) (int, error) {
+	var total int
+	err := db.GetContext(ctx, &total, "SELECT COUNT(*) FROM "+from, args...)
+	if err != nil {
+		return 0, err
+	}
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY %s LIMIT $%d OFFSET $%d", from, order, len(args)+1, len(args)+2)
+	err = db.SelectContext(ctx, dest, query, append(args, page.Limit(), page.Offset())...)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
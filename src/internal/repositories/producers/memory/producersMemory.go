@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ProducersRepoMemory struct {
+	table *memdb.Table[models.Producers, *models.Producers]
+	log   *logrus.Logger
+}
+
+func NewProducersRepoMemory(db *memdb.DB, log *logrus.Logger) *ProducersRepoMemory {
+	return &ProducersRepoMemory{table: memdb.NewTable[models.Producers](db, "producers"), log: log}
+}
+
+func (repo *ProducersRepoMemory) GetProducers(ctx context.Context) ([]*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting all producers from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *ProducersRepoMemory) GetProducerById(ctx context.Context, id int) (*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting producer by id from the database")
+	producer, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return producer, nil
+}
+
+func (repo *ProducersRepoMemory) CreateProducer(ctx context.Context, producer *models.Producers) error {
+	if !producer.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating producer in the database")
+	repo.table.Insert(producer)
+	return nil
+}
+
+func (repo *ProducersRepoMemory) UpdateProducer(ctx context.Context, producer *models.Producers) error {
+	if !producer.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating producer in the database")
+	if !repo.table.Update(producer) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *ProducersRepoMemory) DeleteProducer(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting producer from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
@@ -2,30 +2,53 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ProducersRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewProducersRepoMongo(client *mongo.Client, log *logrus.Logger) *ProducersRepoMongo {
 	db := client.Database("mydb")
-	return &ProducersRepoMongo{db: db, log: log}
+	return &ProducersRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *ProducersRepoMongo) GetProducers() ([]*models.Producers, error) {
-	repo.log.Info("Getting all producers from the database")
+// producerDocument is a producer as it is stored, P_keys and P_tails are
+// the keys of the full name for the suggestions, see mgdb.SuggestFilter.
+type producerDocument struct {
+	models.Producers `bson:",inline"`
+	P_keys           []string `bson:"p_keys"`
+	P_tails          []string `bson:"p_tails"`
+}
+
+func newProducerDocument(producer *models.Producers) *producerDocument {
+	doc := &producerDocument{Producers: *producer}
+	doc.P_keys, doc.P_tails = models.SuggestKeys(producer.GetName() + " " + producer.GetSurname())
+	return doc
+}
+
+// Setup indexes the keys of the full names of the producers and fills them
+// for the producers stored without them. It is run once at startup, see
+// repositories.SetupMongo.
+func (repo *ProducersRepoMongo) Setup(ctx context.Context) error {
+	return mgdb.SetupSuggestKeys(ctx, repo.db.Collection("producers"), "p", "p_name", "p_surname")
+}
+
+func (repo *ProducersRepoMongo) GetProducers(ctx context.Context) ([]*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting all producers from the database")
 	collection := repo.db.Collection("producers")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -34,7 +57,7 @@ func (repo *ProducersRepoMongo) GetProducers() ([]*models.Producers, error) {
 	}
 	defer cursor.Close(ctx)
 
-	var producers []*models.Producers
+	producers := []*models.Producers{}
 	for cursor.Next(ctx) {
 		var producer models.Producers
 		if err := cursor.Decode(&producer); err != nil {
@@ -48,90 +71,75 @@ func (repo *ProducersRepoMongo) GetProducers() ([]*models.Producers, error) {
 	return producers, nil
 }
 
-func (repo *ProducersRepoMongo) GetProducerById(id int) (*models.Producers, error) {
-	repo.log.Info("Getting producer by id from the database")
+func (repo *ProducersRepoMongo) GetProducerById(ctx context.Context, id int) (*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting producer by id from the database")
 	collection := repo.db.Collection("producers")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
 	var producer models.Producers
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&producer)
+	err := collection.FindOne(ctx, bson.M{"p_id": id}).Decode(&producer)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &producer, nil
 }
 
-func (repo *ProducersRepoMongo) CreateProducer(producer *models.Producers) error {
+func (repo *ProducersRepoMongo) CreateProducer(ctx context.Context, producer *models.Producers) error {
 	if !producer.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating producer in the database")
+	repo.log.WithContext(ctx).Info("Creating producer in the database")
 	collection := repo.db.Collection("producers")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"p_name":    producer.GetName(),
-		"p_surname": producer.GetSurname(),
-	})
+	id, err := repo.counters.NextId(ctx, "producers", "p_id")
+	if err != nil {
+		return err
+	}
+	producer.SetId(id)
+
+	_, err = collection.InsertOne(ctx, newProducerDocument(producer))
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	producer.SetId(res)
 
 	return nil
 }
 
-func (repo *ProducersRepoMongo) UpdateProducer(producer *models.Producers) error {
+func (repo *ProducersRepoMongo) UpdateProducer(ctx context.Context, producer *models.Producers) error {
 	if !producer.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating producer in the database")
+	repo.log.WithContext(ctx).Info("Updating producer in the database")
 	collection := repo.db.Collection("producers")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(producer.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"p_id": producer.GetId()}, newProducerDocument(producer))
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"p_name":    producer.GetName(),
-			"p_surname": producer.GetSurname(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *ProducersRepoMongo) DeleteProducer(id int) error {
-	repo.log.Info("Deleting producer from the database")
+func (repo *ProducersRepoMongo) DeleteProducer(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting producer from the database")
 	collection := repo.db.Collection("producers")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"p_id": id})
 	if err != nil {
 		return err
 	}
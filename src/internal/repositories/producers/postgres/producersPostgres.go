@@ -2,48 +2,54 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type ProducersRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewProducersRepoPostgres(db *sqlx.DB, log *logrus.Logger) *ProducersRepoPostgres {
+func NewProducersRepoPostgres(db pgdb.Querier, log *logrus.Logger) *ProducersRepoPostgres {
 	return &ProducersRepoPostgres{db: db, log: log}
 }
 
-func (repo *ProducersRepoPostgres) GetProducers() ([]*models.Producers, error) {
-	repo.log.Info("Getting all producers from the database")
+func (repo *ProducersRepoPostgres) GetProducers(ctx context.Context) ([]*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting all producers from the database")
 	producers := []*models.Producers{}
-	err := repo.db.Select(&producers, "SELECT * FROM producers")
+	err := repo.db.SelectContext(ctx, &producers, "SELECT * FROM producers")
 	if err != nil {
 		return nil, err
 	}
 	return producers, nil
 }
 
-func (repo *ProducersRepoPostgres) GetProducerById(id int) (*models.Producers, error) {
-	repo.log.Info("Getting producer by id from the database")
+func (repo *ProducersRepoPostgres) GetProducerById(ctx context.Context, id int) (*models.Producers, error) {
+	repo.log.WithContext(ctx).Info("Getting producer by id from the database")
 	producer := &models.Producers{}
-	err := repo.db.Get(producer, "SELECT * FROM producers WHERE p_id=$1", id)
+	err := repo.db.GetContext(ctx, producer, "SELECT * FROM producers WHERE p_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return producer, nil
 }
 
-func (repo *ProducersRepoPostgres) CreateProducer(producer *models.Producers) error {
+func (repo *ProducersRepoPostgres) CreateProducer(ctx context.Context, producer *models.Producers) error {
 	if !producer.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating producer in the database")
-	err := repo.db.QueryRow("INSERT INTO producers (p_name, p_surname) VALUES ($1, $2) RETURNING p_id",
+	repo.log.WithContext(ctx).Info("Creating producer in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO producers (p_name, p_surname) VALUES ($1, $2) RETURNING p_id",
 		producer.GetName(), producer.GetSurname()).Scan(&id)
 	if err != nil {
 		return err
@@ -53,25 +59,32 @@ func (repo *ProducersRepoPostgres) CreateProducer(producer *models.Producers) er
 	return nil
 }
 
-func (repo *ProducersRepoPostgres) UpdateProducer(producer *models.Producers) error {
+func (repo *ProducersRepoPostgres) UpdateProducer(ctx context.Context, producer *models.Producers) error {
 	if !producer.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating producer in the database")
-	_, err := repo.db.Exec("UPDATE producers SET p_name=$1, p_surname=$2 WHERE p_id=$3",
+	repo.log.WithContext(ctx).Info("Updating producer in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE producers SET p_name=$1, p_surname=$2 WHERE p_id=$3",
 		producer.GetName(), producer.GetSurname(), producer.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *ProducersRepoPostgres) DeleteProducer(id int) error {
-	repo.log.Info("Deleting producer from the database")
-	_, err := repo.db.Exec("DELETE FROM producers WHERE p_id=$1", id)
+func (repo *ProducersRepoPostgres) DeleteProducer(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting producer from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM producers WHERE p_id=$1", id)
 	if err != nil {
 		return err
 	}
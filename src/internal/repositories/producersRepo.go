@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/producers/memory"
 	mg "app/internal/repositories/producers/mongo"
 	pg "app/internal/repositories/producers/postgres"
 
@@ -14,8 +16,14 @@ func NewProducersRepo(db interface{}, log *logrus.Logger) interfaces.IRepoProduc
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewProducersRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewProducersRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewProducersRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewProducersRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewProducersRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RatingsRepoMemory struct {
+	table *memdb.Table[models.Ratings, *models.Ratings]
+	log   *logrus.Logger
+}
+
+func NewRatingsRepoMemory(db *memdb.DB, log *logrus.Logger) *RatingsRepoMemory {
+	return &RatingsRepoMemory{table: memdb.NewTable[models.Ratings](db, "ratings"), log: log}
+}
+
+func (repo *RatingsRepoMemory) GetRatings(ctx context.Context) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting all ratings from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *RatingsRepoMemory) GetRatingById(ctx context.Context, id int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by id from the database")
+	rating, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoMemory) GetRatingsByUserId(ctx context.Context, idUser int) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting ratings by user id from the database")
+	return repo.table.Select(func(row *models.Ratings) bool {
+		return row.GetIdUser() == idUser
+	}), nil
+}
+
+func (repo *RatingsRepoMemory) GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by serial id and user id from the database")
+	rating, ok := repo.table.First(func(row *models.Ratings) bool {
+		return row.GetIdSerial() == idSerial && row.GetIdUser() == idUser
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoMemory) SumRatings(ctx context.Context, idSerial int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Summing ratings of serial in the database")
+	ratings := repo.table.Select(func(row *models.Ratings) bool {
+		return row.GetIdSerial() == idSerial
+	})
+	sum := 0
+	for _, rating := range ratings {
+		sum += rating.GetScore()
+	}
+	return sum, len(ratings), nil
+}
+
+func (repo *RatingsRepoMemory) CreateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating rating in the database")
+	repo.table.Insert(rating)
+	return nil
+}
+
+func (repo *RatingsRepoMemory) UpdateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating rating in the database")
+	if !repo.table.Update(rating) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *RatingsRepoMemory) DeleteRating(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting rating from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
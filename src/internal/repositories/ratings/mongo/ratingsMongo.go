@@ -0,0 +1,196 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RatingsRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewRatingsRepoMongo(client *mongo.Client, log *logrus.Logger) *RatingsRepoMongo {
+	db := client.Database("mydb")
+	return &RatingsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *RatingsRepoMongo) GetRatings(ctx context.Context) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting all ratings from the database")
+	ratings := []*models.Ratings{}
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var rating models.Ratings
+		if err = cursor.Decode(&rating); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, &rating)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (repo *RatingsRepoMongo) GetRatingById(ctx context.Context, id int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by id from the database")
+	rating := &models.Ratings{}
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"r_id": id}).Decode(rating)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoMongo) GetRatingsByUserId(ctx context.Context, idUser int) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting ratings by user from the database")
+	ratings := []*models.Ratings{}
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"r_iduser": idUser})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var rating models.Ratings
+		if err = cursor.Decode(&rating); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, &rating)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (repo *RatingsRepoMongo) GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by serial and user from the database")
+	rating := &models.Ratings{}
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"r_idserial": idSerial, "r_iduser": idUser}).Decode(rating)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoMongo) SumRatings(ctx context.Context, idSerial int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Summing ratings of serial in the database")
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"r_idserial": idSerial}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "sum": bson.M{"$sum": "$r_score"}, "votes": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	var sums []struct {
+		Sum   int `bson:"sum"`
+		Votes int `bson:"votes"`
+	}
+	err = cursor.All(ctx, &sums)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(sums) == 0 {
+		return 0, 0, nil
+	}
+	return sums[0].Sum, sums[0].Votes, nil
+}
+
+func (repo *RatingsRepoMongo) CreateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating rating in the database")
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "ratings", "r_id")
+	if err != nil {
+		return err
+	}
+	rating.SetId(id)
+
+	_, err = collection.InsertOne(ctx, rating)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *RatingsRepoMongo) UpdateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating rating in the database")
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"r_id": rating.GetId()}, rating)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *RatingsRepoMongo) DeleteRating(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting rating from the database")
+	collection := repo.db.Collection("ratings")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"r_id": id})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
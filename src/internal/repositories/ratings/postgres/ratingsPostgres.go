@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RatingsRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewRatingsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *RatingsRepoPostgres {
+	return &RatingsRepoPostgres{db: db, log: log}
+}
+
+func (repo *RatingsRepoPostgres) GetRatings(ctx context.Context) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting all ratings from the database")
+	ratings := []*models.Ratings{}
+	err := repo.db.SelectContext(ctx, &ratings, "SELECT * FROM ratings")
+	if err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (repo *RatingsRepoPostgres) GetRatingById(ctx context.Context, id int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by id from the database")
+	rating := &models.Ratings{}
+	err := repo.db.GetContext(ctx, rating, "SELECT * FROM ratings WHERE r_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoPostgres) GetRatingsByUserId(ctx context.Context, idUser int) ([]*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting ratings by user from the database")
+	ratings := []*models.Ratings{}
+	err := repo.db.SelectContext(ctx, &ratings, "SELECT * FROM ratings WHERE r_idUser=$1", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (repo *RatingsRepoPostgres) GetRatingBySerialIdUserId(ctx context.Context, idSerial, idUser int) (*models.Ratings, error) {
+	repo.log.WithContext(ctx).Info("Getting rating by serial and user from the database")
+	rating := &models.Ratings{}
+	err := repo.db.GetContext(ctx, rating, "SELECT * FROM ratings WHERE r_idSerial=$1 AND r_idUser=$2", idSerial, idUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (repo *RatingsRepoPostgres) SumRatings(ctx context.Context, idSerial int) (int, int, error) {
+	repo.log.WithContext(ctx).Info("Summing ratings of serial in the database")
+	var sum struct {
+		Sum   int `db:"sum"`
+		Votes int `db:"votes"`
+	}
+	err := repo.db.GetContext(ctx, &sum, "SELECT COALESCE(SUM(r_score), 0) AS sum, COUNT(*) AS votes FROM ratings WHERE r_idSerial=$1", idSerial)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sum.Sum, sum.Votes, nil
+}
+
+func (repo *RatingsRepoPostgres) CreateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating rating in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO ratings (r_idUser, r_idSerial, r_score) VALUES ($1, $2, $3) RETURNING r_id",
+		rating.GetIdUser(), rating.GetIdSerial(), rating.GetScore()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	rating.SetId(int(id))
+
+	return nil
+}
+
+func (repo *RatingsRepoPostgres) UpdateRating(ctx context.Context, rating *models.Ratings) error {
+	if !rating.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating rating in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE ratings SET r_idUser=$1, r_idSerial=$2, r_score=$3 WHERE r_id=$4",
+		rating.GetIdUser(), rating.GetIdSerial(), rating.GetScore(), rating.GetId())
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *RatingsRepoPostgres) DeleteRating(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting rating from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM ratings WHERE r_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/ratings/memory"
+	mg "app/internal/repositories/ratings/mongo"
+	pg "app/internal/repositories/ratings/postgres"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewRatingsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoRatings {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewRatingsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewRatingsRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewRatingsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewRatingsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewRatingsRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RecommendationsRepoMemory struct {
+	table *memdb.Table[models.Recommendations, *models.Recommendations]
+	log   *logrus.Logger
+}
+
+func NewRecommendationsRepoMemory(db *memdb.DB, log *logrus.Logger) *RecommendationsRepoMemory {
+	return &RecommendationsRepoMemory{table: memdb.NewTable[models.Recommendations](db, "recommendations"), log: log}
+}
+
+func (repo *RecommendationsRepoMemory) GetRecommendationsByUserId(ctx context.Context, idUser int) ([]*models.Recommendations, error) {
+	repo.log.WithContext(ctx).Info("Getting recommendations by user id from the database")
+	recommendations := repo.table.Select(func(row *models.Recommendations) bool {
+		return row.GetIdUser() == idUser
+	})
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].GetPosition() < recommendations[j].GetPosition()
+	})
+	return recommendations, nil
+}
+
+func (repo *RecommendationsRepoMemory) CreateRecommendation(ctx context.Context, recommendation *models.Recommendations) error {
+	if !recommendation.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating recommendation in the database")
+	repo.table.Insert(recommendation)
+	return nil
+}
+
+func (repo *RecommendationsRepoMemory) DeleteRecommendationsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting recommendations by user id from the database")
+	repo.table.Delete(func(row *models.Recommendations) bool {
+		return row.GetIdUser() == idUser
+	})
+	return nil
+}
@@ -0,0 +1,88 @@
+package mongo
+
+import (
+	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecommendationsRepoMongo struct {
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
+}
+
+func NewRecommendationsRepoMongo(client *mongo.Client, log *logrus.Logger) *RecommendationsRepoMongo {
+	db := client.Database("mydb")
+	return &RecommendationsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
+}
+
+func (repo *RecommendationsRepoMongo) GetRecommendationsByUserId(ctx context.Context, idUser int) ([]*models.Recommendations, error) {
+	repo.log.WithContext(ctx).Info("Getting recommendations by user id from the database")
+	collection := repo.db.Collection("recommendations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "rc_position", Value: 1}, {Key: "rc_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"rc_iduser": idUser}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	recommendations := []*models.Recommendations{}
+	for cursor.Next(ctx) {
+		var recommendation models.Recommendations
+		if err := cursor.Decode(&recommendation); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, &recommendation)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+func (repo *RecommendationsRepoMongo) CreateRecommendation(ctx context.Context, recommendation *models.Recommendations) error {
+	if !recommendation.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating recommendation in the database")
+	collection := repo.db.Collection("recommendations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	id, err := repo.counters.NextId(ctx, "recommendations", "rc_id")
+	if err != nil {
+		return err
+	}
+	recommendation.SetId(id)
+
+	_, err = collection.InsertOne(ctx, recommendation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *RecommendationsRepoMongo) DeleteRecommendationsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting recommendations by user id from the database")
+	collection := repo.db.Collection("recommendations")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, bson.M{"rc_iduser": idUser})
+	if err != nil {
+		return err
+	}
+	return nil
+}
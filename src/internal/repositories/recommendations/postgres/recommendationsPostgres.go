@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RecommendationsRepoPostgres struct {
+	db  pgdb.Querier
+	log *logrus.Logger
+}
+
+func NewRecommendationsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *RecommendationsRepoPostgres {
+	return &RecommendationsRepoPostgres{db: db, log: log}
+}
+
+func (repo *RecommendationsRepoPostgres) GetRecommendationsByUserId(ctx context.Context, idUser int) ([]*models.Recommendations, error) {
+	repo.log.WithContext(ctx).Info("Getting recommendations by user id from the database")
+	recommendations := []*models.Recommendations{}
+	err := repo.db.SelectContext(ctx, &recommendations, "SELECT * FROM recommendations WHERE rc_idUser=$1 ORDER BY rc_position, rc_id", idUser)
+	if err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+func (repo *RecommendationsRepoPostgres) CreateRecommendation(ctx context.Context, recommendation *models.Recommendations) error {
+	if !recommendation.Validate() {
+		return models.ErrInvalidModel
+	}
+	var id int64
+
+	repo.log.WithContext(ctx).Info("Creating recommendation in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO recommendations (rc_idUser, rc_idSerial, rc_score, rc_source, rc_position) VALUES ($1, $2, $3, $4, $5) RETURNING rc_id",
+		recommendation.GetIdUser(), recommendation.GetIdSerial(), recommendation.GetScore(), recommendation.GetSource(), recommendation.GetPosition()).Scan(&id)
+	if err != nil {
+		return err
+	}
+	recommendation.SetId(int(id))
+
+	return nil
+}
+
+func (repo *RecommendationsRepoPostgres) DeleteRecommendationsByUserId(ctx context.Context, idUser int) error {
+	repo.log.WithContext(ctx).Info("Deleting recommendations by user id from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM recommendations WHERE rc_idUser=$1", idUser)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/recommendations/memory"
+	mg "app/internal/repositories/recommendations/mongo"
+	pg "app/internal/repositories/recommendations/postgres"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewRecommendationsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoRecommendations {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return pg.NewRecommendationsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewRecommendationsRepoPostgres(db, log)
+	case *mongo.Client:
+		return mg.NewRecommendationsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewRecommendationsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewRecommendationsRepoMemory(db, log)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SeasonsRepoMemory struct {
+	table *memdb.Table[models.Seasons, *models.Seasons]
+	log   *logrus.Logger
+}
+
+func NewSeasonsRepoMemory(db *memdb.DB, log *logrus.Logger) *SeasonsRepoMemory {
+	return &SeasonsRepoMemory{table: memdb.NewTable[models.Seasons](db, "seasons"), log: log}
+}
+
+func (repo *SeasonsRepoMemory) GetSeasons(ctx context.Context) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting all seasons from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *SeasonsRepoMemory) GetSeasonById(ctx context.Context, id int) (*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting season by id from the database")
+	season, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return season, nil
+}
+
+func (repo *SeasonsRepoMemory) GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting seasons by serial id from the database")
+	return repo.table.Select(func(row *models.Seasons) bool {
+		return row.GetIdSerial() == id
+	}), nil
+}
+
+func (repo *SeasonsRepoMemory) CreateSeason(ctx context.Context, season *models.Seasons) error {
+	if !season.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating season in the database")
+	repo.table.Insert(season)
+	return nil
+}
+
+func (repo *SeasonsRepoMemory) UpdateSeason(ctx context.Context, season *models.Seasons) error {
+	if !season.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating season in the database")
+	if !repo.table.Update(season) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *SeasonsRepoMemory) DeleteSeason(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting season from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
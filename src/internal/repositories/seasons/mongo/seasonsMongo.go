@@ -2,29 +2,33 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SeasonsRepo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewSeasonsRepoMongo(client *mongo.Client, log *logrus.Logger) *SeasonsRepo {
 	db := client.Database("mydb")
-	return &SeasonsRepo{db: db, log: log}
+	return &SeasonsRepo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
 func (repo *SeasonsRepo) FormatDate(season *models.Seasons) {
 	date := season.GetDate()
-	d1, _ := time.Parse("2006-01-02T00:00:00Z", date)
+	d1, err := time.Parse("2006-01-02T00:00:00Z", date)
+	if err != nil {
+		return
+	}
 	d2 := d1.Format("02.01.2006")
 	season.SetDate(d2)
 }
@@ -35,10 +39,10 @@ func (repo *SeasonsRepo) FormatDateList(seasons []*models.Seasons) {
 	}
 }
 
-func (repo *SeasonsRepo) GetSeasons() ([]*models.Seasons, error) {
-	repo.log.Info("Getting all seasons from the database")
+func (repo *SeasonsRepo) GetSeasons(ctx context.Context) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting all seasons from the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -47,7 +51,7 @@ func (repo *SeasonsRepo) GetSeasons() ([]*models.Seasons, error) {
 	}
 	defer cursor.Close(ctx)
 
-	var seasons []*models.Seasons
+	seasons := []*models.Seasons{}
 	for cursor.Next(ctx) {
 		var season models.Seasons
 		if err := cursor.Decode(&season); err != nil {
@@ -62,19 +66,17 @@ func (repo *SeasonsRepo) GetSeasons() ([]*models.Seasons, error) {
 	return seasons, nil
 }
 
-func (repo *SeasonsRepo) GetSeasonById(id int) (*models.Seasons, error) {
-	repo.log.Info("Getting season by id from the database")
+func (repo *SeasonsRepo) GetSeasonById(ctx context.Context, id int) (*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting season by id from the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
 	var season models.Seasons
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&season)
+	err := collection.FindOne(ctx, bson.M{"ss_id": id}).Decode(&season)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -82,24 +84,19 @@ func (repo *SeasonsRepo) GetSeasonById(id int) (*models.Seasons, error) {
 	return &season, nil
 }
 
-func (repo *SeasonsRepo) GetSeasonsBySerialId(id int) ([]*models.Seasons, error) {
-	repo.log.Info("Getting seasons by serial id from the database")
+func (repo *SeasonsRepo) GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting seasons by serial id from the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
-	cursor, err := collection.Find(ctx, bson.M{"ss_idSerial": objID})
+	cursor, err := collection.Find(ctx, bson.M{"ss_idserial": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var seasons []*models.Seasons
+	seasons := []*models.Seasons{}
 	for cursor.Next(ctx) {
 		var season models.Seasons
 		if err := cursor.Decode(&season); err != nil {
@@ -114,76 +111,57 @@ func (repo *SeasonsRepo) GetSeasonsBySerialId(id int) ([]*models.Seasons, error)
 	return seasons, nil
 }
 
-func (repo *SeasonsRepo) CreateSeason(season *models.Seasons) error {
+func (repo *SeasonsRepo) CreateSeason(ctx context.Context, season *models.Seasons) error {
 	if !season.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating season in the database")
+	repo.log.WithContext(ctx).Info("Creating season in the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"ss_name":        season.GetName(),
-		"ss_date":        season.GetDate(),
-		"ss_idSerial":    season.GetIdSerial(),
-		"ss_num":         season.GetNum(),
-		"ss_cntEpisodes": season.GetCntEpisodes(),
-	})
+	id, err := repo.counters.NextId(ctx, "seasons", "ss_id")
+	if err != nil {
+		return err
+	}
+	season.SetId(id)
+
+	_, err = collection.InsertOne(ctx, season)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	season.SetId(res)
 	return nil
 }
 
-func (repo *SeasonsRepo) UpdateSeason(season *models.Seasons) error {
+func (repo *SeasonsRepo) UpdateSeason(ctx context.Context, season *models.Seasons) error {
 	if !season.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating season in the database")
+	repo.log.WithContext(ctx).Info("Updating season in the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(season.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"ss_id": season.GetId()}, season)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"ss_name":        season.GetName(),
-			"ss_date":        season.GetDate(),
-			"ss_idSerial":    season.GetIdSerial(),
-			"ss_num":         season.GetNum(),
-			"ss_cntEpisodes": season.GetCntEpisodes(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *SeasonsRepo) DeleteSeason(id int) error {
-	repo.log.Info("Deleting season from the database")
+func (repo *SeasonsRepo) DeleteSeason(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting season from the database")
 	collection := repo.db.Collection("seasons")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"ss_id": id})
 	if err != nil {
 		return err
 	}
@@ -2,18 +2,21 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type SeasonsRepo struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewSeasonsRepoPostgres(db *sqlx.DB, log *logrus.Logger) *SeasonsRepo {
+func NewSeasonsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *SeasonsRepo {
 	return &SeasonsRepo{db: db, log: log}
 }
 
@@ -24,16 +27,26 @@ func (repo *SeasonsRepo) FormatDate(season *models.Seasons) {
 	season.SetDate(d2)
 }
 
+// DbDate converts a date in the "02.01.2006" format used by the models
+// into the ISO form expected by the database. Other values are passed as is.
+func (repo *SeasonsRepo) DbDate(date string) string {
+	d, err := time.Parse("02.01.2006", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("2006-01-02")
+}
+
 func (repo *SeasonsRepo) FormatDateList(seasons []*models.Seasons) {
 	for _, season := range seasons {
 		repo.FormatDate(season)
 	}
 }
 
-func (repo *SeasonsRepo) GetSeasons() ([]*models.Seasons, error) {
-	repo.log.Info("Getting all seasons from the database")
+func (repo *SeasonsRepo) GetSeasons(ctx context.Context) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting all seasons from the database")
 	seasons := []*models.Seasons{}
-	err := repo.db.Select(&seasons, "SELECT * FROM seasons")
+	err := repo.db.SelectContext(ctx, &seasons, "SELECT * FROM seasons")
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +54,13 @@ func (repo *SeasonsRepo) GetSeasons() ([]*models.Seasons, error) {
 	return seasons, nil
 }
 
-func (repo *SeasonsRepo) GetSeasonById(id int) (*models.Seasons, error) {
-	repo.log.Info("Getting season by id from the database")
+func (repo *SeasonsRepo) GetSeasonById(ctx context.Context, id int) (*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting season by id from the database")
 	season := &models.Seasons{}
-	err := repo.db.Get(season, "SELECT * FROM seasons WHERE id=$1", id)
+	err := repo.db.GetContext(ctx, season, "SELECT * FROM seasons WHERE ss_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +68,10 @@ func (repo *SeasonsRepo) GetSeasonById(id int) (*models.Seasons, error) {
 	return season, nil
 }
 
-func (repo *SeasonsRepo) GetSeasonsBySerialId(id int) ([]*models.Seasons, error) {
-	repo.log.Info("Getting seasons by serial id from the database")
+func (repo *SeasonsRepo) GetSeasonsBySerialId(ctx context.Context, id int) ([]*models.Seasons, error) {
+	repo.log.WithContext(ctx).Info("Getting seasons by serial id from the database")
 	seasons := []*models.Seasons{}
-	err := repo.db.Select(&seasons, "SELECT * FROM seasons WHERE ss_idSerial=$1", id)
+	err := repo.db.SelectContext(ctx, &seasons, "SELECT * FROM seasons WHERE ss_idSerial=$1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +79,15 @@ func (repo *SeasonsRepo) GetSeasonsBySerialId(id int) ([]*models.Seasons, error)
 	return seasons, nil
 }
 
-func (repo *SeasonsRepo) CreateSeason(season *models.Seasons) error {
+func (repo *SeasonsRepo) CreateSeason(ctx context.Context, season *models.Seasons) error {
 	if !season.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating season in the database")
-	err := repo.db.QueryRow("INSERT INTO seasons (ss_id, ss_name, ss_date, ss_idSerial, ss_num, ss_cntEpisodes) VALUES ($1, $2, $3, $4, $5) RETURNING ss_id",
-		season.GetName(), season.GetDate(), season.GetIdSerial(), season.GetNum(), season.GetCntEpisodes()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating season in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO seasons (ss_name, ss_date, ss_idSerial, ss_num, ss_cntEpisodes) VALUES ($1, $2, $3, $4, $5) RETURNING ss_id",
+		season.GetName(), repo.DbDate(season.GetDate()), season.GetIdSerial(), season.GetNum(), season.GetCntEpisodes()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -80,25 +96,32 @@ func (repo *SeasonsRepo) CreateSeason(season *models.Seasons) error {
 	return nil
 }
 
-func (repo *SeasonsRepo) UpdateSeason(season *models.Seasons) error {
+func (repo *SeasonsRepo) UpdateSeason(ctx context.Context, season *models.Seasons) error {
 	if !season.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating season in the database")
-	_, err := repo.db.Exec("UPDATE seasons SET ss_name=$1, ss_date=$2, ss_idSerial=$3, ss_num=$4, ss_cntEpisodes=$5 WHERE ss_id=$6",
-		season.GetName(), season.GetDate(), season.GetIdSerial(), season.GetNum(), season.GetCntEpisodes(), season.GetId())
+	repo.log.WithContext(ctx).Info("Updating season in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE seasons SET ss_name=$1, ss_date=$2, ss_idSerial=$3, ss_num=$4, ss_cntEpisodes=$5 WHERE ss_id=$6",
+		season.GetName(), repo.DbDate(season.GetDate()), season.GetIdSerial(), season.GetNum(), season.GetCntEpisodes(), season.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *SeasonsRepo) DeleteSeason(id int) error {
-	repo.log.Info("Deleting season from the database")
-	_, err := repo.db.Exec("DELETE FROM seasons WHERE s_id=$1", id)
+func (repo *SeasonsRepo) DeleteSeason(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting season from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM seasons WHERE ss_id=$1", id)
 	if err != nil {
 		return err
 	}
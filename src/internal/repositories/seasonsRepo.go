@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/seasons/memory"
 	mg "app/internal/repositories/seasons/mongo"
 	pg "app/internal/repositories/seasons/postgres"
 
@@ -14,8 +16,14 @@ func NewSeasonsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoSeasons
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewSeasonsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewSeasonsRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewSeasonsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewSeasonsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewSeasonsRepoMemory(db, log)
 	default:
 		return nil
 	}
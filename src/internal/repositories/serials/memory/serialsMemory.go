@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SerialsRepoMemory struct {
+	table     *memdb.Table[models.Serial, *models.Serial]
+	actors    *memdb.Table[models.SerialsActors, *models.SerialsActors]
+	people    *memdb.Table[models.Actors, *models.Actors]
+	producers *memdb.Table[models.Producers, *models.Producers]
+	log       *logrus.Logger
+}
+
+func NewSerialsRepoMemory(db *memdb.DB, log *logrus.Logger) *SerialsRepoMemory {
+	return &SerialsRepoMemory{
+		table:     memdb.NewTable[models.Serial](db, "serials"),
+		actors:    memdb.NewTable[models.SerialsActors](db, "serials_actors"),
+		people:    memdb.NewTable[models.Actors](db, "actors"),
+		producers: memdb.NewTable[models.Producers](db, "producers"),
+		log:       log,
+	}
+}
+
+func (repo *SerialsRepoMemory) GetSerials(ctx context.Context) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *SerialsRepoMemory) GetSerialById(ctx context.Context, id int) (*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by id from the database")
+	serial, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return serial, nil
+}
+
+func (repo *SerialsRepoMemory) GetSerialsByTitle(ctx context.Context, title string) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by title from the database")
+	return repo.table.Select(func(row *models.Serial) bool {
+		return strings.Contains(strings.ToLower(row.GetName()), strings.ToLower(title))
+	}), nil
+}
+
+func (repo *SerialsRepoMemory) SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error) {
+	if !query.Validate() || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Searching serials in the database")
+	var withActor map[int]bool
+	if query.IdActor > 0 {
+		withActor = map[int]bool{}
+		for _, sa := range repo.actors.Select(func(row *models.SerialsActors) bool {
+			return row.GetIdActor() == query.IdActor
+		}) {
+			withActor[sa.GetIdSerial()] = true
+		}
+	}
+	serials := repo.table.Select(func(row *models.Serial) bool {
+		return query.Match(row) && (withActor == nil || withActor[row.GetId()])
+	})
+	sort.Slice(serials, func(i, j int) bool {
+		return query.Less(serials[i], serials[j])
+	})
+	return memdb.Paginate(serials, page.Offset(), page.Limit()), len(serials), nil
+}
+
+// Weights of the serial fields in the full-text search rank.
+const (
+	nameWeight        = 1
+	descriptionWeight = 0.4
+	peopleWeight      = 0.2
+)
+
+// FullTextSearch finds the serials having every word of the text in the name,
+// the description or the names of the producer and the actors. A word matches
+// the words starting with it.
+func (repo *SerialsRepoMemory) FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error) {
+	words := models.SearchWords(text)
+	if len(words) == 0 || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Full-text searching serials in the database")
+	hits := []*models.SearchHit{}
+	for _, serial := range repo.table.Select(nil) {
+		fields := []struct {
+			text   string
+			weight float32
+		}{
+			{serial.GetName(), nameWeight},
+			{serial.GetDescription(), descriptionWeight},
+			{repo.peopleNames(serial), peopleWeight},
+		}
+		var rank float32
+		for _, word := range words {
+			var best float32
+			for _, field := range fields {
+				if field.weight > best && models.ContainsWord(field.text, word) {
+					best = field.weight
+				}
+			}
+			if best == 0 {
+				rank = 0
+				break
+			}
+			rank += best
+		}
+		if rank > 0 {
+			hits = append(hits, &models.SearchHit{Serial: serial, Rank: rank})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Rank > hits[j].Rank
+	})
+
+	res := memdb.Paginate(hits, page.Offset(), page.Limit())
+	for _, hit := range res {
+		hit.Snippet = models.Highlight(hit.Serial.GetDescription(), words)
+	}
+	return res, len(hits), nil
+}
+
+// peopleNames returns the names of the producer and the actors of the serial.
+func (repo *SerialsRepoMemory) peopleNames(serial *models.Serial) string {
+	names := []string{}
+	if producer, ok := repo.producers.Get(serial.GetIdProducer()); ok {
+		names = append(names, producer.GetName(), producer.GetSurname())
+	}
+	for _, sa := range repo.actors.Select(func(row *models.SerialsActors) bool {
+		return row.GetIdSerial() == serial.GetId()
+	}) {
+		if actor, ok := repo.people.Get(sa.GetIdActor()); ok {
+			names = append(names, actor.GetName(), actor.GetSurname())
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// Suggest returns the names of the serials, actors and producers matching the
+// prefix, see models.SuggestQuery.
+func (repo *SerialsRepoMemory) Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error) {
+	if !query.Validate() {
+		return nil, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting suggestions from the database")
+	candidates := []*models.Suggestion{}
+	for _, serial := range repo.table.Select(nil) {
+		candidates = append(candidates, &models.Suggestion{Kind: models.SuggestSerial, Id: serial.GetId(), Text: serial.GetName()})
+	}
+	for _, actor := range repo.people.Select(nil) {
+		candidates = append(candidates, &models.Suggestion{Kind: models.SuggestActor, Id: actor.GetId(), Text: actor.GetName() + " " + actor.GetSurname()})
+	}
+	for _, producer := range repo.producers.Select(nil) {
+		candidates = append(candidates, &models.Suggestion{Kind: models.SuggestProducer, Id: producer.GetId(), Text: producer.GetName() + " " + producer.GetSurname()})
+	}
+	return query.Rank(candidates), nil
+}
+
+func (repo *SerialsRepoMemory) CreateSerial(ctx context.Context, serial *models.Serial) error {
+	if !serial.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating serial in the database")
+	repo.table.Insert(serial)
+	return nil
+}
+
+func (repo *SerialsRepoMemory) UpdateSerial(ctx context.Context, serial *models.Serial) error {
+	if !serial.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating serial in the database")
+	if !repo.table.Update(serial) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// LockSerial only checks the serial exists: the transactions of memdb are
+// serialized already.
+func (repo *SerialsRepoMemory) LockSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking serial in the database")
+	if _, ok := repo.table.Get(id); !ok {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *SerialsRepoMemory) DeleteSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serial from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
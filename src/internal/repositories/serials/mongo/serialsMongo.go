@@ -2,32 +2,36 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"fmt"
-	"strconv"
+	"errors"
+	"regexp"
+	"sort"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type SerialsRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewSerialsRepoMongo(client *mongo.Client, log *logrus.Logger) *SerialsRepoMongo {
 	db := client.Database("mydb")
-	return &SerialsRepoMongo{db: db, log: log}
+	return &SerialsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *SerialsRepoMongo) GetSerials() ([]*models.Serial, error) {
-	repo.log.Info("Getting all serials from the database")
+func (repo *SerialsRepoMongo) GetSerials(ctx context.Context) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials from the database")
 	serials := []*models.Serial{}
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -49,32 +53,31 @@ func (repo *SerialsRepoMongo) GetSerials() ([]*models.Serial, error) {
 	return serials, nil
 }
 
-func (repo *SerialsRepoMongo) GetSerialById(id int) (*models.Serial, error) {
-	repo.log.Info("Getting serial by id from the database")
+func (repo *SerialsRepoMongo) GetSerialById(ctx context.Context, id int) (*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by id from the database")
 	serial := &models.Serial{}
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
+	err := collection.FindOne(ctx, bson.M{"s_id": id}).Decode(serial)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
 	}
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(serial)
 	if err != nil {
 		return nil, err
 	}
 	return serial, nil
 }
 
-func (repo *SerialsRepoMongo) GetSerialsByTitle(title string) ([]*models.Serial, error) {
-	repo.log.Info("Getting serial by title from the database")
+func (repo *SerialsRepoMongo) GetSerialsByTitle(ctx context.Context, title string) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by title from the database")
 	serials := []*models.Serial{}
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"s_name": bson.M{"$regex": title, "$options": "i"}})
+	cursor, err := collection.Find(ctx, bson.M{"s_name": bson.M{"$regex": regexp.QuoteMeta(title), "$options": "i"}})
 	if err != nil {
 		return nil, err
 	}
@@ -93,54 +96,394 @@ func (repo *SerialsRepoMongo) GetSerialsByTitle(title string) ([]*models.Serial,
 	return serials, nil
 }
 
-func (repo *SerialsRepoMongo) CreateSerial(serial *models.Serial) error {
-	if !serial.Validate() {
-		return models.ErrInvalidModel
+var serialsOrder = map[string]string{
+	models.SortById:     "s_id",
+	models.SortByRating: "s_rating",
+	models.SortByYear:   "s_year",
+	models.SortByName:   "s_name",
+}
+
+func (repo *SerialsRepoMongo) SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error) {
+	if !query.Validate() || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating serial in the database")
-	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	repo.log.WithContext(ctx).Info("Searching serials in the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, serial)
+	filter := bson.M{}
+	if query.Title != "" {
+		filter["s_name"] = bson.M{"$regex": regexp.QuoteMeta(query.Title), "$options": "i"}
+	}
+	if query.Genre != "" {
+		filter["s_genre"] = query.Genre
+	}
+	if query.State != "" {
+		filter["s_state"] = query.State
+	}
+	year := bson.M{}
+	if query.YearFrom > 0 {
+		year["$gte"] = query.YearFrom
+	}
+	if query.YearTo > 0 {
+		year["$lte"] = query.YearTo
+	}
+	if len(year) > 0 {
+		filter["s_year"] = year
+	}
+	if query.MinRating > 0 {
+		filter["s_rating"] = bson.M{"$gte": query.MinRating}
+	}
+	if query.IdProducer > 0 {
+		filter["s_idproducer"] = query.IdProducer
+	}
+	if query.IdActor > 0 {
+		ids, err := repo.db.Collection("serials_actors").Distinct(ctx, "sa_idserial", bson.M{"sa_idactor": query.IdActor})
+		if err != nil {
+			return nil, 0, err
+		}
+		if ids == nil {
+			ids = []interface{}{}
+		}
+		filter["s_id"] = bson.M{"$in": ids}
+	}
+	dir := 1
+	if query.Desc {
+		dir = -1
+	}
+	sort := bson.D{{Key: serialsOrder[query.Sort], Value: dir}}
+	if query.Sort != models.SortById {
+		sort = append(sort, bson.E{Key: "s_id", Value: dir})
+	}
+
+	return mgdb.FindPage[models.Serial](ctx, repo.db.Collection("serials"), filter, sort, page)
+}
+
+// Weights of the serial fields in the full-text search rank; the text score
+// of a serial is divided by nameWeight for each of searchLanguages.
+const (
+	nameWeight        = 10
+	descriptionWeight = 4
+	peopleRank        = 0.2
+)
+
+// searchLanguages are the languages the name and the description of a serial
+// are stemmed in by the text index, like in the Postgres search.
+var searchLanguages = []string{"russian", "english"}
+
+// searchText is the name and the description of a serial stemmed in the
+// language by the text index.
+type searchText struct {
+	Language    string `bson:"language"`
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
+}
+
+// serialDocument is a serial as it is stored: a collection has a single text
+// index and it stems all the fields of a document in one language, so the name
+// and the description are repeated in S_search for each of searchLanguages.
+// S_keys and S_tails are the keys of the name for Suggest.
+type serialDocument struct {
+	models.Serial `bson:",inline"`
+	S_search      []searchText `bson:"s_search"`
+	S_keys        []string     `bson:"s_keys"`
+	S_tails       []string     `bson:"s_tails"`
+}
+
+func newSerialDocument(serial *models.Serial) *serialDocument {
+	doc := &serialDocument{Serial: *serial}
+	doc.S_keys, doc.S_tails = models.SuggestKeys(serial.GetName())
+	for _, language := range searchLanguages {
+		doc.S_search = append(doc.S_search, searchText{Language: language, Name: serial.GetName(), Description: serial.GetDescription()})
+	}
+	return doc
+}
+
+// oldTextIndex is the text index of the serials stemmed only in russian,
+// replaced by the index on s_search.
+const oldTextIndex = "s_name_text_s_description_text"
+
+// Setup prepares the collections for the repository: it creates the text
+// indexes used by FullTextSearch and the indexes of the keys used by Suggest
+// and fills them for the serials stored without them. It is run once at startup, see repositories.SetupMongo. The
+// names of people are not stemmed.
+func (repo *SerialsRepoMongo) Setup(ctx context.Context) error {
+	_, err := repo.db.Collection("serials").Indexes().DropOne(ctx, oldTextIndex)
+	var cmdErr mongo.CommandError
+	if err != nil && !(errors.As(err, &cmdErr) && (cmdErr.Code == namespaceNotFound || cmdErr.Code == indexNotFound)) {
+		return err
+	}
+
+	search := bson.A{}
+	for _, language := range searchLanguages {
+		search = append(search, bson.M{"language": language, "name": "$s_name", "description": "$s_description"})
+	}
+	_, err = repo.db.Collection("serials").UpdateMany(ctx, bson.M{"s_search": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"s_search": search}}}})
+	if err != nil {
+		return err
+	}
+
+	err = mgdb.SetupSuggestKeys(ctx, repo.db.Collection("serials"), "s", "s_name")
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	serial.SetId(res)
 
+	indexes := []struct {
+		collection string
+		model      mongo.IndexModel
+	}{
+		{"serials", mongo.IndexModel{
+			Keys: bson.D{{Key: "s_search.name", Value: "text"}, {Key: "s_search.description", Value: "text"}},
+			Options: options.Index().SetWeights(bson.D{{Key: "s_search.name", Value: nameWeight}, {Key: "s_search.description", Value: descriptionWeight}}).
+				SetDefaultLanguage(searchLanguages[0]).SetLanguageOverride("language"),
+		}},
+		{"actors", mongo.IndexModel{
+			Keys:    bson.D{{Key: "a_name", Value: "text"}, {Key: "a_surname", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none"),
+		}},
+		{"producers", mongo.IndexModel{
+			Keys:    bson.D{{Key: "p_name", Value: "text"}, {Key: "p_surname", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none"),
+		}},
+	}
+	for _, index := range indexes {
+		if _, err := repo.db.Collection(index.collection).Indexes().CreateOne(ctx, index.model); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (repo *SerialsRepoMongo) UpdateSerial(serial *models.Serial) error {
+// Codes of the Mongo command errors.
+const (
+	namespaceNotFound = 26
+	indexNotFound     = 27
+)
+
+// FullTextSearch finds the serials having every word of the text in the name,
+// the description or the names of the producer and the actors. A collection
+// has a single text index, so every word is looked up on its own in each of
+// them and the results are intersected.
+func (repo *SerialsRepoMongo) FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error) {
+	words := models.SearchWords(text)
+	if len(words) == 0 || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Full-text searching serials in the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var ranks map[int]float32
+	for _, word := range words {
+		found, err := repo.searchWord(ctx, word)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ranks == nil {
+			ranks = found
+			continue
+		}
+		for id := range ranks {
+			if rank, ok := found[id]; ok {
+				ranks[id] += rank
+			} else {
+				delete(ranks, id)
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(ranks))
+	for id := range ranks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ranks[ids[i]] != ranks[ids[j]] {
+			return ranks[ids[i]] > ranks[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	ids = ids[min(page.Offset(), len(ids)):min(page.Offset()+page.Limit(), len(ids))]
+
+	cursor, err := repo.db.Collection("serials").Find(ctx, bson.M{"s_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, 0, err
+	}
+	serials := map[int]*models.Serial{}
+	for cursor.Next(ctx) {
+		var serial models.Serial
+		if err = cursor.Decode(&serial); err != nil {
+			cursor.Close(ctx)
+			return nil, 0, err
+		}
+		serials[serial.GetId()] = &serial
+	}
+	cursor.Close(ctx)
+	if err = cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	hits := []*models.SearchHit{}
+	for _, id := range ids {
+		if serial, ok := serials[id]; ok {
+			hits = append(hits, &models.SearchHit{Serial: serial, Rank: ranks[id], Snippet: models.Highlight(serial.GetDescription(), words)})
+		}
+	}
+	return hits, len(ranks), nil
+}
+
+// searchWord returns the ranks of the serials matching the word by id.
+func (repo *SerialsRepoMongo) searchWord(ctx context.Context, word string) (map[int]float32, error) {
+	search := bson.M{"$text": bson.M{"$search": word}}
+	ranks := map[int]float32{}
+
+	opts := options.Find().SetProjection(bson.M{"s_id": 1, "score": bson.M{"$meta": "textScore"}})
+	cursor, err := repo.db.Collection("serials").Find(ctx, search, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var row struct {
+			Id    int     `bson:"s_id"`
+			Score float64 `bson:"score"`
+		}
+		if err = cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		ranks[row.Id] = float32(row.Score / nameWeight / float64(len(searchLanguages)))
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	people := func(ids []interface{}) {
+		for _, id := range ids {
+			if id, ok := toInt(id); ok && ranks[id] < peopleRank {
+				ranks[id] = peopleRank
+			}
+		}
+	}
+	actors, err := repo.textIds(ctx, "actors", "a_id", search)
+	if err != nil {
+		return nil, err
+	}
+	if len(actors) > 0 {
+		ids, err := repo.db.Collection("serials_actors").Distinct(ctx, "sa_idserial", bson.M{"sa_idactor": bson.M{"$in": actors}})
+		if err != nil {
+			return nil, err
+		}
+		people(ids)
+	}
+	producers, err := repo.textIds(ctx, "producers", "p_id", search)
+	if err != nil {
+		return nil, err
+	}
+	if len(producers) > 0 {
+		ids, err := repo.db.Collection("serials").Distinct(ctx, "s_id", bson.M{"s_idproducer": bson.M{"$in": producers}})
+		if err != nil {
+			return nil, err
+		}
+		people(ids)
+	}
+	return ranks, nil
+}
+
+// textIds returns the ids in the field of the documents of the collection
+// matching the text search.
+func (repo *SerialsRepoMongo) textIds(ctx context.Context, collection string, field string, search bson.M) ([]interface{}, error) {
+	cursor, err := repo.db.Collection(collection).Find(ctx, search, options.Find().SetProjection(bson.M{field: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []interface{}{}
+	for cursor.Next(ctx) {
+		ids = append(ids, cursor.Current.Lookup(field))
+	}
+	return ids, cursor.Err()
+}
+
+// toInt converts an id read by Distinct.
+func toInt(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// suggestCandidates is the number of the names of each kind read for the
+// prefix ranked by Suggest.
+const suggestCandidates = 50
+
+// Suggest returns the names of the serials, actors and producers matching the
+// prefix, see models.SuggestQuery. The documents are matched by the indexed
+// keys of their names, see mgdb.SuggestFilter, and only the names of at most
+// suggestCandidates of them are read.
+func (repo *SerialsRepoMongo) Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error) {
+	if !query.Validate() {
+		return nil, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting suggestions from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	candidates := []*models.Suggestion{}
+	for _, source := range []struct {
+		collection string
+		kind       string
+		prefix     string
+		id         string
+		text       interface{}
+	}{
+		{"serials", models.SuggestSerial, "s", "$s_id", "$s_name"},
+		{"actors", models.SuggestActor, "a", "$a_id", bson.M{"$concat": bson.A{"$a_name", " ", "$a_surname"}}},
+		{"producers", models.SuggestProducer, "p", "$p_id", bson.M{"$concat": bson.A{"$p_name", " ", "$p_surname"}}},
+	} {
+		cursor, err := repo.db.Collection(source.collection).Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: mgdb.SuggestFilter(query, source.prefix)}},
+			{{Key: "$limit", Value: suggestCandidates}},
+			{{Key: "$project", Value: bson.M{"_id": 0, "kind": bson.M{"$literal": source.kind}, "id": source.id, "text": source.text}}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var found []*models.Suggestion
+		err = cursor.All(ctx, &found)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, found...)
+	}
+	return query.Rank(candidates), nil
+}
+
+func (repo *SerialsRepoMongo) CreateSerial(ctx context.Context, serial *models.Serial) error {
 	if !serial.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serial in the database")
+	repo.log.WithContext(ctx).Info("Creating serial in the database")
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(serial.GetId()))
+	id, err := repo.counters.NextId(ctx, "serials", "s_id")
 	if err != nil {
 		return err
 	}
+	serial.SetId(id)
 
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
-		"$set": bson.M{
-			"s_idProducer":  serial.GetIdProducer(),
-			"s_name":        serial.GetName(),
-			"s_description": serial.GetDescription(),
-			"s_year":        serial.GetYear(),
-			"s_genre":       serial.GetGenre(),
-			"s_rating":      serial.GetRating(),
-			"s_seasons":     serial.GetSeasons(),
-			"s_state":       serial.GetState(),
-			"s_duration":    serial.S_duration,
-		},
-	})
+	_, err = collection.InsertOne(ctx, newSerialDocument(serial))
 	if err != nil {
 		return err
 	}
@@ -148,55 +491,56 @@ func (repo *SerialsRepoMongo) UpdateSerial(serial *models.Serial) error {
 	return nil
 }
 
-func (repo *SerialsRepoMongo) DeleteSerial(id int) error {
-	repo.log.Info("Deleting serial from the database")
+func (repo *SerialsRepoMongo) UpdateSerial(ctx context.Context, serial *models.Serial) error {
+	if !serial.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating serial in the database")
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
+	res, err := collection.ReplaceOne(ctx, bson.M{"s_id": serial.GetId()}, newSerialDocument(serial))
 	if err != nil {
 		return err
 	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
 
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+// LockSerial writes the serial document, so a concurrent transaction writing
+// it gets a write conflict and is retried after this one, see
+// mongo.Session.WithTransaction.
+func (repo *SerialsRepoMongo) LockSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking serial in the database")
+	collection := repo.db.Collection("serials")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := collection.UpdateOne(ctx, bson.M{"s_id": id}, bson.M{"$inc": bson.M{"s_lock": 1}})
 	if err != nil {
 		return err
 	}
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
 	return nil
 }
 
-func (repo *SerialsRepoMongo) CalculateDuration(serial *models.Serial) error {
-	repo.log.Info("Calculating duration of the serial")
+func (repo *SerialsRepoMongo) DeleteSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serial from the database")
 	collection := repo.db.Collection("serials")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	pipeline := mongo.Pipeline{
-		{{"$match", bson.D{{"_id", serial.GetId()}}}},
-		{{"$group", bson.D{
-			{"_id", "$_id"},
-			{"totalDuration", bson.D{{"$sum", "$s_duration"}}},
-		}}},
-	}
-
-	cursor, err := collection.Aggregate(ctx, pipeline)
+	_, err := collection.DeleteOne(ctx, bson.M{"s_id": id})
 	if err != nil {
 		return err
 	}
-	defer cursor.Close(ctx)
-
-	if cursor.Next(ctx) {
-		var result struct {
-			TotalDuration string `bson:"totalDuration"`
-		}
-		if err := cursor.Decode(&result); err != nil {
-			return err
-		}
-		serial.S_duration = result.TotalDuration
-	} else {
-		return fmt.Errorf("no result found for serial ID %d", serial.GetId())
-	}
-
 	return nil
 }
@@ -2,59 +2,220 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type SerialsRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewSerialsRepoPostgres(db *sqlx.DB, log *logrus.Logger) *SerialsRepoPostgres {
+func NewSerialsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *SerialsRepoPostgres {
 	return &SerialsRepoPostgres{db: db, log: log}
 }
 
-func (repo *SerialsRepoPostgres) GetSerials() ([]*models.Serial, error) {
-	repo.log.Info("Getting all serials from the database")
+func (repo *SerialsRepoPostgres) GetSerials(ctx context.Context) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials from the database")
 	serials := []*models.Serial{}
-	err := repo.db.Select(&serials, "SELECT * FROM serials")
+	err := repo.db.SelectContext(ctx, &serials, "SELECT * FROM serials")
 	if err != nil {
 		return nil, err
 	}
 	return serials, nil
 }
 
-func (repo *SerialsRepoPostgres) GetSerialById(id int) (*models.Serial, error) {
-	repo.log.Info("Getting serial by id from the database")
+func (repo *SerialsRepoPostgres) GetSerialById(ctx context.Context, id int) (*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by id from the database")
 	serial := &models.Serial{}
-	err := repo.db.Get(serial, "SELECT * FROM serials WHERE s_id=$1", id)
+	err := repo.db.GetContext(ctx, serial, "SELECT * FROM serials WHERE s_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return serial, nil
 }
 
-func (repo *SerialsRepoPostgres) GetSerialsByTitle(title string) ([]*models.Serial, error) {
-	repo.log.Info("Getting serial by title from the database")
+func (repo *SerialsRepoPostgres) GetSerialsByTitle(ctx context.Context, title string) ([]*models.Serial, error) {
+	repo.log.WithContext(ctx).Info("Getting serial by title from the database")
 	serials := []*models.Serial{}
-	err := repo.db.Select(&serials, "SELECT * FROM serials WHERE s_name LIKE $1", string("%"+title+"%"))
+	err := repo.db.SelectContext(ctx, &serials, "SELECT * FROM serials WHERE s_name ILIKE $1", pgdb.Contains(title))
 	if err != nil {
 		return nil, err
 	}
 	return serials, nil
 }
 
-func (repo *SerialsRepoPostgres) CreateSerial(serial *models.Serial) error {
+var serialsOrder = map[string]string{
+	models.SortById:     "s_id",
+	models.SortByRating: "s_rating",
+	models.SortByYear:   "s_year",
+	models.SortByName:   `s_name COLLATE "C"`,
+}
+
+func (repo *SerialsRepoPostgres) SearchSerials(ctx context.Context, query *models.SerialsQuery, page models.Page) ([]*models.Serial, int, error) {
+	if !query.Validate() || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	conds := []string{"TRUE"}
+	args := []interface{}{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if query.Title != "" {
+		conds = append(conds, "s_name ILIKE "+arg(pgdb.Contains(query.Title)))
+	}
+	if query.Genre != "" {
+		conds = append(conds, "s_genre = "+arg(query.Genre))
+	}
+	if query.State != "" {
+		conds = append(conds, "s_state = "+arg(query.State))
+	}
+	if query.YearFrom > 0 {
+		conds = append(conds, "s_year >= "+arg(query.YearFrom))
+	}
+	if query.YearTo > 0 {
+		conds = append(conds, "s_year <= "+arg(query.YearTo))
+	}
+	if query.MinRating > 0 {
+		conds = append(conds, "s_rating >= "+arg(query.MinRating))
+	}
+	if query.IdProducer > 0 {
+		conds = append(conds, "s_idProducer = "+arg(query.IdProducer))
+	}
+	if query.IdActor > 0 {
+		conds = append(conds, "EXISTS (SELECT 1 FROM serials_actors WHERE sa_idSerial = s_id AND sa_idActor = "+arg(query.IdActor)+")")
+	}
+	dir := "ASC"
+	if query.Desc {
+		dir = "DESC"
+	}
+	order := serialsOrder[query.Sort] + " " + dir
+	if query.Sort != models.SortById {
+		order += ", s_id " + dir
+	}
+
+	repo.log.WithContext(ctx).Info("Searching serials in the database")
+	serials := []*models.Serial{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &serials, "serials WHERE "+strings.Join(conds, " AND "), order, page, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return serials, total, nil
+}
+
+// Delimiters of the matches in the snippets made by ts_headline.
+const (
+	snippetStart = "\x01"
+	snippetStop  = "\x02"
+)
+
+// searchQuery is the tsquery of the text in $1, see serials_search in the
+// migrations.
+const searchQuery = "websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) || websearch_to_tsquery('simple', $1)"
+
+// searchSnippet is the snippet of the description by ts_headline with the
+// options in $2. ts_headline takes one configuration, so of the ones of
+// searchQuery the first highlighting a match in $5, snippetStart, is used.
+const searchSnippet = `(SELECT h FROM unnest(ARRAY[ts_headline('russian', s.s_description, q, $2),
+		ts_headline('english', s.s_description, q, $2), ts_headline('simple', s.s_description, q, $2)])
+		WITH ORDINALITY AS headlines(h, n) ORDER BY strpos(h, $5) > 0 DESC, n LIMIT 1)`
+
+type searchRow struct {
+	models.Serial
+	Rank    float32 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+// FullTextSearch finds the serials by the words of their name, description and
+// the names of their producer and actors, text is in the web search syntax.
+func (repo *SerialsRepoPostgres) FullTextSearch(ctx context.Context, text string, page models.Page) ([]*models.SearchHit, int, error) {
+	if len(models.SearchWords(text)) == 0 || !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Full-text searching serials in the database")
+	var total int
+	err := repo.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM serials_search WHERE ss_document @@ ("+searchQuery+")", text)
+	if err != nil {
+		return nil, 0, err
+	}
+	options := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=%d, MinWords=%d", snippetStart, snippetStop, models.SnippetWords, models.SnippetWords/2)
+	rows := []*searchRow{}
+	err = repo.db.SelectContext(ctx, &rows, `SELECT s.*, ts_rank(ss.ss_document, q) AS rank, `+searchSnippet+` AS snippet
+		FROM serials_search ss JOIN serials s ON s.s_id = ss.ss_idSerial, (SELECT `+searchQuery+` AS q) query
+		WHERE ss.ss_document @@ q ORDER BY rank DESC, s.s_id LIMIT $3 OFFSET $4`, text, options, page.Limit(), page.Offset(), snippetStart)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := []*models.SearchHit{}
+	for _, row := range rows {
+		serial := row.Serial
+		hits = append(hits, &models.SearchHit{Serial: &serial, Rank: row.Rank, Snippet: models.SplitMarked(row.Snippet, snippetStart, snippetStop)})
+	}
+	return hits, total, nil
+}
+
+// suggestCandidates is the number of the names of every kind most similar to
+// the prefix ranked by Suggest.
+const suggestCandidates = 50
+
+// Suggest returns the names of the serials, actors and producers matching the
+// prefix, see models.SuggestQuery. Only the names are read, the trigram
+// indexes from the migrations serve the LIKE patterns of the prefix variants.
+func (repo *SerialsRepoPostgres) Suggest(ctx context.Context, query *models.SuggestQuery) ([]*models.Suggestion, error) {
+	if !query.Validate() {
+		return nil, models.ErrInvalidModel
+	}
+
+	args := []interface{}{query.Prefix, suggestCandidates}
+	likes := []string{}
+	for _, variant := range query.Variants(pgdb.EscapeLike, "_") {
+		args = append(args, "%"+variant+"%")
+		likes = append(likes, fmt.Sprintf("$%d", len(args)))
+	}
+	names := func(kind, id, text, table string) string {
+		conds := []string{}
+		for _, like := range likes {
+			conds = append(conds, text+" ILIKE "+like)
+		}
+		return fmt.Sprintf("(SELECT '%s' AS kind, %s AS id, %s AS text FROM %s WHERE %s ORDER BY word_similarity($1, %s) DESC LIMIT $2)",
+			kind, id, text, table, strings.Join(conds, " OR "), text)
+	}
+
+	repo.log.WithContext(ctx).Info("Getting suggestions from the database")
+	candidates := []*models.Suggestion{}
+	err := repo.db.SelectContext(ctx, &candidates, strings.Join([]string{
+		names(models.SuggestSerial, "s_id", "s_name", "serials"),
+		names(models.SuggestActor, "a_id", "(a_name || ' ' || a_surname)", "actors"),
+		names(models.SuggestProducer, "p_id", "(p_name || ' ' || p_surname)", "producers"),
+	}, " UNION ALL "), args...)
+	if err != nil {
+		return nil, err
+	}
+	return query.Rank(candidates), nil
+}
+
+func (repo *SerialsRepoPostgres) CreateSerial(ctx context.Context, serial *models.Serial) error {
 	if !serial.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating serial in the database")
-	err := repo.db.QueryRow("INSERT INTO serials (s_idProducer, s_name, s_description, s_year, s_genre, s_rating, s_seasons, s_state, s_img, s_duration) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING s_id",
-		serial.GetIdProducer(), serial.GetName(), serial.GetDescription(), serial.GetYear(), serial.GetGenre(), serial.GetRating(), serial.GetSeasons(), serial.GetState(), serial.S_img, serial.S_duration).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating serial in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO serials (s_idProducer, s_name, s_description, s_year, s_genre, s_rating, s_votes, s_seasons, s_state, s_img, s_duration) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING s_id",
+		serial.GetIdProducer(), serial.GetName(), serial.GetDescription(), serial.GetYear(), serial.GetGenre(), serial.GetRating(), serial.GetVotes(), serial.GetSeasons(), serial.GetState(), serial.S_img, serial.S_duration).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -63,33 +224,43 @@ func (repo *SerialsRepoPostgres) CreateSerial(serial *models.Serial) error {
 	return nil
 }
 
-func (repo *SerialsRepoPostgres) UpdateSerial(serial *models.Serial) error {
+func (repo *SerialsRepoPostgres) UpdateSerial(ctx context.Context, serial *models.Serial) error {
 	if !serial.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serial in the database")
-	_, err := repo.db.Exec("UPDATE serials SET s_idProducer=$2, s_name=$3, s_description=$4, s_year=$5, s_genre=$6, s_rating=$7, s_seasons=$8, s_state=$9, s_duration=$10 WHERE s_id=$1",
-		serial.GetId(), serial.GetIdProducer(), serial.GetName(), serial.GetDescription(), serial.GetYear(), serial.GetGenre(), serial.GetRating(), serial.GetSeasons(), serial.GetState(), serial.S_duration)
+	repo.log.WithContext(ctx).Info("Updating serial in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE serials SET s_idProducer=$2, s_name=$3, s_description=$4, s_year=$5, s_genre=$6, s_rating=$7, s_seasons=$8, s_state=$9, s_duration=$10, s_img=$11, s_votes=$12 WHERE s_id=$1",
+		serial.GetId(), serial.GetIdProducer(), serial.GetName(), serial.GetDescription(), serial.GetYear(), serial.GetGenre(), serial.GetRating(), serial.GetSeasons(), serial.GetState(), serial.S_duration, serial.S_img, serial.GetVotes())
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *SerialsRepoPostgres) DeleteSerial(id int) error {
-	repo.log.Info("Deleting serial from the database")
-	_, err := repo.db.Exec("DELETE FROM serials WHERE s_id=$1", id)
-	if err != nil {
-		return err
+// LockSerial locks the row of the serial until the end of the transaction,
+// so the concurrent updates of the serial wait for it.
+func (repo *SerialsRepoPostgres) LockSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Locking serial in the database")
+	var locked int
+	err := repo.db.GetContext(ctx, &locked, "SELECT s_id FROM serials WHERE s_id=$1 FOR UPDATE", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrNotFound
 	}
-	return nil
+	return err
 }
 
-func (repo *SerialsRepoPostgres) CalculateDuration(serial *models.Serial) error {
-	repo.log.Info("Calculating duration of the serial")
-	err := repo.db.QueryRow("SELECT calculate_total_duration($1)", serial.GetId()).Scan(&serial.S_duration)
+func (repo *SerialsRepoPostgres) DeleteSerial(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serial from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials WHERE s_id=$1", id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SerialsActorsRepoMemory struct {
+	table *memdb.Table[models.SerialsActors, *models.SerialsActors]
+	log   *logrus.Logger
+}
+
+func NewSerialsActorsRepoMemory(db *memdb.DB, log *logrus.Logger) *SerialsActorsRepoMemory {
+	return &SerialsActorsRepoMemory{table: memdb.NewTable[models.SerialsActors](db, "serials_actors"), log: log}
+}
+
+// withDefaultRoles sets the default role of the selected links, see
+// models.SerialsActors.SetDefaultRole.
+func withDefaultRoles(serialsActors []*models.SerialsActors) []*models.SerialsActors {
+	for _, serialActor := range serialsActors {
+		serialActor.SetDefaultRole()
+	}
+	return serialsActors
+}
+
+func (repo *SerialsActorsRepoMemory) GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_actors from the database")
+	return withDefaultRoles(repo.table.Select(nil)), nil
+}
+
+func (repo *SerialsActorsRepoMemory) GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_actors by actor id from the database")
+	return withDefaultRoles(repo.table.Select(func(row *models.SerialsActors) bool {
+		return row.GetIdActor() == id
+	})), nil
+}
+
+func (repo *SerialsActorsRepoMemory) GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_actors by serial id from the database")
+	return withDefaultRoles(repo.table.Select(func(row *models.SerialsActors) bool {
+		return row.GetIdSerial() == id
+	})), nil
+}
+
+func (repo *SerialsActorsRepoMemory) GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_actors by id from the database")
+	serialActor, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	serialActor.SetDefaultRole()
+	return serialActor, nil
+}
+
+func (repo *SerialsActorsRepoMemory) CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
+	if !serialActor.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating serials_actors in the database")
+	repo.table.Insert(serialActor)
+	return nil
+}
+
+func (repo *SerialsActorsRepoMemory) UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
+	if !serialActor.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating serials_actors in the database")
+	if !repo.table.Update(serialActor) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *SerialsActorsRepoMemory) DeleteSerialsActors(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_actors from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
@@ -2,30 +2,31 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SerialsActorsRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewSerialsActorsRepoMongo(client *mongo.Client, log *logrus.Logger) *SerialsActorsRepoMongo {
 	db := client.Database("mydb")
-	return &SerialsActorsRepoMongo{db: db, log: log}
+	return &SerialsActorsRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *SerialsActorsRepoMongo) GetSerialsActors() ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting all serials_actors from the database")
+func (repo *SerialsActorsRepoMongo) GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_actors from the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -34,12 +35,13 @@ func (repo *SerialsActorsRepoMongo) GetSerialsActors() ([]*models.SerialsActors,
 	}
 	defer cursor.Close(ctx)
 
-	var serialsActors []*models.SerialsActors
+	serialsActors := []*models.SerialsActors{}
 	for cursor.Next(ctx) {
 		var serialActor models.SerialsActors
 		if err := cursor.Decode(&serialActor); err != nil {
 			return nil, err
 		}
+		serialActor.SetDefaultRole()
 		serialsActors = append(serialsActors, &serialActor)
 	}
 	if err := cursor.Err(); err != nil {
@@ -48,95 +50,87 @@ func (repo *SerialsActorsRepoMongo) GetSerialsActors() ([]*models.SerialsActors,
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoMongo) GetSerialsActorsById(id int) (*models.SerialsActors, error) {
-	repo.log.Info("Getting serials_actors by id from the database")
+func (repo *SerialsActorsRepoMongo) GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_actors by id from the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
 	var serialActor models.SerialsActors
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&serialActor)
+	err := collection.FindOne(ctx, bson.M{"sa_id": id}).Decode(&serialActor)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	serialActor.SetDefaultRole()
 	return &serialActor, nil
 }
 
-func (repo *SerialsActorsRepoMongo) CreateSerialsActors(serialActor *models.SerialsActors) error {
+func (repo *SerialsActorsRepoMongo) CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	if !serialActor.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating serials_actors in the database")
+	repo.log.WithContext(ctx).Info("Creating serials_actors in the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"sa_idSerial": serialActor.GetIdSerial(),
-		"sa_idActor":  serialActor.GetIdActor(),
-	})
+	id, err := repo.counters.NextId(ctx, "serials_actors", "sa_id")
+	if err != nil {
+		return err
+	}
+	serialActor.SetId(id)
+
+	_, err = collection.InsertOne(ctx, serialActor)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	serialActor.SetId(res)
 	return nil
 }
 
-func (repo *SerialsActorsRepoMongo) UpdateSerialsActors(serialActor *models.SerialsActors) error {
+func (repo *SerialsActorsRepoMongo) UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	if !serialActor.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_actors in the database")
+	repo.log.WithContext(ctx).Info("Updating serials_actors in the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(serialActor.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"sa_id": serialActor.GetId()}, serialActor)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"sa_idSerial": serialActor.GetIdSerial(),
-			"sa_idActor":  serialActor.GetIdActor(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *SerialsActorsRepoMongo) GetSerialsByActorId(id int) ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting serials by actor id from the database")
+func (repo *SerialsActorsRepoMongo) GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials by actor id from the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"sa_idActor": id})
+	cursor, err := collection.Find(ctx, bson.M{"sa_idactor": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsActors []*models.SerialsActors
+	serialsActors := []*models.SerialsActors{}
 	for cursor.Next(ctx) {
 		var serialActor models.SerialsActors
 		if err := cursor.Decode(&serialActor); err != nil {
 			return nil, err
 		}
+		serialActor.SetDefaultRole()
 		serialsActors = append(serialsActors, &serialActor)
 	}
 	if err := cursor.Err(); err != nil {
@@ -145,24 +139,25 @@ func (repo *SerialsActorsRepoMongo) GetSerialsByActorId(id int) ([]*models.Seria
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoMongo) GetActorsBySerialId(id int) ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting actors by serial id from the database")
+func (repo *SerialsActorsRepoMongo) GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting actors by serial id from the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"sa_idSerial": id})
+	cursor, err := collection.Find(ctx, bson.M{"sa_idserial": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsActors []*models.SerialsActors
+	serialsActors := []*models.SerialsActors{}
 	for cursor.Next(ctx) {
 		var serialActor models.SerialsActors
 		if err := cursor.Decode(&serialActor); err != nil {
 			return nil, err
 		}
+		serialActor.SetDefaultRole()
 		serialsActors = append(serialsActors, &serialActor)
 	}
 	if err := cursor.Err(); err != nil {
@@ -171,18 +166,13 @@ func (repo *SerialsActorsRepoMongo) GetActorsBySerialId(id int) ([]*models.Seria
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoMongo) DeleteSerialsActors(id int) error {
-	repo.log.Info("Deleting serials_actors from the database")
+func (repo *SerialsActorsRepoMongo) DeleteSerialsActors(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_actors from the database")
 	collection := repo.db.Collection("serials_actors")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"sa_id": id})
 	if err != nil {
 		return err
 	}
@@ -2,49 +2,57 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type SerialsActorsRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewSerialsActorsRepoPostgres(db *sqlx.DB, log *logrus.Logger) *SerialsActorsRepoPostgres {
+func NewSerialsActorsRepoPostgres(db pgdb.Querier, log *logrus.Logger) *SerialsActorsRepoPostgres {
 	return &SerialsActorsRepoPostgres{db: db, log: log}
 }
 
-func (repo *SerialsActorsRepoPostgres) GetSerialsActors() ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting all serials_actors from the database")
+func (repo *SerialsActorsRepoPostgres) GetSerialsActors(ctx context.Context) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_actors from the database")
 	serialsActors := []*models.SerialsActors{}
-	err := repo.db.Select(&serialsActors, "SELECT * FROM serials_actors")
+	err := repo.db.SelectContext(ctx, &serialsActors, "SELECT * FROM serials_actors")
 	if err != nil {
 		return nil, err
 	}
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoPostgres) GetSerialsActorsById(id int) (*models.SerialsActors, error) {
-	repo.log.Info("Getting serials_actors by id from the database")
+func (repo *SerialsActorsRepoPostgres) GetSerialsActorsById(ctx context.Context, id int) (*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_actors by id from the database")
 	serialActor := &models.SerialsActors{}
-	err := repo.db.Get(serialActor, "SELECT * FROM serials_actors WHERE id=$1", id)
+	err := repo.db.GetContext(ctx, serialActor, "SELECT * FROM serials_actors WHERE sa_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return serialActor, nil
 }
 
-func (repo *SerialsActorsRepoPostgres) CreateSerialsActors(serialActor *models.SerialsActors) error {
+func (repo *SerialsActorsRepoPostgres) CreateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	if !serialActor.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating serials_actors in the database")
-	err := repo.db.QueryRow("INSERT INTO serials_actors (sa_idSerial, sa_idActor) VALUES ($1, $2) RETURNING sa_id",
-		serialActor.GetIdSerial(), serialActor.GetIdActor()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating serials_actors in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO serials_actors (sa_idSerial, sa_idActor, sa_character, sa_role, sa_billing, sa_seasonFrom, sa_seasonTo) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING sa_id",
+		serialActor.GetIdSerial(), serialActor.GetIdActor(), serialActor.GetCharacter(), serialActor.GetRole(),
+		serialActor.GetBilling(), serialActor.GetSeasonFrom(), serialActor.GetSeasonTo()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -53,45 +61,54 @@ func (repo *SerialsActorsRepoPostgres) CreateSerialsActors(serialActor *models.S
 	return nil
 }
 
-func (repo *SerialsActorsRepoPostgres) UpdateSerialsActors(serialActor *models.SerialsActors) error {
+func (repo *SerialsActorsRepoPostgres) UpdateSerialsActors(ctx context.Context, serialActor *models.SerialsActors) error {
 	if !serialActor.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_actors in the database")
-	_, err := repo.db.Exec("UPDATE serials_actors SET sa_idSerial=$1, sa_idActor=$2 WHERE sa_id=$3",
-		serialActor.GetIdSerial(), serialActor.GetIdActor(), serialActor.GetId())
+	repo.log.WithContext(ctx).Info("Updating serials_actors in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE serials_actors SET sa_idSerial=$1, sa_idActor=$2, sa_character=$3, sa_role=$4, "+
+		"sa_billing=$5, sa_seasonFrom=$6, sa_seasonTo=$7 WHERE sa_id=$8",
+		serialActor.GetIdSerial(), serialActor.GetIdActor(), serialActor.GetCharacter(), serialActor.GetRole(),
+		serialActor.GetBilling(), serialActor.GetSeasonFrom(), serialActor.GetSeasonTo(), serialActor.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *SerialsActorsRepoPostgres) GetSerialsByActorId(id int) ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting serials by actor id from the database")
+func (repo *SerialsActorsRepoPostgres) GetSerialsByActorId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting serials by actor id from the database")
 	serialsActors := []*models.SerialsActors{}
-	err := repo.db.Select(&serialsActors, "SELECT * FROM serials_actors WHERE sa_idActor=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsActors, "SELECT * FROM serials_actors WHERE sa_idActor=$1", id)
 	if err != nil {
 		return nil, err
 	}
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoPostgres) GetActorsBySerialId(id int) ([]*models.SerialsActors, error) {
-	repo.log.Info("Getting actors by serial id from the database")
+func (repo *SerialsActorsRepoPostgres) GetActorsBySerialId(ctx context.Context, id int) ([]*models.SerialsActors, error) {
+	repo.log.WithContext(ctx).Info("Getting actors by serial id from the database")
 	serialsActors := []*models.SerialsActors{}
-	err := repo.db.Select(&serialsActors, "SELECT * FROM serials_actors WHERE sa_idSerial=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsActors, "SELECT * FROM serials_actors WHERE sa_idSerial=$1", id)
 	if err != nil {
 		return nil, err
 	}
 	return serialsActors, nil
 }
 
-func (repo *SerialsActorsRepoPostgres) DeleteSerialsActors(id int) error {
-	repo.log.Info("Deleting serials_actors from the database")
-	_, err := repo.db.Exec("DELETE FROM serials_actors WHERE sa_id=$1", id)
+func (repo *SerialsActorsRepoPostgres) DeleteSerialsActors(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_actors from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials_actors WHERE sa_id=$1", id)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/serialsActors/memory"
 	mg "app/internal/repositories/serialsActors/mongo"
 	pg "app/internal/repositories/serialsActors/postgres"
 
@@ -14,8 +16,14 @@ func NewSerialsActorsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoSe
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewSerialsActorsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewSerialsActorsRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewSerialsActorsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewSerialsActorsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewSerialsActorsRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SerialsFavouritesRepoMemory struct {
+	table *memdb.Table[models.SerialsFavourites, *models.SerialsFavourites]
+	log   *logrus.Logger
+}
+
+func NewSerialsFavouritesRepoMemory(db *memdb.DB, log *logrus.Logger) *SerialsFavouritesRepoMemory {
+	return &SerialsFavouritesRepoMemory{table: memdb.NewTable[models.SerialsFavourites](db, "serials_favourites"), log: log}
+}
+
+func (repo *SerialsFavouritesRepoMemory) GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_favourites from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by favourite id from the database")
+	return repo.table.Select(func(row *models.SerialsFavourites) bool {
+		return row.GetIdFavourite() == id
+	}), nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by serial id from the database")
+	return repo.table.Select(func(row *models.SerialsFavourites) bool {
+		return row.GetIdSerial() == id
+	}), nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by id from the database")
+	serialFavourite, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return serialFavourite, nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
+	if !serialFavourite.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating serials_favourites in the database")
+	repo.table.Insert(serialFavourite)
+	return nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
+	if !serialFavourite.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating serials_favourites in the database")
+	if !repo.table.Update(serialFavourite) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool {
+	repo.log.WithContext(ctx).Info("Checking serial in favourite")
+	_, ok := repo.table.First(func(row *models.SerialsFavourites) bool {
+		return row.GetIdSerial() == serialFavourite.GetIdSerial() && row.GetIdFavourite() == serialFavourite.GetIdFavourite()
+	})
+	return ok
+}
+
+func (repo *SerialsFavouritesRepoMemory) DeleteSerialById(ctx context.Context, idfav, idserial int) error {
+	repo.log.WithContext(ctx).Info("Deleting serial from favourite in the database")
+	repo.table.Delete(func(row *models.SerialsFavourites) bool {
+		return row.GetIdFavourite() == idfav && row.GetIdSerial() == idserial
+	})
+	return nil
+}
+
+func (repo *SerialsFavouritesRepoMemory) DeleteSerialsFavourites(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_favourites from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
@@ -2,30 +2,31 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SerialsFavouritesRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewSerialsFavouritesRepoMongo(client *mongo.Client, log *logrus.Logger) *SerialsFavouritesRepoMongo {
 	db := client.Database("mydb")
-	return &SerialsFavouritesRepoMongo{db: db, log: log}
+	return &SerialsFavouritesRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
-func (repo *SerialsFavouritesRepoMongo) GetSerialsFavourites() ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting all serials_favourites from the database")
+func (repo *SerialsFavouritesRepoMongo) GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_favourites from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -34,7 +35,7 @@ func (repo *SerialsFavouritesRepoMongo) GetSerialsFavourites() ([]*models.Serial
 	}
 	defer cursor.Close(ctx)
 
-	var serialsFavourites []*models.SerialsFavourites
+	serialsFavourites := []*models.SerialsFavourites{}
 	for cursor.Next(ctx) {
 		var serialFavourite models.SerialsFavourites
 		if err := cursor.Decode(&serialFavourite); err != nil {
@@ -48,38 +49,36 @@ func (repo *SerialsFavouritesRepoMongo) GetSerialsFavourites() ([]*models.Serial
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) GetSerialsFavouritesById(id int) (*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by id from the database")
+func (repo *SerialsFavouritesRepoMongo) GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by id from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
 	var serialFavourite models.SerialsFavourites
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&serialFavourite)
+	err := collection.FindOne(ctx, bson.M{"sf_id": id}).Decode(&serialFavourite)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &serialFavourite, nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) GetSerialsByFavouriteId(id int) ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by favourite id from the database")
+func (repo *SerialsFavouritesRepoMongo) GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by favourite id from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"sf_idFavourite": id})
+	cursor, err := collection.Find(ctx, bson.M{"sf_idfavourite": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsFavourites []*models.SerialsFavourites
+	serialsFavourites := []*models.SerialsFavourites{}
 	for cursor.Next(ctx) {
 		var serialFavourite models.SerialsFavourites
 		if err := cursor.Decode(&serialFavourite); err != nil {
@@ -93,19 +92,19 @@ func (repo *SerialsFavouritesRepoMongo) GetSerialsByFavouriteId(id int) ([]*mode
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) GetFavouritesBySerialId(id int) ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by serial id from the database")
+func (repo *SerialsFavouritesRepoMongo) GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by serial id from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"sf_idSerial": id})
+	cursor, err := collection.Find(ctx, bson.M{"sf_idserial": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsFavourites []*models.SerialsFavourites
+	serialsFavourites := []*models.SerialsFavourites{}
 	for cursor.Next(ctx) {
 		var serialFavourite models.SerialsFavourites
 		if err := cursor.Decode(&serialFavourite); err != nil {
@@ -119,67 +118,59 @@ func (repo *SerialsFavouritesRepoMongo) GetFavouritesBySerialId(id int) ([]*mode
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) CreateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (repo *SerialsFavouritesRepoMongo) CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	if !serialFavourite.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating serials_favourites in the database")
+	repo.log.WithContext(ctx).Info("Creating serials_favourites in the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"sf_idSerial":    serialFavourite.GetIdSerial(),
-		"sf_idFavourite": serialFavourite.GetIdFavourite(),
-	})
+	id, err := repo.counters.NextId(ctx, "serials_favourites", "sf_id")
+	if err != nil {
+		return err
+	}
+	serialFavourite.SetId(id)
+
+	_, err = collection.InsertOne(ctx, serialFavourite)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	serialFavourite.SetId(res)
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) UpdateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (repo *SerialsFavouritesRepoMongo) UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	if !serialFavourite.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_favourites in the database")
+	repo.log.WithContext(ctx).Info("Updating serials_favourites in the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(serialFavourite.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"sf_id": serialFavourite.GetId()}, serialFavourite)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"sf_idSerial":    serialFavourite.GetIdSerial(),
-			"sf_idFavourite": serialFavourite.GetIdFavourite(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		return err
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) CheckSerialInFavourite(serialFavourite *models.SerialsFavourites) bool {
-	repo.log.Info("Checking serial in favourite")
+func (repo *SerialsFavouritesRepoMongo) CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool {
+	repo.log.WithContext(ctx).Info("Checking serial in favourite")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	filter := bson.M{
-		"sf_idSerial":    serialFavourite.GetIdSerial(),
-		"sf_idFavourite": serialFavourite.GetIdFavourite(),
+		"sf_idserial":    serialFavourite.GetIdSerial(),
+		"sf_idfavourite": serialFavourite.GetIdFavourite(),
 	}
 
 	count, err := collection.CountDocuments(ctx, filter)
@@ -190,31 +181,26 @@ func (repo *SerialsFavouritesRepoMongo) CheckSerialInFavourite(serialFavourite *
 	return count > 0
 }
 
-func (repo *SerialsFavouritesRepoMongo) DeleteSerialById(idfav, idserial int) error {
-	repo.log.Info("Deleting serials_favourites from the database")
+func (repo *SerialsFavouritesRepoMongo) DeleteSerialById(ctx context.Context, idfav, idserial int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_favourites from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	_, err := collection.DeleteOne(ctx, bson.M{"sf_idFavourite": idfav, "sf_idSerial": idserial})
+	_, err := collection.DeleteOne(ctx, bson.M{"sf_idfavourite": idfav, "sf_idserial": idserial})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoMongo) DeleteSerialsFavourites(id int) error {
-	repo.log.Info("Deleting serials_favourites from the database")
+func (repo *SerialsFavouritesRepoMongo) DeleteSerialsFavourites(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_favourites from the database")
 	collection := repo.db.Collection("serials_favourites")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"sf_id": id})
 	if err != nil {
 		return err
 	}
@@ -2,69 +2,75 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type SerialsFavouritesRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewSerialsFavouritesRepoPostgres(db *sqlx.DB, log *logrus.Logger) *SerialsFavouritesRepoPostgres {
+func NewSerialsFavouritesRepoPostgres(db pgdb.Querier, log *logrus.Logger) *SerialsFavouritesRepoPostgres {
 	return &SerialsFavouritesRepoPostgres{db: db, log: log}
 }
 
-func (repo *SerialsFavouritesRepoPostgres) GetSerialsFavourites() ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting all serials_favourites from the database")
+func (repo *SerialsFavouritesRepoPostgres) GetSerialsFavourites(ctx context.Context) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_favourites from the database")
 	serialsFavourites := []*models.SerialsFavourites{}
-	err := repo.db.Select(&serialsFavourites, "SELECT * FROM serials_favourites")
+	err := repo.db.SelectContext(ctx, &serialsFavourites, "SELECT * FROM serials_favourites")
 	if err != nil {
 		return nil, err
 	}
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) GetSerialsFavouritesById(id int) (*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by id from the database")
+func (repo *SerialsFavouritesRepoPostgres) GetSerialsFavouritesById(ctx context.Context, id int) (*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by id from the database")
 	serialFavourite := &models.SerialsFavourites{}
-	err := repo.db.Get(serialFavourite, "SELECT * FROM serials_favourites WHERE sf_id=$1", id)
+	err := repo.db.GetContext(ctx, serialFavourite, "SELECT * FROM serials_favourites WHERE sf_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return serialFavourite, nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) GetSerialsByFavouriteId(id int) ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by favourite id from the database")
+func (repo *SerialsFavouritesRepoPostgres) GetSerialsByFavouriteId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by favourite id from the database")
 	serialsFavourites := []*models.SerialsFavourites{}
-	err := repo.db.Select(&serialsFavourites, "SELECT * FROM serials_favourites WHERE sf_idFavourite=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsFavourites, "SELECT * FROM serials_favourites WHERE sf_idFavourite=$1", id)
 	if err != nil {
 		return nil, err
 	}
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) GetFavouritesBySerialId(id int) ([]*models.SerialsFavourites, error) {
-	repo.log.Info("Getting serials_favourites by serial id from the database")
+func (repo *SerialsFavouritesRepoPostgres) GetFavouritesBySerialId(ctx context.Context, id int) ([]*models.SerialsFavourites, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_favourites by serial id from the database")
 	serialsFavourites := []*models.SerialsFavourites{}
-	err := repo.db.Select(&serialsFavourites, "SELECT * FROM serials_favourites WHERE sf_idSerial=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsFavourites, "SELECT * FROM serials_favourites WHERE sf_idSerial=$1", id)
 	if err != nil {
 		return nil, err
 	}
 	return serialsFavourites, nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) CreateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (repo *SerialsFavouritesRepoPostgres) CreateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	if !serialFavourite.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating serials_favourites in the database")
-	err := repo.db.QueryRow("INSERT INTO serials_favourites (sf_idSerial, sf_idFavourite) VALUES ($1, $2) RETURNING sf_id",
-		serialFavourite.GetIdSerial(), serialFavourite.GetIdFavourite()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating serials_favourites in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO serials_favourites (sf_idSerial, sf_idFavourite, sf_date) VALUES ($1, $2, $3) RETURNING sf_id",
+		serialFavourite.GetIdSerial(), serialFavourite.GetIdFavourite(), serialFavourite.GetDate()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -73,43 +79,50 @@ func (repo *SerialsFavouritesRepoPostgres) CreateSerialsFavourites(serialFavouri
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) UpdateSerialsFavourites(serialFavourite *models.SerialsFavourites) error {
+func (repo *SerialsFavouritesRepoPostgres) UpdateSerialsFavourites(ctx context.Context, serialFavourite *models.SerialsFavourites) error {
 	if !serialFavourite.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_favourites in the database")
-	_, err := repo.db.Exec("UPDATE serials_favourites SET sf_idSerial=$1, sf_idFavourite=$2 WHERE sf_id=$3",
-		serialFavourite.GetIdSerial(), serialFavourite.GetIdFavourite(), serialFavourite.GetId())
+	repo.log.WithContext(ctx).Info("Updating serials_favourites in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE serials_favourites SET sf_idSerial=$1, sf_idFavourite=$2, sf_date=$3 WHERE sf_id=$4",
+		serialFavourite.GetIdSerial(), serialFavourite.GetIdFavourite(), serialFavourite.GetDate(), serialFavourite.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) CheckSerialInFavourite(serialFavourite *models.SerialsFavourites) bool {
-	repo.log.Info("Checking serial in favourite")
+func (repo *SerialsFavouritesRepoPostgres) CheckSerialInFavourite(ctx context.Context, serialFavourite *models.SerialsFavourites) bool {
+	repo.log.WithContext(ctx).Info("Checking serial in favourite")
 	sf_temp := []*models.SerialsFavourites{}
-	err := repo.db.Select(&sf_temp, "SELECT * FROM serials_favourites WHERE sf_idSerial=$1 AND sf_idFavourite=$2",
+	err := repo.db.SelectContext(ctx, &sf_temp, "SELECT * FROM serials_favourites WHERE sf_idSerial=$1 AND sf_idFavourite=$2",
 		serialFavourite.GetIdSerial(), serialFavourite.GetIdFavourite())
-	repo.log.Println(err, sf_temp, err == nil)
+	repo.log.WithContext(ctx).Println(err, sf_temp, err == nil)
 	return len(sf_temp) != 0
 }
 
-func (repo *SerialsFavouritesRepoPostgres) DeleteSerialById(idfav, idserial int) error {
-	repo.log.Info("Deleting serials_favourites from the database")
-	_, err := repo.db.Exec("DELETE FROM serials_favourites WHERE sf_idFavourite=$1 AND sf_idSerial=$2", idfav, idserial)
+func (repo *SerialsFavouritesRepoPostgres) DeleteSerialById(ctx context.Context, idfav, idserial int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_favourites from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials_favourites WHERE sf_idFavourite=$1 AND sf_idSerial=$2", idfav, idserial)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (repo *SerialsFavouritesRepoPostgres) DeleteSerialsFavourites(id int) error {
-	repo.log.Info("Deleting serials_favourites from the database")
-	_, err := repo.db.Exec("DELETE FROM serials_favourites WHERE sf_id=$1", id)
+func (repo *SerialsFavouritesRepoPostgres) DeleteSerialsFavourites(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_favourites from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials_favourites WHERE sf_id=$1", id)
 	if err != nil {
 		return err
 	}
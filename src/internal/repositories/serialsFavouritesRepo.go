@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/serialsFavourites/memory"
 	mg "app/internal/repositories/serialsFavourites/mongo"
 	pg "app/internal/repositories/serialsFavourites/postgres"
 
@@ -14,8 +16,14 @@ func NewSerialsFavouritesRepo(db interface{}, log *logrus.Logger) interfaces.IRe
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewSerialsFavouritesRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewSerialsFavouritesRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewSerialsFavouritesRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewSerialsFavouritesRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewSerialsFavouritesRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/serials/memory"
 	mg "app/internal/repositories/serials/mongo"
 	pg "app/internal/repositories/serials/postgres"
 
@@ -14,8 +16,14 @@ func NewSerialsRepo(db interface{}, log *logrus.Logger) interfaces.IRepoSerials
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewSerialsRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewSerialsRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewSerialsRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewSerialsRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewSerialsRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SerialsUsersRepoMemory struct {
+	table *memdb.Table[models.SerialsUsers, *models.SerialsUsers]
+	log   *logrus.Logger
+}
+
+func NewSerialsUsersRepoMemory(db *memdb.DB, log *logrus.Logger) *SerialsUsersRepoMemory {
+	return &SerialsUsersRepoMemory{table: memdb.NewTable[models.SerialsUsers](db, "serials_users"), log: log}
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_users from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials users from the database")
+	serialsUsers := repo.table.Select(nil)
+	return memdb.Paginate(serialsUsers, page.Offset(), page.Limit()), len(serialsUsers), nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id from the database")
+	return repo.table.Select(func(row *models.SerialsUsers) bool {
+		return row.GetIdUser() == id
+	}), nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials by user id from the database")
+	serialsUsers := repo.table.Select(func(row *models.SerialsUsers) bool {
+		return row.GetIdUser() == id
+	})
+	return memdb.Paginate(serialsUsers, page.Offset(), page.Limit()), len(serialsUsers), nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by serial id from the database")
+	return repo.table.Select(func(row *models.SerialsUsers) bool {
+		return row.GetIdSerial() == id
+	}), nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by id from the database")
+	serialUser, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return serialUser, nil
+}
+
+func (repo *SerialsUsersRepoMemory) GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id and serial id from the database")
+	serialUser, ok := repo.table.First(func(row *models.SerialsUsers) bool {
+		return row.GetIdUser() == userId && row.GetIdSerial() == serialId
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return serialUser, nil
+}
+
+func (repo *SerialsUsersRepoMemory) CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
+	if !serialUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating serials_users in the database")
+	repo.table.Insert(serialUser)
+	return nil
+}
+
+func (repo *SerialsUsersRepoMemory) UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
+	if !serialUser.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating serials_users in the database")
+	if !repo.table.Update(serialUser) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *SerialsUsersRepoMemory) DeleteSerialsUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *SerialsUsersRepoMemory) DeleteSerialsByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users by user id from the database")
+	repo.table.Delete(func(row *models.SerialsUsers) bool {
+		return row.GetIdUser() == id
+	})
+	return nil
+}
@@ -2,29 +2,34 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SerialsUsersRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewSerialsUsersRepoMongo(client *mongo.Client, log *logrus.Logger) *SerialsUsersRepoMongo {
 	db := client.Database("mydb")
-	return &SerialsUsersRepoMongo{db: db, log: log}
+	return &SerialsUsersRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
 func (repo *SerialsUsersRepoMongo) FormatDate(su *models.SerialsUsers) {
 	date := su.GetLastSeen()
-	d1, _ := time.Parse(time.RFC3339, date)
+	d1, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return
+	}
 	d2 := d1.Format("02.01.2006")
 	su.SetLastSeen(d2)
 }
@@ -35,10 +40,10 @@ func (repo *SerialsUsersRepoMongo) FormatDateList(suList []*models.SerialsUsers)
 	}
 }
 
-func (repo *SerialsUsersRepoMongo) GetSerialsUsers() ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting all serials_users from the database")
+func (repo *SerialsUsersRepoMongo) GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_users from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -47,7 +52,7 @@ func (repo *SerialsUsersRepoMongo) GetSerialsUsers() ([]*models.SerialsUsers, er
 	}
 	defer cursor.Close(ctx)
 
-	var serialsUsers []*models.SerialsUsers
+	serialsUsers := []*models.SerialsUsers{}
 	for cursor.Next(ctx) {
 		var serialUser models.SerialsUsers
 		if err := cursor.Decode(&serialUser); err != nil {
@@ -62,19 +67,36 @@ func (repo *SerialsUsersRepoMongo) GetSerialsUsers() ([]*models.SerialsUsers, er
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoMongo) GetSerialsByUserId(id int) ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by user id from the database")
+func (repo *SerialsUsersRepoMongo) GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials users from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	serialsUsers, total, err := mgdb.FindPage[models.SerialsUsers](ctx, repo.db.Collection("serials_users"), bson.M{}, bson.D{{Key: "su_id", Value: 1}}, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	repo.FormatDateList(serialsUsers)
+	return serialsUsers, total, nil
+}
+
+func (repo *SerialsUsersRepoMongo) GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"su_idUser": id})
+	cursor, err := collection.Find(ctx, bson.M{"su_iduser": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsUsers []*models.SerialsUsers
+	serialsUsers := []*models.SerialsUsers{}
 	for cursor.Next(ctx) {
 		var serialUser models.SerialsUsers
 		if err := cursor.Decode(&serialUser); err != nil {
@@ -89,19 +111,36 @@ func (repo *SerialsUsersRepoMongo) GetSerialsByUserId(id int) ([]*models.Serials
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoMongo) GetUsersBySerialId(id int) ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by serial id from the database")
+func (repo *SerialsUsersRepoMongo) GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials by user id from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	serialsUsers, total, err := mgdb.FindPage[models.SerialsUsers](ctx, repo.db.Collection("serials_users"), bson.M{"su_iduser": id}, bson.D{{Key: "su_id", Value: 1}}, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	repo.FormatDateList(serialsUsers)
+	return serialsUsers, total, nil
+}
+
+func (repo *SerialsUsersRepoMongo) GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by serial id from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"su_idSerial": id})
+	cursor, err := collection.Find(ctx, bson.M{"su_idserial": id})
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var serialsUsers []*models.SerialsUsers
+	serialsUsers := []*models.SerialsUsers{}
 	for cursor.Next(ctx) {
 		var serialUser models.SerialsUsers
 		if err := cursor.Decode(&serialUser); err != nil {
@@ -116,19 +155,17 @@ func (repo *SerialsUsersRepoMongo) GetUsersBySerialId(id int) ([]*models.Serials
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoMongo) GetSerialsUsersById(id int) (*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by id from the database")
+func (repo *SerialsUsersRepoMongo) GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by id from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return nil, err
-	}
-
 	var serialUser models.SerialsUsers
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&serialUser)
+	err := collection.FindOne(ctx, bson.M{"su_id": id}).Decode(&serialUser)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -136,14 +173,17 @@ func (repo *SerialsUsersRepoMongo) GetSerialsUsersById(id int) (*models.SerialsU
 	return &serialUser, nil
 }
 
-func (repo *SerialsUsersRepoMongo) GetSerialUserByIds(serialId, userId int) (*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by user id and serial id from the database")
+func (repo *SerialsUsersRepoMongo) GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id and serial id from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	var serialUser models.SerialsUsers
-	err := collection.FindOne(ctx, bson.M{"su_idUser": userId, "su_idSerial": serialId}).Decode(&serialUser)
+	err := collection.FindOne(ctx, bson.M{"su_iduser": userId, "su_idserial": serialId}).Decode(&serialUser)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -151,67 +191,70 @@ func (repo *SerialsUsersRepoMongo) GetSerialUserByIds(serialId, userId int) (*mo
 	return &serialUser, nil
 }
 
-func (repo *SerialsUsersRepoMongo) CreateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (repo *SerialsUsersRepoMongo) CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	if !serialUser.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating serials_users in the database")
+	repo.log.WithContext(ctx).Info("Creating serials_users in the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"su_idSerial": serialUser.GetIdSerial(),
-		"su_idUser":   serialUser.GetIdUser(),
-		"su_lastSeen": serialUser.GetLastSeen(),
-	})
+	id, err := repo.counters.NextId(ctx, "serials_users", "su_id")
+	if err != nil {
+		return err
+	}
+	serialUser.SetId(id)
+
+	_, err = collection.InsertOne(ctx, serialUser)
 	if err != nil {
 		return err
 	}
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	serialUser.SetId(res)
 	return nil
 }
 
-func (repo *SerialsUsersRepoMongo) UpdateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (repo *SerialsUsersRepoMongo) UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	if !serialUser.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_users in the database")
+	repo.log.WithContext(ctx).Info("Updating serials_users in the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(serialUser.GetId()))
+	res, err := collection.ReplaceOne(ctx, bson.M{"su_id": serialUser.GetId()}, serialUser)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"su_idSerial": serialUser.GetIdSerial(),
-			"su_idUser":   serialUser.GetIdUser(),
-			"su_lastSeen": serialUser.GetLastSeen(),
-		},
+	if res.MatchedCount == 0 {
+		return models.ErrNotFound
 	}
 
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return nil
+}
+
+func (repo *SerialsUsersRepoMongo) DeleteSerialsUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users from the database")
+	collection := repo.db.Collection("serials_users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"su_id": id})
 	if err != nil {
 		return err
 	}
-
 	return nil
 }
 
-func (repo *SerialsUsersRepoMongo) DeleteSerialsByUserId(id int) error {
-	repo.log.Info("Deleting serials_users by user id from the database")
+func (repo *SerialsUsersRepoMongo) DeleteSerialsByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users by user id from the database")
 	collection := repo.db.Collection("serials_users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, bson.M{"su_idUser": id})
+	_, err := collection.DeleteMany(ctx, bson.M{"su_iduser": id})
 	if err != nil {
 		return err
 	}
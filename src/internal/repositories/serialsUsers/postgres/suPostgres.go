@@ -2,18 +2,21 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type SerialsUsersRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewSerialsUsersRepoPostgres(db *sqlx.DB, log *logrus.Logger) *SerialsUsersRepoPostgres {
+func NewSerialsUsersRepoPostgres(db pgdb.Querier, log *logrus.Logger) *SerialsUsersRepoPostgres {
 	return &SerialsUsersRepoPostgres{db: db, log: log}
 }
 
@@ -24,16 +27,26 @@ func (repo *SerialsUsersRepoPostgres) FormatDate(su *models.SerialsUsers) {
 	su.SetLastSeen(d2)
 }
 
+// DbDate converts a date in the "02.01.2006" format used by the models
+// into the ISO form expected by the database. Other values are passed as is.
+func (repo *SerialsUsersRepoPostgres) DbDate(date string) string {
+	d, err := time.Parse("02.01.2006", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("2006-01-02")
+}
+
 func (repo *SerialsUsersRepoPostgres) FormatDateList(suList []*models.SerialsUsers) {
 	for _, su := range suList {
 		repo.FormatDate(su)
 	}
 }
 
-func (repo *SerialsUsersRepoPostgres) GetSerialsUsers() ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting all serials_users from the database")
+func (repo *SerialsUsersRepoPostgres) GetSerialsUsers(ctx context.Context) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting all serials_users from the database")
 	serialsUsers := []*models.SerialsUsers{}
-	err := repo.db.Select(&serialsUsers, "SELECT * FROM serials_users")
+	err := repo.db.SelectContext(ctx, &serialsUsers, "SELECT * FROM serials_users")
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +54,25 @@ func (repo *SerialsUsersRepoPostgres) GetSerialsUsers() ([]*models.SerialsUsers,
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoPostgres) GetSerialsByUserId(id int) ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by user id from the database")
+func (repo *SerialsUsersRepoPostgres) GetSerialsUsersPage(ctx context.Context, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials users from the database")
+	serialsUsers := []*models.SerialsUsers{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &serialsUsers, "serials_users", "su_id", page)
+	if err != nil {
+		return nil, 0, err
+	}
+	repo.FormatDateList(serialsUsers)
+	return serialsUsers, total, nil
+}
+
+func (repo *SerialsUsersRepoPostgres) GetSerialsByUserId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id from the database")
 	serialsUsers := []*models.SerialsUsers{}
-	err := repo.db.Select(&serialsUsers, "SELECT * FROM serials_users WHERE su_idUser=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsUsers, "SELECT * FROM serials_users WHERE su_idUser=$1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +80,25 @@ func (repo *SerialsUsersRepoPostgres) GetSerialsByUserId(id int) ([]*models.Seri
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoPostgres) GetUsersBySerialId(id int) ([]*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by serial id from the database")
+func (repo *SerialsUsersRepoPostgres) GetSerialsByUserIdPage(ctx context.Context, id int, page models.Page) ([]*models.SerialsUsers, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of serials by user id from the database")
+	serialsUsers := []*models.SerialsUsers{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &serialsUsers, "serials_users WHERE su_idUser=$1", "su_id", page, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	repo.FormatDateList(serialsUsers)
+	return serialsUsers, total, nil
+}
+
+func (repo *SerialsUsersRepoPostgres) GetUsersBySerialId(ctx context.Context, id int) ([]*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by serial id from the database")
 	serialsUsers := []*models.SerialsUsers{}
-	err := repo.db.Select(&serialsUsers, "SELECT * FROM serials_users WHERE su_idSerial=$1", id)
+	err := repo.db.SelectContext(ctx, &serialsUsers, "SELECT * FROM serials_users WHERE su_idSerial=$1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -63,10 +106,13 @@ func (repo *SerialsUsersRepoPostgres) GetUsersBySerialId(id int) ([]*models.Seri
 	return serialsUsers, nil
 }
 
-func (repo *SerialsUsersRepoPostgres) GetSerialsUsersById(id int) (*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by id from the database")
+func (repo *SerialsUsersRepoPostgres) GetSerialsUsersById(ctx context.Context, id int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by id from the database")
 	serialUser := &models.SerialsUsers{}
-	err := repo.db.Get(serialUser, "SELECT * FROM serials_users WHERE su_id=$1", id)
+	err := repo.db.GetContext(ctx, serialUser, "SELECT * FROM serials_users WHERE su_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -74,10 +120,13 @@ func (repo *SerialsUsersRepoPostgres) GetSerialsUsersById(id int) (*models.Seria
 	return serialUser, nil
 }
 
-func (repo *SerialsUsersRepoPostgres) GetSerialUserByIds(serialId, userId int) (*models.SerialsUsers, error) {
-	repo.log.Info("Getting serials_users by user id and serial id from the database")
+func (repo *SerialsUsersRepoPostgres) GetSerialUserByIds(ctx context.Context, serialId, userId int) (*models.SerialsUsers, error) {
+	repo.log.WithContext(ctx).Info("Getting serials_users by user id and serial id from the database")
 	serialUser := &models.SerialsUsers{}
-	err := repo.db.Get(serialUser, "SELECT * FROM serials_users WHERE su_idUser=$1 AND su_idSerial=$2", userId, serialId)
+	err := repo.db.GetContext(ctx, serialUser, "SELECT * FROM serials_users WHERE su_idUser=$1 AND su_idSerial=$2", userId, serialId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -85,15 +134,15 @@ func (repo *SerialsUsersRepoPostgres) GetSerialUserByIds(serialId, userId int) (
 	return serialUser, nil
 }
 
-func (repo *SerialsUsersRepoPostgres) CreateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (repo *SerialsUsersRepoPostgres) CreateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	if !serialUser.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating serials_users in the database")
-	err := repo.db.QueryRow("INSERT INTO serials_users (su_idSerial, su_idUser, su_lastSeen) VALUES ($1, $2, $3) RETURNING su_id",
-		serialUser.GetIdSerial(), serialUser.GetIdUser(), serialUser.GetLastSeen()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating serials_users in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO serials_users (su_idSerial, su_idUser, su_lastSeen) VALUES ($1, $2, $3) RETURNING su_id",
+		serialUser.GetIdSerial(), serialUser.GetIdUser(), repo.DbDate(serialUser.GetLastSeen())).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -102,25 +151,41 @@ func (repo *SerialsUsersRepoPostgres) CreateSerialsUsers(serialUser *models.Seri
 	return nil
 }
 
-func (repo *SerialsUsersRepoPostgres) UpdateSerialsUsers(serialUser *models.SerialsUsers) error {
+func (repo *SerialsUsersRepoPostgres) UpdateSerialsUsers(ctx context.Context, serialUser *models.SerialsUsers) error {
 	if !serialUser.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating serials_users in the database")
-	_, err := repo.db.Exec("UPDATE serials_users SET su_idSerial=$1, su_idUser=$2 WHERE su_id=$3",
-		serialUser.GetIdSerial(), serialUser.GetIdUser(), serialUser.GetId())
+	repo.log.WithContext(ctx).Info("Updating serials_users in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE serials_users SET su_idSerial=$1, su_idUser=$2, su_lastSeen=$3 WHERE su_id=$4",
+		serialUser.GetIdSerial(), serialUser.GetIdUser(), repo.DbDate(serialUser.GetLastSeen()), serialUser.GetId())
 
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
 
+func (repo *SerialsUsersRepoPostgres) DeleteSerialsUsers(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials_users WHERE su_id=$1", id)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-func (repo *SerialsUsersRepoPostgres) DeleteSerialsByUserId(id int) error {
-	repo.log.Info("Deleting serials_users by user id from the database")
-	_, err := repo.db.Exec("DELETE FROM serials_users WHERE su_idUser=$1", id)
+func (repo *SerialsUsersRepoPostgres) DeleteSerialsByUserId(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting serials_users by user id from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM serials_users WHERE su_idUser=$1", id)
 	if err != nil {
 		return err
 	}
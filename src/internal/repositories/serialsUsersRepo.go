@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/serialsUsers/memory"
 	mg "app/internal/repositories/serialsUsers/mongo"
 	pg "app/internal/repositories/serialsUsers/postgres"
 
@@ -14,8 +16,14 @@ func NewSerialsUsersRepo(db interface{}, log *logrus.Logger) interfaces.IRepoSer
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewSerialsUsersRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewSerialsUsersRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewSerialsUsersRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewSerialsUsersRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewSerialsUsersRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type StatisticRepoMemory struct {
+	table *memdb.Table[models.Statistic, *models.Statistic]
+	log   *logrus.Logger
+}
+
+func NewStatisticRepoMemory(db *memdb.DB, log *logrus.Logger) *StatisticRepoMemory {
+	return &StatisticRepoMemory{table: memdb.NewTable[models.Statistic](db, "statistic"), log: log}
+}
+
+// GetStatistic returns the single statistic row, creating an empty one on first use.
+func (repo *StatisticRepoMemory) GetStatistic(ctx context.Context) (*models.Statistic, error) {
+	repo.log.WithContext(ctx).Info("Getting statistic from the database")
+	return repo.table.FirstOrInsert(&models.Statistic{}), nil
+}
+
+func (repo *StatisticRepoMemory) UpdateStatistic(ctx context.Context, stat *models.Statistic) error {
+	if !stat.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating statistic in the database")
+	if !repo.table.Update(stat) {
+		return models.ErrNotFound
+	}
+	return nil
+}
@@ -2,40 +2,54 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type StatisticRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewStatisticRepoPostgres(db *sqlx.DB, log *logrus.Logger) *StatisticRepoPostgres {
+func NewStatisticRepoPostgres(db pgdb.Querier, log *logrus.Logger) *StatisticRepoPostgres {
 	return &StatisticRepoPostgres{db: db, log: log}
 }
 
-func (repo *StatisticRepoPostgres) GetStatistic() (*models.Statistic, error) {
-	repo.log.Info("Getting statistic from the database")
+// GetStatistic returns the single statistic row, creating an empty one on first use.
+func (repo *StatisticRepoPostgres) GetStatistic(ctx context.Context) (*models.Statistic, error) {
+	repo.log.WithContext(ctx).Info("Getting statistic from the database")
 	stat := &models.Statistic{}
-	err := repo.db.Get(stat, "SELECT * FROM statistic")
+	err := repo.db.GetContext(ctx, stat, "SELECT * FROM statistic ORDER BY st_id LIMIT 1")
+	if errors.Is(err, sql.ErrNoRows) {
+		err = repo.db.GetContext(ctx, stat, "INSERT INTO statistic (st_gender_male, st_gender_female, st_role_user, st_role_admin, st_age_0_18, st_age_19_30, st_age_31_50, st_age_51_100) VALUES (0, 0, 0, 0, 0, 0, 0, 0) RETURNING *")
+	}
 	if err != nil {
 		return nil, err
 	}
 	return stat, nil
 }
 
-func (repo *StatisticRepoPostgres) UpdateStatistic(stat *models.Statistic) error {
+func (repo *StatisticRepoPostgres) UpdateStatistic(ctx context.Context, stat *models.Statistic) error {
 	if !stat.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating statistic in the database")
-	_, err := repo.db.Exec("UPDATE statistic SET st_gender_male=$1, st_gender_female=$2, st_role_user=$3, st_role_admin=$4, st_age_0_18=$5, st_age_19_30=$6, st_age_31_50=$7, st_age_51_100=$8 WHERE st_id=$9",
+	repo.log.WithContext(ctx).Info("Updating statistic in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE statistic SET st_gender_male=$1, st_gender_female=$2, st_role_user=$3, st_role_admin=$4, st_age_0_18=$5, st_age_19_30=$6, st_age_31_50=$7, st_age_51_100=$8 WHERE st_id=$9",
 		stat.GetGenderMale(), stat.GetGenderFemale(), stat.GetRoleUser(), stat.GetRoleAdmin(), stat.GetAge0_18(), stat.GetAge19_30(), stat.GetAge31_50(), stat.GetAge51_100(), stat.GetId())
 	if err != nil {
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 	return nil
 }
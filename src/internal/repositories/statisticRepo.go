@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/statistic/memory"
 	mg "app/internal/repositories/statistic/mongo"
 	pg "app/internal/repositories/statistic/postgres"
 
@@ -14,8 +16,14 @@ func NewStatisticRepo(db interface{}, log *logrus.Logger) interfaces.IRepoStatis
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewStatisticRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewStatisticRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewStatisticRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewStatisticRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewStatisticRepoMemory(db, log)
 	default:
 		return nil
 	}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/unitOfWork/memory"
+	mg "app/internal/repositories/unitOfWork/mongo"
+	pg "app/internal/repositories/unitOfWork/postgres"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// runner starts a transaction of one backend and passes its context and
+// handle to fn. The handle is accepted by the NewXRepo constructors.
+type runner interface {
+	Do(ctx context.Context, fn func(ctx context.Context, tx interface{}) error) error
+}
+
+type unitOfWork struct {
+	runner runner
+	log    *logrus.Logger
+}
+
+func NewUnitOfWork(db interface{}, log *logrus.Logger) interfaces.IUnitOfWork {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return &unitOfWork{runner: pg.NewUnitOfWorkPostgres(db, log), log: log}
+	case *mongo.Client:
+		return &unitOfWork{runner: mg.NewUnitOfWorkMongo(db, log), log: log}
+	case *memdb.DB:
+		return &unitOfWork{runner: mem.NewUnitOfWorkMemory(db, log), log: log}
+	default:
+		return nil
+	}
+}
+
+func (uow *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx interfaces.ITx) error) error {
+	return uow.runner.Do(ctx, func(ctx context.Context, tx interface{}) error {
+		return fn(ctx, &txRepos{tx: tx, log: uow.log})
+	})
+}
+
+type txRepos struct {
+	tx  interface{}
+	log *logrus.Logger
+}
+
+func (r *txRepos) Actors() interfaces.IRepoActors {
+	return NewActorsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Comments() interfaces.IRepoComments {
+	return NewCommentsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) CommentsReports() interfaces.IRepoCommentsReports {
+	return NewCommentsReportsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) CommentsVotes() interfaces.IRepoCommentsVotes {
+	return NewCommentsVotesRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Comparisons() interfaces.IRepoComparisons {
+	return NewComparisonsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Episodes() interfaces.IRepoEpisodes {
+	return NewEpisodesRepo(r.tx, r.log)
+}
+
+func (r *txRepos) EpisodesUsers() interfaces.IRepoEpisodesUsers {
+	return NewEpisodesUsersRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Favourites() interfaces.IRepoFavourites {
+	return NewFavouritesRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Moderations() interfaces.IRepoModerations {
+	return NewModerationsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Producers() interfaces.IRepoProducers {
+	return NewProducersRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Ratings() interfaces.IRepoRatings {
+	return NewRatingsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Recommendations() interfaces.IRepoRecommendations {
+	return NewRecommendationsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Seasons() interfaces.IRepoSeasons {
+	return NewSeasonsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Serials() interfaces.IRepoSerials {
+	return NewSerialsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) SerialsActors() interfaces.IRepoSerialsActors {
+	return NewSerialsActorsRepo(r.tx, r.log)
+}
+
+func (r *txRepos) SerialsFavourites() interfaces.IRepoSerialsFavourites {
+	return NewSerialsFavouritesRepo(r.tx, r.log)
+}
+
+func (r *txRepos) SerialsUsers() interfaces.IRepoSerialsUsers {
+	return NewSerialsUsersRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Statistic() interfaces.IRepoStatistic {
+	return NewStatisticRepo(r.tx, r.log)
+}
+
+func (r *txRepos) Users() interfaces.IRepoUsers {
+	return NewUsersRepo(r.tx, r.log)
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type UnitOfWorkMemory struct {
+	db  *memdb.DB
+	log *logrus.Logger
+}
+
+func NewUnitOfWorkMemory(db *memdb.DB, log *logrus.Logger) *UnitOfWorkMemory {
+	return &UnitOfWorkMemory{db: db, log: log}
+}
+
+// Do calls fn with the database itself and restores the data if fn fails,
+// see memdb.DB.Tx for the limits of the rollback.
+func (uow *UnitOfWorkMemory) Do(ctx context.Context, fn func(ctx context.Context, tx interface{}) error) error {
+	return uow.db.Tx(func() error {
+		return fn(ctx, uow.db)
+	})
+}
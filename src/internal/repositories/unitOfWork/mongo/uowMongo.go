@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UnitOfWorkMongo struct {
+	client *mongo.Client
+	log    *logrus.Logger
+}
+
+func NewUnitOfWorkMongo(client *mongo.Client, log *logrus.Logger) *UnitOfWorkMongo {
+	return &UnitOfWorkMongo{client: client, log: log}
+}
+
+// Do calls fn with the mongo.SessionContext of a session transaction and
+// commits it if fn succeeds. Transactions need a replica set or a sharded
+// cluster. The driver retries fn on transient transaction errors. The
+// repositories must be called with the context passed to fn: it carries the
+// session.
+func (uow *UnitOfWorkMongo) Do(ctx context.Context, fn func(ctx context.Context, tx interface{}) error) error {
+	session, err := uow.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc, sc)
+	})
+	if err != nil {
+		uow.log.WithContext(ctx).Info("Transaction aborted")
+	}
+	return err
+}
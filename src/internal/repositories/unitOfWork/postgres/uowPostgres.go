@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type UnitOfWorkPostgres struct {
+	db  *sqlx.DB
+	log *logrus.Logger
+}
+
+func NewUnitOfWorkPostgres(db *sqlx.DB, log *logrus.Logger) *UnitOfWorkPostgres {
+	return &UnitOfWorkPostgres{db: db, log: log}
+}
+
+// Do calls fn with a *sqlx.Tx and commits it if fn succeeds. The transaction
+// is rolled back if ctx is done before the commit.
+func (uow *UnitOfWorkPostgres) Do(ctx context.Context, fn func(ctx context.Context, tx interface{}) error) error {
+	tx, err := uow.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(ctx, tx)
+	if err != nil {
+		uow.log.WithContext(ctx).Info("Rolling back the transaction")
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"app/internal/models"
+	"app/internal/repositories/memdb"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type UsersRepoMemory struct {
+	table *memdb.Table[models.Users, *models.Users]
+	log   *logrus.Logger
+}
+
+func NewUsersRepoMemory(db *memdb.DB, log *logrus.Logger) *UsersRepoMemory {
+	return &UsersRepoMemory{table: memdb.NewTable[models.Users](db, "users"), log: log}
+}
+
+func (repo *UsersRepoMemory) GetUsers(ctx context.Context) ([]*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting all users from the database")
+	return repo.table.Select(nil), nil
+}
+
+func (repo *UsersRepoMemory) GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of users from the database")
+	users := repo.table.Select(nil)
+	return memdb.Paginate(users, page.Offset(), page.Limit()), len(users), nil
+}
+
+func (repo *UsersRepoMemory) GetUserById(ctx context.Context, id int) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by id from the database")
+	user, ok := repo.table.Get(id)
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return user, nil
+}
+
+func (repo *UsersRepoMemory) GetUserByLogin(ctx context.Context, login string) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by login from the database")
+	user, ok := repo.table.First(func(row *models.Users) bool {
+		return row.GetLogin() == login
+	})
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return user, nil
+}
+
+func (repo *UsersRepoMemory) CreateUser(ctx context.Context, user *models.Users) error {
+	if !user.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Creating user in the database")
+	repo.table.Insert(user)
+	return nil
+}
+
+func (repo *UsersRepoMemory) UpdateUser(ctx context.Context, user *models.Users) error {
+	if !user.Validate() {
+		return models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Updating user in the database")
+	if !repo.table.Update(user) {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (repo *UsersRepoMemory) DeleteUser(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting user from the database")
+	repo.table.DeleteById(id)
+	return nil
+}
+
+func (repo *UsersRepoMemory) CheckUser(ctx context.Context, login string) bool {
+	repo.log.WithContext(ctx).Info("Checking user by login from the database")
+	_, err := repo.GetUserByLogin(ctx, login)
+	return err == nil
+}
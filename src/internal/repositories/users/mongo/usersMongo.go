@@ -2,29 +2,34 @@ package mongo
 
 import (
 	"app/internal/models"
+	counters "app/internal/repositories/counters/mongo"
+	"app/internal/repositories/mgdb"
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type UsersRepoMongo struct {
-	db  *mongo.Database
-	log *logrus.Logger
+	db       *mongo.Database
+	log      *logrus.Logger
+	counters *counters.CountersRepoMongo
 }
 
 func NewUsersRepoMongo(client *mongo.Client, log *logrus.Logger) *UsersRepoMongo {
 	db := client.Database("mydb")
-	return &UsersRepoMongo{db: db, log: log}
+	return &UsersRepoMongo{db: db, log: log, counters: counters.NewCountersRepoMongo(db)}
 }
 
 func (repo *UsersRepoMongo) FormatDate(user *models.Users) {
 	date := user.GetBdate()
-	d1, _ := time.Parse("2006-01-02T00:00:00Z", date)
+	d1, err := time.Parse("2006-01-02T00:00:00Z", date)
+	if err != nil {
+		return
+	}
 	d2 := d1.Format("02.01.2006")
 	user.SetBdate(d2)
 }
@@ -35,10 +40,10 @@ func (repo *UsersRepoMongo) FormatDateList(users []*models.Users) {
 	}
 }
 
-func (repo *UsersRepoMongo) GetUsers() ([]*models.Users, error) {
-	repo.log.Info("Getting all users from the database")
+func (repo *UsersRepoMongo) GetUsers(ctx context.Context) ([]*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting all users from the database")
 	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	cursor, err := collection.Find(ctx, bson.M{})
@@ -47,7 +52,7 @@ func (repo *UsersRepoMongo) GetUsers() ([]*models.Users, error) {
 	}
 	defer cursor.Close(ctx)
 
-	var users []*models.Users
+	users := []*models.Users{}
 	for cursor.Next(ctx) {
 		var user models.Users
 		if err := cursor.Decode(&user); err != nil {
@@ -62,19 +67,34 @@ func (repo *UsersRepoMongo) GetUsers() ([]*models.Users, error) {
 	return users, nil
 }
 
-func (repo *UsersRepoMongo) GetUserById(id int) (*models.Users, error) {
-	repo.log.Info("Getting user by id from the database")
-	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (repo *UsersRepoMongo) GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of users from the database")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
+	users, total, err := mgdb.FindPage[models.Users](ctx, repo.db.Collection("users"), bson.M{}, bson.D{{Key: "u_id", Value: 1}}, page)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	repo.FormatDateList(users)
+	return users, total, nil
+}
+
+func (repo *UsersRepoMongo) GetUserById(ctx context.Context, id int) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by id from the database")
+	collection := repo.db.Collection("users")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
 	var user models.Users
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&user)
+	err := collection.FindOne(ctx, bson.M{"u_id": id}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -82,14 +102,17 @@ func (repo *UsersRepoMongo) GetUserById(id int) (*models.Users, error) {
 	return &user, nil
 }
 
-func (repo *UsersRepoMongo) GetUserByLogin(login string) (*models.Users, error) {
-	repo.log.Info("Getting user by login from the database")
+func (repo *UsersRepoMongo) GetUserByLogin(ctx context.Context, login string) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by login from the database")
 	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	var user models.Users
 	err := collection.FindOne(ctx, bson.M{"u_login": login}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -97,91 +120,62 @@ func (repo *UsersRepoMongo) GetUserByLogin(login string) (*models.Users, error)
 	return &user, nil
 }
 
-func (repo *UsersRepoMongo) CreateUser(user *models.Users) error {
+func (repo *UsersRepoMongo) CreateUser(ctx context.Context, user *models.Users) error {
 	if !user.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Creating user in the database")
+	repo.log.WithContext(ctx).Info("Creating user in the database")
 	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := collection.InsertOne(ctx, bson.M{
-		"u_login":        user.GetLogin(),
-		"u_password":     user.GetPassword(),
-		"u_role":         user.GetRole(),
-		"u_name":         user.GetName(),
-		"u_surname":      user.GetSurname(),
-		"u_gender":       user.GetGender(),
-		"u_bdate":        user.GetBdate(),
-		"u_idFavourites": user.GetIdFavourites(),
-	})
+	id, err := repo.counters.NextId(ctx, "users", "u_id")
 	if err != nil {
 		return err
 	}
+	user.SetId(id)
 
-	res, _ := strconv.Atoi(result.InsertedID.(primitive.ObjectID).Hex())
-	user.SetId(res)
+	_, err = collection.InsertOne(ctx, user)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-func (repo *UsersRepoMongo) UpdateUser(user *models.Users) error {
+func (repo *UsersRepoMongo) UpdateUser(ctx context.Context, user *models.Users) error {
 	if !user.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating user in the database")
+	repo.log.WithContext(ctx).Info("Updating user in the database")
 	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(user.GetId()))
+	_, err := collection.ReplaceOne(ctx, bson.M{"u_id": user.GetId()}, user)
 	if err != nil {
-		return err
-	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"u_login":        user.GetLogin(),
-			"u_password":     user.GetPassword(),
-			"u_role":         user.GetRole(),
-			"u_name":         user.GetName(),
-			"u_surname":      user.GetSurname(),
-			"u_gender":       user.GetGender(),
-			"u_bdate":        user.GetBdate(),
-			"u_idFavourites": user.GetIdFavourites(),
-		},
-	}
-
-	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
-	if err != nil {
-		repo.log.Error(err)
+		repo.log.WithContext(ctx).Error(err)
 		return err
 	}
 	return nil
 }
 
-func (repo *UsersRepoMongo) DeleteUser(id int) error {
-	repo.log.Info("Deleting user from the database")
+func (repo *UsersRepoMongo) DeleteUser(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting user from the database")
 	collection := repo.db.Collection("users")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(strconv.Itoa(id))
-	if err != nil {
-		return err
-	}
-
-	_, err = collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := collection.DeleteOne(ctx, bson.M{"u_id": id})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (repo *UsersRepoMongo) CheckUser(login string) bool {
-	repo.log.Info("Checking user by login from the database")
-	_, err := repo.GetUserByLogin(login)
+func (repo *UsersRepoMongo) CheckUser(ctx context.Context, login string) bool {
+	repo.log.WithContext(ctx).Info("Checking user by login from the database")
+	_, err := repo.GetUserByLogin(ctx, login)
 	return err == nil
 }
@@ -2,18 +2,21 @@ package postgres
 
 import (
 	"app/internal/models"
+	"app/internal/repositories/pgdb"
+	"context"
+	"database/sql"
+	"errors"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type UsersRepoPostgres struct {
-	db  *sqlx.DB
+	db  pgdb.Querier
 	log *logrus.Logger
 }
 
-func NewUsersRepoPostgres(db *sqlx.DB, log *logrus.Logger) *UsersRepoPostgres {
+func NewUsersRepoPostgres(db pgdb.Querier, log *logrus.Logger) *UsersRepoPostgres {
 	return &UsersRepoPostgres{db: db, log: log}
 }
 
@@ -24,16 +27,26 @@ func (repo *UsersRepoPostgres) FormatDate(user *models.Users) {
 	user.SetBdate(d2)
 }
 
+// DbDate converts a date in the "02.01.2006" format used by the models
+// into the ISO form expected by the database. Other values are passed as is.
+func (repo *UsersRepoPostgres) DbDate(date string) string {
+	d, err := time.Parse("02.01.2006", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("2006-01-02")
+}
+
 func (repo *UsersRepoPostgres) FormatDateList(users []*models.Users) {
 	for _, user := range users {
 		repo.FormatDate(user)
 	}
 }
 
-func (repo *UsersRepoPostgres) GetUsers() ([]*models.Users, error) {
-	repo.log.Info("Getting all users from the database")
+func (repo *UsersRepoPostgres) GetUsers(ctx context.Context) ([]*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting all users from the database")
 	users := []*models.Users{}
-	err := repo.db.Select(&users, "SELECT * FROM users")
+	err := repo.db.SelectContext(ctx, &users, "SELECT * FROM users")
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +54,28 @@ func (repo *UsersRepoPostgres) GetUsers() ([]*models.Users, error) {
 	return users, nil
 }
 
-func (repo *UsersRepoPostgres) GetUserById(id int) (*models.Users, error) {
-	repo.log.Info("Getting user by id from the database")
+func (repo *UsersRepoPostgres) GetUsersPage(ctx context.Context, page models.Page) ([]*models.Users, int, error) {
+	if !page.Validate() {
+		return nil, 0, models.ErrInvalidModel
+	}
+
+	repo.log.WithContext(ctx).Info("Getting a page of users from the database")
+	users := []*models.Users{}
+	total, err := pgdb.SelectPage(ctx, repo.db, &users, "users", "u_id", page)
+	if err != nil {
+		return nil, 0, err
+	}
+	repo.FormatDateList(users)
+	return users, total, nil
+}
+
+func (repo *UsersRepoPostgres) GetUserById(ctx context.Context, id int) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by id from the database")
 	user := &models.Users{}
-	err := repo.db.Get(user, "SELECT * FROM users WHERE u_id=$1", id)
+	err := repo.db.GetContext(ctx, user, "SELECT * FROM users WHERE u_id=$1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +83,13 @@ func (repo *UsersRepoPostgres) GetUserById(id int) (*models.Users, error) {
 	return user, nil
 }
 
-func (repo *UsersRepoPostgres) GetUserByLogin(login string) (*models.Users, error) {
-	repo.log.Info("Getting user by login from the database")
+func (repo *UsersRepoPostgres) GetUserByLogin(ctx context.Context, login string) (*models.Users, error) {
+	repo.log.WithContext(ctx).Info("Getting user by login from the database")
 	user := &models.Users{}
-	err := repo.db.Get(user, "SELECT * FROM users WHERE u_login=$1", login)
+	err := repo.db.GetContext(ctx, user, "SELECT * FROM users WHERE u_login=$1", login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +97,15 @@ func (repo *UsersRepoPostgres) GetUserByLogin(login string) (*models.Users, erro
 	return user, nil
 }
 
-func (repo *UsersRepoPostgres) CreateUser(user *models.Users) error {
+func (repo *UsersRepoPostgres) CreateUser(ctx context.Context, user *models.Users) error {
 	if !user.Validate() {
 		return models.ErrInvalidModel
 	}
 	var id int64
 
-	repo.log.Info("Creating user in the database")
-	err := repo.db.QueryRow("INSERT INTO users (u_login, u_password, u_role, u_name, u_surname, u_gender, u_bdate, u_idFavourites) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING u_id",
-		user.GetLogin(), user.GetPassword(), user.GetRole(), user.GetName(), user.GetSurname(), user.GetGender(), user.GetBdate(), user.GetIdFavourites()).Scan(&id)
+	repo.log.WithContext(ctx).Info("Creating user in the database")
+	err := repo.db.QueryRowContext(ctx, "INSERT INTO users (u_login, u_password, u_role, u_name, u_surname, u_gender, u_bdate, u_idFavourites) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING u_id",
+		user.GetLogin(), user.GetPassword(), user.GetRole(), user.GetName(), user.GetSurname(), user.GetGender(), repo.DbDate(user.GetBdate()), user.GetIdFavourites()).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -80,33 +114,40 @@ func (repo *UsersRepoPostgres) CreateUser(user *models.Users) error {
 	return nil
 }
 
-func (repo *UsersRepoPostgres) UpdateUser(user *models.Users) error {
+func (repo *UsersRepoPostgres) UpdateUser(ctx context.Context, user *models.Users) error {
 	if !user.Validate() {
 		return models.ErrInvalidModel
 	}
 
-	repo.log.Info("Updating user in the database")
-	_, err := repo.db.Exec("UPDATE users SET u_login=$1, u_password=$2, u_role=$3, u_name=$4, u_surname=$5, u_gender=$6, u_bdate=$7, u_idFavourites=$8 WHERE u_id=$9",
-		user.GetLogin(), user.GetPassword(), user.GetRole(), user.GetName(), user.GetSurname(), user.GetGender(), user.GetBdate(), user.GetIdFavourites(), user.GetId())
+	repo.log.WithContext(ctx).Info("Updating user in the database")
+	res, err := repo.db.ExecContext(ctx, "UPDATE users SET u_login=$1, u_password=$2, u_role=$3, u_name=$4, u_surname=$5, u_gender=$6, u_bdate=$7, u_idFavourites=$8 WHERE u_id=$9",
+		user.GetLogin(), user.GetPassword(), user.GetRole(), user.GetName(), user.GetSurname(), user.GetGender(), repo.DbDate(user.GetBdate()), user.GetIdFavourites(), user.GetId())
 
 	if err != nil {
-		repo.log.Error(err)
+		repo.log.WithContext(ctx).Error(err)
 		return err
 	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return models.ErrNotFound
+	}
 	return nil
 }
 
-func (repo *UsersRepoPostgres) DeleteUser(id int) error {
-	repo.log.Info("Deleting user from the database")
-	_, err := repo.db.Exec("DELETE FROM users WHERE u_id=$1", id)
+func (repo *UsersRepoPostgres) DeleteUser(ctx context.Context, id int) error {
+	repo.log.WithContext(ctx).Info("Deleting user from the database")
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM users WHERE u_id=$1", id)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (repo *UsersRepoPostgres) CheckUser(login string) bool {
-	repo.log.Info("Checking user by login from the database")
-	_, err := repo.GetUserByLogin(login)
+func (repo *UsersRepoPostgres) CheckUser(ctx context.Context, login string) bool {
+	repo.log.WithContext(ctx).Info("Checking user by login from the database")
+	_, err := repo.GetUserByLogin(ctx, login)
 	return err == nil
 }
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"app/internal/interfaces"
+	"app/internal/repositories/memdb"
+	mem "app/internal/repositories/users/memory"
 	mg "app/internal/repositories/users/mongo"
 	pg "app/internal/repositories/users/postgres"
 
@@ -14,8 +16,14 @@ func NewUsersRepo(db interface{}, log *logrus.Logger) interfaces.IRepoUsers {
 	switch db := db.(type) {
 	case *sqlx.DB:
 		return pg.NewUsersRepoPostgres(db, log)
+	case *sqlx.Tx:
+		return pg.NewUsersRepoPostgres(db, log)
 	case *mongo.Client:
 		return mg.NewUsersRepoMongo(db, log)
+	case mongo.SessionContext:
+		return mg.NewUsersRepoMongo(db.Client(), log)
+	case *memdb.DB:
+		return mem.NewUsersRepoMemory(db, log)
 	default:
 		return nil
 	}
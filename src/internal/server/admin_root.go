@@ -4,9 +4,14 @@ import (
 	"app/internal/controllers"
 	"app/internal/models"
 	"app/internal/repositories"
+	"context"
+	"errors"
+	"html/template"
 	"net/http"
+	"sort"
 	"strconv"
-	"text/template"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -107,11 +112,11 @@ func (s *srv) HandleAddSerial() http.HandlerFunc {
 			s.AcceptAddSerial(w, r)
 			return
 		}
-		s.addSerialTemplate(w, "")
+		s.addSerialTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) addSerialTemplate(w http.ResponseWriter, err string) {
+func (s *srv) addSerialTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type addSerialErr struct {
 		Err       string
 		Producers []*models.Producers
@@ -119,13 +124,13 @@ func (s *srv) addSerialTemplate(w http.ResponseWriter, err string) {
 	tmpl, _ := template.ParseFiles("templates/admin/addSerial.html")
 	cerr := &addSerialErr{Err: err}
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producers, _ := ctrl.GetProducers()
+	producers, _ := ctrl.GetProducers(ctx)
 	cerr.Producers = producers
 	tmpl.Execute(w, cerr)
 }
 
 func (s *srv) AcceptAddSerial(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
 	serial := &models.Serial{
 		S_name:        r.FormValue("name"),
 		S_description: r.FormValue("description"),
@@ -135,29 +140,24 @@ func (s *srv) AcceptAddSerial(w http.ResponseWriter, r *http.Request) {
 	}
 	idProducer, err := strconv.Atoi(r.FormValue("idProducer"))
 	if err != nil {
-		s.addSerialTemplate(w, "Продюссер не выбран")
+		s.addSerialTemplate(r.Context(), w, "Продюссер не выбран")
 		return
 	}
 	serial.SetIdProducer(idProducer)
 	year, err := strconv.Atoi(r.FormValue("year"))
 	if err != nil {
-		s.addSerialTemplate(w, "Год выхода должен быть числом")
+		s.addSerialTemplate(r.Context(), w, "Год выхода должен быть числом")
 		return
 	}
 	serial.SetYear(year)
-	rating, err := strconv.ParseFloat(r.FormValue("rating"), 32)
-	if err != nil {
-		s.addSerialTemplate(w, "Рейтинг должен быть числом")
-		return
-	}
-	serial.SetRating(float32(rating))
 	serial.SetSeasons(0)
 
-	err = ctrl.CreateSerial(serial)
+	err = ctrl.CreateSerial(r.Context(), serial)
 	if err != nil {
-		s.addSerialTemplate(w, "Ошибка создания сериала")
+		s.addSerialTemplate(r.Context(), w, "Ошибка создания сериала")
 		return
 	}
+	s.similar.Invalidate()
 	http.Redirect(w, r, "/admin/cabinet/1", http.StatusSeeOther)
 }
 
@@ -170,21 +170,21 @@ func (s *srv) HandleUpdateSerial() http.HandlerFunc {
 			s.ChoosenSerial(w, r)
 			return
 		}
-		s.updateSerialTemplate(w, "", nil)
+		s.updateSerialTemplate(r.Context(), w, "", nil)
 	}
 }
 
-func (s *srv) updateSerialTemplate(w http.ResponseWriter, err string, serial *models.Serial) {
+func (s *srv) updateSerialTemplate(ctx context.Context, w http.ResponseWriter, err string, serial *models.Serial) {
 	type updateSerialErr struct {
 		S         *models.Serial
 		Serials   []*models.Serial
 		Producers []*models.Producers
 		Err       string
 	}
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, _ := ctrl.GetSerials()
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serials, _ := ctrl.GetSerials(ctx)
 	ctrlProducer := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producers, _ := ctrlProducer.GetProducers()
+	producers, _ := ctrlProducer.GetProducers(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/updateSerial.html")
 	cerr := &updateSerialErr{Err: err, Serials: serials, S: serial, Producers: producers}
 	tmpl.Execute(w, cerr)
@@ -192,15 +192,15 @@ func (s *srv) updateSerialTemplate(w http.ResponseWriter, err string, serial *mo
 
 func (s *srv) ChoosenSerial(w http.ResponseWriter, r *http.Request) {
 	s_id, _ := strconv.Atoi(r.FormValue("serial"))
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serial, _ := ctrl.GetSerialById(s_id)
-	s.updateSerialTemplate(w, "", serial)
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serial, _ := ctrl.GetSerialById(r.Context(), s_id)
+	s.updateSerialTemplate(r.Context(), w, "", serial)
 }
 
 func (s *srv) AcceptUpdateSerial(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
 	s_id, _ := strconv.Atoi(r.FormValue("id"))
-	serial_prev, _ := ctrl.GetSerialById(s_id)
+	serial_prev, _ := ctrl.GetSerialById(r.Context(), s_id)
 	serial := &models.Serial{
 		S_id:          s_id,
 		S_name:        r.FormValue("name"),
@@ -211,30 +211,26 @@ func (s *srv) AcceptUpdateSerial(w http.ResponseWriter, r *http.Request) {
 	}
 	idProducer, err := strconv.Atoi(r.FormValue("idProducer"))
 	if err != nil {
-		s.updateSerialTemplate(w, "id продюсера должен быть числом", serial_prev)
+		s.updateSerialTemplate(r.Context(), w, "id продюсера должен быть числом", serial_prev)
 		return
 	}
 	serial.SetIdProducer(idProducer)
 	year, err := strconv.Atoi(r.FormValue("year"))
 	if err != nil {
-		s.updateSerialTemplate(w, "Год выхода должен быть числом", serial_prev)
+		s.updateSerialTemplate(r.Context(), w, "Год выхода должен быть числом", serial_prev)
 		return
 	}
 	serial.SetYear(year)
-	rating, err := strconv.ParseFloat(r.FormValue("rating"), 32)
-	if err != nil {
-		s.updateSerialTemplate(w, "Рейтинг должен быть числом", serial_prev)
-		return
-	}
-	serial.SetRating(float32(rating))
 	serial.SetSeasons(serial_prev.S_seasons)
-	serial.S_duration = serial_prev.S_duration
+	serial.SetRating(serial_prev.S_rating)
+	serial.SetVotes(serial_prev.S_votes)
 
-	err = ctrl.UpdateSerial(serial)
+	err = ctrl.UpdateSerial(r.Context(), serial)
 	if err != nil {
-		s.updateSerialTemplate(w, "Ошибка обновления сериала", serial_prev)
+		s.updateSerialTemplate(r.Context(), w, "Ошибка обновления сериала", serial_prev)
 		return
 	}
+	s.similar.Invalidate()
 	http.Redirect(w, r, "/admin/cabinet/2", http.StatusSeeOther)
 }
 
@@ -244,34 +240,35 @@ func (s *srv) HandleDeleteSerial() http.HandlerFunc {
 			s.AcceptDeleteSerial(w, r)
 			return
 		}
-		s.deleteSerialTemplate(w, "")
+		s.deleteSerialTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) deleteSerialTemplate(w http.ResponseWriter, err string) {
+func (s *srv) deleteSerialTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type deleteSerialErr struct {
 		Err     string
 		Serials []*models.Serial
 	}
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, _ := ctrl.GetSerials()
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serials, _ := ctrl.GetSerials(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/deleteSerial.html")
 	cerr := &deleteSerialErr{Err: err, Serials: serials}
 	tmpl.Execute(w, cerr)
 }
 
 func (s *srv) AcceptDeleteSerial(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
 	s_id, err := strconv.Atoi(r.FormValue("serial"))
 	if err != nil {
-		s.deleteSerialTemplate(w, "Сериал не выбран")
+		s.deleteSerialTemplate(r.Context(), w, "Сериал не выбран")
 		return
 	}
-	err = ctrl.DeleteSerial(s_id)
+	err = ctrl.DeleteSerial(r.Context(), s_id)
 	if err != nil {
-		s.deleteSerialTemplate(w, "Ошибка удаления сериала")
+		s.deleteSerialTemplate(r.Context(), w, "Ошибка удаления сериала")
 		return
 	}
+	s.similar.Invalidate()
 	http.Redirect(w, r, "/admin/cabinet/3", http.StatusSeeOther)
 }
 
@@ -304,7 +301,7 @@ func (s *srv) AcceptAddProducer(w http.ResponseWriter, r *http.Request) {
 		s.addProducerTemplate(w, "Имя и фамилия не могут быть пустыми")
 		return
 	}
-	err := ctrl.CreateProducer(producer)
+	err := ctrl.CreateProducer(r.Context(), producer)
 	if err != nil {
 		s.addProducerTemplate(w, "Ошибка создания продюссера")
 		return
@@ -321,18 +318,18 @@ func (s *srv) HandleUpdateProducer() http.HandlerFunc {
 			s.ChoosenProducer(w, r)
 			return
 		}
-		s.updateProducerTemplate(w, "", nil)
+		s.updateProducerTemplate(r.Context(), w, "", nil)
 	}
 }
 
-func (s *srv) updateProducerTemplate(w http.ResponseWriter, err string, producer *models.Producers) {
+func (s *srv) updateProducerTemplate(ctx context.Context, w http.ResponseWriter, err string, producer *models.Producers) {
 	type updateProducerErr struct {
 		P         *models.Producers
 		Producers []*models.Producers
 		Err       string
 	}
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producers, _ := ctrl.GetProducers()
+	producers, _ := ctrl.GetProducers(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/updateProducer.html")
 	cerr := &updateProducerErr{Err: err, Producers: producers, P: producer}
 	tmpl.Execute(w, cerr)
@@ -341,27 +338,27 @@ func (s *srv) updateProducerTemplate(w http.ResponseWriter, err string, producer
 func (s *srv) ChoosenProducer(w http.ResponseWriter, r *http.Request) {
 	p_id, _ := strconv.Atoi(r.FormValue("producer"))
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producer, _ := ctrl.GetProducerById(p_id)
-	s.Log.Println(producer)
-	s.updateProducerTemplate(w, "", producer)
+	producer, _ := ctrl.GetProducerById(r.Context(), p_id)
+	s.Log.WithContext(r.Context()).Println(producer)
+	s.updateProducerTemplate(r.Context(), w, "", producer)
 }
 
 func (s *srv) AcceptUpdateProducer(w http.ResponseWriter, r *http.Request) {
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
 	p_id, _ := strconv.Atoi(r.FormValue("id"))
-	prev_producer, _ := ctrl.GetProducerById(p_id)
+	prev_producer, _ := ctrl.GetProducerById(r.Context(), p_id)
 	producer := &models.Producers{
 		P_id:      p_id,
 		P_name:    r.FormValue("name"),
 		P_surname: r.FormValue("surname"),
 	}
 	if !producer.Validate() {
-		s.updateProducerTemplate(w, "Имя и фамилия не могут быть пустыми", prev_producer)
+		s.updateProducerTemplate(r.Context(), w, "Имя и фамилия не могут быть пустыми", prev_producer)
 		return
 	}
-	err := ctrl.UpdateProducer(producer)
+	err := ctrl.UpdateProducer(r.Context(), producer)
 	if err != nil {
-		s.updateProducerTemplate(w, "Ошибка обновления продюссера", prev_producer)
+		s.updateProducerTemplate(r.Context(), w, "Ошибка обновления продюссера", prev_producer)
 		return
 	}
 	http.Redirect(w, r, "/admin/cabinet/5", http.StatusSeeOther)
@@ -373,17 +370,17 @@ func (s *srv) HandleDeleteProducer() http.HandlerFunc {
 			s.AcceptDeleteProducer(w, r)
 			return
 		}
-		s.deleteProducerTemplate(w, "")
+		s.deleteProducerTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) deleteProducerTemplate(w http.ResponseWriter, err string) {
+func (s *srv) deleteProducerTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type deleteProducerErr struct {
 		Err       string
 		Producers []*models.Producers
 	}
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producers, _ := ctrl.GetProducers()
+	producers, _ := ctrl.GetProducers(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/deleteProducer.html")
 	cerr := &deleteProducerErr{Err: err, Producers: producers}
 	tmpl.Execute(w, cerr)
@@ -393,12 +390,12 @@ func (s *srv) AcceptDeleteProducer(w http.ResponseWriter, r *http.Request) {
 	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
 	p_id, err := strconv.Atoi(r.FormValue("producer"))
 	if err != nil {
-		s.deleteProducerTemplate(w, "Режиссер не выбран")
+		s.deleteProducerTemplate(r.Context(), w, "Режиссер не выбран")
 		return
 	}
-	err = ctrl.DeleteProducer(p_id)
+	err = ctrl.DeleteProducer(r.Context(), p_id)
 	if err != nil {
-		s.deleteProducerTemplate(w, "Ошибка удаления продюссера")
+		s.deleteProducerTemplate(r.Context(), w, "Ошибка удаления продюссера")
 		return
 	}
 	http.Redirect(w, r, "/admin/cabinet/6", http.StatusSeeOther)
@@ -410,17 +407,17 @@ func (s *srv) HandleAddActor() http.HandlerFunc {
 			s.AcceptAddActor(w, r)
 			return
 		}
-		s.addActorTemplate(w, "")
+		s.addActorTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) addActorTemplate(w http.ResponseWriter, err string) {
+func (s *srv) addActorTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type addActorErr struct {
 		Err     string
 		Serials []*models.Serial
 	}
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, _ := ctrl.GetSerials()
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serials, _ := ctrl.GetSerials(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/addActor.html")
 	cerr := &addActorErr{Err: err, Serials: serials}
 	tmpl.Execute(w, cerr)
@@ -436,34 +433,36 @@ func (s *srv) AcceptAddActor(w http.ResponseWriter, r *http.Request) {
 		A_bdate:   r.FormValue("bdate"),
 	}
 	if !actor.Validate() {
-		s.addActorTemplate(w, "Данные заполнены некорректно")
+		s.addActorTemplate(r.Context(), w, "Данные заполнены некорректно")
 		return
 	}
-	if ctrl.CheckActor(actor) {
-		s.addActorTemplate(w, "Актер уже существует")
+	if ctrl.CheckActor(r.Context(), actor) {
+		s.addActorTemplate(r.Context(), w, "Актер уже существует")
 		return
 	}
-	err := ctrl.CreateActor(actor)
+	err := ctrl.CreateActor(r.Context(), actor)
 	if err != nil {
-		s.addActorTemplate(w, "Ошибка создания актера")
+		s.addActorTemplate(r.Context(), w, "Ошибка создания актера")
 		return
 	}
 
 	_s_id := r.FormValue("serial")
 	s_id, err := strconv.Atoi(_s_id)
 	if err != nil {
-		s.addActorTemplate(w, "Сериал не выбран")
+		s.addActorTemplate(r.Context(), w, "Сериал не выбран")
 		return
 	}
 	sa := &models.SerialsActors{
 		Sa_idSerial: s_id,
 		Sa_idActor:  actor.GetId(),
+		Sa_role:     models.CastMain,
 	}
-	err = ctrlSA.CreateSerialsActors(sa)
+	err = ctrlSA.CreateSerialsActors(r.Context(), sa)
 	if err != nil {
-		s.addActorTemplate(w, "Ошибка добавления актера в сериал")
+		s.addActorTemplate(r.Context(), w, "Ошибка добавления актера в сериал")
 		return
 	}
+	s.similar.Invalidate()
 	http.Redirect(w, r, "/admin/cabinet/7", http.StatusSeeOther)
 }
 
@@ -476,11 +475,11 @@ func (s *srv) HandleUpdateActor() http.HandlerFunc {
 			s.ChoosenActor(w, r)
 			return
 		}
-		s.updateActorTemplate(w, "", nil)
+		s.updateActorTemplate(r.Context(), w, "", nil)
 	}
 }
 
-func (s *srv) updateActorTemplate(w http.ResponseWriter, err string, actor *models.Actors) {
+func (s *srv) updateActorTemplate(ctx context.Context, w http.ResponseWriter, err string, actor *models.Actors) {
 	type updateActorErr struct {
 		A       *models.Actors
 		Actors  []*models.Actors
@@ -488,9 +487,9 @@ func (s *srv) updateActorTemplate(w http.ResponseWriter, err string, actor *mode
 		Err     string
 	}
 	ctrl := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
-	actors, _ := ctrl.GetActors()
-	ctrlSerial := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, _ := ctrlSerial.GetSerials()
+	actors, _ := ctrl.GetActors(ctx)
+	ctrlSerial := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serials, _ := ctrlSerial.GetSerials(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/updateActor.html")
 	cerr := &updateActorErr{Err: err, Actors: actors, A: actor, Serials: serials}
 	tmpl.Execute(w, cerr)
@@ -499,14 +498,14 @@ func (s *srv) updateActorTemplate(w http.ResponseWriter, err string, actor *mode
 func (s *srv) ChoosenActor(w http.ResponseWriter, r *http.Request) {
 	a_id, _ := strconv.Atoi(r.FormValue("actor"))
 	ctrl := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
-	actor, _ := ctrl.GetActorById(a_id)
-	s.updateActorTemplate(w, "", actor)
+	actor, _ := ctrl.GetActorById(r.Context(), a_id)
+	s.updateActorTemplate(r.Context(), w, "", actor)
 }
 
 func (s *srv) AcceptUpdateActor(w http.ResponseWriter, r *http.Request) {
 	ctrl := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
 	a_id, _ := strconv.Atoi(r.FormValue("id"))
-	prev_actor, _ := ctrl.GetActorById(a_id)
+	prev_actor, _ := ctrl.GetActorById(r.Context(), a_id)
 	actor := &models.Actors{
 		A_id:      a_id,
 		A_name:    r.FormValue("name"),
@@ -515,12 +514,12 @@ func (s *srv) AcceptUpdateActor(w http.ResponseWriter, r *http.Request) {
 		A_bdate:   r.FormValue("bdate"),
 	}
 	if !actor.Validate() {
-		s.updateActorTemplate(w, "Данные заполнены некорректно", prev_actor)
+		s.updateActorTemplate(r.Context(), w, "Данные заполнены некорректно", prev_actor)
 		return
 	}
-	err := ctrl.UpdateActor(actor)
+	err := ctrl.UpdateActor(r.Context(), actor)
 	if err != nil {
-		s.updateActorTemplate(w, "Ошибка обновления актера", prev_actor)
+		s.updateActorTemplate(r.Context(), w, "Ошибка обновления актера", prev_actor)
 		return
 	}
 	http.Redirect(w, r, "/admin/cabinet/8", http.StatusSeeOther)
@@ -532,17 +531,17 @@ func (s *srv) HandleDeleteActor() http.HandlerFunc {
 			s.AcceptDeleteActor(w, r)
 			return
 		}
-		s.deleteActorTemplate(w, "")
+		s.deleteActorTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) deleteActorTemplate(w http.ResponseWriter, err string) {
+func (s *srv) deleteActorTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type deleteActorErr struct {
 		Err    string
 		Actors []*models.Actors
 	}
 	ctrl := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
-	actors, _ := ctrl.GetActors()
+	actors, _ := ctrl.GetActors(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/deleteActor.html")
 	cerr := &deleteActorErr{Err: err, Actors: actors}
 	tmpl.Execute(w, cerr)
@@ -552,33 +551,39 @@ func (s *srv) AcceptDeleteActor(w http.ResponseWriter, r *http.Request) {
 	ctrl := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
 	a_id, err := strconv.Atoi(r.FormValue("actor"))
 	if err != nil {
-		s.deleteActorTemplate(w, "Актер не выбран")
+		s.deleteActorTemplate(r.Context(), w, "Актер не выбран")
 		return
 	}
 	ctrlSA := controllers.NewSerialsActorsCtrl(repositories.NewSerialsActorsRepo(s.DB, s.Log))
-	serials, _ := ctrlSA.GetSerialsByActorId(a_id)
+	serials, _ := ctrlSA.GetSerialsByActorId(r.Context(), a_id)
 	for _, serial := range serials {
-		err = ctrlSA.DeleteSerialsActors(serial.GetId())
+		err = ctrlSA.DeleteSerialsActors(r.Context(), serial.GetId())
 		if err != nil {
-			s.deleteActorTemplate(w, "Ошибка удаления актера из сериала")
+			s.deleteActorTemplate(r.Context(), w, "Ошибка удаления актера из сериала")
 			return
 		}
 	}
 
-	err = ctrl.DeleteActor(a_id)
+	err = ctrl.DeleteActor(r.Context(), a_id)
 	if err != nil {
-		s.deleteActorTemplate(w, "Ошибка удаления актера")
+		s.deleteActorTemplate(r.Context(), w, "Ошибка удаления актера")
 		return
 	}
+	s.similar.Invalidate()
 	http.Redirect(w, r, "/admin/cabinet/9", http.StatusSeeOther)
 }
 
 func (s *srv) HandleShowUsers() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-		users, _ := ctrl.GetUsers()
-		tmpl, _ := template.ParseFiles("templates/admin/showUsers.html")
-		tmpl.Execute(w, users)
+		type Data struct {
+			Users []*models.Users
+			Pager *pager
+		}
+		page := htmlPage(r)
+		ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		users, total, _ := ctrl.GetUsersPage(r.Context(), page)
+		tmpl, _ := template.ParseFiles("templates/admin/showUsers.html", "templates/pager.html")
+		tmpl.Execute(w, &Data{Users: users, Pager: newPager(r, page, total)})
 	}
 }
 
@@ -588,44 +593,44 @@ func (s *srv) HandleGrantAdmin() http.HandlerFunc {
 			s.AcceptGrantAdmin(w, r)
 			return
 		}
-		s.grantAdminTemplate(w, "")
+		s.grantAdminTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) grantAdminTemplate(w http.ResponseWriter, err string) {
+func (s *srv) grantAdminTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type grantAdminErr struct {
 		Err   string
 		Users []*models.Users
 	}
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	users, _ := ctrl.GetUsers()
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	users, _ := ctrl.GetUsers(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/grantAdmin.html")
 	cerr := &grantAdminErr{Err: err, Users: users}
 	tmpl.Execute(w, cerr)
 }
 
 func (s *srv) AcceptGrantAdmin(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
 	u_id, err := strconv.Atoi(r.FormValue("user"))
 	if err != nil {
-		s.grantAdminTemplate(w, "Пользователь не выбран")
+		s.grantAdminTemplate(r.Context(), w, "Пользователь не выбран")
 		return
 	}
-	user, _ := ctrl.GetUserById(u_id)
-	s.UpdateStatisticDown(user)
+	user, _ := ctrl.GetUserById(r.Context(), u_id)
+	s.UpdateStatisticDown(r.Context(), user)
 
-	err = ctrl.GrantAdmin(u_id)
+	err = ctrl.GrantAdmin(r.Context(), u_id)
 	if err != nil {
-		s.grantAdminTemplate(w, "Ошибка назначения администратора")
+		s.grantAdminTemplate(r.Context(), w, "Ошибка назначения администратора")
 		return
 	}
 	// update statistics
 	ctrlStat := controllers.NewStatisticCtrl(repositories.NewStatisticRepo(s.DB, s.Log))
-	statistic, _ := ctrlStat.GetStatistic()
+	statistic, _ := ctrlStat.GetStatistic(r.Context())
 	statistic.IncreaseRoleAdmin()
-	err = ctrlStat.UpdateStatistic(statistic)
+	err = ctrlStat.UpdateStatistic(r.Context(), statistic)
 	if err != nil {
-		s.grantAdminTemplate(w, "Ошибка обновления статистики")
+		s.grantAdminTemplate(r.Context(), w, "Ошибка обновления статистики")
 		return
 	}
 
@@ -638,123 +643,712 @@ func (s *srv) HandleDeleteUser() http.HandlerFunc {
 			s.AcceptDeleteUser(w, r)
 			return
 		}
-		s.deleteUserTemplate(w, "")
+		s.deleteUserTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) deleteUserTemplate(w http.ResponseWriter, err string) {
+func (s *srv) deleteUserTemplate(ctx context.Context, w http.ResponseWriter, err string) {
 	type deleteUserErr struct {
 		Err   string
 		Users []*models.Users
 	}
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	users, _ := ctrl.GetUsers()
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	users, _ := ctrl.GetUsers(ctx)
 	tmpl, _ := template.ParseFiles("templates/admin/deleteUser.html")
 	cerr := &deleteUserErr{Err: err, Users: users}
 	tmpl.Execute(w, cerr)
 }
 
 func (s *srv) AcceptDeleteUser(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
 	u_id, err := strconv.Atoi(r.FormValue("user"))
 	if err != nil {
-		s.deleteUserTemplate(w, "Пользователь не выбран")
+		s.deleteUserTemplate(r.Context(), w, "Пользователь не выбран")
 		return
 	}
-	user, _ := ctrl.GetUserById(u_id)
-	ctrlSF := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
-	favourites, _ := ctrlSF.GetSerialsByFavouriteId(user.GetIdFavourites())
-	for _, favourite := range favourites {
-		err = ctrlSF.DeleteSerialsFavourites(favourite.GetId())
-		if err != nil {
-			s.deleteUserTemplate(w, "Ошибка удаления сериала из избранного")
-			return
-		}
-	}
-
-	ctrlC := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	comments, _ := ctrlC.GetCommentsByUserId(user.GetId())
-	for _, comment := range comments {
-		err = ctrlC.DeleteComment(comment.GetId())
-		if err != nil {
-			s.deleteUserTemplate(w, "Ошибка удаления комментариев")
-			return
-		}
-	}
-
-	ctrlSu := controllers.NewSerialsUsersCtrl(repositories.NewSerialsUsersRepo(s.DB, s.Log))
-	err = ctrlSu.DeleteSerialsByUserId(user.GetId())
+	err = ctrl.DeleteUser(r.Context(), u_id)
 	if err != nil {
-		s.deleteUserTemplate(w, "Ошибка удаления сериалов пользователя")
+		s.deleteUserTemplate(r.Context(), w, "Ошибка удаления пользователя")
 		return
 	}
 
-	err = ctrl.DeleteUser(u_id)
-	if err != nil {
-		s.deleteUserTemplate(w, "Ошибка удаления пользователя")
-		return
-	}
-
-	// update statistics
-	s.UpdateStatisticDown(user)
-
 	http.Redirect(w, r, "/admin/cabinet/11", http.StatusSeeOther)
 }
 
 func (s *srv) HandleAddSerialActor() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
-			s.AcceptAddSerialActor(w, r)
+			switch r.FormValue("action") {
+			case "update":
+				s.AcceptUpdateSerialActor(w, r)
+			case "delete":
+				s.AcceptDeleteSerialActor(w, r)
+			default:
+				s.AcceptAddSerialActor(w, r)
+			}
 			return
 		}
-		s.addSerialActorTemplate(w, "")
+		s_id, _ := strconv.Atoi(r.FormValue("serial"))
+		msg := ""
+		switch r.FormValue("msg") {
+		case "1":
+			msg = "Актер успешно добавлен в сериал"
+		case "2":
+			msg = "Роль успешно изменена"
+		case "3":
+			msg = "Актер успешно удален из сериала"
+		}
+		s.addSerialActorTemplate(r.Context(), w, "", msg, s_id)
 	}
 }
 
-func (s *srv) addSerialActorTemplate(w http.ResponseWriter, err string) {
+func (s *srv) addSerialActorTemplate(ctx context.Context, w http.ResponseWriter, err string, msg string, s_id int) {
 	type addSerialActorErr struct {
-		Err    string
-		Serial []*models.Serial
-		Actors []*models.Actors
+		S       *models.Serial
+		Serials []*models.Serial
+		Actors  []*models.Actors
+		Cast    []*models.CastGroup
+		Err     string
+		Msg     string
+	}
+	cerr := &addSerialActorErr{Err: err, Msg: msg}
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	if s_id != 0 {
+		cerr.S, _ = ctrl.GetSerialById(ctx, s_id)
+	}
+	if cerr.S != nil {
+		ctrlA := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
+		cerr.Actors, _ = ctrlA.GetActors(ctx)
+		cerr.Cast, _ = s.serialCast(ctx, s_id)
+	} else {
+		cerr.Serials, _ = ctrl.GetSerials(ctx)
 	}
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, _ := ctrl.GetSerials()
-	ctrlA := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
-	actors, _ := ctrlA.GetActors()
 	tmpl, _ := template.ParseFiles("templates/admin/addSerialActor.html")
-	cerr := &addSerialActorErr{Err: err, Serial: serials, Actors: actors}
 	tmpl.Execute(w, cerr)
 }
 
+// castForm sets the role of the actor in the serial from the form, it
+// returns the message to show if the form is filled in wrong. The empty
+// billing and seasons are 0.
+func castForm(r *http.Request, sa *models.SerialsActors) string {
+	sa.SetCharacter(strings.TrimSpace(r.FormValue("character")))
+	sa.SetRole(r.FormValue("role"))
+	if !models.ValidCastRole(sa.GetRole()) {
+		return "Тип роли не выбран"
+	}
+	nums := []int{0, 0, 0}
+	for i, name := range []string{"billing", "seasonFrom", "seasonTo"} {
+		value := r.FormValue(name)
+		if value == "" {
+			continue
+		}
+		num, err := strconv.Atoi(value)
+		if err != nil || num < 0 {
+			return "Место в титрах и сезоны должны быть неотрицательными числами"
+		}
+		nums[i] = num
+	}
+	sa.SetBilling(nums[0])
+	sa.SetSeasonFrom(nums[1])
+	sa.SetSeasonTo(nums[2])
+	if !sa.Validate() {
+		return "Последний сезон не может быть указан без первого или раньше него"
+	}
+	return ""
+}
+
 func (s *srv) AcceptAddSerialActor(w http.ResponseWriter, r *http.Request) {
 	ctrl := controllers.NewSerialsActorsCtrl(repositories.NewSerialsActorsRepo(s.DB, s.Log))
 	s_id, err := strconv.Atoi(r.FormValue("serial"))
 	if err != nil {
-		s.addSerialActorTemplate(w, "Сериал не выбран")
+		s.addSerialActorTemplate(r.Context(), w, "Сериал не выбран", "", 0)
 		return
 	}
 	a_id, err := strconv.Atoi(r.FormValue("actor"))
 	if err != nil {
-		s.addSerialActorTemplate(w, "Актер не выбран")
+		s.addSerialActorTemplate(r.Context(), w, "Актер не выбран", "", s_id)
 		return
 	}
 	sa := &models.SerialsActors{
 		Sa_idSerial: s_id,
 		Sa_idActor:  a_id,
 	}
-	err = ctrl.CreateSerialsActors(sa)
+	if msg := castForm(r, sa); msg != "" {
+		s.addSerialActorTemplate(r.Context(), w, msg, "", s_id)
+		return
+	}
+	err = ctrl.CreateSerialsActors(r.Context(), sa)
 	if err != nil {
-		s.addSerialActorTemplate(w, "Ошибка добавления актера в сериал")
+		s.addSerialActorTemplate(r.Context(), w, "Ошибка добавления актера в сериал", "", s_id)
 		return
 	}
-	http.Redirect(w, r, "/admin/cabinet/7", http.StatusSeeOther)
+	s.similar.Invalidate()
+	http.Redirect(w, r, "/admin/addSerialActor?serial="+strconv.Itoa(s_id)+"&msg=1", http.StatusSeeOther)
+}
+
+func (s *srv) AcceptUpdateSerialActor(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewSerialsActorsCtrl(repositories.NewSerialsActorsRepo(s.DB, s.Log))
+	sa_id, _ := strconv.Atoi(r.FormValue("id"))
+	sa_prev, err := ctrl.GetSerialsActorsById(r.Context(), sa_id)
+	if err != nil {
+		http.Redirect(w, r, "/admin/addSerialActor", http.StatusSeeOther)
+		return
+	}
+	sa := *sa_prev
+	if msg := castForm(r, &sa); msg != "" {
+		s.addSerialActorTemplate(r.Context(), w, msg, "", sa.GetIdSerial())
+		return
+	}
+	err = ctrl.UpdateSerialsActors(r.Context(), &sa)
+	if err != nil {
+		s.addSerialActorTemplate(r.Context(), w, "Ошибка изменения роли", "", sa.GetIdSerial())
+		return
+	}
+	http.Redirect(w, r, "/admin/addSerialActor?serial="+strconv.Itoa(sa.GetIdSerial())+"&msg=2", http.StatusSeeOther)
+}
+
+func (s *srv) AcceptDeleteSerialActor(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewSerialsActorsCtrl(repositories.NewSerialsActorsRepo(s.DB, s.Log))
+	sa_id, _ := strconv.Atoi(r.FormValue("id"))
+	sa, err := ctrl.GetSerialsActorsById(r.Context(), sa_id)
+	if err != nil {
+		http.Redirect(w, r, "/admin/addSerialActor", http.StatusSeeOther)
+		return
+	}
+	err = ctrl.DeleteSerialsActors(r.Context(), sa_id)
+	if err != nil {
+		s.addSerialActorTemplate(r.Context(), w, "Ошибка удаления актера из сериала", "", sa.GetIdSerial())
+		return
+	}
+	s.similar.Invalidate()
+	http.Redirect(w, r, "/admin/addSerialActor?serial="+strconv.Itoa(sa.GetIdSerial())+"&msg=3", http.StatusSeeOther)
 }
 
 func (s *srv) HandleShowStatistics() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctrl := controllers.NewStatisticCtrl(repositories.NewStatisticRepo(s.DB, s.Log))
-		statistic, _ := ctrl.GetStatistic()
+		statistic, _ := ctrl.GetStatistic(r.Context())
 		tmpl, _ := template.ParseFiles("templates/admin/showStatistics.html")
 		tmpl.Execute(w, statistic)
 	}
 }
+
+func (s *srv) HandleModeration() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.AcceptModeration(w, r)
+			return
+		}
+		msg := ""
+		switch r.FormValue("msg") {
+		case models.ModerationHide:
+			msg = "Комментарий скрыт"
+		case models.ModerationDelete:
+			msg = "Комментарий удален"
+		case models.ModerationDismiss:
+			msg = "Жалобы отклонены"
+		}
+		s.moderationTemplate(w, r, "", msg)
+	}
+}
+
+func (s *srv) moderationTemplate(w http.ResponseWriter, r *http.Request, err string, msg string) {
+	type reportView struct {
+		*models.CommentsReports
+		U_name string
+	}
+	type itemView struct {
+		Comment *models.Comments
+		U_name  string
+		Serial  string
+		Reports []*reportView
+	}
+	type moderationErr struct {
+		Err   string
+		Msg   string
+		Queue []*itemView
+	}
+	cerr := &moderationErr{Err: err, Msg: msg}
+	ctrl := controllers.NewModerationCtrl(repositories.NewCommentsReportsRepo(s.DB, s.Log), repositories.NewModerationsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	queue, qerr := ctrl.GetQueue(r.Context())
+	if qerr != nil {
+		s.Log.WithContext(r.Context()).Error(qerr)
+		cerr.Err = "Ошибка загрузки очереди модерации"
+	}
+	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	ctrlSerial := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	name := func(id int) string {
+		user, err := ctrlUser.GetUserById(r.Context(), id)
+		if err != nil {
+			return ""
+		}
+		return user.GetLogin()
+	}
+	for _, item := range queue {
+		view := &itemView{Comment: item.Comment, U_name: name(item.Comment.GetIdUser())}
+		if serial, err := ctrlSerial.GetSerialById(r.Context(), item.Comment.GetIdSerial()); err == nil {
+			view.Serial = serial.GetName()
+		}
+		for _, report := range item.Reports {
+			view.Reports = append(view.Reports, &reportView{CommentsReports: report, U_name: name(report.GetIdUser())})
+		}
+		cerr.Queue = append(cerr.Queue, view)
+	}
+	tmpl, _ := template.ParseFiles("templates/admin/moderation.html")
+	tmpl.Execute(w, cerr)
+}
+
+// AcceptModeration carries out the decision of the admin on a comment. The
+// decisions made on the serial page return there.
+func (s *srv) AcceptModeration(w http.ResponseWriter, r *http.Request) {
+	session, err := s.session.Get(r, "sname")
+	if err != nil {
+		return
+	}
+	idAdmin := session.Values["admin"].(int)
+	c_id, err := strconv.Atoi(r.FormValue("comment"))
+	if err != nil {
+		s.moderationTemplate(w, r, "Комментарий не выбран", "")
+		return
+	}
+	action := r.FormValue("action")
+
+	ctrl := controllers.NewModerationCtrl(repositories.NewCommentsReportsRepo(s.DB, s.Log), repositories.NewModerationsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	err = ctrl.Moderate(r.Context(), c_id, idAdmin, action)
+	if errors.Is(err, models.ErrNotFound) {
+		s.moderationTemplate(w, r, "Комментарий не найден", "")
+		return
+	}
+	if errors.Is(err, models.ErrInvalidModel) {
+		s.moderationTemplate(w, r, "Неизвестное решение", "")
+		return
+	}
+	if err != nil {
+		s.Log.WithContext(r.Context()).Error(err)
+		s.moderationTemplate(w, r, "Ошибка модерации комментария", "")
+		return
+	}
+	if serial := r.FormValue("serial"); serial != "" {
+		http.Redirect(w, r, "/serial/"+serial+"#comments", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/admin/moderation?msg="+action, http.StatusSeeOther)
+}
+
+func (s *srv) HandleModerationLog() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type moderationView struct {
+			*models.Moderations
+			U_name string
+		}
+		type Data struct {
+			Moderations []*moderationView
+			Pager       *pager
+		}
+		page := htmlPage(r)
+		ctrl := controllers.NewModerationCtrl(repositories.NewCommentsReportsRepo(s.DB, s.Log), repositories.NewModerationsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		moderations, total, _ := ctrl.GetModerationsPage(r.Context(), page)
+		ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		d := &Data{Pager: newPager(r, page, total)}
+		for _, moderation := range moderations {
+			view := &moderationView{Moderations: moderation}
+			if user, err := ctrlUser.GetUserById(r.Context(), moderation.GetIdModerator()); err == nil {
+				view.U_name = user.GetLogin()
+			}
+			d.Moderations = append(d.Moderations, view)
+		}
+		tmpl, _ := template.ParseFiles("templates/admin/moderationLog.html", "templates/pager.html")
+		tmpl.Execute(w, d)
+	}
+}
+
+// formOrder returns the ids posted by a reorder form sorted by their new positions.
+func formOrder(r *http.Request) ([]int, error) {
+	r.ParseForm()
+	ids, positions := r.Form["id"], r.Form["pos"]
+	if len(ids) != len(positions) {
+		return nil, controllers.ErrInvalidOrder
+	}
+	type item struct {
+		id  int
+		pos int
+	}
+	items := make([]item, len(ids))
+	for i := range ids {
+		id, err := strconv.Atoi(ids[i])
+		if err != nil {
+			return nil, err
+		}
+		pos, err := strconv.Atoi(positions[i])
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item{id: id, pos: pos}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].pos < items[j].pos
+	})
+	order := make([]int, len(items))
+	for i, it := range items {
+		order[i] = it.id
+	}
+	return order, nil
+}
+
+func validDate(date string) bool {
+	_, err := time.Parse("02.01.2006", date)
+	return err == nil
+}
+
+func (s *srv) HandleSeasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s_id, _ := strconv.Atoi(r.FormValue("serial"))
+		msg := ""
+		switch r.FormValue("msg") {
+		case "1":
+			msg = "Сезон успешно добавлен"
+		case "2":
+			msg = "Сезон успешно обновлен"
+		case "3":
+			msg = "Сезон успешно удален"
+		case "4":
+			msg = "Порядок сезонов сохранен"
+		}
+		s.seasonsTemplate(r.Context(), w, "", msg, s_id)
+	}
+}
+
+func (s *srv) seasonsTemplate(ctx context.Context, w http.ResponseWriter, err string, msg string, s_id int) {
+	type seasonsErr struct {
+		S       *models.Serial
+		Serials []*models.Serial
+		Seasons []*models.Seasons
+		Err     string
+		Msg     string
+	}
+	cerr := &seasonsErr{Err: err, Msg: msg}
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	if s_id != 0 {
+		cerr.S, _ = ctrl.GetSerialById(ctx, s_id)
+	}
+	if cerr.S != nil {
+		ctrlSeasons := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		cerr.Seasons, _ = ctrlSeasons.GetSeasonsBySerialId(ctx, s_id)
+	} else {
+		cerr.Serials, _ = ctrl.GetSerials(ctx)
+	}
+	tmpl, _ := template.ParseFiles("templates/admin/seasons.html")
+	tmpl.Execute(w, cerr)
+}
+
+func (s *srv) HandleAddSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.AcceptAddSeason(w, r)
+			return
+		}
+		http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+	}
+}
+
+func (s *srv) AcceptAddSeason(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	s_id, err := strconv.Atoi(r.FormValue("serial"))
+	if err != nil {
+		s.seasonsTemplate(r.Context(), w, "Сериал не выбран", "", 0)
+		return
+	}
+	season := &models.Seasons{
+		Ss_name:     r.FormValue("name"),
+		Ss_date:     r.FormValue("date"),
+		Ss_idSerial: s_id,
+	}
+	if !validDate(season.GetDate()) {
+		s.seasonsTemplate(r.Context(), w, "Дата выхода должна быть в формате ДД.ММ.ГГГГ", "", s_id)
+		return
+	}
+	seasons, _ := ctrl.GetSeasonsBySerialId(r.Context(), s_id)
+	season.SetNum(len(seasons) + 1)
+	if !season.Validate() {
+		s.seasonsTemplate(r.Context(), w, "Название сезона не может быть пустым", "", s_id)
+		return
+	}
+	err = ctrl.CreateSeason(r.Context(), season)
+	if err != nil {
+		s.seasonsTemplate(r.Context(), w, "Ошибка добавления сезона", "", s_id)
+		return
+	}
+	http.Redirect(w, r, "/admin/seasons?serial="+strconv.Itoa(s_id)+"&msg=1", http.StatusSeeOther)
+}
+
+func (s *srv) HandleUpdateSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.AcceptUpdateSeason(w, r)
+			return
+		}
+		ss_id, _ := strconv.Atoi(r.FormValue("id"))
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		season, err := ctrl.GetSeasonById(r.Context(), ss_id)
+		if err != nil {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		s.updateSeasonTemplate(w, "", season)
+	}
+}
+
+func (s *srv) updateSeasonTemplate(w http.ResponseWriter, err string, season *models.Seasons) {
+	type updateSeasonErr struct {
+		Ss  *models.Seasons
+		Err string
+	}
+	tmpl, _ := template.ParseFiles("templates/admin/updateSeason.html")
+	cerr := &updateSeasonErr{Err: err, Ss: season}
+	tmpl.Execute(w, cerr)
+}
+
+func (s *srv) AcceptUpdateSeason(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	ss_id, _ := strconv.Atoi(r.FormValue("id"))
+	season_prev, err := ctrl.GetSeasonById(r.Context(), ss_id)
+	if err != nil {
+		http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+		return
+	}
+	season := *season_prev
+	season.SetName(r.FormValue("name"))
+	season.SetDate(r.FormValue("date"))
+	if !validDate(season.GetDate()) {
+		s.updateSeasonTemplate(w, "Дата выхода должна быть в формате ДД.ММ.ГГГГ", season_prev)
+		return
+	}
+	if !season.Validate() {
+		s.updateSeasonTemplate(w, "Название сезона не может быть пустым", season_prev)
+		return
+	}
+	err = ctrl.UpdateSeason(r.Context(), &season)
+	if err != nil {
+		s.updateSeasonTemplate(w, "Ошибка обновления сезона", season_prev)
+		return
+	}
+	http.Redirect(w, r, "/admin/seasons?serial="+strconv.Itoa(season.GetIdSerial())+"&msg=2", http.StatusSeeOther)
+}
+
+func (s *srv) HandleDeleteSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		ss_id, _ := strconv.Atoi(r.FormValue("id"))
+		season, err := ctrl.GetSeasonById(r.Context(), ss_id)
+		if err != nil {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		err = ctrl.DeleteSeason(r.Context(), ss_id)
+		if err != nil {
+			s.seasonsTemplate(r.Context(), w, "Ошибка удаления сезона", "", season.GetIdSerial())
+			return
+		}
+		http.Redirect(w, r, "/admin/seasons?serial="+strconv.Itoa(season.GetIdSerial())+"&msg=3", http.StatusSeeOther)
+	}
+}
+
+func (s *srv) HandleReorderSeasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		s_id, _ := strconv.Atoi(r.FormValue("serial"))
+		order, err := formOrder(r)
+		if err != nil {
+			s.seasonsTemplate(r.Context(), w, "Позиции должны быть числами", "", s_id)
+			return
+		}
+		err = ctrl.ReorderSeasons(r.Context(), s_id, order)
+		if err != nil {
+			s.seasonsTemplate(r.Context(), w, "Ошибка изменения порядка сезонов", "", s_id)
+			return
+		}
+		http.Redirect(w, r, "/admin/seasons?serial="+strconv.Itoa(s_id)+"&msg=4", http.StatusSeeOther)
+	}
+}
+
+func (s *srv) HandleEpisodes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ss_id, _ := strconv.Atoi(r.FormValue("season"))
+		msg := ""
+		switch r.FormValue("msg") {
+		case "1":
+			msg = "Серия успешно добавлена"
+		case "2":
+			msg = "Серия успешно обновлена"
+		case "3":
+			msg = "Серия успешно удалена"
+		case "4":
+			msg = "Порядок серий сохранен"
+		}
+		s.episodesTemplate(w, r, "", msg, ss_id)
+	}
+}
+
+func (s *srv) episodesTemplate(w http.ResponseWriter, r *http.Request, err string, msg string, ss_id int) {
+	type episodesErr struct {
+		Ss       *models.Seasons
+		Episodes []*models.Episodes
+		Err      string
+		Msg      string
+	}
+	ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	season, e := ctrl.GetSeasonById(r.Context(), ss_id)
+	if e != nil {
+		http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+		return
+	}
+	ctrlEpisodes := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	episodes, _ := ctrlEpisodes.GetEpisodesBySeasonId(r.Context(), ss_id)
+	tmpl, _ := template.ParseFiles("templates/admin/episodes.html")
+	cerr := &episodesErr{Err: err, Msg: msg, Ss: season, Episodes: episodes}
+	tmpl.Execute(w, cerr)
+}
+
+func (s *srv) HandleAddEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.AcceptAddEpisode(w, r)
+			return
+		}
+		http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+	}
+}
+
+func (s *srv) AcceptAddEpisode(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	ss_id, _ := strconv.Atoi(r.FormValue("season"))
+	episode := &models.Episodes{
+		E_name:     r.FormValue("name"),
+		E_date:     r.FormValue("date"),
+		E_duration: r.FormValue("duration"),
+		E_idSeason: ss_id,
+	}
+	if !validDate(episode.GetDate()) {
+		s.episodesTemplate(w, r, "Дата выхода должна быть в формате ДД.ММ.ГГГГ", "", ss_id)
+		return
+	}
+	episodes, _ := ctrl.GetEpisodesBySeasonId(r.Context(), ss_id)
+	episode.SetNum(len(episodes) + 1)
+	if !episode.Validate() {
+		s.episodesTemplate(w, r, "Название не может быть пустым, длительность — в формате ЧЧ:ММ:СС", "", ss_id)
+		return
+	}
+	err := ctrl.CreateEpisode(r.Context(), episode)
+	if err != nil {
+		s.episodesTemplate(w, r, "Ошибка добавления серии", "", ss_id)
+		return
+	}
+	http.Redirect(w, r, "/admin/episodes?season="+strconv.Itoa(ss_id)+"&msg=1", http.StatusSeeOther)
+}
+
+func (s *srv) HandleUpdateEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.AcceptUpdateEpisode(w, r)
+			return
+		}
+		e_id, _ := strconv.Atoi(r.FormValue("id"))
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		episode, err := ctrl.GetEpisodeById(r.Context(), e_id)
+		if err != nil {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		s.updateEpisodeTemplate(w, "", episode)
+	}
+}
+
+func (s *srv) updateEpisodeTemplate(w http.ResponseWriter, err string, episode *models.Episodes) {
+	type updateEpisodeErr struct {
+		E   *models.Episodes
+		Err string
+	}
+	tmpl, _ := template.ParseFiles("templates/admin/updateEpisode.html")
+	cerr := &updateEpisodeErr{Err: err, E: episode}
+	tmpl.Execute(w, cerr)
+}
+
+func (s *srv) AcceptUpdateEpisode(w http.ResponseWriter, r *http.Request) {
+	ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	e_id, _ := strconv.Atoi(r.FormValue("id"))
+	episode_prev, err := ctrl.GetEpisodeById(r.Context(), e_id)
+	if err != nil {
+		http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+		return
+	}
+	episode := *episode_prev
+	episode.SetName(r.FormValue("name"))
+	episode.SetDate(r.FormValue("date"))
+	episode.SetDuration(r.FormValue("duration"))
+	if !validDate(episode.GetDate()) {
+		s.updateEpisodeTemplate(w, "Дата выхода должна быть в формате ДД.ММ.ГГГГ", episode_prev)
+		return
+	}
+	if !episode.Validate() {
+		s.updateEpisodeTemplate(w, "Название не может быть пустым, длительность — в формате ЧЧ:ММ:СС", episode_prev)
+		return
+	}
+	err = ctrl.UpdateEpisode(r.Context(), &episode)
+	if err != nil {
+		s.updateEpisodeTemplate(w, "Ошибка обновления серии", episode_prev)
+		return
+	}
+	http.Redirect(w, r, "/admin/episodes?season="+strconv.Itoa(episode.GetIdSeason())+"&msg=2", http.StatusSeeOther)
+}
+
+func (s *srv) HandleDeleteEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		e_id, _ := strconv.Atoi(r.FormValue("id"))
+		episode, err := ctrl.GetEpisodeById(r.Context(), e_id)
+		if err != nil {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		err = ctrl.DeleteEpisode(r.Context(), e_id)
+		if err != nil {
+			s.episodesTemplate(w, r, "Ошибка удаления серии", "", episode.GetIdSeason())
+			return
+		}
+		http.Redirect(w, r, "/admin/episodes?season="+strconv.Itoa(episode.GetIdSeason())+"&msg=3", http.StatusSeeOther)
+	}
+}
+
+func (s *srv) HandleReorderEpisodes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/seasons", http.StatusSeeOther)
+			return
+		}
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		ss_id, _ := strconv.Atoi(r.FormValue("season"))
+		order, err := formOrder(r)
+		if err != nil {
+			s.episodesTemplate(w, r, "Позиции должны быть числами", "", ss_id)
+			return
+		}
+		err = ctrl.ReorderEpisodes(r.Context(), ss_id, order)
+		if err != nil {
+			s.episodesTemplate(w, r, "Ошибка изменения порядка серий", "", ss_id)
+			return
+		}
+		http.Redirect(w, r, "/admin/episodes?season="+strconv.Itoa(ss_id)+"&msg=4", http.StatusSeeOther)
+	}
+}
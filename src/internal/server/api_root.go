@@ -0,0 +1,731 @@
+package server
+
+import (
+	"app/internal/controllers"
+	"app/internal/models"
+	"app/internal/repositories"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func (s *srv) respondJSON(w http.ResponseWriter, code int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	if data == nil {
+		return
+	}
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		s.Log.Error(err)
+	}
+}
+
+func (s *srv) respondError(w http.ResponseWriter, code int, msg string) {
+	s.respondJSON(w, code, &apiError{Error: msg})
+}
+
+// respondRepoError maps errors returned by controllers to HTTP status codes.
+func (s *srv) respondRepoError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		s.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrInvalidModel):
+		s.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		s.Log.WithContext(r.Context()).Error(err)
+		s.respondError(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func (s *srv) decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid JSON body")
+		return false
+	}
+	return true
+}
+
+// apiAdmin reports whether an administrator is logged in by the session,
+// otherwise it responds with 401, or with 403 to a user.
+func (s *srv) apiAdmin(w http.ResponseWriter, r *http.Request) bool {
+	session, err := s.session.Get(r, "sname")
+	if err == nil {
+		if session.Values["admin"] != nil {
+			return true
+		}
+		if session.Values["user"] != nil {
+			s.respondError(w, http.StatusForbidden, "admin rights required")
+			return false
+		}
+	}
+	s.respondError(w, http.StatusUnauthorized, "user is not logged in")
+	return false
+}
+
+func pathId(r *http.Request) int {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	return id
+}
+
+func (s *srv) HandleApiGetSerials() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := serialsQuery(r)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		page, err := pageParams(r)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		serials, total, err := ctrl.SearchSerials(r.Context(), query, page)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, &models.Paged[*models.Serial]{Items: serials, Total: total, Page: page})
+	}
+}
+
+// HandleApiSearch is the full-text search over the serials by the parameter q.
+func (s *srv) HandleApiSearch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		text := r.FormValue("q")
+		if text == "" {
+			s.respondError(w, http.StatusBadRequest, "q is required")
+			return
+		}
+		page, err := pageParams(r)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		hits, total, err := ctrl.FullTextSearch(r.Context(), text, page)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, &models.Paged[*models.SearchHit]{Items: hits, Total: total, Page: page})
+	}
+}
+
+// HandleApiSuggest returns the autocomplete suggestions for the text typed so
+// far in the parameter q, at most limit of them.
+func (s *srv) HandleApiSuggest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := models.DefaultSuggestions
+		if value := r.FormValue("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > models.MaxSuggestions {
+				s.respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be from 1 to %d", models.MaxSuggestions))
+				return
+			}
+			limit = n
+		}
+		query := models.NewSuggestQuery(r.FormValue("q"), limit)
+		if query.Prefix == "" {
+			s.respondJSON(w, http.StatusOK, []*models.Suggestion{})
+			return
+		}
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		suggestions, err := ctrl.Suggest(r.Context(), query)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, suggestions)
+	}
+}
+
+func (s *srv) HandleApiGetSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		serial, err := ctrl.GetSerialById(r.Context(), pathId(r))
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, serial)
+	}
+}
+
+// HandleApiGetSimilar returns the serials most similar to the serial, at most
+// limit of them.
+func (s *srv) HandleApiGetSimilar() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := models.SimilarCount
+		if value := r.FormValue("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > models.MaxSimilar {
+				s.respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be from 1 to %d", models.MaxSimilar))
+				return
+			}
+			limit = n
+		}
+		ctrl := controllers.NewSimilarCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSerialsActorsRepo(s.DB, s.Log), s.similar)
+		similar, err := ctrl.GetSimilar(r.Context(), pathId(r), limit)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, similar)
+	}
+}
+
+func (s *srv) HandleApiCreateSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		serial := &models.Serial{}
+		if !s.decodeJSON(w, r, serial) {
+			return
+		}
+		serial.SetId(0)
+		serial.SetRating(0)
+		serial.SetVotes(0)
+
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		err := ctrl.CreateSerial(r.Context(), serial)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.similar.Invalidate()
+		w.Header().Set("Location", "/api/v1/serials/"+strconv.Itoa(serial.GetId()))
+		s.respondJSON(w, http.StatusCreated, serial)
+	}
+}
+
+func (s *srv) HandleApiUpdateSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		serial_prev, err := ctrl.GetSerialById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		serial := &models.Serial{}
+		if !s.decodeJSON(w, r, serial) {
+			return
+		}
+		serial.SetId(id)
+		serial.SetRating(serial_prev.GetRating())
+		serial.SetVotes(serial_prev.GetVotes())
+
+		err = ctrl.UpdateSerial(r.Context(), serial)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.similar.Invalidate()
+		s.respondJSON(w, http.StatusOK, serial)
+	}
+}
+
+func (s *srv) HandleApiDeleteSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		_, err := ctrl.GetSerialById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		err = ctrl.DeleteSerial(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.similar.Invalidate()
+		s.respondJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// apiUser returns the id of the user logged in by the session, otherwise it
+// responds with 401.
+func (s *srv) apiUser(w http.ResponseWriter, r *http.Request) (int, bool) {
+	session, err := s.session.Get(r, "sname")
+	if err == nil && session.Values["admin"] == nil {
+		if id, ok := session.Values["user"].(int); ok {
+			return id, true
+		}
+	}
+	s.respondError(w, http.StatusUnauthorized, "user is not logged in")
+	return 0, false
+}
+
+func (s *srv) HandleApiGetRating() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		ctrl := controllers.NewRatingsCtrl(repositories.NewRatingsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		rating, err := ctrl.GetRatingBySerialIdUserId(r.Context(), pathId(r), idUser)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, rating)
+	}
+}
+
+func (s *srv) HandleApiRateSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		rating := &models.Ratings{}
+		if !s.decodeJSON(w, r, rating) {
+			return
+		}
+		rating.SetId(0)
+		rating.SetIdUser(idUser)
+		rating.SetIdSerial(pathId(r))
+
+		ctrl := controllers.NewRatingsCtrl(repositories.NewRatingsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err := ctrl.RateSerial(r.Context(), rating)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, rating)
+	}
+}
+
+func (s *srv) HandleApiDeleteRating() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		ctrl := controllers.NewRatingsCtrl(repositories.NewRatingsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err := ctrl.DeleteRating(r.Context(), pathId(r), idUser)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func (s *srv) HandleApiGetProgress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		id := pathId(r)
+		ctrlSerials := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		_, err := ctrlSerials.GetSerialById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		ctrl := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		progress, err := ctrl.GetProgress(r.Context(), id, idUser)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, progress)
+	}
+}
+
+// HandleApiGetRecommendations returns the serials recommended to the user, at
+// most limit of them.
+func (s *srv) HandleApiGetRecommendations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		limit := models.RecommendCount
+		if value := r.FormValue("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > models.MaxRecommendations {
+				s.respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be from 1 to %d", models.MaxRecommendations))
+				return
+			}
+			limit = n
+		}
+		ctrl := controllers.NewRecommendationsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		recs, err := ctrl.GetRecommendations(r.Context(), idUser, limit)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, recs)
+	}
+}
+
+// HandleApiCompare compares the serials with the ids given by the ids
+// parameter separated by commas.
+func (s *srv) HandleApiCompare() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, err := formIds(strings.Split(r.FormValue("ids"), ","))
+		if err != nil || !models.ValidCompareIds(ids) {
+			s.respondError(w, http.StatusBadRequest, fmt.Sprintf("ids must be from %d to %d different serial ids", models.MinCompare, models.MaxCompare))
+			return
+		}
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		comparison, err := ctrl.Compare(r.Context(), ids)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, comparison)
+	}
+}
+
+// HandleApiSaveComparison saves the comparison of the serials for the user
+// and returns it with the slug of its link.
+func (s *srv) HandleApiSaveComparison() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		body := &struct {
+			Serials []int `json:"serials"`
+		}{}
+		if !s.decodeJSON(w, r, body) {
+			return
+		}
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		saved, err := ctrl.SaveComparison(r.Context(), idUser, body.Serials)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		comparison, err := ctrl.GetSavedComparison(r.Context(), saved.GetSlug())
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusCreated, comparison)
+	}
+}
+
+func (s *srv) HandleApiGetComparison() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		comparison, err := ctrl.GetSavedComparison(r.Context(), mux.Vars(r)["slug"])
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, comparison)
+	}
+}
+
+func (s *srv) HandleApiGetSeasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := pathId(r)
+		ctrlSerials := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		_, err := ctrlSerials.GetSerialById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		seasons, err := ctrl.GetSeasonsBySerialId(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		if seasons == nil {
+			seasons = []*models.Seasons{}
+		}
+		s.respondJSON(w, http.StatusOK, seasons)
+	}
+}
+
+func (s *srv) HandleApiCreateSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrlSerials := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		_, err := ctrlSerials.GetSerialById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		season := &models.Seasons{}
+		if !s.decodeJSON(w, r, season) {
+			return
+		}
+		season.SetId(0)
+		season.SetIdSerial(id)
+
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err = ctrl.CreateSeason(r.Context(), season)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		w.Header().Set("Location", "/api/v1/seasons/"+strconv.Itoa(season.GetId()))
+		s.respondJSON(w, http.StatusCreated, season)
+	}
+}
+
+func (s *srv) HandleApiGetSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		season, err := ctrl.GetSeasonById(r.Context(), pathId(r))
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, season)
+	}
+}
+
+func (s *srv) HandleApiUpdateSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		season_prev, err := ctrl.GetSeasonById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		season := &models.Seasons{}
+		if !s.decodeJSON(w, r, season) {
+			return
+		}
+		season.SetId(id)
+		season.SetIdSerial(season_prev.GetIdSerial())
+
+		err = ctrl.UpdateSeason(r.Context(), season)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, season)
+	}
+}
+
+func (s *srv) HandleApiDeleteSeason() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		_, err := ctrl.GetSeasonById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		err = ctrl.DeleteSeason(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func (s *srv) HandleApiGetEpisodes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := pathId(r)
+		ctrlSeasons := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		_, err := ctrlSeasons.GetSeasonById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		episodes, err := ctrl.GetEpisodesBySeasonId(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		if episodes == nil {
+			episodes = []*models.Episodes{}
+		}
+		s.respondJSON(w, http.StatusOK, episodes)
+	}
+}
+
+func (s *srv) HandleApiCreateEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrlSeasons := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		_, err := ctrlSeasons.GetSeasonById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		episode := &models.Episodes{}
+		if !s.decodeJSON(w, r, episode) {
+			return
+		}
+		episode.SetId(0)
+		episode.SetIdSeason(id)
+
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err = ctrl.CreateEpisode(r.Context(), episode)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		w.Header().Set("Location", "/api/v1/episodes/"+strconv.Itoa(episode.GetId()))
+		s.respondJSON(w, http.StatusCreated, episode)
+	}
+}
+
+func (s *srv) HandleApiWatchEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		ctrl := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		episodeUser, err := ctrl.MarkWatched(r.Context(), pathId(r), idUser)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, episodeUser)
+	}
+}
+
+func (s *srv) HandleApiUnwatchEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idUser, ok := s.apiUser(w, r)
+		if !ok {
+			return
+		}
+		ctrl := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err := ctrl.UnmarkWatched(r.Context(), pathId(r), idUser)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func (s *srv) HandleApiGetEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		episode, err := ctrl.GetEpisodeById(r.Context(), pathId(r))
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, episode)
+	}
+}
+
+func (s *srv) HandleApiUpdateEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		episode_prev, err := ctrl.GetEpisodeById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		episode := &models.Episodes{}
+		if !s.decodeJSON(w, r, episode) {
+			return
+		}
+		episode.SetId(id)
+		episode.SetIdSeason(episode_prev.GetIdSeason())
+
+		err = ctrl.UpdateEpisode(r.Context(), episode)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, episode)
+	}
+}
+
+func (s *srv) HandleApiDeleteEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.apiAdmin(w, r) {
+			return
+		}
+		id := pathId(r)
+		ctrl := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		_, err := ctrl.GetEpisodeById(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+
+		err = ctrl.DeleteEpisode(r.Context(), id)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// HandleApiGetList returns the unlisted or public list with the slug and its
+// serials.
+func (s *srv) HandleApiGetList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		list, err := ctrl.GetSharedList(r.Context(), mux.Vars(r)["slug"])
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		shared, err := s.sharedList(r.Context(), list)
+		if err != nil {
+			s.respondRepoError(w, r, err)
+			return
+		}
+		s.respondJSON(w, http.StatusOK, shared)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"app/internal/controllers"
 	"app/internal/models"
 	"app/internal/repositories"
+	"context"
 	"errors"
+	"html/template"
 	"net/http"
 	"strconv"
-	"text/template"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -27,33 +29,25 @@ func (s *srv) AddToFavorite(w http.ResponseWriter, r *http.Request) error {
 		return errors.New("Пользователь не авторизирован")
 	}
 	id := id_str.(int)
-	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user, err := ctrlUser.GetUserById(id)
+	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user, err := ctrlUser.GetUserById(r.Context(), id)
 	if err != nil {
 		return err
 	}
 
 	idSerial, _ := strconv.Atoi(r.FormValue("serial_id"))
-	ctrlF := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log))
-	fav, err := ctrlF.GetFavouriteById(user.U_idFavourites)
-	if err != nil {
-		return err
-	}
-	serialFav := &models.SerialsFavourites{
-		Sf_idSerial:    idSerial,
-		Sf_idFavourite: fav.GetId(),
+	idList := user.GetIdFavourites()
+	if r.FormValue("list") != "" {
+		idList, _ = strconv.Atoi(r.FormValue("list"))
 	}
-
-	ctrlSF := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
-	if ctrlSF.CheckSerialInFavourite(serialFav) {
-		return errors.New("Сериал уже добавлен в избранное")
+	ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	err = ctrl.AddSerial(r.Context(), id, idList, idSerial)
+	if errors.Is(err, controllers.ErrSerialInList) {
+		return errors.New("Сериал уже добавлен в этот список")
 	}
-	err = ctrlSF.CreateSerialsFavourites(serialFav)
 	if err != nil {
-		return err
+		return errors.New("Ошибка добавления сериала в список")
 	}
-	fav.SetCntSerials(fav.GetCntSerials() + 1)
-	ctrlF.UpdateFavourite(fav)
 	return nil
 }
 
@@ -65,7 +59,7 @@ func (s *srv) HandleSerial() http.HandlerFunc {
 				s.serialTemplate(w, r, err.Error())
 				return
 			} else {
-				s.serialTemplate(w, r, "Сериал успешно добавлен в избранное")
+				s.serialTemplate(w, r, "Сериал успешно добавлен в список")
 				return
 			}
 		}
@@ -73,128 +67,455 @@ func (s *srv) HandleSerial() http.HandlerFunc {
 	}
 }
 
-func (s *srv) serialTemplate(w http.ResponseWriter, r *http.Request, msg string) {
-	type seasonEpisodes struct {
-		Season   *models.Seasons
-		Episodes []*models.Episodes
+// RateSerial sets the score of the serial given by the user, or removes it
+// when the action is delete. The message to show is returned on success.
+func (s *srv) RateSerial(w http.ResponseWriter, r *http.Request) (string, error) {
+	session, err := s.session.Get(r, "sname")
+	if err != nil {
+		return "", err
+	}
+	if session.Values["admin"] != nil {
+		return "", errors.New("Администратор не может оценивать сериалы")
+	}
+	id_str := session.Values["user"]
+	if id_str == nil {
+		return "", errors.New("Пользователь не авторизирован")
 	}
+	idUser := id_str.(int)
+	idSerial := pathId(r)
 
-	type CommentsData struct {
-		C_text string
-		U_name string
+	ctrl := controllers.NewRatingsCtrl(repositories.NewRatingsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	if r.FormValue("action") == "delete" {
+		err = ctrl.DeleteRating(r.Context(), idSerial, idUser)
+		if errors.Is(err, models.ErrNotFound) {
+			return "", errors.New("Вы еще не оценили сериал")
+		}
+		if err != nil {
+			return "", err
+		}
+		return "Оценка удалена", nil
 	}
 
-	type Data struct {
-		Serial   *models.Serial
-		Seasons  []*seasonEpisodes
-		Err      string
-		Comments []*CommentsData
-		Actors   []*models.Actors
-		Producer *models.Producers
+	score, err := strconv.Atoi(r.FormValue("score"))
+	if err != nil {
+		return "", errors.New("Оценка должна быть числом")
+	}
+	rating := &models.Ratings{R_idUser: idUser, R_idSerial: idSerial, R_score: score}
+	err = ctrl.RateSerial(r.Context(), rating)
+	if errors.Is(err, models.ErrInvalidModel) {
+		return "", errors.New("Оценка должна быть от 1 до 10")
+	}
+	if err != nil {
+		return "", err
 	}
+	return "Оценка сохранена", nil
+}
 
-	d := &Data{Err: msg}
+func (s *srv) HandleRateSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"], http.StatusSeeOther)
+			return
+		}
+		msg, err := s.RateSerial(w, r)
+		if err != nil {
+			msg = err.Error()
+		}
+		s.serialTemplate(w, r, msg)
+	}
+}
 
-	id, _ := strconv.Atoi(mux.Vars(r)["id"])
-	ctrlSerials := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serial, err := ctrlSerials.GetSerialById(id)
+// WatchEpisode marks the episode as watched by the user, or removes the mark
+// when the action is unwatch.
+func (s *srv) WatchEpisode(w http.ResponseWriter, r *http.Request) error {
+	session, err := s.session.Get(r, "sname")
 	if err != nil {
-		return
+		return err
 	}
-	d.Serial = serial
+	if session.Values["admin"] != nil {
+		return errors.New("Администратор не может отмечать просмотренные серии")
+	}
+	id_str := session.Values["user"]
+	if id_str == nil {
+		return errors.New("Пользователь не авторизирован")
+	}
+	idUser := id_str.(int)
+	idEpisode, _ := strconv.Atoi(r.FormValue("episode_id"))
+
+	ctrl := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	if r.FormValue("action") == "unwatch" {
+		err = ctrl.UnmarkWatched(r.Context(), idEpisode, idUser)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	_, err = ctrl.MarkWatched(r.Context(), idEpisode, idUser)
+	if errors.Is(err, models.ErrNotFound) {
+		return errors.New("Серия не найдена")
+	}
+	return err
+}
+
+func (s *srv) HandleWatchEpisode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"], http.StatusSeeOther)
+			return
+		}
+		err := s.WatchEpisode(w, r)
+		if err != nil {
+			s.serialTemplate(w, r, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"]+"#episode"+r.FormValue("episode_id"), http.StatusSeeOther)
+	}
+}
 
-	ctrlSeasons := controllers.NewSeasonsCtrl(repositories.NewSeasonsRepo(s.DB, s.Log))
-	seasons, err := ctrlSeasons.GetSeasonsBySerialId(id)
+// CommentSerial adds the reply of the user to a comment of the serial, or
+// deletes a comment of the user with the replies to it when the action is
+// delete.
+func (s *srv) CommentSerial(w http.ResponseWriter, r *http.Request) error {
+	session, err := s.session.Get(r, "sname")
 	if err != nil {
-		return
+		return err
 	}
+	if session.Values["admin"] != nil {
+		return errors.New("Администратор не может оставлять комментарии")
+	}
+	id_str := session.Values["user"]
+	if id_str == nil {
+		return errors.New("Пользователь не авторизирован")
+	}
+	idUser := id_str.(int)
+	idComment, _ := strconv.Atoi(r.FormValue("comment_id"))
 
-	ctrlEpisodes := controllers.NewEpisodesCtrl(repositories.NewEpisodesRepo(s.DB, s.Log))
-	for _, season := range seasons {
-		episodes, err := ctrlEpisodes.GetEpisodesBySeasonId(season.GetId())
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	if r.FormValue("action") == "delete" {
+		comment, err := ctrl.GetCommentById(r.Context(), idComment)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
 		if err != nil {
+			return err
+		}
+		if comment.GetIdUser() != idUser {
+			return errors.New("Можно удалять только свои комментарии")
+		}
+		return ctrl.DeleteComment(r.Context(), idComment)
+	}
+
+	text := r.FormValue("comment")
+	if text == "" {
+		return errors.New("Текст комментария не может быть пустым")
+	}
+	err = ctrl.ReplyComment(r.Context(), &models.Comments{
+		C_idUser:   idUser,
+		C_idParent: idComment,
+		C_text:     text,
+		C_date:     time.Now().Format("2006-01-02"),
+	})
+	if errors.Is(err, models.ErrNotFound) {
+		return errors.New("Комментарий не найден")
+	}
+	if msg, ok := bannedWordsMessage(err); ok {
+		return errors.New(msg)
+	}
+	return err
+}
+
+func (s *srv) HandleCommentSerial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"], http.StatusSeeOther)
 			return
 		}
-		SesEp := &seasonEpisodes{
-			Season:   season,
-			Episodes: episodes,
+		err := s.CommentSerial(w, r)
+		if err != nil {
+			s.serialTemplate(w, r, err.Error())
+			return
 		}
-		d.Seasons = append(d.Seasons, SesEp)
+		http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"]+"?sort="+commentsSort(r)+"#comments", http.StatusSeeOther)
 	}
+}
 
-	ctrlComments := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	comments, err := ctrlComments.GetCommentsBySerialId(id)
+// VoteComment sets the vote of the user for a comment of the serial, the
+// value is up, down or none to take the vote back.
+func (s *srv) VoteComment(w http.ResponseWriter, r *http.Request) error {
+	session, err := s.session.Get(r, "sname")
 	if err != nil {
-		return
+		return err
+	}
+	if session.Values["admin"] != nil {
+		return errors.New("Администратор не может оценивать комментарии")
+	}
+	id_str := session.Values["user"]
+	if id_str == nil {
+		return errors.New("Пользователь не авторизирован")
 	}
-	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	for _, comment := range comments {
-		user, err := ctrlUser.GetUserById(comment.GetIdUser())
+	idUser := id_str.(int)
+	idComment, _ := strconv.Atoi(r.FormValue("comment_id"))
+	value, ok := map[string]int{"up": 1, "down": -1, "none": 0}[r.FormValue("value")]
+	if !ok {
+		return errors.New("Неизвестная оценка комментария")
+	}
+
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	err = ctrl.VoteComment(r.Context(), idComment, idUser, value)
+	if errors.Is(err, models.ErrNotFound) {
+		return errors.New("Комментарий не найден")
+	}
+	if errors.Is(err, controllers.ErrOwnComment) {
+		return errors.New("Нельзя оценивать свои комментарии")
+	}
+	return err
+}
+
+func (s *srv) HandleVoteComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"], http.StatusSeeOther)
+			return
+		}
+		err := s.VoteComment(w, r)
 		if err != nil {
+			s.serialTemplate(w, r, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"]+"?sort="+commentsSort(r)+"#comment"+r.FormValue("comment_id"), http.StatusSeeOther)
+	}
+}
+
+// ReportComment files the complaint of the user about a comment of the serial.
+func (s *srv) ReportComment(w http.ResponseWriter, r *http.Request) error {
+	session, err := s.session.Get(r, "sname")
+	if err != nil {
+		return err
+	}
+	if session.Values["admin"] != nil {
+		return errors.New("Администратор не может жаловаться на комментарии")
+	}
+	id_str := session.Values["user"]
+	if id_str == nil {
+		return errors.New("Пользователь не авторизирован")
+	}
+	idUser := id_str.(int)
+	idComment, _ := strconv.Atoi(r.FormValue("comment_id"))
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		return errors.New("Укажите причину жалобы")
+	}
+
+	ctrl := controllers.NewModerationCtrl(repositories.NewCommentsReportsRepo(s.DB, s.Log), repositories.NewModerationsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	err = ctrl.ReportComment(r.Context(), idComment, idUser, reason)
+	if errors.Is(err, models.ErrNotFound) {
+		return errors.New("Комментарий не найден")
+	}
+	if errors.Is(err, controllers.ErrOwnComment) {
+		return errors.New("Нельзя жаловаться на свои комментарии")
+	}
+	if errors.Is(err, controllers.ErrAlreadyReported) {
+		return errors.New("Вы уже пожаловались на этот комментарий")
+	}
+	return err
+}
+
+func (s *srv) HandleReportComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/serial/"+mux.Vars(r)["id"], http.StatusSeeOther)
 			return
 		}
-		c := &CommentsData{
-			C_text: comment.GetText(),
-			U_name: user.GetName(),
+		err := s.ReportComment(w, r)
+		if err != nil {
+			s.serialTemplate(w, r, err.Error())
+			return
 		}
-		d.Comments = append(d.Comments, c)
+		s.serialTemplate(w, r, "Жалоба отправлена модератору")
+	}
+}
+
+// bannedWordsMessage returns the message shown when the comment is rejected
+// by the filter of the banned words, ok is false for the other errors.
+func bannedWordsMessage(err error) (msg string, ok bool) {
+	var banned *controllers.BannedWordsError
+	if !errors.As(err, &banned) {
+		return "", false
+	}
+	return "Комментарий содержит запрещенные слова: " + strings.Join(banned.Words, ", "), true
+}
+
+// commentView is a comment on the serial page with the name of its author.
+// Sort and User repeat the order of the page and whether the visitor may
+// reply, vote and report, Own is set for the comments of the visitor and
+// Admin lets the visitor hide and delete the comment.
+type commentView struct {
+	*models.Comments
+	U_name  string
+	Sort    string
+	User    bool
+	Own     bool
+	Admin   bool
+	Replies []*commentView
+}
+
+// commentsSort returns the order of the comments asked for by the request,
+// newest first by default.
+func commentsSort(r *http.Request) string {
+	sort := r.FormValue("sort")
+	if !models.ValidCommentsOrder(sort) {
+		return models.CommentsByNewest
 	}
+	return sort
+}
+
+// serialCast returns the cast of the serial grouped by the type of role, see
+// models.GroupCast.
+func (s *srv) serialCast(ctx context.Context, id int) ([]*models.CastGroup, error) {
 	ctrlSa := controllers.NewSerialsActorsCtrl(repositories.NewSerialsActorsRepo(s.DB, s.Log))
-	actors, err := ctrlSa.GetActorsBySerialId(id)
+	roles, err := ctrlSa.GetActorsBySerialId(ctx, id)
 	if err != nil {
-		return
+		return nil, err
 	}
 	ctrlA := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
-	for _, actor := range actors {
-		a, err := ctrlA.GetActorById(actor.GetIdActor())
+	cast := []*models.CastMember{}
+	for _, role := range roles {
+		actor, err := ctrlA.GetActorById(ctx, role.GetIdActor())
 		if err != nil {
-			return
+			return nil, err
 		}
-		d.Actors = append(d.Actors, a)
+		cast = append(cast, &models.CastMember{Actor: actor, Role: role})
 	}
-	ctrlP := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
-	producer, err := ctrlP.GetProducerById(serial.GetIdProducer())
+	return models.GroupCast(cast), nil
+}
+
+func (s *srv) serialTemplate(w http.ResponseWriter, r *http.Request, msg string) {
+	type Data struct {
+		Serial   *models.Serial
+		Progress *models.SerialProgress
+		User     bool
+		Err      string
+		Comments []*commentView
+		Sort     string
+		Pager    *pager
+		Cast     []*models.CastGroup
+		Producer *models.Producers
+		Rating   int
+		Scores   []int
+		Lists    []*models.Favourites
+		InLists  []string
+		Similar  []*models.SimilarSerial
+	}
+
+	d := &Data{Err: msg}
+	for score := models.MinScore; score <= models.MaxScore; score++ {
+		d.Scores = append(d.Scores, score)
+	}
+
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	ctrlSerials := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serial, err := ctrlSerials.GetSerialById(r.Context(), id)
 	if err != nil {
 		return
 	}
-	d.Producer = producer
+	d.Serial = serial
 
 	session, err := s.session.Get(r, "sname")
 	if err != nil {
 		return
 	}
-	iduser := session.Values["user"]
-	if iduser != nil {
-		s.addToHistory(iduser.(int), id)
-	}
+	iduser, _ := session.Values["user"].(int)
+	d.User = iduser != 0 && session.Values["admin"] == nil
+	admin := session.Values["admin"] != nil
 
-	tmpl, _ := template.ParseFiles("templates/serial/serial.html")
-	tmpl.Execute(w, d)
-}
+	ctrlWatched := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	progress, err := ctrlWatched.GetProgress(r.Context(), id, iduser)
+	if err != nil {
+		return
+	}
+	d.Progress = progress
 
-func (s *srv) addToHistory(iduser int, idserial int) {
-	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user, err := ctrlUser.GetUserById(iduser)
+	ctrlComments := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	d.Sort = commentsSort(r)
+	page := htmlPage(r)
+	threads, total, err := ctrlComments.GetCommentThreadsPage(r.Context(), id, d.Sort, page)
 	if err != nil {
 		return
 	}
-	ctrlHistory := controllers.NewSerialsUsersCtrl(repositories.NewSerialsUsersRepo(s.DB, s.Log))
-	su, err := ctrlHistory.GetSerialUserByIds(idserial, user.GetId())
-	if err == nil {
-		su.SetLastSeen(time.Now().Format("2006-01-02"))
-		err = ctrlHistory.UpdateSerialsUsers(su)
+	d.Pager = newPager(r, page, total)
+	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	names := map[int]string{}
+	var view func(thread *models.CommentThread) (*commentView, error)
+	view = func(thread *models.CommentThread) (*commentView, error) {
+		name, ok := names[thread.GetIdUser()]
+		if !ok {
+			user, err := ctrlUser.GetUserById(r.Context(), thread.GetIdUser())
+			if err != nil {
+				return nil, err
+			}
+			name = user.GetName()
+			names[thread.GetIdUser()] = name
+		}
+		c := &commentView{
+			Comments: thread.Comments,
+			U_name:   name,
+			Sort:     d.Sort,
+			User:     d.User,
+			Own:      d.User && thread.GetIdUser() == iduser,
+			Admin:    admin,
+		}
+		for _, reply := range thread.Replies {
+			v, err := view(reply)
+			if err != nil {
+				return nil, err
+			}
+			c.Replies = append(c.Replies, v)
+		}
+		return c, nil
+	}
+	for _, thread := range threads {
+		c, err := view(thread)
 		if err != nil {
 			return
 		}
-	} else {
-		history := &models.SerialsUsers{
-			Su_idSerial: idserial,
-			Su_idUser:   user.GetId(),
-			Su_lastSeen: time.Now().Format("2006-01-02"),
+		d.Comments = append(d.Comments, c)
+	}
+	d.Cast, err = s.serialCast(r.Context(), id)
+	if err != nil {
+		return
+	}
+	ctrlP := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
+	producer, err := ctrlP.GetProducerById(r.Context(), serial.GetIdProducer())
+	if err != nil {
+		return
+	}
+	d.Producer = producer
+	ctrlSimilar := controllers.NewSimilarCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSerialsActorsRepo(s.DB, s.Log), s.similar)
+	d.Similar, err = ctrlSimilar.GetSimilar(r.Context(), id, models.SimilarCount)
+	if err != nil {
+		return
+	}
+
+	if d.User {
+		ctrlRatings := controllers.NewRatingsCtrl(repositories.NewRatingsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		rating, err := ctrlRatings.GetRatingBySerialIdUserId(r.Context(), id, iduser)
+		if err == nil {
+			d.Rating = rating.GetScore()
 		}
-		err = ctrlHistory.CreateSerialsUsers(history)
+		ctrlF := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		lists, err := ctrlF.GetLists(r.Context(), iduser)
 		if err != nil {
 			return
 		}
+		d.Lists = lists
+		ctrlSF := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
+		for _, list := range lists {
+			if ctrlSF.CheckSerialInFavourite(r.Context(), &models.SerialsFavourites{Sf_idSerial: id, Sf_idFavourite: list.GetId()}) {
+				d.InLists = append(d.InLists, list.GetName())
+			}
+		}
 	}
+
+	tmpl, _ := template.ParseFiles("templates/serial/serial.html", "templates/pager.html", "templates/markup.html")
+	tmpl.Execute(w, d)
 }
@@ -4,9 +4,14 @@ import (
 	"app/internal/controllers"
 	"app/internal/models"
 	"app/internal/repositories"
-	"log"
+	"app/logger"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
 	"net/http"
-	"text/template"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/sessions"
@@ -19,14 +24,27 @@ type srv struct {
 	Log     *logrus.Logger
 	DB      interface{}
 	session *sessions.CookieStore
+	timeout time.Duration
+	filter  *models.WordFilter
+	similar *models.SimilarCache
 }
 
-func NewServer(logger *logrus.Logger, db interface{}, session string) *srv {
+// similarTTL is how long the similar serials are cached, the changes made
+// by other processes show up after it.
+const similarTTL = 10 * time.Minute
+
+// NewServer returns the server. The database queries of a request are
+// cancelled after timeout, zero means no limit. The comments with the words
+// banned by filter are rejected.
+func NewServer(logger *logrus.Logger, db interface{}, session string, timeout time.Duration, filter *models.WordFilter) *srv {
 	s := &srv{
 		Router:  mux.NewRouter(),
 		Log:     logger,
 		DB:      db,
 		session: sessions.NewCookieStore([]byte(session)),
+		timeout: timeout,
+		filter:  filter,
+		similar: models.NewSimilarCache(similarTTL),
 	}
 	s.InitRouter()
 	return s
@@ -36,14 +54,41 @@ func (s *srv) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.Router.ServeHTTP(w, r)
 }
 
+// RequestContext gives every request an id, sent back in the X-Request-ID
+// header and added to the log entries made with the request context, and
+// the server timeout. The context is cancelled when the client disconnects.
+func (s *srv) RequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := logger.NewRequestID()
+		w.Header().Set("X-Request-ID", id)
+		ctx := logger.WithRequestID(r.Context(), id)
+		if s.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+		}
+		s.Log.WithContext(ctx).Infof("%s %s", r.Method, r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (s *srv) InitRouter() {
+	s.Router.Use(s.RequestContext)
 	s.Router.HandleFunc("/", s.HandleStart())
 	s.Router.HandleFunc("/search", s.HandleSearch())
 	s.Router.HandleFunc("/login", s.HandleLogin())
 	s.Router.HandleFunc("/createUser", s.HandleCreateUser())
+	s.Router.HandleFunc("/lists/{slug:[0-9a-f]+}", s.HandleSharedList())
+	s.Router.HandleFunc("/users/{id:[0-9]+}", s.HandleProfile())
+	s.Router.HandleFunc("/compare/{slug:[0-9a-f]+}", s.HandleSharedComparison())
 
 	serial_root := s.Router.PathPrefix("/serial").Subrouter()
 	serial_root.HandleFunc("/{id:[0-9]+}", s.HandleSerial())
+	serial_root.HandleFunc("/{id:[0-9]+}/rate", s.HandleRateSerial())
+	serial_root.HandleFunc("/{id:[0-9]+}/watch", s.HandleWatchEpisode())
+	serial_root.HandleFunc("/{id:[0-9]+}/comment", s.HandleCommentSerial())
+	serial_root.HandleFunc("/{id:[0-9]+}/vote", s.HandleVoteComment())
+	serial_root.HandleFunc("/{id:[0-9]+}/report", s.HandleReportComment())
 
 	user_root := s.Router.PathPrefix("/user").Subrouter()
 	user_root.Use(s.UserAuth)
@@ -54,9 +99,18 @@ func (s *srv) InitRouter() {
 	user_root.HandleFunc("/deleteComment", s.HandleDeleteComment())
 	user_root.HandleFunc("/favourites", s.HandleFavourites())
 	user_root.HandleFunc("/deleteFavourite", s.HandleDeleteFavourite())
+	user_root.HandleFunc("/moveFavourite", s.HandleMoveFavourite())
+	user_root.HandleFunc("/addList", s.HandleAddList())
+	user_root.HandleFunc("/updateList", s.HandleUpdateList())
+	user_root.HandleFunc("/deleteList", s.HandleDeleteList())
+	user_root.HandleFunc("/reorderLists", s.HandleReorderLists())
+	user_root.HandleFunc("/shareList", s.HandleShareList())
 	user_root.HandleFunc("/history", s.HandleHistory())
+	user_root.HandleFunc("/recommendations", s.HandleRecommendations())
 	user_root.HandleFunc("/clearHistory", s.HandleClearHistory())
 	user_root.HandleFunc("/compareSerials", s.HandleCompareSerials())
+	user_root.HandleFunc("/saveComparison", s.HandleSaveComparison())
+	user_root.HandleFunc("/deleteComparison", s.HandleDeleteComparison())
 	user_root.HandleFunc("/changeProfile", s.HandleUpdateProfile())
 
 	admin_root := s.Router.PathPrefix("/admin").Subrouter()
@@ -67,6 +121,16 @@ func (s *srv) InitRouter() {
 	admin_root.HandleFunc("/changeSerial", s.HandleUpdateSerial())
 	admin_root.HandleFunc("/deleteSerial", s.HandleDeleteSerial())
 	admin_root.HandleFunc("/addSerialActor", s.HandleAddSerialActor())
+	admin_root.HandleFunc("/seasons", s.HandleSeasons())
+	admin_root.HandleFunc("/addSeason", s.HandleAddSeason())
+	admin_root.HandleFunc("/changeSeason", s.HandleUpdateSeason())
+	admin_root.HandleFunc("/deleteSeason", s.HandleDeleteSeason())
+	admin_root.HandleFunc("/reorderSeasons", s.HandleReorderSeasons())
+	admin_root.HandleFunc("/episodes", s.HandleEpisodes())
+	admin_root.HandleFunc("/addEpisode", s.HandleAddEpisode())
+	admin_root.HandleFunc("/changeEpisode", s.HandleUpdateEpisode())
+	admin_root.HandleFunc("/deleteEpisode", s.HandleDeleteEpisode())
+	admin_root.HandleFunc("/reorderEpisodes", s.HandleReorderEpisodes())
 	admin_root.HandleFunc("/addActor", s.HandleAddActor())
 	admin_root.HandleFunc("/changeActor", s.HandleUpdateActor())
 	admin_root.HandleFunc("/deleteActor", s.HandleDeleteActor())
@@ -77,7 +141,39 @@ func (s *srv) InitRouter() {
 	admin_root.HandleFunc("/deleteUser", s.HandleDeleteUser())
 	admin_root.HandleFunc("/grantAdmin", s.HandleGrantAdmin())
 	admin_root.HandleFunc("/showStatistics", s.HandleShowStatistics())
+	admin_root.HandleFunc("/moderation", s.HandleModeration())
+	admin_root.HandleFunc("/moderationLog", s.HandleModerationLog())
 
+	api_root := s.Router.PathPrefix("/api/v1").Subrouter()
+	api_root.HandleFunc("/serials", s.HandleApiGetSerials()).Methods(http.MethodGet)
+	api_root.HandleFunc("/search", s.HandleApiSearch()).Methods(http.MethodGet)
+	api_root.HandleFunc("/suggest", s.HandleApiSuggest()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials", s.HandleApiCreateSerial()).Methods(http.MethodPost)
+	api_root.HandleFunc("/serials/{id:[0-9]+}", s.HandleApiGetSerial()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials/{id:[0-9]+}", s.HandleApiUpdateSerial()).Methods(http.MethodPut)
+	api_root.HandleFunc("/serials/{id:[0-9]+}", s.HandleApiDeleteSerial()).Methods(http.MethodDelete)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/rating", s.HandleApiGetRating()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/rating", s.HandleApiRateSerial()).Methods(http.MethodPut)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/rating", s.HandleApiDeleteRating()).Methods(http.MethodDelete)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/similar", s.HandleApiGetSimilar()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/progress", s.HandleApiGetProgress()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/seasons", s.HandleApiGetSeasons()).Methods(http.MethodGet)
+	api_root.HandleFunc("/serials/{id:[0-9]+}/seasons", s.HandleApiCreateSeason()).Methods(http.MethodPost)
+	api_root.HandleFunc("/seasons/{id:[0-9]+}", s.HandleApiGetSeason()).Methods(http.MethodGet)
+	api_root.HandleFunc("/seasons/{id:[0-9]+}", s.HandleApiUpdateSeason()).Methods(http.MethodPut)
+	api_root.HandleFunc("/seasons/{id:[0-9]+}", s.HandleApiDeleteSeason()).Methods(http.MethodDelete)
+	api_root.HandleFunc("/seasons/{id:[0-9]+}/episodes", s.HandleApiGetEpisodes()).Methods(http.MethodGet)
+	api_root.HandleFunc("/seasons/{id:[0-9]+}/episodes", s.HandleApiCreateEpisode()).Methods(http.MethodPost)
+	api_root.HandleFunc("/episodes/{id:[0-9]+}", s.HandleApiGetEpisode()).Methods(http.MethodGet)
+	api_root.HandleFunc("/episodes/{id:[0-9]+}", s.HandleApiUpdateEpisode()).Methods(http.MethodPut)
+	api_root.HandleFunc("/episodes/{id:[0-9]+}", s.HandleApiDeleteEpisode()).Methods(http.MethodDelete)
+	api_root.HandleFunc("/episodes/{id:[0-9]+}/watched", s.HandleApiWatchEpisode()).Methods(http.MethodPut)
+	api_root.HandleFunc("/episodes/{id:[0-9]+}/watched", s.HandleApiUnwatchEpisode()).Methods(http.MethodDelete)
+	api_root.HandleFunc("/lists/{slug:[0-9a-f]+}", s.HandleApiGetList()).Methods(http.MethodGet)
+	api_root.HandleFunc("/recommendations", s.HandleApiGetRecommendations()).Methods(http.MethodGet)
+	api_root.HandleFunc("/compare", s.HandleApiCompare()).Methods(http.MethodGet)
+	api_root.HandleFunc("/comparisons", s.HandleApiSaveComparison()).Methods(http.MethodPost)
+	api_root.HandleFunc("/comparisons/{slug:[0-9a-f]+}", s.HandleApiGetComparison()).Methods(http.MethodGet)
 }
 
 func (s *srv) HandleExit() http.HandlerFunc {
@@ -92,26 +188,174 @@ func (s *srv) HandleExit() http.HandlerFunc {
 
 func (s *srv) HandleSearch() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-		serials, err := ctrl.GetSerialByTitle(r.FormValue("search"))
+		type searchPage struct {
+			Text      string
+			Hits      []*models.SearchHit
+			Query     *models.SerialsQuery
+			Serials   []*models.Serial
+			Producers []*models.Producers
+			Actors    []*models.Actors
+			Pager     *pager
+			Err       string
+		}
+		page := &searchPage{Text: r.FormValue("q")}
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		num := htmlPage(r)
+		query, err := serialsQuery(r)
+		if page.Text != "" {
+			// The full-text search ignores the filters.
+			page.Query = &models.SerialsQuery{}
+			var total int
+			page.Hits, total, err = ctrl.FullTextSearch(r.Context(), page.Text, num)
+			if errors.Is(err, models.ErrInvalidModel) {
+				page.Err = "Некорректные параметры поиска"
+			} else if err != nil {
+				s.Log.WithContext(r.Context()).Error(err)
+				return
+			}
+			page.Pager = newPager(r, num, total)
+		} else if err != nil {
+			page.Query = &models.SerialsQuery{}
+			page.Err = "Некорректные параметры поиска"
+		} else {
+			page.Query = query
+			var total int
+			page.Serials, total, err = ctrl.SearchSerials(r.Context(), query, num)
+			if errors.Is(err, models.ErrInvalidModel) {
+				page.Err = "Некорректные параметры поиска"
+			} else if err != nil {
+				s.Log.WithContext(r.Context()).Error(err)
+				return
+			}
+			page.Pager = newPager(r, num, total)
+		}
+		ctrlProducers := controllers.NewProducersCtrl(repositories.NewProducersRepo(s.DB, s.Log))
+		page.Producers, _ = ctrlProducers.GetProducers(r.Context())
+		ctrlActors := controllers.NewActorsCtrl(repositories.NewActorsRepo(s.DB, s.Log))
+		page.Actors, _ = ctrlActors.GetActors(r.Context())
+		tmpl, _ := template.ParseFiles("templates/search.html", "templates/pager.html")
+		tmpl.Execute(w, page)
+	}
+}
+
+// serialsQuery reads the serials search query from the request parameters
+// title, genre, state, yearFrom, yearTo, minRating, producer, actor, sort
+// (rating, year or name) and order (asc or desc). Empty parameters are ignored.
+func serialsQuery(r *http.Request) (*models.SerialsQuery, error) {
+	query := &models.SerialsQuery{
+		Title: r.FormValue("title"),
+		Genre: r.FormValue("genre"),
+		State: r.FormValue("state"),
+		Sort:  r.FormValue("sort"),
+		Desc:  r.FormValue("order") == "desc",
+	}
+	ints := []struct {
+		name string
+		dst  *int
+	}{
+		{"yearFrom", &query.YearFrom},
+		{"yearTo", &query.YearTo},
+		{"producer", &query.IdProducer},
+		{"actor", &query.IdActor},
+	}
+	for _, param := range ints {
+		value := r.FormValue(param.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
 		if err != nil {
-			s.Log.Error(err)
-			return
+			return nil, fmt.Errorf("%s must be an integer", param.name)
+		}
+		*param.dst = n
+	}
+	if value := r.FormValue("minRating"); value != "" {
+		rating, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return nil, fmt.Errorf("minRating must be a number")
+		}
+		query.MinRating = float32(rating)
+	}
+	return query, nil
+}
+
+// pageParams reads the page from the request parameters page, numbered from 1,
+// and pageSize. They default to the first page of models.DefaultPageSize rows.
+func pageParams(r *http.Request) (models.Page, error) {
+	page := models.NewPage(1, models.DefaultPageSize)
+	if value := r.FormValue("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return page, fmt.Errorf("page must be an integer")
 		}
-		tmpl, _ := template.ParseFiles("templates/search.html")
-		tmpl.Execute(w, serials)
+		page.Num = n
 	}
+	if value := r.FormValue("pageSize"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return page, fmt.Errorf("pageSize must be an integer")
+		}
+		page.Size = n
+	}
+	if !page.Validate() {
+		return page, fmt.Errorf("page must be positive and not too large, pageSize from 1 to %d", models.MaxPageSize)
+	}
+	return page, nil
+}
+
+// htmlPage is pageParams for the pages of the site, which show the first page
+// instead of an error.
+func htmlPage(r *http.Request) models.Page {
+	page, err := pageParams(r)
+	if err != nil {
+		return models.NewPage(1, models.DefaultPageSize)
+	}
+	return page
+}
+
+// pager holds the page controls rendered by templates/pager.html. Prev and
+// Next are the links to the neighbouring pages, empty at the ends of the list.
+type pager struct {
+	Num   int
+	Count int
+	Total int
+	Prev  string
+	Next  string
+}
+
+// newPager returns the controls of the page of a list of total rows. The links
+// keep the other request parameters, e.g. the search filters.
+func newPager(r *http.Request, page models.Page, total int) *pager {
+	p := &pager{Num: page.Num, Count: page.Count(total), Total: total}
+	link := func(num int) string {
+		params := r.URL.Query()
+		params.Set("page", strconv.Itoa(num))
+		return "?" + params.Encode()
+	}
+	if p.Num > 1 {
+		p.Prev = link(min(p.Num-1, p.Count))
+	}
+	if p.Num < p.Count {
+		p.Next = link(p.Num + 1)
+	}
+	return p
 }
 
 func (s *srv) HandleStart() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-		serials, err := ctrl.GetSerials()
+		type startPage struct {
+			Serials []*models.Serial
+			Pager   *pager
+		}
+		page := htmlPage(r)
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		serials, total, err := ctrl.GetSerialsPage(r.Context(), page)
 		if err != nil {
-			log.Fatal(err)
+			s.Log.WithContext(r.Context()).Error(err)
+			return
 		}
-		tmpl, _ := template.ParseFiles("templates/index.html")
-		tmpl.Execute(w, serials)
+		tmpl, _ := template.ParseFiles("templates/index.html", "templates/pager.html")
+		tmpl.Execute(w, &startPage{Serials: serials, Pager: newPager(r, page, total)})
 	}
 }
 
@@ -143,7 +387,7 @@ func (s *srv) loginTemplate(w http.ResponseWriter, err error) {
 	case nil:
 		lerr.Err = ""
 	case controllers.ErrUserNotFound:
-		lerr.Err = "Пользователя с таким<br>логином не существует"
+		lerr.Err = "Пользователя с таким логином не существует"
 	case controllers.ErrInvalidPass:
 		lerr.Err = "Неверный пароль"
 	}
@@ -154,8 +398,8 @@ func (s *srv) Auth(w http.ResponseWriter, r *http.Request) {
 	login := r.FormValue("login")
 	password := r.FormValue("password")
 
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user, err := ctrl.AuthUser(login, password)
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user, err := ctrl.AuthUser(r.Context(), login, password)
 	if err != nil {
 		s.loginTemplate(w, err)
 		return
@@ -214,13 +458,13 @@ func (s *srv) AcceptUserCreation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	user.SetPassword(string(pass))
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	err = ctrl.CreateUser(user)
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	err = ctrl.CreateUser(r.Context(), user)
 	if err != nil {
 		if err == models.ErrInvalidModel {
 			s.creationTemplate(w, "Заполнены не все поля")
 		} else if err == controllers.ErrUserExists {
-			s.creationTemplate(w, "Пользователь с таким<br>логином уже существует")
+			s.creationTemplate(w, "Пользователь с таким логином уже существует")
 		}
 		return
 	}
@@ -231,76 +475,95 @@ func (s *srv) AcceptUserCreation(w http.ResponseWriter, r *http.Request) {
 	session.Values[user.U_role] = user.U_id
 	s.session.Save(r, w, session)
 
-	// update statistics
-	s.UpdateStatisticUp(user)
-
 	http.Redirect(w, r, "/user/cabinet/0", http.StatusMovedPermanently)
 }
 
-func (s *srv) UpdateStatisticUp(user *models.Users) {
+func (s *srv) UpdateStatisticUp(ctx context.Context, user *models.Users) {
 	ctrl := controllers.NewStatisticCtrl(repositories.NewStatisticRepo(s.DB, s.Log))
-	stat, err := ctrl.GetStatistic()
-	if err != nil {
-		s.Log.Error(err)
-		return
-	}
-	if user.U_gender == "мужской" {
-		stat.IncreaseGenderMale()
-	} else {
-		stat.IncreaseGenderFemale()
-	}
-	if user.U_role == "user" {
-		stat.IncreaseRoleUser()
-	} else {
-		stat.IncreaseRoleAdmin()
-	}
-	age := user.GetAge()
-	switch {
-	case age <= 18:
-		stat.IncreaseAge0_18()
-	case age <= 30:
-		stat.IncreaseAge19_30()
-	case age <= 50:
-		stat.IncreaseAge31_50()
-	case age > 50:
-		stat.IncreaseAge51_100()
-	}
-	err = ctrl.UpdateStatistic(stat)
+	err := ctrl.AddUser(ctx, user)
 	if err != nil {
-		s.Log.Error(err)
+		s.Log.WithContext(ctx).Error(err)
 	}
 }
 
-func (s *srv) UpdateStatisticDown(user *models.Users) {
+func (s *srv) UpdateStatisticDown(ctx context.Context, user *models.Users) {
 	ctrl := controllers.NewStatisticCtrl(repositories.NewStatisticRepo(s.DB, s.Log))
-	stat, err := ctrl.GetStatistic()
+	err := ctrl.RemoveUser(ctx, user)
 	if err != nil {
-		s.Log.Error(err)
-		return
+		s.Log.WithContext(ctx).Error(err)
 	}
-	if user.U_gender == "мужской" {
-		stat.DecreaseGenderMale()
-	} else {
-		stat.DecreaseGenderFemale()
+}
+
+// HandleSharedList shows the unlisted or public list with the slug read-only.
+func (s *srv) HandleSharedList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		list, err := ctrl.GetSharedList(r.Context(), mux.Vars(r)["slug"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		shared, err := s.sharedList(r.Context(), list)
+		if err != nil {
+			return
+		}
+		tmpl, _ := template.ParseFiles("templates/public/list.html")
+		tmpl.Execute(w, shared)
 	}
-	if user.U_role == "user" {
-		stat.DecreaseRoleUser()
-	} else {
-		stat.DecreaseRoleAdmin()
-	}
-	age := user.GetAge()
-	switch {
-	case age <= 18:
-		stat.DecreaseAge0_18()
-	case age <= 30 && age > 18:
-		stat.DecreaseAge19_30()
-	case age <= 50 && age > 30:
-		stat.DecreaseAge31_50()
-	case age > 50:
-		stat.DecreaseAge51_100()
-	}
-	err = ctrl.UpdateStatistic(stat)
-	if err != nil {
-		s.Log.Error(err)
+}
+
+// HandleSharedComparison shows the saved comparison by its link.
+func (s *srv) HandleSharedComparison() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		comparison, err := ctrl.GetSavedComparison(r.Context(), mux.Vars(r)["slug"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		tmpl, _ := template.ParseFiles("templates/public/compare.html", "templates/comparison.html")
+		tmpl.Execute(w, comparison)
+	}
+}
+
+// HandleProfile shows the public profile of the user: the name, the number of
+// the reviews and the public lists.
+func (s *srv) HandleProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type Data struct {
+			Name    string
+			Reviews int
+			Lists   []*models.SharedList
+		}
+
+		id, _ := strconv.Atoi(mux.Vars(r)["id"])
+		ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		user, err := ctrlUser.GetUserById(r.Context(), id)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		d := &Data{Name: user.GetName()}
+
+		ctrlComments := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+		d.Reviews, err = ctrlComments.CountReviews(r.Context(), id)
+		if err != nil {
+			return
+		}
+		ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		lists, err := ctrl.GetPublicLists(r.Context(), id)
+		if err != nil {
+			return
+		}
+		for _, list := range lists {
+			shared, err := s.sharedList(r.Context(), list)
+			if err != nil {
+				return
+			}
+			d.Lists = append(d.Lists, shared)
+		}
+
+		tmpl, _ := template.ParseFiles("templates/public/profile.html")
+		tmpl.Execute(w, d)
 	}
 }
@@ -4,9 +4,12 @@ import (
 	"app/internal/controllers"
 	"app/internal/models"
 	"app/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
 	"net/http"
 	"strconv"
-	"text/template"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -69,8 +72,8 @@ func (s *srv) userCabinetTemplate(w http.ResponseWriter, r *http.Request, msg st
 		return
 	}
 	id := session.Values["user"].(int)
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user, err := ctrl.GetUserById(id)
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user, err := ctrl.GetUserById(r.Context(), id)
 	if err != nil {
 		return
 	}
@@ -83,112 +86,367 @@ func (s *srv) userCabinetTemplate(w http.ResponseWriter, r *http.Request, msg st
 	tmpl.Execute(w, d)
 }
 
+// HandleCompareSerials shows the serials to pick for the comparison with the
+// saved comparisons of the user and compares the picked serials on POST.
 func (s *srv) HandleCompareSerials() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		type Data struct {
-			Serials []*models.Serial
-			Compare []*models.Serial
+		msg := ""
+		if r.FormValue("msg") == "1" {
+			msg = "Сравнение удалено"
 		}
-		d := &Data{}
+		if r.Method != http.MethodPost {
+			s.compareTemplate(w, r, nil, "", msg)
+			return
+		}
+		r.ParseForm()
+		ids, err := formIds(r.Form["serial"])
+		if err != nil {
+			s.compareTemplate(w, r, nil, compareMessage(models.ErrInvalidModel), "")
+			return
+		}
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		comparison, err := ctrl.Compare(r.Context(), ids)
+		if err != nil {
+			s.compareTemplate(w, r, nil, compareMessage(err), "")
+			return
+		}
+		s.compareTemplate(w, r, comparison, "", "")
+	}
+}
 
-		if r.Method == http.MethodPost {
-			r.ParseForm()
-			ids := r.Form["serial"]
-			ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-			for _, id := range ids {
-				id, _ := strconv.Atoi(id)
-				serial, err := ctrl.GetSerialById(id)
-				if err != nil {
-					return
-				}
-				d.Compare = append(d.Compare, serial)
-			}
-		} else {
-			ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-			serials, err := ctrl.GetSerials()
-			if err != nil {
-				return
-			}
-			d.Serials = serials
+func (s *srv) compareTemplate(w http.ResponseWriter, r *http.Request, comparison *models.Comparison, err string, msg string) {
+	type Data struct {
+		Serials    []*models.Serial
+		Saved      []*models.SavedComparison
+		Comparison *models.Comparison
+		Min        int
+		Max        int
+		Err        string
+		Msg        string
+	}
+	d := &Data{Comparison: comparison, Min: models.MinCompare, Max: models.MaxCompare, Err: err, Msg: msg}
+
+	if comparison == nil {
+		session, e := s.session.Get(r, "sname")
+		if e != nil {
+			return
+		}
+		id := session.Values["user"].(int)
+		ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		d.Serials, e = ctrl.GetSerials(r.Context())
+		if e != nil {
+			return
+		}
+		ctrlComparisons := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		d.Saved, e = ctrlComparisons.GetComparisonsByUserId(r.Context(), id)
+		if e != nil {
+			return
 		}
-		tmpl, _ := template.ParseFiles("templates/user/compare.html")
-		tmpl.Execute(w, d)
 	}
+	tmpl, _ := template.ParseFiles("templates/user/compare.html", "templates/comparison.html")
+	tmpl.Execute(w, d)
 }
 
-func (s *srv) HandleFavourites() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		type Data struct {
-			Favourites []*models.Serial
+// compareMessage returns the message shown for an error of comparing serials.
+func compareMessage(err error) string {
+	if errors.Is(err, models.ErrInvalidModel) {
+		return fmt.Sprintf("Выберите от %d до %d сериалов", models.MinCompare, models.MaxCompare)
+	}
+	return "Не удалось сравнить сериалы"
+}
+
+// formIds parses the ids of the serials picked in a form.
+func formIds(values []string) ([]int, error) {
+	ids := []int{}
+	for _, value := range values {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
 		}
-		d := &Data{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
 
+// HandleSaveComparison saves the comparison of the picked serials and opens
+// its link.
+func (s *srv) HandleSaveComparison() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/user/compareSerials", http.StatusSeeOther)
+			return
+		}
 		session, err := s.session.Get(r, "sname")
 		if err != nil {
 			return
 		}
 		id := session.Values["user"].(int)
-		ctrl := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
-		serials, err := ctrl.GetSerialsByFavouriteId(id)
+		r.ParseForm()
+		ids, err := formIds(r.Form["serial"])
 		if err != nil {
+			s.compareTemplate(w, r, nil, compareMessage(models.ErrInvalidModel), "")
 			return
 		}
-		for _, serial := range serials {
-			ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-			s, err := ctrl.GetSerialById(serial.GetIdSerial())
-			if err != nil {
-				return
-			}
-			d.Favourites = append(d.Favourites, s)
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		comparison, err := ctrl.SaveComparison(r.Context(), id, ids)
+		if err != nil {
+			s.compareTemplate(w, r, nil, compareMessage(err), "")
+			return
 		}
-
-		tmpl, _ := template.ParseFiles("templates/user/favourites.html")
-		tmpl.Execute(w, d)
+		http.Redirect(w, r, "/compare/"+comparison.GetSlug(), http.StatusSeeOther)
 	}
 }
 
-func (s *srv) HandleDeleteFavourite() http.HandlerFunc {
+func (s *srv) HandleDeleteComparison() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		tid := r.FormValue("serial")
-		id, _ := strconv.Atoi(tid)
-
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/user/compareSerials", http.StatusSeeOther)
+			return
+		}
 		session, err := s.session.Get(r, "sname")
 		if err != nil {
 			return
 		}
-		iduser := session.Values["user"].(int)
-		ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-		user, err := ctrlUser.GetUserById(iduser)
+		id := session.Values["user"].(int)
+		idComparison, _ := strconv.Atoi(r.FormValue("comparison"))
+		ctrl := controllers.NewComparisonsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		err = ctrl.DeleteComparison(r.Context(), id, idComparison)
 		if err != nil {
+			s.compareTemplate(w, r, nil, "Сравнение не найдено", "")
 			return
 		}
+		http.Redirect(w, r, "/user/compareSerials?msg=1", http.StatusSeeOther)
+	}
+}
 
-		ctrl := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
-		err = ctrl.DeleteSerialById(user.GetIdFavourites(), id)
+func (s *srv) HandleFavourites() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		msg := ""
+		switch r.FormValue("msg") {
+		case "1":
+			msg = "Список создан"
+		case "2":
+			msg = "Список сохранен"
+		case "3":
+			msg = "Список удален"
+		case "4":
+			msg = "Порядок списков сохранен"
+		case "5":
+			msg = "Сериал удален из списка"
+		case "6":
+			msg = "Сериал перенесен в другой список"
+		case "7":
+			msg = "Доступ к списку изменен"
+		}
+		s.favouritesTemplate(w, r, "", msg)
+	}
+}
+
+func (s *srv) favouritesTemplate(w http.ResponseWriter, r *http.Request, err string, msg string) {
+	type List struct {
+		List    *models.Favourites
+		Default bool
+		Entries []*models.ListEntry
+	}
+	type Data struct {
+		Err         string
+		Msg         string
+		Lists       []*List
+		All         []*models.Favourites
+		ProfileLink string
+	}
+	d := &Data{Err: err, Msg: msg}
+
+	session, e := s.session.Get(r, "sname")
+	if e != nil {
+		return
+	}
+	id := session.Values["user"].(int)
+	d.ProfileLink = "/users/" + strconv.Itoa(id)
+	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user, e := ctrlUser.GetUserById(r.Context(), id)
+	if e != nil {
+		return
+	}
+	ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	lists, e := ctrl.GetLists(r.Context(), id)
+	if e != nil {
+		return
+	}
+	d.All = lists
+	for _, list := range lists {
+		entries, e := s.listEntries(r.Context(), list)
+		if e != nil {
+			return
+		}
+		d.Lists = append(d.Lists, &List{List: list, Default: list.GetId() == user.GetIdFavourites(), Entries: entries})
+	}
+
+	tmpl, _ := template.ParseFiles("templates/user/favourites.html")
+	tmpl.Execute(w, d)
+}
+
+// listEntries returns the serials of the list with the dates they were added,
+// skipping the serials deleted since.
+func (s *srv) listEntries(ctx context.Context, list *models.Favourites) ([]*models.ListEntry, error) {
+	ctrlSF := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(s.DB, s.Log))
+	serials, err := ctrlSF.GetSerialsByFavouriteId(ctx, list.GetId())
+	if err != nil {
+		return nil, err
+	}
+	ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	entries := []*models.ListEntry{}
+	for _, sf := range serials {
+		serial, err := ctrlS.GetSerialById(ctx, sf.GetIdSerial())
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
 		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &models.ListEntry{Serial: serial, Date: sf.GetDate()})
+	}
+	return entries, nil
+}
+
+// sharedList returns the list with its serials and the name of its owner.
+func (s *srv) sharedList(ctx context.Context, list *models.Favourites) (*models.SharedList, error) {
+	ctrlUser := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	owner, err := ctrlUser.GetUserById(ctx, list.GetIdUser())
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.listEntries(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SharedList{
+		Name:    list.GetName(),
+		Note:    list.GetNote(),
+		IdOwner: owner.GetId(),
+		Owner:   owner.GetName(),
+		Slug:    list.GetSlug(),
+		Entries: entries,
+	}, nil
+}
+
+// listsMessage is the message shown on the lists page when a change fails.
+func listsMessage(err error) string {
+	switch {
+	case errors.Is(err, controllers.ErrListExists):
+		return "Список с таким названием уже есть"
+	case errors.Is(err, controllers.ErrDefaultList):
+		return "Основной список нельзя удалить"
+	case errors.Is(err, controllers.ErrSerialInList):
+		return "Сериал уже есть в этом списке"
+	case errors.Is(err, controllers.ErrInvalidOrder):
+		return "Неверный порядок списков"
+	case errors.Is(err, models.ErrInvalidModel):
+		return fmt.Sprintf("Название списка не может быть пустым или длиннее %d символов, заметка - длиннее %d символов", models.MaxListName, models.MaxListNote)
+	case errors.Is(err, models.ErrNotFound):
+		return "Список или сериал не найден"
+	}
+	return "Ошибка изменения списков"
+}
+
+// handleLists runs the change of the lists of the user posted by a form of the
+// lists page and redirects back to the page with the message code msg.
+func (s *srv) handleLists(msg string, change func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/user/favourites", http.StatusSeeOther)
+			return
+		}
+		session, err := s.session.Get(r, "sname")
+		if err != nil {
+			return
+		}
+		id := session.Values["user"].(int)
+		ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err = change(r, ctrl, id)
+		if err != nil {
+			s.favouritesTemplate(w, r, listsMessage(err), "")
 			return
 		}
-		http.Redirect(w, r, "/user/favourites", http.StatusSeeOther)
+		http.Redirect(w, r, "/user/favourites?msg="+msg, http.StatusSeeOther)
 	}
 }
 
+func (s *srv) HandleAddList() http.HandlerFunc {
+	return s.handleLists("1", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		return ctrl.CreateList(r.Context(), &models.Favourites{
+			F_idUser: idUser,
+			F_name:   r.FormValue("name"),
+			F_note:   r.FormValue("note"),
+		})
+	})
+}
+
+func (s *srv) HandleUpdateList() http.HandlerFunc {
+	return s.handleLists("2", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		id, _ := strconv.Atoi(r.FormValue("list"))
+		return ctrl.UpdateList(r.Context(), idUser, id, r.FormValue("name"), r.FormValue("note"))
+	})
+}
+
+func (s *srv) HandleDeleteList() http.HandlerFunc {
+	return s.handleLists("3", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		id, _ := strconv.Atoi(r.FormValue("list"))
+		return ctrl.DeleteList(r.Context(), idUser, id)
+	})
+}
+
+func (s *srv) HandleShareList() http.HandlerFunc {
+	return s.handleLists("7", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		id, _ := strconv.Atoi(r.FormValue("list"))
+		return ctrl.SetVisibility(r.Context(), idUser, id, r.FormValue("visibility"))
+	})
+}
+
+func (s *srv) HandleReorderLists() http.HandlerFunc {
+	return s.handleLists("4", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		order, err := formOrder(r)
+		if err != nil {
+			return controllers.ErrInvalidOrder
+		}
+		return ctrl.ReorderLists(r.Context(), idUser, order)
+	})
+}
+
+func (s *srv) HandleDeleteFavourite() http.HandlerFunc {
+	return s.handleLists("5", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		id, _ := strconv.Atoi(r.FormValue("list"))
+		idSerial, _ := strconv.Atoi(r.FormValue("serial"))
+		return ctrl.RemoveSerial(r.Context(), idUser, id, idSerial)
+	})
+}
+
+func (s *srv) HandleMoveFavourite() http.HandlerFunc {
+	return s.handleLists("6", func(r *http.Request, ctrl *controllers.FavouritesCtrl, idUser int) error {
+		from, _ := strconv.Atoi(r.FormValue("list"))
+		to, _ := strconv.Atoi(r.FormValue("to"))
+		idSerial, _ := strconv.Atoi(r.FormValue("serial"))
+		return ctrl.MoveSerial(r.Context(), idUser, from, to, idSerial)
+	})
+}
+
 func (s *srv) HandleAddComment() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			s.AcceptAddComment(w, r)
 			return
 		}
-		s.addCommentTemplate(w, "")
+		s.addCommentTemplate(r.Context(), w, "")
 	}
 }
 
-func (s *srv) addCommentTemplate(w http.ResponseWriter, msg string) {
+func (s *srv) addCommentTemplate(ctx context.Context, w http.ResponseWriter, msg string) {
 	type Data struct {
 		Err     string
 		Serials []*models.Serial
 	}
-	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
-	serials, err := ctrl.GetSerials()
+	ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+	serials, err := ctrl.GetSerials(ctx)
 	if err != nil {
 		return
 	}
@@ -207,28 +465,32 @@ func (s *srv) AcceptAddComment(w http.ResponseWriter, r *http.Request) {
 
 	idserial, err := strconv.Atoi(r.FormValue("idserial"))
 	if err != nil {
-		s.addCommentTemplate(w, "Сериал не выбран")
+		s.addCommentTemplate(r.Context(), w, "Сериал не выбран")
 		return
 	}
-	ctrlComment := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	if ctrlComment.CheckComment(id, idserial) {
-		s.addCommentTemplate(w, "Вы уже оставляли комментарий к этому сериалу")
+	ctrlComment := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	if ctrlComment.CheckComment(r.Context(), id, idserial) {
+		s.addCommentTemplate(r.Context(), w, "Вы уже оставляли комментарий к этому сериалу")
 		return
 
 	}
 
 	comment := r.FormValue("comment")
 	if comment == "" {
-		s.addCommentTemplate(w, "Текст комментария не может быть пустым")
+		s.addCommentTemplate(r.Context(), w, "Текст комментария не может быть пустым")
 		return
 	}
 
-	err = ctrlComment.CreateComment(&models.Comments{
+	err = ctrlComment.CreateComment(r.Context(), &models.Comments{
 		C_idUser:   id,
 		C_idSerial: idserial,
 		C_text:     comment,
 		C_date:     time.Now().Format("2006-01-02"),
 	})
+	if msg, ok := bannedWordsMessage(err); ok {
+		s.addCommentTemplate(r.Context(), w, msg)
+		return
+	}
 	if err != nil {
 		return
 	}
@@ -263,15 +525,18 @@ func (s *srv) updateCommentTemplate(w http.ResponseWriter, r *http.Request, msg
 		return
 	}
 	id := session.Values["user"].(int)
-	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	comments, err := ctrl.GetCommentsByUserId(id)
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	comments, err := ctrl.GetCommentsByUserId(r.Context(), id)
 	if err != nil {
 		return
 	}
 	serialscomments := []*SerialsComments{}
-	ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+	ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
 	for _, comment := range comments {
-		serial, err := ctrlS.GetSerialById(comment.GetIdSerial())
+		if !comment.IsReview() {
+			continue
+		}
+		serial, err := ctrlS.GetSerialById(r.Context(), comment.GetIdSerial())
 		if err != nil {
 			return
 		}
@@ -285,17 +550,53 @@ func (s *srv) updateCommentTemplate(w http.ResponseWriter, r *http.Request, msg
 }
 
 func (s *srv) ChoosenComment(w http.ResponseWriter, r *http.Request) {
+	session, err := s.session.Get(r, "sname")
+	if err != nil {
+		return
+	}
+	iduser := session.Values["user"].(int)
+
 	c_id, _ := strconv.Atoi(r.FormValue("idcomment"))
-	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	comment, _ := ctrl.GetCommentById(c_id)
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	comment, err := s.ownComment(r, ctrl, c_id, iduser)
+	if err != nil {
+		s.updateCommentTemplate(w, r, err.Error(), nil)
+		return
+	}
 	s.updateCommentTemplate(w, r, "", comment)
+}
 
+// ownComment returns the comment of the user by its id, or the error to show
+// if there is no such comment or it is of another user.
+func (s *srv) ownComment(r *http.Request, ctrl *controllers.CommentsCtrl, id, idUser int) (*models.Comments, error) {
+	comment, err := ctrl.GetCommentById(r.Context(), id)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, errors.New("Комментарий не найден")
+	}
+	if err != nil {
+		s.Log.Error(err)
+		return nil, errors.New("Не удалось получить комментарий")
+	}
+	if comment.GetIdUser() != idUser {
+		return nil, errors.New("Можно изменять только свои комментарии")
+	}
+	return comment, nil
 }
 
 func (s *srv) AcceptUpdateComment(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
+	session, err := s.session.Get(r, "sname")
+	if err != nil {
+		return
+	}
+	iduser := session.Values["user"].(int)
+
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
 	c_id, _ := strconv.Atoi(r.FormValue("id"))
-	comment_prev, _ := ctrl.GetCommentById(c_id)
+	comment_prev, err := s.ownComment(r, ctrl, c_id, iduser)
+	if err != nil {
+		s.updateCommentTemplate(w, r, err.Error(), nil)
+		return
+	}
 
 	comment := r.FormValue("comment")
 	if comment == "" {
@@ -303,19 +604,21 @@ func (s *srv) AcceptUpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := s.session.Get(r, "sname")
-	if err != nil {
-		return
-	}
-	iduser := session.Values["user"].(int)
-
-	err = ctrl.UpdateComment(&models.Comments{
+	err = ctrl.UpdateComment(r.Context(), &models.Comments{
 		C_id:       c_id,
 		C_idUser:   iduser,
 		C_idSerial: comment_prev.GetIdSerial(),
+		C_idParent: comment_prev.GetIdParent(),
+		C_up:       comment_prev.GetUp(),
+		C_down:     comment_prev.GetDown(),
+		C_hidden:   comment_prev.GetHidden(),
 		C_text:     comment,
 		C_date:     time.Now().Format("2006-01-02"),
 	})
+	if msg, ok := bannedWordsMessage(err); ok {
+		s.updateCommentTemplate(w, r, msg, comment_prev)
+		return
+	}
 	if err != nil {
 		return
 	}
@@ -343,15 +646,18 @@ func (s *srv) deleteCommentTemplate(w http.ResponseWriter, r *http.Request, msg
 		return
 	}
 	iduser := session.Values["user"].(int)
-	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
-	comments, err := ctrl.GetCommentsByUserId(iduser)
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
+	comments, err := ctrl.GetCommentsByUserId(r.Context(), iduser)
 	if err != nil {
 		return
 	}
 	serials := []*models.Serial{}
-	ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+	ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
 	for _, comment := range comments {
-		serial, err := ctrlS.GetSerialById(comment.GetIdSerial())
+		if !comment.IsReview() {
+			continue
+		}
+		serial, err := ctrlS.GetSerialById(r.Context(), comment.GetIdSerial())
 		if err != nil {
 			return
 		}
@@ -364,7 +670,7 @@ func (s *srv) deleteCommentTemplate(w http.ResponseWriter, r *http.Request, msg
 }
 
 func (s *srv) AcceptDeleteComment(w http.ResponseWriter, r *http.Request) {
-	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log))
+	ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log), s.filter)
 	_s_id := r.FormValue("serial")
 	if _s_id == "" {
 		s.deleteCommentTemplate(w, r, "Сериал не выбран")
@@ -376,8 +682,8 @@ func (s *srv) AcceptDeleteComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	iduser := session.Values["user"].(int)
-	comment, _ := ctrl.GetCommentsBySerialIdUserId(s_id, iduser)
-	err = ctrl.DeleteComment(comment.GetId())
+	comment, _ := ctrl.GetCommentsBySerialIdUserId(r.Context(), s_id, iduser)
+	err = ctrl.DeleteComment(r.Context(), comment.GetId())
 	if err != nil {
 		s.deleteCommentTemplate(w, r, "Ошибка удаления комментария")
 		return
@@ -405,8 +711,8 @@ func (s *srv) updateProfileTemplate(w http.ResponseWriter, r *http.Request, msg
 		return
 	}
 	id := session.Values["user"].(int)
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user, err := ctrl.GetUserById(id)
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user, err := ctrl.GetUserById(r.Context(), id)
 	if err != nil {
 		return
 	}
@@ -422,8 +728,8 @@ func (s *srv) AcceptUpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	id := session.Values["user"].(int)
-	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log))
-	user_prev, err := ctrl.GetUserById(id)
+	ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(s.DB, s.Log), repositories.NewFavouritesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+	user_prev, err := ctrl.GetUserById(r.Context(), id)
 	if err != nil {
 		return
 	}
@@ -476,24 +782,26 @@ func (s *srv) AcceptUpdateProfile(w http.ResponseWriter, r *http.Request) {
 		U_role:         "user",
 		U_idFavourites: user_prev.GetIdFavourites(),
 	}
-	err = ctrl.UpdateUser(user)
+	err = ctrl.UpdateUser(r.Context(), user)
 	if err != nil {
-		s.Log.Error(err)
+		s.Log.WithContext(r.Context()).Error(err)
 		return
 	}
-	s.UpdateStatisticDown(user_prev)
-	s.UpdateStatisticUp(user)
+	s.UpdateStatisticDown(r.Context(), user_prev)
+	s.UpdateStatisticUp(r.Context(), user)
 	http.Redirect(w, r, "/user/cabinet/4", http.StatusSeeOther)
 }
 
 func (s *srv) HandleHistory() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type History struct {
-			Serial *models.Serial
-			Date   string
+			Serial   *models.Serial
+			Date     string
+			Progress *models.SerialProgress
 		}
 		type Data struct {
 			History []*History
+			Pager   *pager
 		}
 		d := &Data{}
 
@@ -503,25 +811,50 @@ func (s *srv) HandleHistory() http.HandlerFunc {
 		}
 		id := session.Values["user"].(int)
 		ctrl := controllers.NewSerialsUsersCtrl(repositories.NewSerialsUsersRepo(s.DB, s.Log))
-		history, err := ctrl.GetSerialsByUserId(id)
+		page := htmlPage(r)
+		history, total, err := ctrl.GetSerialsByUserIdPage(r.Context(), id, page)
 		if err != nil {
 			return
 		}
-		ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log))
+		d.Pager = newPager(r, page, total)
+		ctrlS := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log))
+		ctrlWatched := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
 		for _, h := range history {
-			serial, err := ctrlS.GetSerialById(h.GetIdSerial())
+			serial, err := ctrlS.GetSerialById(r.Context(), h.GetIdSerial())
 			if err != nil {
 				return
 			}
-			dat := &History{Serial: serial, Date: h.GetLastSeen()}
+			progress, err := ctrlWatched.GetProgress(r.Context(), h.GetIdSerial(), id)
+			if err != nil {
+				return
+			}
+			dat := &History{Serial: serial, Date: h.GetLastSeen(), Progress: progress}
 			d.History = append(d.History, dat)
 		}
 
-		tmpl, _ := template.ParseFiles("templates/user/history.html")
+		tmpl, _ := template.ParseFiles("templates/user/history.html", "templates/pager.html")
 		tmpl.Execute(w, d)
 	}
 }
 
+func (s *srv) HandleRecommendations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := s.session.Get(r, "sname")
+		if err != nil {
+			return
+		}
+		id := session.Values["user"].(int)
+		ctrl := controllers.NewRecommendationsCtrl(repositories.NewUnitOfWork(s.DB, s.Log))
+		recs, err := ctrl.GetRecommendations(r.Context(), id, models.RecommendCount)
+		if err != nil {
+			return
+		}
+
+		tmpl, _ := template.ParseFiles("templates/user/recommendations.html")
+		tmpl.Execute(w, recs)
+	}
+}
+
 func (s *srv) HandleClearHistory() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		session, err := s.session.Get(r, "sname")
@@ -529,8 +862,8 @@ func (s *srv) HandleClearHistory() http.HandlerFunc {
 			return
 		}
 		id := session.Values["user"].(int)
-		ctrl := controllers.NewSerialsUsersCtrl(repositories.NewSerialsUsersRepo(s.DB, s.Log))
-		err = ctrl.DeleteSerialsByUserId(id)
+		ctrl := controllers.NewEpisodesUsersCtrl(repositories.NewEpisodesUsersRepo(s.DB, s.Log), repositories.NewSeasonsRepo(s.DB, s.Log), repositories.NewEpisodesRepo(s.DB, s.Log), repositories.NewUnitOfWork(s.DB, s.Log))
+		err = ctrl.ClearHistory(r.Context(), id)
 		if err != nil {
 			return
 		}
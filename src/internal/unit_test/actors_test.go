@@ -1,6 +1,7 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/controllers"
@@ -15,7 +16,7 @@ func TestGetActors(t *testing.T) {
 	mockRepo.On("GetActors").Return([]*models.Actors{{A_id: 1}}, nil)
 
 	ctrl := controllers.NewActorsCtrl(mockRepo)
-	actors, err := ctrl.GetActors()
+	actors, err := ctrl.GetActors(context.Background())
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, actors[0].A_id)
@@ -27,7 +28,7 @@ func TestGetActorById(t *testing.T) {
 	mockRepo.On("GetActorById", 1).Return(&models.Actors{A_id: 1}, nil)
 
 	ctrl := controllers.NewActorsCtrl(mockRepo)
-	actor, err := ctrl.GetActorById(1)
+	actor, err := ctrl.GetActorById(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, actor.A_id)
@@ -39,7 +40,7 @@ func TestCreateActor(t *testing.T) {
 	mockRepo.On("CreateActor", &models.Actors{A_id: 1}).Return(nil)
 
 	ctrl := controllers.NewActorsCtrl(mockRepo)
-	err := ctrl.CreateActor(&models.Actors{A_id: 1})
+	err := ctrl.CreateActor(context.Background(), &models.Actors{A_id: 1})
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "CreateActor", &models.Actors{A_id: 1})
@@ -50,7 +51,7 @@ func TestUpdateActor(t *testing.T) {
 	mockRepo.On("UpdateActor", &models.Actors{A_id: 1}).Return(nil)
 
 	ctrl := controllers.NewActorsCtrl(mockRepo)
-	err := ctrl.UpdateActor(&models.Actors{A_id: 1})
+	err := ctrl.UpdateActor(context.Background(), &models.Actors{A_id: 1})
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "UpdateActor", &models.Actors{A_id: 1})
@@ -61,7 +62,7 @@ func TestDeleteActor(t *testing.T) {
 	mockRepo.On("DeleteActor", 1).Return(nil)
 
 	ctrl := controllers.NewActorsCtrl(mockRepo)
-	err := ctrl.DeleteActor(1)
+	err := ctrl.DeleteActor(context.Background(), 1)
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "DeleteActor", 1)
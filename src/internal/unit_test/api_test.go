@@ -0,0 +1,86 @@
+package unit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/server"
+
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiSecret = "secret"
+
+// newApiServer returns the server without a database: the requests checked
+// here are rejected before any repository is used.
+func newApiServer() http.Handler {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return server.NewServer(log, nil, apiSecret, time.Second, nil)
+}
+
+// sessionCookie returns the cookie of the session logged in with the role.
+func sessionCookie(t *testing.T, role string) string {
+	store := sessions.NewCookieStore([]byte(apiSecret))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	session, err := store.New(r, "sname")
+	require.NoError(t, err)
+	session.Values[role] = 1
+	require.NoError(t, session.Save(r, w))
+	return w.Header().Get("Set-Cookie")
+}
+
+func apiRequest(h http.Handler, method, path, cookie string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	if cookie != "" {
+		r.Header.Set("Cookie", cookie)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// adminRoutes are the API routes changing the catalogue.
+var adminRoutes = []struct {
+	method string
+	path   string
+}{
+	{http.MethodPost, "/api/v1/serials"},
+	{http.MethodPut, "/api/v1/serials/1"},
+	{http.MethodDelete, "/api/v1/serials/1"},
+	{http.MethodPost, "/api/v1/serials/1/seasons"},
+	{http.MethodPut, "/api/v1/seasons/1"},
+	{http.MethodDelete, "/api/v1/seasons/1"},
+	{http.MethodPost, "/api/v1/seasons/1/episodes"},
+	{http.MethodPut, "/api/v1/episodes/1"},
+	{http.MethodDelete, "/api/v1/episodes/1"},
+}
+
+func TestApiAdminRoutes(t *testing.T) {
+	h := newApiServer()
+	user := sessionCookie(t, "user")
+
+	for _, route := range adminRoutes {
+		w := apiRequest(h, route.method, route.path, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "%s %s", route.method, route.path)
+		assert.JSONEq(t, `{"error": "user is not logged in"}`, w.Body.String())
+
+		w = apiRequest(h, route.method, route.path, user)
+		assert.Equal(t, http.StatusForbidden, w.Code, "%s %s", route.method, route.path)
+		assert.JSONEq(t, `{"error": "admin rights required"}`, w.Body.String())
+	}
+}
+
+func TestApiPageTooFar(t *testing.T) {
+	w := apiRequest(newApiServer(), http.MethodGet, "/api/v1/serials?page=92233720368547760&pageSize=100", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "page must be positive and not too large, pageSize from 1 to 100"}`, w.Body.String())
+}
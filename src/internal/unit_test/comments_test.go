@@ -1,12 +1,14 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/controllers"
 	"app/internal/mocks"
 	"app/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,20 +16,50 @@ func TestGetComments(t *testing.T) {
 	mockRepo := new(mocks.MockRepoComments)
 	mockRepo.On("GetComments").Return([]*models.Comments{{C_id: 1}}, nil)
 
-	ctrl := controllers.NewCommentsCtrl(mockRepo)
-	comments, err := ctrl.GetComments()
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	comments, err := ctrl.GetComments(context.Background())
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, comments[0].C_id)
 	mockRepo.AssertCalled(t, "GetComments")
 }
 
+func TestCountReviews(t *testing.T) {
+	mockRepo := new(mocks.MockRepoComments)
+	mockRepo.On("GetCommentsByUserId", 1).Return([]*models.Comments{
+		{C_id: 1},
+		{C_id: 2, C_idParent: 1},
+		{C_id: 3, C_hidden: true},
+		{C_id: 4},
+	}, nil)
+
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	cnt, err := ctrl.CountReviews(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, cnt)
+}
+
+func TestGetCommentsBySerialIdPage(t *testing.T) {
+	mockRepo := new(mocks.MockRepoComments)
+	page := models.NewPage(1, 2)
+	mockRepo.On("GetCommentsBySerialIdPage", 1, models.CommentsByHelpful, page).Return([]*models.Comments{{C_id: 1}, {C_id: 2}}, 5, nil)
+
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	comments, total, err := ctrl.GetCommentsBySerialIdPage(context.Background(), 1, models.CommentsByHelpful, page)
+
+	require.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, 5, total)
+	mockRepo.AssertCalled(t, "GetCommentsBySerialIdPage", 1, models.CommentsByHelpful, page)
+}
+
 func TestGetCommentById(t *testing.T) {
 	mockRepo := new(mocks.MockRepoComments)
 	mockRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1}, nil)
 
-	ctrl := controllers.NewCommentsCtrl(mockRepo)
-	comment, err := ctrl.GetCommentById(1)
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	comment, err := ctrl.GetCommentById(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, comment.C_id)
@@ -38,8 +70,8 @@ func TestCreateComment(t *testing.T) {
 	mockRepo := new(mocks.MockRepoComments)
 	mockRepo.On("CreateComment", &models.Comments{C_id: 1}).Return(nil)
 
-	ctrl := controllers.NewCommentsCtrl(mockRepo)
-	err := ctrl.CreateComment(&models.Comments{C_id: 1})
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	err := ctrl.CreateComment(context.Background(), &models.Comments{C_id: 1})
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "CreateComment", &models.Comments{C_id: 1})
@@ -49,20 +81,222 @@ func TestUpdateComment(t *testing.T) {
 	mockRepo := new(mocks.MockRepoComments)
 	mockRepo.On("UpdateComment", &models.Comments{C_id: 1}).Return(nil)
 
-	ctrl := controllers.NewCommentsCtrl(mockRepo)
-	err := ctrl.UpdateComment(&models.Comments{C_id: 1})
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	err := ctrl.UpdateComment(context.Background(), &models.Comments{C_id: 1})
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "UpdateComment", &models.Comments{C_id: 1})
 }
 
-func TestDeleteComment(t *testing.T) {
+func TestGetCommentThreadsPage(t *testing.T) {
 	mockRepo := new(mocks.MockRepoComments)
-	mockRepo.On("DeleteComment", 1).Return(nil)
+	page := models.NewPage(1, 2)
+	first := &models.Comments{C_id: 1}
+	second := &models.Comments{C_id: 2}
+	reply := &models.Comments{C_id: 3, C_idParent: 1}
+	nested := &models.Comments{C_id: 4, C_idParent: 3}
+	later := &models.Comments{C_id: 5, C_idParent: 1}
+	orphan := &models.Comments{C_id: 6, C_idParent: 9}
+	mockRepo.On("GetCommentsBySerialIdPage", 1, models.CommentsByNewest, page).Return([]*models.Comments{second, first}, 3, nil)
+	mockRepo.On("GetRepliesBySerialId", 1).Return([]*models.Comments{later, nested, reply, orphan}, nil)
 
-	ctrl := controllers.NewCommentsCtrl(mockRepo)
-	err := ctrl.DeleteComment(1)
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, nil)
+	threads, total, err := ctrl.GetCommentThreadsPage(context.Background(), 1, models.CommentsByNewest, page)
 
 	require.NoError(t, err)
-	mockRepo.AssertCalled(t, "DeleteComment", 1)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []*models.CommentThread{
+		{Comments: second, Replies: []*models.CommentThread{}},
+		{Comments: first, Replies: []*models.CommentThread{
+			{Comments: reply, Replies: []*models.CommentThread{{Comments: nested, Replies: []*models.CommentThread{}}}},
+			{Comments: later, Replies: []*models.CommentThread{}},
+		}},
+	}, threads)
+}
+
+func TestReplyComment(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	reply := &models.Comments{C_idUser: 2, C_idParent: 1, C_text: "Согласен", C_date: "2024-01-02", C_up: 3}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idSerial: 4}, nil)
+	tx.CommentsRepo.On("CreateComment", reply).Return(nil)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	require.NoError(t, ctrl.ReplyComment(context.Background(), reply))
+
+	assert.Equal(t, 4, reply.C_idSerial)
+	assert.Zero(t, reply.C_up)
+	tx.CommentsRepo.AssertExpectations(t)
+}
+
+func TestReplyComment_ParentNotFound(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return((*models.Comments)(nil), models.ErrNotFound)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	err := ctrl.ReplyComment(context.Background(), &models.Comments{C_idUser: 2, C_idParent: 1})
+
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	tx.CommentsRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+}
+
+func TestVoteComment(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	comment := &models.Comments{C_id: 1, C_idUser: 2}
+	// The comment is read again after it is locked: the edit made meanwhile is kept.
+	edited := &models.Comments{C_id: 1, C_idUser: 2, C_text: "Изменено", C_hidden: true}
+	vote := &models.CommentsVotes{Cv_idComment: 1, Cv_idUser: 3, Cv_value: 1}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(comment, nil).Once()
+	tx.CommentsVotesRepo.On("GetCommentVoteByIds", 1, 3).Return((*models.CommentsVotes)(nil), models.ErrNotFound)
+	tx.CommentsVotesRepo.On("CreateCommentVote", vote).Return(nil)
+	tx.CommentsRepo.On("LockComment", 1).Return(nil)
+	tx.CommentsVotesRepo.On("CountCommentVotes", 1).Return(4, 1, nil)
+	tx.CommentsRepo.On("GetCommentById", 1).Return(edited, nil).Once()
+	tx.CommentsRepo.On("UpdateComment", edited).Return(nil)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	require.NoError(t, ctrl.VoteComment(context.Background(), 1, 3, 1))
+
+	assert.Equal(t, &models.Comments{C_id: 1, C_idUser: 2, C_text: "Изменено", C_hidden: true, C_up: 4, C_down: 1}, edited)
+	tx.CommentsRepo.AssertExpectations(t)
+	tx.CommentsVotesRepo.AssertExpectations(t)
+}
+
+func TestVoteComment_Change(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	comment := &models.Comments{C_id: 1, C_idUser: 2, C_up: 1}
+	vote := &models.CommentsVotes{Cv_id: 5, Cv_idComment: 1, Cv_idUser: 3, Cv_value: 1}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(comment, nil)
+	tx.CommentsVotesRepo.On("GetCommentVoteByIds", 1, 3).Return(vote, nil)
+	tx.CommentsVotesRepo.On("UpdateCommentVote", vote).Return(nil)
+	tx.CommentsRepo.On("LockComment", 1).Return(nil)
+	tx.CommentsVotesRepo.On("CountCommentVotes", 1).Return(0, 1, nil)
+	tx.CommentsRepo.On("UpdateComment", comment).Return(nil)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	require.NoError(t, ctrl.VoteComment(context.Background(), 1, 3, -1))
+
+	assert.Equal(t, -1, vote.Cv_value)
+	assert.Equal(t, &models.Comments{C_id: 1, C_idUser: 2, C_down: 1}, comment)
+	tx.CommentsVotesRepo.AssertExpectations(t)
+}
+
+func TestVoteComment_Remove(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	comment := &models.Comments{C_id: 1, C_idUser: 2, C_up: 1}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(comment, nil)
+	tx.CommentsVotesRepo.On("GetCommentVoteByIds", 1, 3).Return(&models.CommentsVotes{Cv_id: 5, Cv_idComment: 1, Cv_idUser: 3, Cv_value: 1}, nil)
+	tx.CommentsVotesRepo.On("DeleteCommentVote", 5).Return(nil)
+	tx.CommentsRepo.On("LockComment", 1).Return(nil)
+	tx.CommentsVotesRepo.On("CountCommentVotes", 1).Return(0, 0, nil)
+	tx.CommentsRepo.On("UpdateComment", comment).Return(nil)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	require.NoError(t, ctrl.VoteComment(context.Background(), 1, 3, 0))
+
+	assert.Equal(t, &models.Comments{C_id: 1, C_idUser: 2}, comment)
+	tx.CommentsVotesRepo.AssertExpectations(t)
+}
+
+func TestVoteComment_OwnComment(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idUser: 2}, nil)
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	err := ctrl.VoteComment(context.Background(), 1, 2, 1)
+
+	assert.ErrorIs(t, err, controllers.ErrOwnComment)
+	tx.CommentsVotesRepo.AssertNotCalled(t, "CreateCommentVote", mock.Anything)
+}
+
+func TestVoteComment_InvalidValue(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{Tx: newMockTx()}
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	err := ctrl.VoteComment(context.Background(), 1, 3, 2)
+
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+	assert.Zero(t, uow.Calls)
+}
+
+func TestDeleteComment(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idSerial: 4}, nil)
+	tx.CommentsRepo.On("GetRepliesBySerialId", 4).Return([]*models.Comments{
+		{C_id: 2, C_idParent: 1}, {C_id: 3, C_idParent: 2}, {C_id: 5, C_idParent: 7},
+	}, nil)
+	for _, id := range []int{1, 2, 3} {
+		tx.CommentsVotesRepo.On("DeleteCommentVotesByCommentId", id).Return(nil)
+		tx.CommentsReportsRepo.On("DeleteCommentReportsByCommentId", id).Return(nil)
+		tx.CommentsRepo.On("DeleteComment", id).Return(nil)
+	}
+
+	ctrl := controllers.NewCommentsCtrl(nil, uow, nil)
+	require.NoError(t, ctrl.DeleteComment(context.Background(), 1))
+
+	tx.CommentsRepo.AssertExpectations(t)
+	tx.CommentsVotesRepo.AssertExpectations(t)
+	tx.CommentsReportsRepo.AssertExpectations(t)
+	tx.CommentsRepo.AssertNotCalled(t, "DeleteComment", 5)
+}
+
+func TestCreateComment_BannedWords(t *testing.T) {
+	mockRepo := new(mocks.MockRepoComments)
+	filter := models.NewWordFilter([]string{"Спойлер", "дурак"})
+
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, filter)
+	err := ctrl.CreateComment(context.Background(), &models.Comments{C_idUser: 1, C_idSerial: 2, C_text: "Тут СПОЙЛЕР, а автор дурак", C_date: "2024-01-02"})
+
+	var banned *controllers.BannedWordsError
+	require.ErrorAs(t, err, &banned)
+	assert.Equal(t, []string{"спойлер", "дурак"}, banned.Words)
+	mockRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+}
+
+func TestUpdateComment_BannedWords(t *testing.T) {
+	mockRepo := new(mocks.MockRepoComments)
+	comment := &models.Comments{C_id: 1, C_idUser: 1, C_idSerial: 2, C_text: "Без спойлеров", C_date: "2024-01-02"}
+	mockRepo.On("UpdateComment", comment).Return(nil)
+
+	ctrl := controllers.NewCommentsCtrl(mockRepo, nil, models.NewWordFilter([]string{"спойлер"}))
+	require.NoError(t, ctrl.UpdateComment(context.Background(), comment))
+
+	comment.C_text = "Один спойлер"
+	var banned *controllers.BannedWordsError
+	require.ErrorAs(t, ctrl.UpdateComment(context.Background(), comment), &banned)
+	mockRepo.AssertNumberOfCalls(t, "UpdateComment", 1)
+}
+
+func TestParseMarkup(t *testing.T) {
+	text := func(s string) *models.MarkupNode {
+		return &models.MarkupNode{Kind: models.MarkupText, Text: s}
+	}
+	node := func(kind string, children ...*models.MarkupNode) *models.MarkupNode {
+		return &models.MarkupNode{Kind: kind, Children: children}
+	}
+	tests := []struct {
+		text string
+		want []*models.MarkupNode
+	}{
+		{"просто текст", []*models.MarkupNode{text("просто текст")}},
+		{"**жирный** и *курсив*", []*models.MarkupNode{node(models.MarkupBold, text("жирный")), text(" и "), node(models.MarkupItalic, text("курсив"))}},
+		{"||убийца **дворецкий**||", []*models.MarkupNode{node(models.MarkupSpoiler, text("убийца "), node(models.MarkupBold, text("дворецкий")))}},
+		{"[вики](https://ru.wikipedia.org/wiki/Шерлок)", []*models.MarkupNode{{Kind: models.MarkupLink, Text: "вики", URL: "https://ru.wikipedia.org/wiki/Шерлок"}}},
+		{"[x](javascript:alert(1))", []*models.MarkupNode{text("[x](javascript:alert(1))")}},
+		{"**не закрыт", []*models.MarkupNode{text("**не закрыт")}},
+		{"2 * 3 * 4", []*models.MarkupNode{text("2 * 3 * 4")}},
+		{`\*не курсив\*`, []*models.MarkupNode{text("*не курсив*")}},
+		{"||a||b||", []*models.MarkupNode{node(models.MarkupSpoiler, text("a")), text("b||")}},
+		{"<script>", []*models.MarkupNode{text("<script>")}},
+		{"", []*models.MarkupNode{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.ParseMarkup(tt.text), tt.text)
+	}
 }
@@ -0,0 +1,165 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func compareInputs() []*models.CompareInput {
+	actors := []*models.Actors{{A_id: 1}, {A_id: 2}, {A_id: 3}}
+	return []*models.CompareInput{
+		{
+			Serial:  &models.Serial{S_id: 1, S_year: 2010, S_state: models.SerialFinished, S_rating: 8, S_votes: 10},
+			Seasons: []*models.Seasons{{Ss_id: 1, Ss_date: "20.01.2010"}, {Ss_id: 2, Ss_date: "2012-03-01"}},
+			Episodes: []*models.Episodes{
+				{E_duration: "00:40:00", E_date: "20.01.2010"},
+				{E_duration: "00:50:00", E_date: "27.01.2010"},
+				{E_duration: "00:45:00", E_date: "05.05.2012"},
+			},
+			Actors:     []*models.Actors{actors[0], actors[1]},
+			Favourited: 3,
+		},
+		{
+			Serial:     &models.Serial{S_id: 2, S_year: 2020, S_state: models.SerialOngoing, S_rating: 8, S_votes: 4},
+			Seasons:    []*models.Seasons{{Ss_id: 3, Ss_date: "2020-09-01"}},
+			Episodes:   []*models.Episodes{{E_duration: "01:00:00", E_date: "2020-09-01"}, {E_duration: "01:00:00", E_date: "2020-09-08"}},
+			Actors:     []*models.Actors{actors[1], actors[2]},
+			Favourited: 3,
+		},
+	}
+}
+
+func TestNewComparison(t *testing.T) {
+	inputs := compareInputs()
+	c := models.NewComparison(inputs, 2024)
+
+	require.Len(t, c.Serials, 2)
+	first, second := c.Serials[0], c.Serials[1]
+	assert.Equal(t, inputs[0].Serial, first.Serial)
+	assert.Equal(t, 2, first.Seasons)
+	assert.Equal(t, 3, first.Episodes)
+	assert.Equal(t, "02:15:00", first.Runtime)
+	assert.Equal(t, "00:45:00", first.AvgRuntime)
+	assert.Equal(t, 1.5, first.EpisodesPerSeason)
+	assert.Equal(t, []int{2010, 2012, 3}, []int{first.FirstYear, first.LastYear, first.YearsOnAir})
+	assert.Equal(t, 1, first.SharedActors)
+	assert.Equal(t, []string{models.CompareRuntime, models.CompareVotes}, first.Best)
+
+	assert.Equal(t, "02:00:00", second.Runtime)
+	assert.Equal(t, "01:00:00", second.AvgRuntime)
+	assert.Equal(t, 2.0, second.EpisodesPerSeason)
+	assert.Equal(t, []int{2020, 2024, 5}, []int{second.FirstYear, second.LastYear, second.YearsOnAir})
+	assert.Equal(t, []string{models.CompareAvgRuntime, models.CompareEpisodesPerSeason, models.CompareYearsOnAir}, second.Best)
+	assert.True(t, second.Wins(models.CompareYearsOnAir))
+	assert.False(t, second.Wins(models.CompareRating))
+
+	require.Len(t, c.SharedActors, 1)
+	assert.Equal(t, &models.SharedActor{Actor: &models.Actors{A_id: 2}, Serials: []int{1, 2}}, c.SharedActors[0])
+}
+
+func TestValidCompareIds(t *testing.T) {
+	assert.True(t, models.ValidCompareIds([]int{1, 2}))
+	assert.False(t, models.ValidCompareIds([]int{1}))
+	assert.False(t, models.ValidCompareIds([]int{1, 1}))
+	assert.False(t, models.ValidCompareIds([]int{1, 0}))
+	assert.False(t, models.ValidCompareIds([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}))
+}
+
+func newComparisonsTx() *mocks.MockTx {
+	inputs := compareInputs()
+	tx := newMockTx()
+	tx.ActorsRepo = new(mocks.MockRepoActors)
+	tx.SerialsActorsRepo = new(mocks.MockRepoSerialsActors)
+	tx.ActorsRepo.On("GetActors").Return([]*models.Actors{{A_id: 1}, {A_id: 2}, {A_id: 3}}, nil)
+	tx.UsersRepo.On("GetUsers").Return([]*models.Users{{U_id: 1, U_idFavourites: 5}, {U_id: 2, U_idFavourites: 7}}, nil)
+	for i, in := range inputs {
+		id := in.Serial.S_id
+		tx.SerialsRepo.On("GetSerialById", id).Return(in.Serial, nil)
+		tx.SeasonsRepo.On("GetSeasonsBySerialId", id).Return(in.Seasons, nil)
+		tx.SerialsActorsRepo.On("GetActorsBySerialId", id).Return([]*models.SerialsActors{
+			{Sa_idSerial: id, Sa_idActor: i + 1},
+			{Sa_idSerial: id, Sa_idActor: i + 2},
+		}, nil)
+	}
+	tx.EpisodesRepo.On("GetEpisodesBySeasonId", 1).Return(inputs[0].Episodes[:2], nil)
+	tx.EpisodesRepo.On("GetEpisodesBySeasonId", 2).Return(inputs[0].Episodes[2:], nil)
+	tx.EpisodesRepo.On("GetEpisodesBySeasonId", 3).Return(inputs[1].Episodes, nil)
+	tx.SerialsFavouritesRepo.On("GetFavouritesBySerialId", 1).Return([]*models.SerialsFavourites{{Sf_idFavourite: 5}, {Sf_idFavourite: 6}, {Sf_idFavourite: 7}}, nil)
+	tx.SerialsFavouritesRepo.On("GetFavouritesBySerialId", 2).Return([]*models.SerialsFavourites{{Sf_idFavourite: 6}, {Sf_idFavourite: 7}}, nil)
+	tx.SerialsRepo.On("GetSerialById", 3).Return((*models.Serial)(nil), models.ErrNotFound)
+	return tx
+}
+
+func TestComparisonsCtrl_Compare(t *testing.T) {
+	tx := newComparisonsTx()
+	ctrl := controllers.NewComparisonsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	c, err := ctrl.Compare(context.Background(), []int{2, 1})
+	require.NoError(t, err)
+	require.Len(t, c.Serials, 2)
+	assert.Equal(t, 2, c.Serials[0].Serial.S_id)
+	assert.Equal(t, 1, c.Serials[0].Favourited)
+	assert.Equal(t, 2, c.Serials[1].Favourited)
+	assert.Equal(t, "02:15:00", c.Serials[1].Runtime)
+	assert.Equal(t, []int{2, 1}, c.SharedActors[0].Serials)
+	assert.True(t, c.Serials[1].Wins(models.CompareFavourited))
+
+	_, err = ctrl.Compare(context.Background(), []int{1, 3})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	_, err = ctrl.Compare(context.Background(), []int{1})
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+}
+
+func TestComparisonsCtrl_SaveComparison(t *testing.T) {
+	tx := newComparisonsTx()
+	ctrl := controllers.NewComparisonsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	tx.ComparisonsRepo.On("CreateComparison", mock.MatchedBy(func(cm *models.Comparisons) bool {
+		return cm.Cm_idUser == 4 && cm.Cm_serials == "2,1" && len(cm.Cm_slug) == 16 && cm.Cm_date != ""
+	})).Return(nil)
+
+	saved, err := ctrl.SaveComparison(context.Background(), 4, []int{2, 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, saved.GetSerialIds())
+	tx.ComparisonsRepo.AssertExpectations(t)
+
+	_, err = ctrl.SaveComparison(context.Background(), 4, []int{1, 3})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	_, err = ctrl.SaveComparison(context.Background(), 4, []int{1, 1})
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+	tx.ComparisonsRepo.AssertNumberOfCalls(t, "CreateComparison", 1)
+}
+
+func TestComparisonsCtrl_GetSavedComparison_DeletedSerial(t *testing.T) {
+	tx := newComparisonsTx()
+	ctrl := controllers.NewComparisonsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	tx.ComparisonsRepo.On("GetComparisonBySlug", "0123456789abcdef").Return(&models.Comparisons{Cm_id: 8, Cm_slug: "0123456789abcdef", Cm_serials: "1,3,2"}, nil)
+
+	c, err := ctrl.GetSavedComparison(context.Background(), "0123456789abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", c.Slug)
+	require.Len(t, c.Serials, 2)
+	assert.Equal(t, 1, c.Serials[0].Serial.S_id)
+	assert.Equal(t, 2, c.Serials[1].Serial.S_id)
+}
+
+func TestComparisonsCtrl_DeleteComparison(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewComparisonsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	tx.ComparisonsRepo.On("GetComparisonById", 8).Return(&models.Comparisons{Cm_id: 8, Cm_idUser: 4}, nil)
+	tx.ComparisonsRepo.On("DeleteComparison", 8).Return(nil)
+
+	assert.ErrorIs(t, ctrl.DeleteComparison(context.Background(), 5, 8), models.ErrNotFound)
+	tx.ComparisonsRepo.AssertNotCalled(t, "DeleteComparison", 8)
+	require.NoError(t, ctrl.DeleteComparison(context.Background(), 4, 8))
+	tx.ComparisonsRepo.AssertExpectations(t)
+}
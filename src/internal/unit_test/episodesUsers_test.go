@@ -0,0 +1,178 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkWatched_New(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, uow)
+	want := &models.EpisodesUsers{Eu_idEpisode: 4, Eu_idSerial: 3, Eu_idUser: 2}
+
+	tx.EpisodesRepo.On("GetEpisodeById", 4).Return(&models.Episodes{E_id: 4, E_idSeason: 5}, nil)
+	tx.SeasonsRepo.On("GetSeasonById", 5).Return(&models.Seasons{Ss_id: 5, Ss_idSerial: 3}, nil)
+	tx.EpisodesUsersRepo.On("GetEpisodeUserByIds", 4, 2).Return((*models.EpisodesUsers)(nil), models.ErrNotFound)
+	tx.EpisodesUsersRepo.On("CreateEpisodesUsers", want).Return(nil)
+	tx.SerialsUsersRepo.On("GetSerialUserByIds", 3, 2).Return((*models.SerialsUsers)(nil), models.ErrNotFound)
+	tx.SerialsUsersRepo.On("CreateSerialsUsers", mock.MatchedBy(func(su *models.SerialsUsers) bool {
+		return su.Su_idSerial == 3 && su.Su_idUser == 2 && su.Su_lastSeen != ""
+	})).Return(nil)
+
+	episodeUser, err := ctrl.MarkWatched(context.Background(), 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, want, episodeUser)
+	assert.Equal(t, 1, uow.Calls)
+	tx.EpisodesUsersRepo.AssertExpectations(t)
+	tx.SerialsUsersRepo.AssertExpectations(t)
+}
+
+func TestMarkWatched_Again(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+	marked := &models.EpisodesUsers{Eu_id: 6, Eu_idEpisode: 4, Eu_idSerial: 3, Eu_idUser: 2}
+	history := &models.SerialsUsers{Su_id: 7, Su_idSerial: 3, Su_idUser: 2, Su_lastSeen: "01.01.2020"}
+
+	tx.EpisodesRepo.On("GetEpisodeById", 4).Return(&models.Episodes{E_id: 4, E_idSeason: 5}, nil)
+	tx.SeasonsRepo.On("GetSeasonById", 5).Return(&models.Seasons{Ss_id: 5, Ss_idSerial: 3}, nil)
+	tx.EpisodesUsersRepo.On("GetEpisodeUserByIds", 4, 2).Return(marked, nil)
+	tx.SerialsUsersRepo.On("GetSerialUserByIds", 3, 2).Return(history, nil)
+	tx.SerialsUsersRepo.On("UpdateSerialsUsers", history).Return(nil)
+
+	episodeUser, err := ctrl.MarkWatched(context.Background(), 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, marked, episodeUser)
+	assert.NotEqual(t, "01.01.2020", history.Su_lastSeen)
+	tx.EpisodesUsersRepo.AssertNotCalled(t, "CreateEpisodesUsers", mock.Anything)
+}
+
+func TestMarkWatched_EpisodeNotFound(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.EpisodesRepo.On("GetEpisodeById", 4).Return((*models.Episodes)(nil), models.ErrNotFound)
+
+	episodeUser, err := ctrl.MarkWatched(context.Background(), 4, 2)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.Nil(t, episodeUser)
+}
+
+func TestUnmarkWatched_LastOfSerial(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.EpisodesUsersRepo.On("GetEpisodeUserByIds", 4, 2).Return(&models.EpisodesUsers{Eu_id: 6, Eu_idEpisode: 4, Eu_idSerial: 3, Eu_idUser: 2}, nil)
+	tx.EpisodesUsersRepo.On("DeleteEpisodesUsers", 6).Return(nil)
+	tx.EpisodesUsersRepo.On("GetEpisodesBySerialIdUserId", 3, 2).Return([]*models.EpisodesUsers{}, nil)
+	tx.SerialsUsersRepo.On("GetSerialUserByIds", 3, 2).Return(&models.SerialsUsers{Su_id: 7}, nil)
+	tx.SerialsUsersRepo.On("DeleteSerialsUsers", 7).Return(nil)
+
+	require.NoError(t, ctrl.UnmarkWatched(context.Background(), 4, 2))
+	tx.EpisodesUsersRepo.AssertExpectations(t)
+	tx.SerialsUsersRepo.AssertExpectations(t)
+}
+
+func TestUnmarkWatched_KeepsHistory(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.EpisodesUsersRepo.On("GetEpisodeUserByIds", 4, 2).Return(&models.EpisodesUsers{Eu_id: 6, Eu_idEpisode: 4, Eu_idSerial: 3, Eu_idUser: 2}, nil)
+	tx.EpisodesUsersRepo.On("DeleteEpisodesUsers", 6).Return(nil)
+	tx.EpisodesUsersRepo.On("GetEpisodesBySerialIdUserId", 3, 2).Return([]*models.EpisodesUsers{{Eu_id: 8}}, nil)
+
+	require.NoError(t, ctrl.UnmarkWatched(context.Background(), 4, 2))
+	tx.SerialsUsersRepo.AssertNotCalled(t, "DeleteSerialsUsers", mock.Anything)
+}
+
+func TestUnmarkWatched_NotMarked(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewEpisodesUsersCtrl(nil, nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.EpisodesUsersRepo.On("GetEpisodeUserByIds", 4, 2).Return((*models.EpisodesUsers)(nil), models.ErrNotFound)
+
+	assert.ErrorIs(t, ctrl.UnmarkWatched(context.Background(), 4, 2), models.ErrNotFound)
+	tx.EpisodesUsersRepo.AssertNotCalled(t, "DeleteEpisodesUsers", mock.Anything)
+}
+
+func TestGetProgress(t *testing.T) {
+	mockEU := new(mocks.MockRepoEpisodesUsers)
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	ctrl := controllers.NewEpisodesUsersCtrl(mockEU, mockSeasons, mockEpisodes, nil)
+	first := &models.Seasons{Ss_id: 1, Ss_num: 1}
+	second := &models.Seasons{Ss_id: 2, Ss_num: 2}
+	e1 := &models.Episodes{E_id: 10, E_num: 1}
+	e2 := &models.Episodes{E_id: 11, E_num: 2}
+	e3 := &models.Episodes{E_id: 12, E_num: 1}
+
+	mockSeasons.On("GetSeasonsBySerialId", 3).Return([]*models.Seasons{second, first}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 1).Return([]*models.Episodes{e2, e1}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{e3}, nil)
+	mockEU.On("GetEpisodesBySerialIdUserId", 3, 2).Return([]*models.EpisodesUsers{{Eu_idEpisode: 10}}, nil)
+
+	progress, err := ctrl.GetProgress(context.Background(), 3, 2)
+	require.NoError(t, err)
+	assert.Equal(t, models.Progress{Watched: 1, Total: 3, Percent: 33}, progress.Progress)
+	require.Len(t, progress.Seasons, 2)
+	assert.Equal(t, first, progress.Seasons[0].Season)
+	assert.Equal(t, models.Progress{Watched: 1, Total: 2, Percent: 50}, progress.Seasons[0].Progress)
+	assert.Equal(t, []*models.WatchedEpisode{{Episode: e1, Watched: true}, {Episode: e2}}, progress.Seasons[0].Episodes)
+	assert.Equal(t, models.Progress{Watched: 0, Total: 1, Percent: 0}, progress.Seasons[1].Progress)
+	assert.Equal(t, e2, progress.Next)
+	assert.Equal(t, first, progress.NextSeason)
+}
+
+func TestNewSerialProgress_Next(t *testing.T) {
+	seasons := []*models.Seasons{{Ss_id: 1}, {Ss_id: 2}}
+	episodes := map[int][]*models.Episodes{
+		1: {{E_id: 10}, {E_id: 11}},
+		2: {{E_id: 12}, {E_id: 13}},
+	}
+	watched := func(ids ...int) []*models.EpisodesUsers {
+		eu := []*models.EpisodesUsers{}
+		for _, id := range ids {
+			eu = append(eu, &models.EpisodesUsers{Eu_idEpisode: id})
+		}
+		return eu
+	}
+
+	tests := []struct {
+		name       string
+		watched    []*models.EpisodesUsers
+		next       int
+		nextSeason int
+	}{
+		{"nothing watched", watched(), 10, 1},
+		{"after the last watched", watched(10, 12), 13, 2},
+		{"next season", watched(10, 11), 12, 2},
+		{"gap before the last watched", watched(11, 13), 10, 1},
+		{"all watched", watched(10, 11, 12, 13), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := models.NewSerialProgress(3, seasons, episodes, tt.watched)
+			if tt.next == 0 {
+				assert.Nil(t, progress.Next)
+				assert.Nil(t, progress.NextSeason)
+				assert.Equal(t, 100, progress.Percent)
+				return
+			}
+			require.NotNil(t, progress.Next)
+			assert.Equal(t, tt.next, progress.Next.GetId())
+			assert.Equal(t, tt.nextSeason, progress.NextSeason.GetId())
+		})
+	}
+
+	empty := models.NewSerialProgress(3, nil, nil, nil)
+	assert.Equal(t, models.Progress{}, empty.Progress)
+	assert.Nil(t, empty.Next)
+}
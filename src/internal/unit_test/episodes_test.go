@@ -1,11 +1,14 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
+	"app/internal/controllers"
 	"app/internal/mocks"
 	"app/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,7 +23,7 @@ func TestGetEpisodes(t *testing.T) {
 
 	mockRepo.On("GetEpisodes").Return(mockData, nil)
 
-	episodes, err := mockRepo.GetEpisodes()
+	episodes, err := mockRepo.GetEpisodes(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, episodes)
 	assert.Equal(t, mockData, episodes)
@@ -35,7 +38,7 @@ func TestGetEpisodeById(t *testing.T) {
 
 	mockRepo.On("GetEpisodeById", 1).Return(mockData, nil)
 
-	episode, err := mockRepo.GetEpisodeById(1)
+	episode, err := mockRepo.GetEpisodeById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, episode)
 	assert.Equal(t, mockData, episode)
@@ -50,7 +53,7 @@ func TestCreateEpisode(t *testing.T) {
 
 	mockRepo.On("CreateEpisode", mockData).Return(nil)
 
-	err := mockRepo.CreateEpisode(mockData)
+	err := mockRepo.CreateEpisode(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -63,7 +66,7 @@ func TestUpdateEpisode(t *testing.T) {
 
 	mockRepo.On("UpdateEpisode", mockData).Return(nil)
 
-	err := mockRepo.UpdateEpisode(mockData)
+	err := mockRepo.UpdateEpisode(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -72,6 +75,92 @@ func TestDeleteEpisode(t *testing.T) {
 
 	mockRepo.On("DeleteEpisode", 1).Return(nil)
 
-	err := mockRepo.DeleteEpisode(1)
+	err := mockRepo.DeleteEpisode(context.Background(), 1)
 	require.NoError(t, err)
 }
+
+func TestCreateEpisodeSyncsSeasonAndSerial(t *testing.T) {
+	mockRepo := new(mocks.MockRepoEpisodes)
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockSerials := new(mocks.MockRepoSerials)
+	episode := &models.Episodes{E_id: 1, E_idSeason: 2, E_duration: "00:50:00"}
+	mockRepo.On("CreateEpisode", episode).Return(nil)
+	mockRepo.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{episode}, nil)
+	mockRepo.On("GetEpisodesBySeasonId", 3).Return([]*models.Episodes{{E_duration: "23:30:00"}}, nil)
+	mockSeasons.On("GetSeasonById", 2).Return(&models.Seasons{Ss_id: 2, Ss_idSerial: 5}, nil)
+	mockSeasons.On("UpdateSeason", &models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 1}).Return(nil)
+	mockSeasons.On("GetSeasonsBySerialId", 5).Return([]*models.Seasons{{Ss_id: 2}, {Ss_id: 3}}, nil)
+	mockSerials.On("GetSerialById", 5).Return(&models.Serial{S_id: 5, S_seasons: 2, S_duration: "23:30:00"}, nil)
+	mockSerials.On("UpdateSerial", &models.Serial{S_id: 5, S_seasons: 2, S_duration: "24:20:00"}).Return(nil)
+
+	uow := newSeasonsUnitOfWork(mockSeasons, mockSerials, mockRepo)
+	ctrl := controllers.NewEpisodesCtrl(nil, nil, nil, uow)
+	err := ctrl.CreateEpisode(context.Background(), episode)
+
+	require.NoError(t, err)
+	mockSeasons.AssertCalled(t, "UpdateSeason", &models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 1})
+	mockSerials.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 5, S_seasons: 2, S_duration: "24:20:00"})
+}
+
+func TestUpdateEpisodeMovedToOtherSeason(t *testing.T) {
+	mockRepo := new(mocks.MockRepoEpisodes)
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockSerials := new(mocks.MockRepoSerials)
+	episode := &models.Episodes{E_id: 1, E_idSeason: 3, E_duration: "00:40:00"}
+	mockRepo.On("GetEpisodeById", 1).Return(&models.Episodes{E_id: 1, E_idSeason: 2, E_duration: "00:40:00"}, nil)
+	mockRepo.On("UpdateEpisode", episode).Return(nil)
+	mockRepo.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{}, nil)
+	mockRepo.On("GetEpisodesBySeasonId", 3).Return([]*models.Episodes{episode}, nil)
+	mockSeasons.On("GetSeasonById", 2).Return(&models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 1}, nil)
+	mockSeasons.On("GetSeasonById", 3).Return(&models.Seasons{Ss_id: 3, Ss_idSerial: 5}, nil)
+	mockSeasons.On("UpdateSeason", &models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 0}).Return(nil)
+	mockSeasons.On("UpdateSeason", &models.Seasons{Ss_id: 3, Ss_idSerial: 5, Ss_cntEpisodes: 1}).Return(nil)
+	mockSeasons.On("GetSeasonsBySerialId", 5).Return([]*models.Seasons{{Ss_id: 2}, {Ss_id: 3}}, nil)
+	mockSerials.On("GetSerialById", 5).Return(&models.Serial{S_id: 5, S_seasons: 2, S_duration: "00:40:00"}, nil)
+
+	uow := newSeasonsUnitOfWork(mockSeasons, mockSerials, mockRepo)
+	ctrl := controllers.NewEpisodesCtrl(nil, nil, nil, uow)
+	err := ctrl.UpdateEpisode(context.Background(), episode)
+
+	require.NoError(t, err)
+	mockSeasons.AssertNumberOfCalls(t, "UpdateSeason", 2)
+	mockSerials.AssertNotCalled(t, "UpdateSerial", mock.Anything)
+}
+
+func TestDeleteEpisodeSyncsSeasonAndSerial(t *testing.T) {
+	mockRepo := new(mocks.MockRepoEpisodes)
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockSerials := new(mocks.MockRepoSerials)
+	mockRepo.On("GetEpisodeById", 1).Return(&models.Episodes{E_id: 1, E_idSeason: 2}, nil)
+	mockRepo.On("DeleteEpisode", 1).Return(nil)
+	mockRepo.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{}, nil)
+	mockSeasons.On("GetSeasonById", 2).Return(&models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 1}, nil)
+	mockSeasons.On("UpdateSeason", &models.Seasons{Ss_id: 2, Ss_idSerial: 5, Ss_cntEpisodes: 0}).Return(nil)
+	mockSeasons.On("GetSeasonsBySerialId", 5).Return([]*models.Seasons{{Ss_id: 2}}, nil)
+	mockSerials.On("GetSerialById", 5).Return(&models.Serial{S_id: 5, S_seasons: 1, S_duration: "01:00:00"}, nil)
+	mockSerials.On("UpdateSerial", &models.Serial{S_id: 5, S_seasons: 1, S_duration: "00:00:00"}).Return(nil)
+
+	uow := newSeasonsUnitOfWork(mockSeasons, mockSerials, mockRepo)
+	ctrl := controllers.NewEpisodesCtrl(nil, nil, nil, uow)
+	err := ctrl.DeleteEpisode(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
+	mockRepo.AssertCalled(t, "DeleteEpisode", 1)
+	mockSerials.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 5, S_seasons: 1, S_duration: "00:00:00"})
+}
+
+func TestReorderEpisodes(t *testing.T) {
+	mockRepo := new(mocks.MockRepoEpisodes)
+	mockRepo.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{{E_id: 1, E_num: 1}, {E_id: 3, E_num: 2}}, nil)
+	mockRepo.On("UpdateEpisode", &models.Episodes{E_id: 3, E_num: 1}).Return(nil)
+	mockRepo.On("UpdateEpisode", &models.Episodes{E_id: 1, E_num: 2}).Return(nil)
+
+	uow := newSeasonsUnitOfWork(nil, nil, mockRepo)
+	ctrl := controllers.NewEpisodesCtrl(nil, nil, nil, uow)
+	err := ctrl.ReorderEpisodes(context.Background(), 2, []int{3, 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
+	mockRepo.AssertNumberOfCalls(t, "UpdateEpisode", 2)
+}
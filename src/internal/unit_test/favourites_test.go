@@ -1,12 +1,15 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"app/internal/controllers"
 	"app/internal/mocks"
 	"app/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,8 +17,8 @@ func TestGetFavourites(t *testing.T) {
 	mockRepo := new(mocks.MockRepoFavourites)
 	mockRepo.On("GetFavourites").Return([]*models.Favourites{{F_id: 1}}, nil)
 
-	ctrl := controllers.NewFavouritesCtrl(mockRepo)
-	favourites, err := ctrl.GetFavourites()
+	ctrl := controllers.NewFavouritesCtrl(mockRepo, nil)
+	favourites, err := ctrl.GetFavourites(context.Background())
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, favourites[0].F_id)
@@ -26,8 +29,8 @@ func TestGetFavouriteById(t *testing.T) {
 	mockRepo := new(mocks.MockRepoFavourites)
 	mockRepo.On("GetFavouriteById", 1).Return(&models.Favourites{F_id: 1}, nil)
 
-	ctrl := controllers.NewFavouritesCtrl(mockRepo)
-	favourite, err := ctrl.GetFavouriteById(1)
+	ctrl := controllers.NewFavouritesCtrl(mockRepo, nil)
+	favourite, err := ctrl.GetFavouriteById(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, favourite.F_id)
@@ -38,9 +41,211 @@ func TestUpdateFavourite(t *testing.T) {
 	mockRepo := new(mocks.MockRepoFavourites)
 	mockRepo.On("UpdateFavourite", &models.Favourites{F_id: 1}).Return(nil)
 
-	ctrl := controllers.NewFavouritesCtrl(mockRepo)
-	err := ctrl.UpdateFavourite(&models.Favourites{F_id: 1})
+	ctrl := controllers.NewFavouritesCtrl(mockRepo, nil)
+	err := ctrl.UpdateFavourite(context.Background(), &models.Favourites{F_id: 1})
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "UpdateFavourite", &models.Favourites{F_id: 1})
 }
+
+func TestFavouritesCtrl_CreateList(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+	list := &models.Favourites{F_idUser: 1, F_name: "  Пересмотреть ", F_note: "на выходные", F_cntSerials: 4}
+
+	tx.FavouritesRepo.On("GetFavouritesByUserId", 1).
+		Return([]*models.Favourites{{F_id: 5, F_name: "Избранное", F_position: 1}, {F_id: 6, F_name: "Смотрю", F_position: 3}}, nil)
+	tx.FavouritesRepo.On("CreateFavourite", &models.Favourites{F_idUser: 1, F_name: "Пересмотреть", F_note: "на выходные", F_position: 4, F_visibility: "private"}).Return(9, nil)
+
+	require.NoError(t, ctrl.CreateList(context.Background(), list))
+	assert.Equal(t, 9, list.GetId())
+	tx.FavouritesRepo.AssertExpectations(t)
+}
+
+func TestFavouritesCtrl_CreateList_Exists(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouritesByUserId", 1).
+		Return([]*models.Favourites{{F_id: 6, F_name: "Смотрю", F_position: 1}}, nil)
+
+	err := ctrl.CreateList(context.Background(), &models.Favourites{F_idUser: 1, F_name: "смотрю"})
+	assert.ErrorIs(t, err, controllers.ErrListExists)
+	tx.FavouritesRepo.AssertNotCalled(t, "CreateFavourite", mock.Anything)
+}
+
+func TestFavouritesCtrl_CreateList_Invalid(t *testing.T) {
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: newMockTx()})
+
+	err := ctrl.CreateList(context.Background(), &models.Favourites{F_idUser: 1, F_name: "   "})
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+}
+
+func TestFavouritesCtrl_UpdateList_OtherUser(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 2, F_name: "Смотрю"}, nil)
+
+	err := ctrl.UpdateList(context.Background(), 1, 5, "Чужой", "")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	tx.FavouritesRepo.AssertNotCalled(t, "UpdateFavourite", mock.Anything)
+}
+
+func TestFavouritesCtrl_SetVisibility(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_visibility: "private"}, nil)
+	tx.FavouritesRepo.On("UpdateFavourite", mock.MatchedBy(func(list *models.Favourites) bool {
+		return list.GetVisibility() == models.ListPublic && len(list.GetSlug()) == 16
+	})).Return(nil)
+
+	require.NoError(t, ctrl.SetVisibility(context.Background(), 1, 5, models.ListPublic))
+	tx.FavouritesRepo.AssertExpectations(t)
+	assert.ErrorIs(t, ctrl.SetVisibility(context.Background(), 1, 5, "friends"), models.ErrInvalidModel)
+}
+
+func TestFavouritesCtrl_SetVisibility_KeepsSlug(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).
+		Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_visibility: "public", F_slug: "0123456789abcdef"}, nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_visibility: "unlisted", F_slug: "0123456789abcdef"}).Return(nil)
+
+	require.NoError(t, ctrl.SetVisibility(context.Background(), 1, 5, models.ListUnlisted))
+	tx.FavouritesRepo.AssertExpectations(t)
+}
+
+func TestFavouritesCtrl_GetSharedList_Private(t *testing.T) {
+	mockRepo := new(mocks.MockRepoFavourites)
+	mockRepo.On("GetFavouriteBySlug", "0123456789abcdef").
+		Return(&models.Favourites{F_id: 5, F_visibility: "private", F_slug: "0123456789abcdef"}, nil)
+
+	ctrl := controllers.NewFavouritesCtrl(mockRepo, nil)
+	_, err := ctrl.GetSharedList(context.Background(), "0123456789abcdef")
+
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestFavouritesCtrl_GetPublicLists(t *testing.T) {
+	mockRepo := new(mocks.MockRepoFavourites)
+	mockRepo.On("GetFavouritesByUserId", 1).Return([]*models.Favourites{
+		{F_id: 5, F_visibility: "public"},
+		{F_id: 6, F_visibility: "unlisted"},
+		{F_id: 7, F_visibility: "private"},
+		{F_id: 8, F_visibility: "public"},
+	}, nil)
+
+	ctrl := controllers.NewFavouritesCtrl(mockRepo, nil)
+	lists, err := ctrl.GetPublicLists(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, lists, 2)
+	assert.Equal(t, 5, lists[0].F_id)
+	assert.Equal(t, 8, lists[1].F_id)
+}
+
+func TestFavouritesCtrl_ReorderLists(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouritesByUserId", 1).
+		Return([]*models.Favourites{{F_id: 5, F_position: 1}, {F_id: 6, F_position: 2}, {F_id: 7, F_position: 3}}, nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 7, F_position: 1}).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 5, F_position: 3}).Return(nil)
+
+	require.NoError(t, ctrl.ReorderLists(context.Background(), 1, []int{7, 6, 5}))
+	tx.FavouritesRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertNumberOfCalls(t, "UpdateFavourite", 2)
+
+	assert.ErrorIs(t, ctrl.ReorderLists(context.Background(), 1, []int{7, 7, 5}), controllers.ErrInvalidOrder)
+	assert.ErrorIs(t, ctrl.ReorderLists(context.Background(), 1, []int{7, 6}), controllers.ErrInvalidOrder)
+}
+
+func TestFavouritesCtrl_DeleteList(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("GetUserById", 1).Return(&models.Users{U_id: 1, U_idFavourites: 5}, nil)
+	tx.FavouritesRepo.On("GetFavouriteById", 6).Return(&models.Favourites{F_id: 6, F_idUser: 1, F_name: "Брошено"}, nil)
+	tx.SerialsFavouritesRepo.On("GetSerialsByFavouriteId", 6).
+		Return([]*models.SerialsFavourites{{Sf_id: 11}, {Sf_id: 12}}, nil)
+	tx.SerialsFavouritesRepo.On("DeleteSerialsFavourites", 11).Return(nil)
+	tx.SerialsFavouritesRepo.On("DeleteSerialsFavourites", 12).Return(nil)
+	tx.FavouritesRepo.On("DeleteFavourite", 6).Return(nil)
+
+	require.NoError(t, ctrl.DeleteList(context.Background(), 1, 6))
+	tx.SerialsFavouritesRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertExpectations(t)
+}
+
+func TestFavouritesCtrl_DeleteList_Default(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("GetUserById", 1).Return(&models.Users{U_id: 1, U_idFavourites: 5}, nil)
+
+	assert.ErrorIs(t, ctrl.DeleteList(context.Background(), 1, 5), controllers.ErrDefaultList)
+	tx.FavouritesRepo.AssertNotCalled(t, "DeleteFavourite", mock.Anything)
+}
+
+func TestFavouritesCtrl_AddSerial(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+	today := time.Now().Format("2006-01-02")
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_cntSerials: 2}, nil)
+	tx.SerialsRepo.On("GetSerialById", 3).Return(&models.Serial{S_id: 3}, nil)
+	tx.SerialsFavouritesRepo.On("CheckSerialInFavourite", mock.Anything).Return(false)
+	tx.SerialsFavouritesRepo.On("CreateSerialsFavourites", &models.SerialsFavourites{Sf_idSerial: 3, Sf_idFavourite: 5, Sf_date: today}).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_cntSerials: 3}).Return(nil)
+
+	require.NoError(t, ctrl.AddSerial(context.Background(), 1, 5, 3))
+	tx.SerialsFavouritesRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertExpectations(t)
+}
+
+func TestFavouritesCtrl_AddSerial_InList(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю"}, nil)
+	tx.SerialsRepo.On("GetSerialById", 3).Return(&models.Serial{S_id: 3}, nil)
+	tx.SerialsFavouritesRepo.On("CheckSerialInFavourite", mock.Anything).Return(true)
+
+	assert.ErrorIs(t, ctrl.AddSerial(context.Background(), 1, 5, 3), controllers.ErrSerialInList)
+	tx.SerialsFavouritesRepo.AssertNotCalled(t, "CreateSerialsFavourites", mock.Anything)
+}
+
+func TestFavouritesCtrl_MoveSerial(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю", F_cntSerials: 1}, nil)
+	tx.FavouritesRepo.On("GetFavouriteById", 6).Return(&models.Favourites{F_id: 6, F_idUser: 1, F_name: "Брошено"}, nil)
+	tx.SerialsFavouritesRepo.On("CheckSerialInFavourite", &models.SerialsFavourites{Sf_idSerial: 3, Sf_idFavourite: 5}).Return(true)
+	tx.SerialsFavouritesRepo.On("CheckSerialInFavourite", mock.Anything).Return(false)
+	tx.SerialsFavouritesRepo.On("DeleteSerialById", 5, 3).Return(nil)
+	tx.SerialsFavouritesRepo.On("CreateSerialsFavourites", mock.MatchedBy(func(sf *models.SerialsFavourites) bool {
+		return sf.GetIdFavourite() == 6 && sf.GetIdSerial() == 3
+	})).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю"}).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 6, F_idUser: 1, F_name: "Брошено", F_cntSerials: 1}).Return(nil)
+
+	require.NoError(t, ctrl.MoveSerial(context.Background(), 1, 5, 6, 3))
+	tx.SerialsFavouritesRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertExpectations(t)
+}
+
+func TestFavouritesCtrl_RemoveSerial_NotInList(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewFavouritesCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.FavouritesRepo.On("GetFavouriteById", 5).Return(&models.Favourites{F_id: 5, F_idUser: 1, F_name: "Смотрю"}, nil)
+	tx.SerialsFavouritesRepo.On("CheckSerialInFavourite", mock.Anything).Return(false)
+
+	assert.ErrorIs(t, ctrl.RemoveSerial(context.Background(), 1, 5, 3), models.ErrNotFound)
+	tx.SerialsFavouritesRepo.AssertNotCalled(t, "DeleteSerialById", mock.Anything, mock.Anything)
+}
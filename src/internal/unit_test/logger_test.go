@@ -0,0 +1,35 @@
+package unit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/models"
+	"app/internal/repositories"
+	"app/internal/repositories/memdb"
+	"app/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRequestID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	log, err := logger.InitLog(path)
+	require.NoError(t, err)
+	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(memdb.New(), log))
+
+	ctx := logger.WithRequestID(context.Background(), "0123abcd")
+	assert.Equal(t, "0123abcd", logger.RequestID(ctx))
+	require.NoError(t, ctrl.CreateProducer(ctx, &models.Producers{P_name: "Name", P_surname: "Surname"}))
+	_, err = ctrl.GetProducers(context.Background())
+	require.NoError(t, err)
+
+	text, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `\[producersMemory\.go:\d+\] \[0123abcd\] Creating producer in the database\n`, string(text))
+	assert.Regexp(t, `\[producersMemory\.go:\d+\] Getting all producers from the database\n`, string(text))
+}
@@ -0,0 +1,77 @@
+package unit_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/models"
+	"app/internal/repositories"
+	"app/internal/repositories/memdb"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepoConcurrentCreate(t *testing.T) {
+	db := memdb.New()
+	log := logrus.New()
+	ctrl := controllers.NewProducersCtrl(repositories.NewProducersRepo(db, log))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := ctrl.CreateProducer(context.Background(), &models.Producers{P_name: "Name", P_surname: "Surname"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	producers, err := ctrl.GetProducers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, producers, 50)
+	for i, producer := range producers {
+		assert.Equal(t, i+1, producer.GetId())
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	rows := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		offset int
+		limit  int
+		want   []int
+	}{
+		{0, 2, []int{1, 2}},
+		{4, 2, []int{5}},
+		{5, 2, []int{}},
+		{3, math.MaxInt, []int{4, 5}},
+		{math.MaxInt, 100, []int{}},
+		{-100, 100, []int{}},
+		{0, -1, []int{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, memdb.Paginate(rows, tt.offset, tt.limit), "offset %d, limit %d", tt.offset, tt.limit)
+	}
+}
+
+func TestMemoryRepoReturnsCopies(t *testing.T) {
+	db := memdb.New()
+	log := logrus.New()
+	repo := repositories.NewProducersRepo(db, log)
+	producer := &models.Producers{P_name: "Name", P_surname: "Surname"}
+	require.NoError(t, repo.CreateProducer(context.Background(), producer))
+
+	producer.P_name = "Changed"
+	stored, err := repo.GetProducerById(context.Background(), producer.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "Name", stored.P_name)
+
+	_, err = repo.GetProducerById(context.Background(), producer.GetId()+1)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
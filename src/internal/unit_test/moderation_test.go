@@ -0,0 +1,159 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportComment(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idUser: 2}, nil)
+	tx.CommentsReportsRepo.On("GetCommentReportByIds", 1, 3).Return((*models.CommentsReports)(nil), models.ErrNotFound)
+	tx.CommentsReportsRepo.On("CreateCommentReport", mock.MatchedBy(func(report *models.CommentsReports) bool {
+		return report.Cr_idComment == 1 && report.Cr_idUser == 3 && report.Cr_reason == "Спойлер" && report.Cr_state == models.ReportOpen && report.Cr_date != ""
+	})).Return(nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	require.NoError(t, ctrl.ReportComment(context.Background(), 1, 3, "Спойлер"))
+
+	tx.CommentsReportsRepo.AssertExpectations(t)
+}
+
+func TestReportComment_Twice(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idUser: 2}, nil)
+	tx.CommentsReportsRepo.On("GetCommentReportByIds", 1, 3).Return(&models.CommentsReports{Cr_id: 4}, nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	err := ctrl.ReportComment(context.Background(), 1, 3, "Спойлер")
+
+	assert.ErrorIs(t, err, controllers.ErrAlreadyReported)
+	tx.CommentsReportsRepo.AssertNotCalled(t, "CreateCommentReport", mock.Anything)
+}
+
+func TestReportComment_Own(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idUser: 3}, nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	err := ctrl.ReportComment(context.Background(), 1, 3, "Спойлер")
+
+	assert.ErrorIs(t, err, controllers.ErrOwnComment)
+	tx.CommentsReportsRepo.AssertNotCalled(t, "CreateCommentReport", mock.Anything)
+}
+
+func TestReportComment_NoReason(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{Tx: newMockTx()}
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	err := ctrl.ReportComment(context.Background(), 1, 3, "")
+
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+	assert.Zero(t, uow.Calls)
+}
+
+func TestGetQueue(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	reports := []*models.CommentsReports{
+		{Cr_id: 1, Cr_idComment: 5}, {Cr_id: 2, Cr_idComment: 3}, {Cr_id: 3, Cr_idComment: 5}, {Cr_id: 4, Cr_idComment: 9},
+	}
+	tx.CommentsReportsRepo.On("GetOpenCommentsReports").Return(reports, nil)
+	tx.CommentsRepo.On("GetCommentById", 5).Return(&models.Comments{C_id: 5}, nil).Once()
+	tx.CommentsRepo.On("GetCommentById", 3).Return(&models.Comments{C_id: 3}, nil).Once()
+	tx.CommentsRepo.On("GetCommentById", 9).Return((*models.Comments)(nil), models.ErrNotFound)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	queue, err := ctrl.GetQueue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.ModerationItem{
+		{Comment: &models.Comments{C_id: 5}, Reports: []*models.CommentsReports{reports[0], reports[2]}},
+		{Comment: &models.Comments{C_id: 3}, Reports: []*models.CommentsReports{reports[1]}},
+	}, queue)
+	tx.CommentsRepo.AssertExpectations(t)
+}
+
+func TestModerate_Hide(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	comment := &models.Comments{C_id: 1, C_idUser: 2, C_text: "Грубость"}
+	open := &models.CommentsReports{Cr_id: 4, Cr_idComment: 1, Cr_state: models.ReportOpen}
+	closed := &models.CommentsReports{Cr_id: 5, Cr_idComment: 1, Cr_state: models.ReportClosed}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(comment, nil)
+	tx.CommentsRepo.On("UpdateComment", comment).Return(nil)
+	tx.CommentsReportsRepo.On("GetCommentsReportsByCommentId", 1).Return([]*models.CommentsReports{open, closed}, nil)
+	tx.CommentsReportsRepo.On("UpdateCommentReport", open).Return(nil)
+	tx.ModerationsRepo.On("CreateModeration", mock.MatchedBy(func(md *models.Moderations) bool {
+		return md.Md_idComment == 1 && md.Md_idModerator == 7 && md.Md_action == models.ModerationHide && md.Md_text == "Грубость" && md.Md_date != ""
+	})).Return(nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	require.NoError(t, ctrl.Moderate(context.Background(), 1, 7, models.ModerationHide))
+
+	assert.True(t, comment.C_hidden)
+	assert.Equal(t, models.ReportClosed, open.Cr_state)
+	tx.CommentsRepo.AssertExpectations(t)
+	tx.CommentsReportsRepo.AssertExpectations(t)
+	tx.CommentsReportsRepo.AssertNumberOfCalls(t, "UpdateCommentReport", 1)
+	tx.ModerationsRepo.AssertExpectations(t)
+}
+
+func TestModerate_Delete(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1, C_idSerial: 4, C_text: "Грубость"}, nil)
+	tx.CommentsRepo.On("GetRepliesBySerialId", 4).Return([]*models.Comments{{C_id: 2, C_idParent: 1}}, nil)
+	for _, id := range []int{1, 2} {
+		tx.CommentsVotesRepo.On("DeleteCommentVotesByCommentId", id).Return(nil)
+		tx.CommentsReportsRepo.On("DeleteCommentReportsByCommentId", id).Return(nil)
+		tx.CommentsRepo.On("DeleteComment", id).Return(nil)
+	}
+	tx.ModerationsRepo.On("CreateModeration", mock.MatchedBy(func(md *models.Moderations) bool {
+		return md.Md_action == models.ModerationDelete && md.Md_text == "Грубость"
+	})).Return(nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	require.NoError(t, ctrl.Moderate(context.Background(), 1, 7, models.ModerationDelete))
+
+	tx.CommentsRepo.AssertExpectations(t)
+	tx.CommentsReportsRepo.AssertExpectations(t)
+	tx.ModerationsRepo.AssertExpectations(t)
+}
+
+func TestModerate_Dismiss(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	open := &models.CommentsReports{Cr_id: 4, Cr_idComment: 1, Cr_state: models.ReportOpen}
+	tx.CommentsRepo.On("GetCommentById", 1).Return(&models.Comments{C_id: 1}, nil)
+	tx.CommentsReportsRepo.On("GetCommentsReportsByCommentId", 1).Return([]*models.CommentsReports{open}, nil)
+	tx.CommentsReportsRepo.On("UpdateCommentReport", open).Return(nil)
+	tx.ModerationsRepo.On("CreateModeration", mock.Anything).Return(nil)
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	require.NoError(t, ctrl.Moderate(context.Background(), 1, 7, models.ModerationDismiss))
+
+	assert.Equal(t, models.ReportClosed, open.Cr_state)
+	tx.CommentsRepo.AssertNotCalled(t, "UpdateComment", mock.Anything)
+	tx.ModerationsRepo.AssertExpectations(t)
+}
+
+func TestModerate_InvalidAction(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{Tx: newMockTx()}
+
+	ctrl := controllers.NewModerationCtrl(nil, nil, uow)
+	err := ctrl.Moderate(context.Background(), 1, 7, "ban")
+
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+	assert.Zero(t, uow.Calls)
+}
@@ -1,6 +1,7 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/mocks"
@@ -20,7 +21,7 @@ func TestGetProducers(t *testing.T) {
 
 	mockRepo.On("GetProducers").Return(mockData, nil)
 
-	producers, err := mockRepo.GetProducers()
+	producers, err := mockRepo.GetProducers(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, producers)
 	assert.Equal(t, mockData, producers)
@@ -35,7 +36,7 @@ func TestGetProducerById(t *testing.T) {
 
 	mockRepo.On("GetProducerById", 1).Return(mockData, nil)
 
-	producer, err := mockRepo.GetProducerById(1)
+	producer, err := mockRepo.GetProducerById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, producer)
 	assert.Equal(t, mockData, producer)
@@ -50,7 +51,7 @@ func TestCreateProducer(t *testing.T) {
 
 	mockRepo.On("CreateProducer", mockData).Return(nil)
 
-	err := mockRepo.CreateProducer(mockData)
+	err := mockRepo.CreateProducer(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -63,7 +64,7 @@ func TestUpdateProducer(t *testing.T) {
 
 	mockRepo.On("UpdateProducer", mockData).Return(nil)
 
-	err := mockRepo.UpdateProducer(mockData)
+	err := mockRepo.UpdateProducer(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -72,6 +73,6 @@ func TestDeleteProducer(t *testing.T) {
 
 	mockRepo.On("DeleteProducer", 1).Return(nil)
 
-	err := mockRepo.DeleteProducer(1)
+	err := mockRepo.DeleteProducer(context.Background(), 1)
 	require.NoError(t, err)
 }
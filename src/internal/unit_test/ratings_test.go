@@ -0,0 +1,109 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateSerial_New(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	ctrl := controllers.NewRatingsCtrl(nil, uow)
+	rating := &models.Ratings{R_idUser: 2, R_idSerial: 3, R_score: 9}
+	serial := &models.Serial{S_id: 3, S_rating: 6, S_votes: 2}
+
+	tx.SerialsRepo.On("GetSerialById", 3).Return(serial, nil)
+	tx.RatingsRepo.On("GetRatingBySerialIdUserId", 3, 2).Return((*models.Ratings)(nil), models.ErrNotFound)
+	tx.RatingsRepo.On("CreateRating", rating).Return(nil)
+	tx.SerialsRepo.On("LockSerial", 3).Return(nil)
+	tx.RatingsRepo.On("SumRatings", 3).Return(21, 3, nil)
+	tx.SerialsRepo.On("UpdateSerial", serial).Return(nil)
+
+	require.NoError(t, ctrl.RateSerial(context.Background(), rating))
+	assert.Equal(t, 1, uow.Calls)
+	assert.Equal(t, &models.Serial{S_id: 3, S_rating: 7, S_votes: 3}, serial)
+	tx.RatingsRepo.AssertExpectations(t)
+	tx.SerialsRepo.AssertExpectations(t)
+}
+
+func TestRateSerial_Change(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRatingsCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+	rating := &models.Ratings{R_idUser: 2, R_idSerial: 3, R_score: 4}
+	serial := &models.Serial{S_id: 3, S_rating: 8, S_votes: 2}
+
+	tx.SerialsRepo.On("GetSerialById", 3).Return(serial, nil)
+	tx.RatingsRepo.On("GetRatingBySerialIdUserId", 3, 2).Return(&models.Ratings{R_id: 5, R_idUser: 2, R_idSerial: 3, R_score: 10}, nil)
+	tx.RatingsRepo.On("UpdateRating", &models.Ratings{R_id: 5, R_idUser: 2, R_idSerial: 3, R_score: 4}).Return(nil)
+	tx.SerialsRepo.On("LockSerial", 3).Return(nil)
+	tx.RatingsRepo.On("SumRatings", 3).Return(10, 2, nil)
+	tx.SerialsRepo.On("UpdateSerial", serial).Return(nil)
+
+	require.NoError(t, ctrl.RateSerial(context.Background(), rating))
+	assert.Equal(t, 5, rating.GetId())
+	assert.Equal(t, &models.Serial{S_id: 3, S_rating: 5, S_votes: 2}, serial)
+	tx.RatingsRepo.AssertNotCalled(t, "CreateRating", mock.Anything)
+}
+
+func TestRateSerial_Invalid(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{Tx: newMockTx()}
+	ctrl := controllers.NewRatingsCtrl(nil, uow)
+
+	for _, score := range []int{models.MinScore - 1, models.MaxScore + 1} {
+		err := ctrl.RateSerial(context.Background(), &models.Ratings{R_idUser: 2, R_idSerial: 3, R_score: score})
+		assert.ErrorIs(t, err, models.ErrInvalidModel)
+	}
+	assert.Equal(t, 0, uow.Calls)
+}
+
+func TestRateSerial_SerialNotFound(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRatingsCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.SerialsRepo.On("GetSerialById", 3).Return((*models.Serial)(nil), models.ErrNotFound)
+
+	err := ctrl.RateSerial(context.Background(), &models.Ratings{R_idUser: 2, R_idSerial: 3, R_score: 5})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	tx.RatingsRepo.AssertNotCalled(t, "CreateRating", mock.Anything)
+}
+
+func TestDeleteRating(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRatingsCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+	serial := &models.Serial{S_id: 3, S_rating: 9, S_votes: 1}
+
+	tx.RatingsRepo.On("GetRatingBySerialIdUserId", 3, 2).Return(&models.Ratings{R_id: 5, R_idUser: 2, R_idSerial: 3, R_score: 9}, nil)
+	tx.RatingsRepo.On("DeleteRating", 5).Return(nil)
+	tx.SerialsRepo.On("LockSerial", 3).Return(nil)
+	tx.RatingsRepo.On("SumRatings", 3).Return(0, 0, nil)
+	tx.SerialsRepo.On("GetSerialById", 3).Return(serial, nil)
+	tx.SerialsRepo.On("UpdateSerial", serial).Return(nil)
+
+	require.NoError(t, ctrl.DeleteRating(context.Background(), 3, 2))
+	assert.Equal(t, &models.Serial{S_id: 3}, serial)
+	tx.RatingsRepo.AssertExpectations(t)
+}
+
+func TestDeleteRating_NotFound(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRatingsCtrl(nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.RatingsRepo.On("GetRatingBySerialIdUserId", 3, 2).Return((*models.Ratings)(nil), models.ErrNotFound)
+
+	assert.ErrorIs(t, ctrl.DeleteRating(context.Background(), 3, 2), models.ErrNotFound)
+	tx.RatingsRepo.AssertNotCalled(t, "DeleteRating", mock.Anything)
+}
+
+func TestMeanRating(t *testing.T) {
+	assert.Equal(t, float32(0), models.MeanRating(0, 0))
+	assert.Equal(t, float32(7), models.MeanRating(21, 3))
+	assert.Equal(t, float32(6.67), models.MeanRating(20, 3))
+}
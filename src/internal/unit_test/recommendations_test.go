@@ -0,0 +1,159 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecommender() *models.Recommender {
+	recommender := models.NewRecommender(similarSerials())
+	recommender.AddViewed(1, 1)
+	recommender.AddViewed(1, 2)
+	recommender.AddViewed(2, 1)
+	recommender.AddViewed(2, 3)
+	recommender.AddSaved(2, 3)
+	recommender.AddSaved(2, 3)
+	recommender.AddViewed(3, 1)
+	recommender.AddViewed(3, 3)
+	recommender.AddViewed(10, 1)
+	return recommender
+}
+
+func recommendedIds(recs []*models.Recommendation) []int {
+	ids := []int{}
+	for _, rec := range recs {
+		ids = append(ids, rec.Serial.S_id)
+	}
+	return ids
+}
+
+func TestRecommender_Recommend(t *testing.T) {
+	recommender := newRecommender()
+
+	recs := recommender.Recommend(10, 4)
+	require.Equal(t, []int{3, 2, 5, 4}, recommendedIds(recs))
+	assert.Equal(t, &models.Recommendation{Serial: recs[0].Serial, Score: 0.632, Source: models.RecommendByUsers}, recs[0])
+	assert.Equal(t, &models.Recommendation{Serial: recs[1].Serial, Score: 0.5, Source: models.RecommendByUsers}, recs[1])
+	assert.Equal(t, &models.Recommendation{Serial: recs[2].Serial, Score: 9, Source: models.RecommendBySimilar}, recs[2])
+	assert.Equal(t, &models.Recommendation{Serial: recs[3].Serial, Score: 0, Source: models.RecommendPopular}, recs[3])
+
+	assert.Equal(t, []int{3}, recommendedIds(recommender.Recommend(10, 1)))
+}
+
+func TestRecommender_Recommend_ExcludesDisliked(t *testing.T) {
+	recommender := newRecommender()
+	recommender.AddRating(10, 5, models.MinScore)
+
+	assert.Equal(t, []int{3, 2, 4}, recommendedIds(recommender.Recommend(10, models.MaxRecommendations)))
+}
+
+func TestRecommender_Recommend_ColdStart(t *testing.T) {
+	recommender := newRecommender()
+
+	recs := recommender.Recommend(20, 3)
+	require.Equal(t, []int{1, 3, 2}, recommendedIds(recs))
+	assert.Equal(t, models.RecommendPopular, recs[0].Source)
+	assert.Equal(t, 4.0, recs[0].Score)
+	assert.Equal(t, 2.0, recs[1].Score)
+}
+
+// newRecommendationsTx returns the transaction holding the interactions of
+// newRecommender.
+func newRecommendationsTx() *mocks.MockTx {
+	serials, serialsActors := similarSerials()
+	tx := newMockTx()
+	tx.SerialsActorsRepo = new(mocks.MockRepoSerialsActors)
+	tx.SerialsRepo.On("GetSerials").Return(serials, nil)
+	tx.SerialsActorsRepo.On("GetSerialsActors").Return(serialsActors, nil)
+	tx.SerialsUsersRepo.On("GetSerialsUsers").Return([]*models.SerialsUsers{
+		{Su_idUser: 1, Su_idSerial: 1},
+		{Su_idUser: 1, Su_idSerial: 2},
+		{Su_idUser: 2, Su_idSerial: 1},
+		{Su_idUser: 2, Su_idSerial: 3},
+		{Su_idUser: 3, Su_idSerial: 1},
+		{Su_idUser: 3, Su_idSerial: 3},
+		{Su_idUser: 10, Su_idSerial: 1},
+	}, nil)
+	tx.FavouritesRepo.On("GetFavourites").Return([]*models.Favourites{{F_id: 7, F_idUser: 2}}, nil)
+	tx.SerialsFavouritesRepo.On("GetSerialsFavourites").Return([]*models.SerialsFavourites{{Sf_idFavourite: 7, Sf_idSerial: 3}}, nil)
+	tx.CommentsRepo.On("GetComments").Return([]*models.Comments{}, nil)
+	tx.RatingsRepo.On("GetRatings").Return([]*models.Ratings{}, nil)
+	return tx
+}
+
+func TestRecommendationsCtrl_GetRecommendations_Stored(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRecommendationsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+	serial := &models.Serial{S_id: 4}
+
+	tx.UsersRepo.On("GetUserById", 10).Return(&models.Users{U_id: 10}, nil)
+	tx.RecommendationsRepo.On("GetRecommendationsByUserId", 10).Return([]*models.Recommendations{
+		{Rc_idUser: 10, Rc_idSerial: 3, Rc_score: 0.6, Rc_source: models.RecommendByUsers, Rc_position: 1},
+		{Rc_idUser: 10, Rc_idSerial: 2, Rc_score: 0.5, Rc_source: models.RecommendByUsers, Rc_position: 2},
+		{Rc_idUser: 10, Rc_idSerial: 4, Rc_score: 0, Rc_source: models.RecommendPopular, Rc_position: 3},
+	}, nil)
+	tx.SerialsUsersRepo.On("GetSerialsByUserId", 10).Return([]*models.SerialsUsers{{Su_idUser: 10, Su_idSerial: 3}}, nil)
+	tx.FavouritesRepo.On("GetFavouritesByUserId", 10).Return([]*models.Favourites{}, nil)
+	tx.CommentsRepo.On("GetCommentsByUserId", 10).Return([]*models.Comments{}, nil)
+	tx.RatingsRepo.On("GetRatingsByUserId", 10).Return([]*models.Ratings{}, nil)
+	tx.SerialsRepo.On("GetSerialById", 2).Return((*models.Serial)(nil), models.ErrNotFound)
+	tx.SerialsRepo.On("GetSerialById", 4).Return(serial, nil)
+
+	recs, err := ctrl.GetRecommendations(context.Background(), 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Recommendation{{Serial: serial, Score: 0, Source: models.RecommendPopular}}, recs)
+	tx.SerialsRepo.AssertNotCalled(t, "GetSerials")
+}
+
+func TestRecommendationsCtrl_GetRecommendations_Live(t *testing.T) {
+	tx := newRecommendationsTx()
+	ctrl := controllers.NewRecommendationsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("GetUserById", 10).Return(&models.Users{U_id: 10}, nil)
+	tx.RecommendationsRepo.On("GetRecommendationsByUserId", 10).Return([]*models.Recommendations{}, nil)
+
+	recs, err := ctrl.GetRecommendations(context.Background(), 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, recommendedIds(recs))
+
+	_, err = ctrl.GetRecommendations(context.Background(), 10, models.MaxRecommendations+1)
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+}
+
+func TestRecommendationsCtrl_GetRecommendations_NoUser(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewRecommendationsCtrl(&mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("GetUserById", 11).Return((*models.Users)(nil), models.ErrNotFound)
+
+	_, err := ctrl.GetRecommendations(context.Background(), 11, 2)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	tx.RecommendationsRepo.AssertNotCalled(t, "GetRecommendationsByUserId", mock.Anything)
+}
+
+func TestRecommendationsCtrl_RankAll(t *testing.T) {
+	tx := newRecommendationsTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	ctrl := controllers.NewRecommendationsCtrl(uow)
+
+	tx.UsersRepo.On("GetUsers").Return([]*models.Users{{U_id: 10}, {U_id: 20}}, nil)
+	tx.RecommendationsRepo.On("DeleteRecommendationsByUserId", 10).Return(nil)
+	tx.RecommendationsRepo.On("DeleteRecommendationsByUserId", 20).Return(nil)
+	tx.RecommendationsRepo.On("CreateRecommendation", &models.Recommendations{Rc_idUser: 10, Rc_idSerial: 3, Rc_score: 0.632, Rc_source: models.RecommendByUsers, Rc_position: 1}).Return(nil)
+	tx.RecommendationsRepo.On("CreateRecommendation", &models.Recommendations{Rc_idUser: 10, Rc_idSerial: 2, Rc_score: 0.5, Rc_source: models.RecommendByUsers, Rc_position: 2}).Return(nil)
+	tx.RecommendationsRepo.On("CreateRecommendation", &models.Recommendations{Rc_idUser: 20, Rc_idSerial: 1, Rc_score: 4, Rc_source: models.RecommendPopular, Rc_position: 1}).Return(nil)
+	tx.RecommendationsRepo.On("CreateRecommendation", &models.Recommendations{Rc_idUser: 20, Rc_idSerial: 3, Rc_score: 2, Rc_source: models.RecommendPopular, Rc_position: 2}).Return(nil)
+
+	count, err := ctrl.RankAll(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 3, uow.Calls)
+	tx.RecommendationsRepo.AssertExpectations(t)
+}
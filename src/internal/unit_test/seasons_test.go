@@ -1,21 +1,31 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/controllers"
 	"app/internal/mocks"
 	"app/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// newSeasonsUnitOfWork returns the unit of work over the mock repositories,
+// the controllers change the seasons and the episodes only through it.
+func newSeasonsUnitOfWork(seasons *mocks.MockRepoSeasons, serials *mocks.MockRepoSerials, episodes *mocks.MockRepoEpisodes) *mocks.MockUnitOfWork {
+	tx := newMockTx()
+	tx.SeasonsRepo, tx.SerialsRepo, tx.EpisodesRepo = seasons, serials, episodes
+	return &mocks.MockUnitOfWork{Tx: tx}
+}
+
 func TestGetSeasons(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSeasons)
 	mockRepo.On("GetSeasons").Return([]*models.Seasons{{Ss_id: 1}}, nil)
 
-	ctrl := controllers.NewSeasonsCtrl(mockRepo)
-	seasons, err := ctrl.GetSeasons()
+	ctrl := controllers.NewSeasonsCtrl(mockRepo, nil, nil, nil)
+	seasons, err := ctrl.GetSeasons(context.Background())
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, seasons[0].Ss_id)
@@ -26,43 +36,111 @@ func TestGetSeasonById(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSeasons)
 	mockRepo.On("GetSeasonById", 1).Return(&models.Seasons{Ss_id: 1}, nil)
 
-	ctrl := controllers.NewSeasonsCtrl(mockRepo)
-	season, err := ctrl.GetSeasonById(1)
+	ctrl := controllers.NewSeasonsCtrl(mockRepo, nil, nil, nil)
+	season, err := ctrl.GetSeasonById(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, season.Ss_id)
 	mockRepo.AssertCalled(t, "GetSeasonById", 1)
 }
 
-func TestCreateSeason(t *testing.T) {
+func TestGetSeasonsBySerialIdSorted(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSeasons)
-	mockRepo.On("CreateSeason", &models.Seasons{Ss_id: 1}).Return(nil)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{{Ss_id: 1, Ss_num: 2}, {Ss_id: 3, Ss_num: 1}}, nil)
 
-	ctrl := controllers.NewSeasonsCtrl(mockRepo)
-	err := ctrl.CreateSeason(&models.Seasons{Ss_id: 1})
+	ctrl := controllers.NewSeasonsCtrl(mockRepo, nil, nil, nil)
+	seasons, err := ctrl.GetSeasonsBySerialId(context.Background(), 2)
 
 	require.NoError(t, err)
-	mockRepo.AssertCalled(t, "CreateSeason", &models.Seasons{Ss_id: 1})
+	assert.Equal(t, 3, seasons[0].Ss_id)
+	assert.Equal(t, 1, seasons[1].Ss_id)
 }
 
-func TestUpdateSeason(t *testing.T) {
+func TestCreateSeason(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSeasons)
-	mockRepo.On("UpdateSeason", &models.Seasons{Ss_id: 1}).Return(nil)
+	mockSerials := new(mocks.MockRepoSerials)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	season := &models.Seasons{Ss_id: 1, Ss_idSerial: 2}
+	mockRepo.On("CreateSeason", season).Return(nil)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{season}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 1).Return([]*models.Episodes{}, nil)
+	mockSerials.On("GetSerialById", 2).Return(&models.Serial{S_id: 2}, nil)
+	mockSerials.On("UpdateSerial", &models.Serial{S_id: 2, S_seasons: 1, S_duration: "00:00:00"}).Return(nil)
+
+	uow := newSeasonsUnitOfWork(mockRepo, mockSerials, mockEpisodes)
+	ctrl := controllers.NewSeasonsCtrl(nil, nil, nil, uow)
+	err := ctrl.CreateSeason(context.Background(), season)
 
-	ctrl := controllers.NewSeasonsCtrl(mockRepo)
-	err := ctrl.UpdateSeason(&models.Seasons{Ss_id: 1})
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "CreateSeason", season)
+	mockSerials.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 2, S_seasons: 1, S_duration: "00:00:00"})
+}
+
+func TestUpdateSeason(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSeasons)
+	mockSerials := new(mocks.MockRepoSerials)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	season := &models.Seasons{Ss_id: 1, Ss_idSerial: 2}
+	mockRepo.On("GetSeasonById", 1).Return(&models.Seasons{Ss_id: 1, Ss_idSerial: 2}, nil)
+	mockRepo.On("UpdateSeason", season).Return(nil)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{season}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 1).Return([]*models.Episodes{{E_duration: "00:45:00"}}, nil)
+	mockSerials.On("GetSerialById", 2).Return(&models.Serial{S_id: 2, S_seasons: 1, S_duration: "00:45:00"}, nil)
+
+	uow := newSeasonsUnitOfWork(mockRepo, mockSerials, mockEpisodes)
+	ctrl := controllers.NewSeasonsCtrl(nil, nil, nil, uow)
+	err := ctrl.UpdateSeason(context.Background(), season)
 
 	require.NoError(t, err)
-	mockRepo.AssertCalled(t, "UpdateSeason", &models.Seasons{Ss_id: 1})
+	mockRepo.AssertCalled(t, "UpdateSeason", season)
+	mockSerials.AssertNotCalled(t, "UpdateSerial", mock.Anything)
 }
 
 func TestDeleteSeason(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSeasons)
+	mockSerials := new(mocks.MockRepoSerials)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	mockRepo.On("GetSeasonById", 1).Return(&models.Seasons{Ss_id: 1, Ss_idSerial: 2}, nil)
 	mockRepo.On("DeleteSeason", 1).Return(nil)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 1).Return([]*models.Episodes{{E_id: 3, E_idSeason: 1}}, nil)
+	mockEpisodes.On("DeleteEpisode", 3).Return(nil)
+	mockSerials.On("GetSerialById", 2).Return(&models.Serial{S_id: 2, S_seasons: 1}, nil)
+	mockSerials.On("UpdateSerial", &models.Serial{S_id: 2, S_seasons: 0, S_duration: "00:00:00"}).Return(nil)
 
-	ctrl := controllers.NewSeasonsCtrl(mockRepo)
-	err := ctrl.DeleteSeason(1)
+	uow := newSeasonsUnitOfWork(mockRepo, mockSerials, mockEpisodes)
+	ctrl := controllers.NewSeasonsCtrl(nil, nil, nil, uow)
+	err := ctrl.DeleteSeason(context.Background(), 1)
 
 	require.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
 	mockRepo.AssertCalled(t, "DeleteSeason", 1)
+	mockEpisodes.AssertCalled(t, "DeleteEpisode", 3)
+	mockSerials.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 2, S_seasons: 0, S_duration: "00:00:00"})
+}
+
+func TestReorderSeasons(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSeasons)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{{Ss_id: 1, Ss_num: 1}, {Ss_id: 3, Ss_num: 2}}, nil)
+	mockRepo.On("UpdateSeason", &models.Seasons{Ss_id: 3, Ss_num: 1}).Return(nil)
+	mockRepo.On("UpdateSeason", &models.Seasons{Ss_id: 1, Ss_num: 2}).Return(nil)
+
+	uow := newSeasonsUnitOfWork(mockRepo, nil, nil)
+	ctrl := controllers.NewSeasonsCtrl(nil, nil, nil, uow)
+	err := ctrl.ReorderSeasons(context.Background(), 2, []int{3, 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
+	mockRepo.AssertNumberOfCalls(t, "UpdateSeason", 2)
+}
+
+func TestReorderSeasonsInvalidOrder(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSeasons)
+	mockRepo.On("GetSeasonsBySerialId", 2).Return([]*models.Seasons{{Ss_id: 1, Ss_num: 1}, {Ss_id: 3, Ss_num: 2}}, nil)
+
+	ctrl := controllers.NewSeasonsCtrl(nil, nil, nil, newSeasonsUnitOfWork(mockRepo, nil, nil))
+	err := ctrl.ReorderSeasons(context.Background(), 2, []int{3, 3})
+
+	require.ErrorIs(t, err, controllers.ErrInvalidOrder)
+	mockRepo.AssertNotCalled(t, "UpdateSeason")
 }
@@ -1,6 +1,7 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/mocks"
@@ -21,7 +22,7 @@ func TestGetSerialsActors(t *testing.T) {
 
 	mockRepo.On("GetSerialsActors").Return(mockData, nil)
 
-	serialsActors, err := mockRepo.GetSerialsActors()
+	serialsActors, err := mockRepo.GetSerialsActors(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, serialsActors)
 	assert.Equal(t, mockData, serialsActors)
@@ -39,7 +40,7 @@ func TestGetSerialsByActorId(t *testing.T) {
 
 	mockRepo.On("GetSerialsByActorId", 1).Return(mockData, nil)
 
-	serialsActors, err := mockRepo.GetSerialsByActorId(1)
+	serialsActors, err := mockRepo.GetSerialsByActorId(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsActors)
 	assert.Equal(t, mockData, serialsActors)
@@ -57,7 +58,7 @@ func TestGetActorsBySerialId(t *testing.T) {
 
 	mockRepo.On("GetActorsBySerialId", 1).Return(mockData, nil)
 
-	serialsActors, err := mockRepo.GetActorsBySerialId(1)
+	serialsActors, err := mockRepo.GetActorsBySerialId(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsActors)
 	assert.Equal(t, mockData, serialsActors)
@@ -73,7 +74,7 @@ func TestGetSerialsActorsById(t *testing.T) {
 
 	mockRepo.On("GetSerialsActorsById", 1).Return(mockData, nil)
 
-	serialsActors, err := mockRepo.GetSerialsActorsById(1)
+	serialsActors, err := mockRepo.GetSerialsActorsById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsActors)
 	assert.Equal(t, mockData, serialsActors)
@@ -89,7 +90,7 @@ func TestCreateSerialsActors(t *testing.T) {
 
 	mockRepo.On("CreateSerialsActors", mockData).Return(nil)
 
-	err := mockRepo.CreateSerialsActors(mockData)
+	err := mockRepo.CreateSerialsActors(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -103,6 +104,58 @@ func TestUpdateSerialsActors(t *testing.T) {
 
 	mockRepo.On("UpdateSerialsActors", mockData).Return(nil)
 
-	err := mockRepo.UpdateSerialsActors(mockData)
+	err := mockRepo.UpdateSerialsActors(context.Background(), mockData)
 	require.NoError(t, err)
 }
+
+func TestSerialsActors_Validate(t *testing.T) {
+	valid := func() *models.SerialsActors {
+		return &models.SerialsActors{Sa_idSerial: 1, Sa_idActor: 1, Sa_role: models.CastMain}
+	}
+	assert.True(t, valid().Validate())
+
+	sa := valid()
+	sa.Sa_seasonFrom, sa.Sa_seasonTo = 2, 2
+	assert.True(t, sa.Validate())
+	sa.Sa_seasonTo = 0
+	assert.True(t, sa.Validate())
+
+	for _, invalidate := range []func(sa *models.SerialsActors){
+		func(sa *models.SerialsActors) { sa.Sa_role = "" },
+		func(sa *models.SerialsActors) { sa.Sa_role = "cameo" },
+		func(sa *models.SerialsActors) { sa.Sa_billing = -1 },
+		func(sa *models.SerialsActors) { sa.Sa_seasonTo = 2 },
+		func(sa *models.SerialsActors) { sa.Sa_seasonFrom, sa.Sa_seasonTo = 3, 2 },
+		func(sa *models.SerialsActors) { sa.Sa_seasonFrom = -1 },
+	} {
+		sa := valid()
+		invalidate(sa)
+		assert.False(t, sa.Validate())
+	}
+}
+
+func TestGroupCast(t *testing.T) {
+	member := func(id int, surname, role string, billing int) *models.CastMember {
+		return &models.CastMember{
+			Actor: &models.Actors{A_id: id, A_name: "X", A_surname: surname},
+			Role:  &models.SerialsActors{Sa_id: id, Sa_idActor: id, Sa_role: role, Sa_billing: billing},
+		}
+	}
+	cast := []*models.CastMember{
+		member(1, "Абботт", models.CastGuest, 0),
+		member(2, "Скотт", models.CastMain, 0),
+		member(3, "Фримен", models.CastMain, 2),
+		member(4, "Камбербэтч", models.CastMain, 1),
+		member(5, "Грэйвс", models.CastMain, 0),
+	}
+
+	groups := models.GroupCast(cast)
+	require.Len(t, groups, 2)
+	assert.Equal(t, models.CastMain, groups[0].Role)
+	assert.Equal(t, []*models.CastMember{cast[3], cast[2], cast[4], cast[1]}, groups[0].Members)
+	assert.Equal(t, models.CastGuest, groups[1].Role)
+	assert.Equal(t, []*models.CastMember{cast[0]}, groups[1].Members)
+	assert.Equal(t, 1, cast[0].Actor.A_id)
+
+	assert.Empty(t, models.GroupCast(nil))
+}
@@ -1,6 +1,7 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
 	"app/internal/mocks"
@@ -21,7 +22,7 @@ func TestGetSerialsFavourites(t *testing.T) {
 
 	mockRepo.On("GetSerialsFavourites").Return(mockData, nil)
 
-	serialsFavourites, err := mockRepo.GetSerialsFavourites()
+	serialsFavourites, err := mockRepo.GetSerialsFavourites(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, serialsFavourites)
 	assert.Equal(t, mockData, serialsFavourites)
@@ -37,7 +38,7 @@ func TestGetSerialsFavouritesById(t *testing.T) {
 
 	mockRepo.On("GetSerialsFavouritesById", 1).Return(mockData, nil)
 
-	serialFavourite, err := mockRepo.GetSerialsFavouritesById(1)
+	serialFavourite, err := mockRepo.GetSerialsFavouritesById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialFavourite)
 	assert.Equal(t, mockData, serialFavourite)
@@ -53,7 +54,7 @@ func TestCreateSerialsFavourites(t *testing.T) {
 
 	mockRepo.On("CreateSerialsFavourites", mockData).Return(nil)
 
-	err := mockRepo.CreateSerialsFavourites(mockData)
+	err := mockRepo.CreateSerialsFavourites(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -67,6 +68,6 @@ func TestUpdateSerialsFavourites(t *testing.T) {
 
 	mockRepo.On("UpdateSerialsFavourites", mockData).Return(nil)
 
-	err := mockRepo.UpdateSerialsFavourites(mockData)
+	err := mockRepo.UpdateSerialsFavourites(context.Background(), mockData)
 	require.NoError(t, err)
 }
@@ -1,8 +1,10 @@
 package unit_test
 
 import (
+	"context"
 	"testing"
 
+	"app/internal/controllers"
 	"app/internal/mocks"
 	"app/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +23,7 @@ func TestGetSerialsUsers(t *testing.T) {
 
 	mockRepo.On("GetSerialsUsers").Return(mockData, nil)
 
-	serialsUsers, err := mockRepo.GetSerialsUsers()
+	serialsUsers, err := mockRepo.GetSerialsUsers(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, serialsUsers)
 	assert.Equal(t, mockData, serialsUsers)
@@ -37,7 +39,7 @@ func TestGetSerialsUsersById(t *testing.T) {
 
 	mockRepo.On("GetSerialsUsersById", 1).Return(mockData, nil)
 
-	serialsUsers, err := mockRepo.GetSerialsUsersById(1)
+	serialsUsers, err := mockRepo.GetSerialsUsersById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsUsers)
 	assert.Equal(t, mockData, serialsUsers)
@@ -55,12 +57,26 @@ func TestGetSerialsByUserId(t *testing.T) {
 
 	mockRepo.On("GetSerialsByUserId", 1).Return(mockData, nil)
 
-	serialsUsers, err := mockRepo.GetSerialsByUserId(1)
+	serialsUsers, err := mockRepo.GetSerialsByUserId(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsUsers)
 	assert.Equal(t, mockData, serialsUsers)
 }
 
+func TestGetSerialsByUserIdPage(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSerialsUsers)
+	page := models.NewPage(2, 2)
+	mockRepo.On("GetSerialsByUserIdPage", 1, page).Return([]*models.SerialsUsers{{Su_id: 3}}, 3, nil)
+
+	ctrl := controllers.NewSerialsUsersCtrl(mockRepo)
+	history, total, err := ctrl.GetSerialsByUserIdPage(context.Background(), 1, page)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.SerialsUsers{{Su_id: 3}}, history)
+	assert.Equal(t, 3, total)
+	mockRepo.AssertCalled(t, "GetSerialsByUserIdPage", 1, page)
+}
+
 func TestGetUsersBySerialId(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerialsUsers)
 	mockData := []*models.SerialsUsers{
@@ -73,7 +89,7 @@ func TestGetUsersBySerialId(t *testing.T) {
 
 	mockRepo.On("GetUsersBySerialId", 1).Return(mockData, nil)
 
-	serialsUsers, err := mockRepo.GetUsersBySerialId(1)
+	serialsUsers, err := mockRepo.GetUsersBySerialId(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, serialsUsers)
 	assert.Equal(t, mockData, serialsUsers)
@@ -89,7 +105,7 @@ func TestCreateSerialsUsers(t *testing.T) {
 
 	mockRepo.On("CreateSerialsUsers", mockData).Return(nil)
 
-	err := mockRepo.CreateSerialsUsers(mockData)
+	err := mockRepo.CreateSerialsUsers(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -103,6 +119,6 @@ func TestUpdateSerialsUsers(t *testing.T) {
 
 	mockRepo.On("UpdateSerialsUsers", mockData).Return(nil)
 
-	err := mockRepo.UpdateSerialsUsers(mockData)
+	err := mockRepo.UpdateSerialsUsers(context.Background(), mockData)
 	require.NoError(t, err)
 }
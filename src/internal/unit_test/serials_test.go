@@ -1,6 +1,10 @@
 package unit_test
 
 import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
 	"testing"
 
 	"app/internal/controllers"
@@ -15,8 +19,8 @@ func TestGetSerials(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
 	mockRepo.On("GetSerials").Return([]*models.Serial{{S_id: 1}}, nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	serials, err := ctrl.GetSerials()
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	serials, err := ctrl.GetSerials(context.Background())
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, serials[0].S_id)
@@ -27,8 +31,8 @@ func TestGetSerialById(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
 	mockRepo.On("GetSerialById", 1).Return(&models.Serial{S_id: 1}, nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	serial, err := ctrl.GetSerialById(1)
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	serial, err := ctrl.GetSerialById(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, serial.S_id)
@@ -37,34 +41,62 @@ func TestGetSerialById(t *testing.T) {
 
 func TestCreateSerial(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
-	mockRepo.On("CreateSerial", &models.Serial{S_id: 1}).Return(nil)
-	mockRepo.On("CalculateDuration", &models.Serial{S_id: 1}).Return(nil)
+	mockRepo.On("CreateSerial", &models.Serial{S_duration: "00:00:00"}).Return(nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	err := ctrl.CreateSerial(&models.Serial{S_id: 1})
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	err := ctrl.CreateSerial(context.Background(), &models.Serial{})
 
 	require.NoError(t, err)
-	mockRepo.AssertCalled(t, "CreateSerial", &models.Serial{S_id: 1})
-	mockRepo.AssertCalled(t, "CalculateDuration", &models.Serial{S_id: 1})
+	mockRepo.AssertCalled(t, "CreateSerial", &models.Serial{S_duration: "00:00:00"})
 }
 
 func TestUpdateSerial(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
-	mockRepo.On("UpdateSerial", &models.Serial{S_id: 1}).Return(nil)
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	mockSeasons.On("GetSeasonsBySerialId", 1).Return([]*models.Seasons{}, nil)
+	mockRepo.On("UpdateSerial", &models.Serial{S_id: 1, S_duration: "00:00:00"}).Return(nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	err := ctrl.UpdateSerial(&models.Serial{S_id: 1})
+	ctrl := controllers.NewSerialsCtrl(mockRepo, mockSeasons, mockEpisodes)
+	err := ctrl.UpdateSerial(context.Background(), &models.Serial{S_id: 1, S_duration: "10:00:00"})
 
 	require.NoError(t, err)
-	mockRepo.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 1})
+	mockRepo.AssertCalled(t, "UpdateSerial", &models.Serial{S_id: 1, S_duration: "00:00:00"})
+}
+
+func TestCalculateDuration(t *testing.T) {
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	mockSeasons.On("GetSeasonsBySerialId", 1).Return([]*models.Seasons{{Ss_id: 2}, {Ss_id: 3}}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{{E_duration: "00:45:30"}, {E_duration: "00:44:30"}}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 3).Return([]*models.Episodes{{E_duration: "23:00:00"}}, nil)
+
+	ctrl := controllers.NewSerialsCtrl(nil, mockSeasons, mockEpisodes)
+	serial := &models.Serial{S_id: 1}
+	err := ctrl.CalculateDuration(context.Background(), serial)
+
+	require.NoError(t, err)
+	assert.Equal(t, "24:30:00", serial.S_duration)
+}
+
+func TestCalculateDurationInvalidEpisode(t *testing.T) {
+	mockSeasons := new(mocks.MockRepoSeasons)
+	mockEpisodes := new(mocks.MockRepoEpisodes)
+	mockSeasons.On("GetSeasonsBySerialId", 1).Return([]*models.Seasons{{Ss_id: 2}}, nil)
+	mockEpisodes.On("GetEpisodesBySeasonId", 2).Return([]*models.Episodes{{E_duration: "45 min"}}, nil)
+
+	ctrl := controllers.NewSerialsCtrl(nil, mockSeasons, mockEpisodes)
+	err := ctrl.CalculateDuration(context.Background(), &models.Serial{S_id: 1})
+
+	require.ErrorIs(t, err, models.ErrInvalidDuration)
 }
 
 func TestDeleteSerial(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
 	mockRepo.On("DeleteSerial", 1).Return(nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	err := ctrl.DeleteSerial(1)
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	err := ctrl.DeleteSerial(context.Background(), 1)
 
 	require.NoError(t, err)
 	mockRepo.AssertCalled(t, "DeleteSerial", 1)
@@ -74,10 +106,219 @@ func TestGetSerialByTitle(t *testing.T) {
 	mockRepo := new(mocks.MockRepoSerials)
 	mockRepo.On("GetSerialsByTitle", "title").Return([]*models.Serial{{S_id: 1}}, nil)
 
-	ctrl := controllers.NewSerialsCtrl(mockRepo)
-	serials, err := ctrl.GetSerialByTitle("title")
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	serials, err := ctrl.GetSerialByTitle(context.Background(), "title")
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, serials[0].S_id)
 	mockRepo.AssertCalled(t, "GetSerialsByTitle", "title")
 }
+
+func TestSearchSerials(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSerials)
+	query := &models.SerialsQuery{Genre: "драма", Sort: models.SortByRating, Desc: true}
+	page := models.NewPage(2, 2)
+	mockRepo.On("SearchSerials", query, page).Return([]*models.Serial{{S_id: 2}, {S_id: 1}}, 4, nil)
+
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	serials, total, err := ctrl.SearchSerials(context.Background(), query, page)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Serial{{S_id: 2}, {S_id: 1}}, serials)
+	assert.Equal(t, 4, total)
+	mockRepo.AssertCalled(t, "SearchSerials", query, page)
+}
+
+func TestGetSerialsPage(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSerials)
+	page := models.NewPage(1, models.DefaultPageSize)
+	mockRepo.On("SearchSerials", &models.SerialsQuery{}, page).Return([]*models.Serial{{S_id: 1}}, 1, nil)
+
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	serials, total, err := ctrl.GetSerialsPage(context.Background(), page)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Serial{{S_id: 1}}, serials)
+	assert.Equal(t, 1, total)
+}
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		page   models.Page
+		valid  bool
+		offset int
+		count  int
+	}{
+		{models.NewPage(1, 20), true, 0, 2},
+		{models.NewPage(3, 20), true, 40, 2},
+		{models.NewPage(2, 10), true, 10, 3},
+		{models.NewPage(1, models.MaxPageSize), true, 0, 1},
+		{models.NewPage(0, 20), false, -20, 1},
+		{models.NewPage(1, 0), false, 0, 1},
+		{models.NewPage(1, models.MaxPageSize+1), false, 0, 1},
+		{models.NewPage(math.MaxInt/models.MaxPageSize, models.MaxPageSize), true, math.MaxInt/models.MaxPageSize*models.MaxPageSize - models.MaxPageSize, 1},
+		{models.NewPage(math.MaxInt/models.MaxPageSize+1, models.MaxPageSize), false, 0, 1},
+		{models.NewPage(92233720368547760, 100), false, 0, 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, tt.page.Validate(), "%+v", tt.page)
+		if tt.valid {
+			assert.Equal(t, tt.offset, tt.page.Offset(), "%+v", tt.page)
+			assert.Equal(t, tt.count, tt.page.Count(25), "%+v", tt.page)
+		}
+	}
+}
+
+func TestSerialsQueryValidate(t *testing.T) {
+	tests := []struct {
+		query models.SerialsQuery
+		valid bool
+	}{
+		{models.SerialsQuery{}, true},
+		{models.SerialsQuery{YearFrom: 2000, YearTo: 2000, Sort: models.SortByName}, true},
+		{models.SerialsQuery{YearFrom: 2001, YearTo: 2000}, false},
+		{models.SerialsQuery{YearTo: -1}, false},
+		{models.SerialsQuery{MinRating: -0.5}, false},
+		{models.SerialsQuery{Sort: "duration"}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, tt.query.Validate(), "%+v", tt.query)
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSerials)
+	page := models.NewPage(1, models.DefaultPageSize)
+	hits := []*models.SearchHit{{Serial: &models.Serial{S_id: 1}, Rank: 0.5, Snippet: []models.Fragment{{Text: "Лондон", Match: true}}}}
+	mockRepo.On("FullTextSearch", "Лондон", page).Return(hits, 1, nil)
+
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	got, total, err := ctrl.FullTextSearch(context.Background(), "Лондон", page)
+
+	require.NoError(t, err)
+	assert.Equal(t, hits, got)
+	assert.Equal(t, 1, total)
+	mockRepo.AssertCalled(t, "FullTextSearch", "Лондон", page)
+}
+
+func TestSearchWords(t *testing.T) {
+	assert.Equal(t, []string{"шерлок", "холмс"}, models.SearchWords("Шерлок, ХОЛМС! шерлок"))
+	assert.Empty(t, models.SearchWords(" - ,"))
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		text  string
+		words []string
+		want  []models.Fragment
+	}{
+		{"Детектив в Лондоне", []string{"лондон"}, []models.Fragment{{Text: "Детектив в "}, {Text: "Лондоне", Match: true}}},
+		{"Детектив в Лондоне", []string{"париж"}, []models.Fragment{{Text: "Детектив в Лондоне"}}},
+		{"Шерлок и Ватсон", []string{"шерлок", "ватсон"}, []models.Fragment{{Text: "Шерлок", Match: true}, {Text: " и "}, {Text: "Ватсон", Match: true}}},
+		{"", []string{"шерлок"}, []models.Fragment{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.Highlight(tt.text, tt.words), tt.text)
+	}
+
+	long := strings.Repeat("слово ", 20) + "Лондон" + strings.Repeat(" слово", 40)
+	got := models.Highlight(long, []string{"лондон"})
+	assert.Equal(t, "… "+strings.Repeat("слово ", models.SnippetWords/4), got[0].Text)
+	assert.Equal(t, models.Fragment{Text: "Лондон", Match: true}, got[1])
+	assert.Equal(t, " …", got[len(got)-1].Text)
+}
+
+func TestSplitMarked(t *testing.T) {
+	assert.Equal(t, []models.Fragment{{Text: "Детектив в "}, {Text: "Лондоне", Match: true}, {Text: "."}},
+		models.SplitMarked("Детектив в <b>Лондоне</b>.", "<b>", "</b>"))
+	assert.Equal(t, []models.Fragment{{Text: "Лондон", Match: true}}, models.SplitMarked("<b>Лондон", "<b>", "</b>"))
+	assert.Equal(t, []models.Fragment{}, models.SplitMarked("", "<b>", "</b>"))
+}
+
+func TestSuggest(t *testing.T) {
+	mockRepo := new(mocks.MockRepoSerials)
+	query := models.NewSuggestQuery(" Шер ", models.DefaultSuggestions)
+	suggestions := []*models.Suggestion{{Kind: models.SuggestSerial, Id: 1, Text: "Шерлок"}}
+	mockRepo.On("Suggest", query).Return(suggestions, nil)
+
+	ctrl := controllers.NewSerialsCtrl(mockRepo, nil, nil)
+	got, err := ctrl.Suggest(context.Background(), query)
+
+	require.NoError(t, err)
+	assert.Equal(t, suggestions, got)
+	assert.Equal(t, "шер", query.Prefix)
+	mockRepo.AssertCalled(t, "Suggest", query)
+}
+
+func TestSuggestDistance(t *testing.T) {
+	tests := []struct {
+		prefix string
+		text   string
+		want   int
+	}{
+		{"шер", "Шерлок", 0},
+		{"холм", "Шерлок Холмс", 0},
+		{"ерл", "Шерлок", -1},
+		{"ерло", "Шерлок", 1},
+		{"шрл", "Шерлок", -1},
+		{"шерлк", "Шерлок", 1},
+		{"шрелок", "Шерлок", 1},
+		{"шерлоок", "Шерлок", 1},
+		{"шарлок", "Шерлок", 1},
+		{"шарлак", "Шерлок", -1},
+		{"шер", "", -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.NewSuggestQuery(tt.prefix, 1).Distance(tt.text), tt.prefix+" "+tt.text)
+	}
+}
+
+func TestSuggestPattern(t *testing.T) {
+	pattern := regexp.MustCompile("(?i)" + models.NewSuggestQuery("шрелок", 1).Pattern())
+	for _, text := range []string{"Шерлок", "Шрелок", "Мистер Шерлок", "Шелок"} {
+		assert.True(t, pattern.MatchString(text), text)
+	}
+	assert.False(t, pattern.MatchString("Тьма"))
+
+	exact := models.NewSuggestQuery("a.b", 1)
+	assert.Equal(t, []string{`a\.b`}, exact.Variants(regexp.QuoteMeta, "."))
+}
+
+func TestSuggestKeys(t *testing.T) {
+	keys, tails := models.SuggestKeys("Шерлок Холмс")
+	assert.Equal(t, []string{"шерлок холмс", "холмс"}, keys)
+	assert.Equal(t, []string{"ерлок холмс", "олмс"}, tails)
+
+	// The keys matched by the anchored KeyVariants find every text Distance
+	// matches.
+	matches := func(query *models.SuggestQuery, text string) bool {
+		heads, tails := query.KeyVariants(regexp.QuoteMeta, ".")
+		keys, keyTails := models.SuggestKeys(text)
+		for _, set := range []struct{ patterns, keys []string }{{heads, keys}, {tails, keyTails}} {
+			for _, pattern := range set.patterns {
+				for _, key := range set.keys {
+					if regexp.MustCompile("^" + pattern).MatchString(key) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	for _, prefix := range []string{"шер", "холм", "ерл", "ерло", "шрл", "шерлк", "шрелок", "шерлоок", "шарлок", "шарлак", "aерлок", "эшерлок", "шерлок хол"} {
+		query := models.NewSuggestQuery(prefix, 1)
+		assert.Equal(t, query.Distance("Шерлок Холмс") >= 0, matches(query, "Шерлок Холмс"), prefix)
+	}
+}
+
+func TestSuggestRank(t *testing.T) {
+	suggestions := []*models.Suggestion{
+		{Kind: models.SuggestSerial, Id: 1, Text: "Потерянный Лондон"},
+		{Kind: models.SuggestSerial, Id: 2, Text: "Тьма"},
+		{Kind: models.SuggestActor, Id: 3, Text: "Джек Ландон"},
+		{Kind: models.SuggestSerial, Id: 4, Text: "Лондон"},
+	}
+	assert.Equal(t, []*models.Suggestion{suggestions[3], suggestions[0], suggestions[2]},
+		models.NewSuggestQuery("лондо", 3).Rank(suggestions))
+	assert.Equal(t, []*models.Suggestion{suggestions[3]}, models.NewSuggestQuery("лондо", 1).Rank(suggestions))
+}
@@ -0,0 +1,94 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"app/internal/controllers"
+	"app/internal/mocks"
+	"app/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func similarSerials() ([]*models.Serial, []*models.SerialsActors) {
+	serials := []*models.Serial{
+		{S_id: 1, S_genre: "драма", S_idProducer: 1, S_year: 2010},
+		{S_id: 2, S_genre: "Драма", S_idProducer: 2, S_year: 2012},
+		{S_id: 3, S_genre: "комедия", S_idProducer: 1, S_year: 2010},
+		{S_id: 4, S_genre: "комедия", S_idProducer: 3, S_year: 2010},
+		{S_id: 5, S_genre: "драма", S_idProducer: 1, S_year: 2010},
+	}
+	serialsActors := []*models.SerialsActors{
+		{Sa_idSerial: 1, Sa_idActor: 1},
+		{Sa_idSerial: 1, Sa_idActor: 2},
+		{Sa_idSerial: 2, Sa_idActor: 2},
+		{Sa_idSerial: 5, Sa_idActor: 1},
+		{Sa_idSerial: 5, Sa_idActor: 2},
+	}
+	return serials, serialsActors
+}
+
+func TestSimilarIndex(t *testing.T) {
+	index := models.NewSimilarIndex(similarSerials())
+
+	similar := index.Similar(1, models.MaxSimilar)
+	require.Len(t, similar, 3)
+	assert.Equal(t, &models.SimilarSerial{Serial: similar[0].Serial, Score: 9, Genre: true, Actors: 2, Producer: true}, similar[0])
+	assert.Equal(t, 5, similar[0].Serial.S_id)
+	assert.Equal(t, 2, similar[1].Serial.S_id)
+	assert.Equal(t, 5.3, similar[1].Score)
+	assert.Equal(t, 3, similar[2].Serial.S_id)
+	assert.Equal(t, 3.0, similar[2].Score)
+
+	assert.Len(t, index.Similar(1, 2), 2)
+	similar = index.Similar(4, models.MaxSimilar)
+	require.Len(t, similar, 1)
+	assert.Equal(t, 3, similar[0].Serial.S_id)
+	assert.False(t, index.Has(6))
+}
+
+func newSimilarMocks() (*mocks.MockRepoSerials, *mocks.MockRepoSerialsActors) {
+	serials, serialsActors := similarSerials()
+	serialsRepo := new(mocks.MockRepoSerials)
+	serialsRepo.On("GetSerialById", mock.Anything).Return(&models.Serial{}, nil)
+	serialsRepo.On("GetSerials").Return(serials, nil)
+	saRepo := new(mocks.MockRepoSerialsActors)
+	saRepo.On("GetSerialsActors").Return(serialsActors, nil)
+	return serialsRepo, saRepo
+}
+
+func TestSimilarCtrl_GetSimilar_Cached(t *testing.T) {
+	serialsRepo, saRepo := newSimilarMocks()
+	cache := models.NewSimilarCache(time.Minute)
+	ctrl := controllers.NewSimilarCtrl(serialsRepo, saRepo, cache)
+
+	similar, err := ctrl.GetSimilar(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.Len(t, similar, 2)
+	assert.Equal(t, 5, similar[0].Serial.S_id)
+	_, err = ctrl.GetSimilar(context.Background(), 2, 2)
+	require.NoError(t, err)
+	serialsRepo.AssertNumberOfCalls(t, "GetSerials", 1)
+
+	cache.Invalidate()
+	_, err = ctrl.GetSimilar(context.Background(), 1, 2)
+	require.NoError(t, err)
+	serialsRepo.AssertNumberOfCalls(t, "GetSerials", 2)
+	saRepo.AssertNumberOfCalls(t, "GetSerialsActors", 2)
+}
+
+func TestSimilarCtrl_GetSimilar_NotFound(t *testing.T) {
+	serialsRepo := new(mocks.MockRepoSerials)
+	serialsRepo.On("GetSerialById", 6).Return((*models.Serial)(nil), models.ErrNotFound)
+	ctrl := controllers.NewSimilarCtrl(serialsRepo, nil, nil)
+
+	_, err := ctrl.GetSimilar(context.Background(), 6, 2)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	serialsRepo.AssertNotCalled(t, "GetSerials")
+
+	_, err = ctrl.GetSimilar(context.Background(), 6, models.MaxSimilar+1)
+	assert.ErrorIs(t, err, models.ErrInvalidModel)
+}
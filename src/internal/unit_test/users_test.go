@@ -1,6 +1,8 @@
 package unit_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"app/internal/controllers"
@@ -8,6 +10,7 @@ import (
 	"app/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -22,14 +25,27 @@ func TestGetUsers(t *testing.T) {
 		},
 	}
 	mockRepo.On("GetUsers").Return(mockData, nil)
-	ctrl := controllers.NewUsersCtrl(mockRepo, nil)
+	ctrl := controllers.NewUsersCtrl(mockRepo, nil, nil)
 
-	users, err := ctrl.GetUsers()
+	users, err := ctrl.GetUsers(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, users)
 	assert.Equal(t, mockData, users)
 }
 
+func TestGetUsersPage(t *testing.T) {
+	mockRepo := new(mocks.MockRepoUsers)
+	page := models.NewPage(2, 1)
+	mockRepo.On("GetUsersPage", page).Return([]*models.Users{{U_id: 2}}, 3, nil)
+	ctrl := controllers.NewUsersCtrl(mockRepo, nil, nil)
+
+	users, total, err := ctrl.GetUsersPage(context.Background(), page)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Users{{U_id: 2}}, users)
+	assert.Equal(t, 3, total)
+	mockRepo.AssertCalled(t, "GetUsersPage", page)
+}
+
 func TestGetUserById(t *testing.T) {
 	mockRepo := new(mocks.MockRepoUsers)
 	mockData := &models.Users{
@@ -40,7 +56,7 @@ func TestGetUserById(t *testing.T) {
 
 	mockRepo.On("GetUserById", 1).Return(mockData, nil)
 
-	user, err := mockRepo.GetUserById(1)
+	user, err := mockRepo.GetUserById(context.Background(), 1)
 	require.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, mockData, user)
@@ -56,7 +72,7 @@ func TestCreateUser(t *testing.T) {
 
 	mockRepo.On("CreateUser", mockData).Return(nil)
 
-	err := mockRepo.CreateUser(mockData)
+	err := mockRepo.CreateUser(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -70,7 +86,7 @@ func TestUpdateUser(t *testing.T) {
 
 	mockRepo.On("UpdateUser", mockData).Return(nil)
 
-	err := mockRepo.UpdateUser(mockData)
+	err := mockRepo.UpdateUser(context.Background(), mockData)
 	require.NoError(t, err)
 }
 
@@ -79,7 +95,7 @@ func TestDeleteUser(t *testing.T) {
 
 	mockRepo.On("DeleteUser", 1).Return(nil)
 
-	err := mockRepo.DeleteUser(1)
+	err := mockRepo.DeleteUser(context.Background(), 1)
 	require.NoError(t, err)
 }
 
@@ -93,7 +109,7 @@ func TestGetUserByLogin(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", "user").Return(mockData, nil)
 
-	user, err := mockRepo.GetUserByLogin("user")
+	user, err := mockRepo.GetUserByLogin(context.Background(), "user")
 	require.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, mockData.U_id, user.U_id)
@@ -103,29 +119,29 @@ func TestGetUserByLogin(t *testing.T) {
 
 func TestAuthUser_UserNotFound(t *testing.T) {
 	mockRepo := new(mocks.MockRepoUsers)
-	ctrl := controllers.NewUsersCtrl(mockRepo, nil)
+	ctrl := controllers.NewUsersCtrl(mockRepo, nil, nil)
 
 	mockRepo.On("AuthUser", "test", "password").Return(nil, controllers.ErrUserNotFound)
 
-	usr, err := ctrl.AuthUser("test", "password")
+	usr, err := ctrl.AuthUser(context.Background(), "test", "password")
 	assert.EqualError(t, err, controllers.ErrUserNotFound.Error())
 	assert.Nil(t, usr)
 }
 
 func TestAuthUser_InvalidPassword(t *testing.T) {
 	mockRepo := new(mocks.MockRepoUsers)
-	ctrl := controllers.NewUsersCtrl(mockRepo, nil)
+	ctrl := controllers.NewUsersCtrl(mockRepo, nil, nil)
 
 	mockRepo.On("AuthUser", "user", "wrongpassword").Return(nil, controllers.ErrInvalidPass)
 
-	usr, err := ctrl.AuthUser("user", "wrongpassword")
+	usr, err := ctrl.AuthUser(context.Background(), "user", "wrongpassword")
 	assert.EqualError(t, err, controllers.ErrInvalidPass.Error())
 	assert.Nil(t, usr)
 }
 
 func TestAuthUser_Success(t *testing.T) {
 	mockRepo := new(mocks.MockRepoUsers)
-	ctrl := controllers.NewUsersCtrl(mockRepo, nil)
+	ctrl := controllers.NewUsersCtrl(mockRepo, nil, nil)
 
 	mockUser := &models.Users{
 		U_id:       1,
@@ -135,7 +151,171 @@ func TestAuthUser_Success(t *testing.T) {
 
 	mockRepo.On("AuthUser", "admin", "password").Return(mockUser, nil)
 
-	usr, err := ctrl.AuthUser("admin", "password")
+	usr, err := ctrl.AuthUser(context.Background(), "admin", "password")
 	require.NoError(t, err)
 	assert.NotNil(t, usr)
 }
+
+func newMockTx() *mocks.MockTx {
+	return &mocks.MockTx{
+		CommentsRepo:          new(mocks.MockRepoComments),
+		CommentsReportsRepo:   new(mocks.MockRepoCommentsReports),
+		CommentsVotesRepo:     new(mocks.MockRepoCommentsVotes),
+		ComparisonsRepo:       new(mocks.MockRepoComparisons),
+		EpisodesRepo:          new(mocks.MockRepoEpisodes),
+		EpisodesUsersRepo:     new(mocks.MockRepoEpisodesUsers),
+		FavouritesRepo:        new(mocks.MockRepoFavourites),
+		ModerationsRepo:       new(mocks.MockRepoModerations),
+		RatingsRepo:           new(mocks.MockRepoRatings),
+		RecommendationsRepo:   new(mocks.MockRepoRecommendations),
+		SeasonsRepo:           new(mocks.MockRepoSeasons),
+		SerialsRepo:           new(mocks.MockRepoSerials),
+		SerialsFavouritesRepo: new(mocks.MockRepoSerialsFavourites),
+		SerialsUsersRepo:      new(mocks.MockRepoSerialsUsers),
+		StatisticRepo:         new(mocks.MockRepoStatistic),
+		UsersRepo:             new(mocks.MockRepoUsers),
+	}
+}
+
+func TestUsersCtrl_CreateUser(t *testing.T) {
+	tx := newMockTx()
+	uow := &mocks.MockUnitOfWork{Tx: tx}
+	ctrl := controllers.NewUsersCtrl(nil, nil, uow)
+	user := &models.Users{
+		U_id:     3,
+		U_login:  "test",
+		U_role:   "user",
+		U_gender: "женский",
+		U_bdate:  "01.01.2000",
+	}
+	stat := &models.Statistic{St_id: 1}
+
+	tx.UsersRepo.On("CheckUser", "test").Return(false)
+	tx.FavouritesRepo.On("CreateFavourite", &models.Favourites{F_name: "Избранное", F_position: 1, F_visibility: "private"}).Return(5, nil)
+	tx.UsersRepo.On("CreateUser", user).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", &models.Favourites{F_id: 5, F_idUser: 3, F_name: "Избранное", F_position: 1, F_visibility: "private"}).Return(nil)
+	tx.FavouritesRepo.On("CreateFavourite", &models.Favourites{F_idUser: 3, F_name: "Смотрю", F_position: 2, F_visibility: "private"}).Return(6, nil)
+	tx.FavouritesRepo.On("CreateFavourite", &models.Favourites{F_idUser: 3, F_name: "Буду смотреть", F_position: 3, F_visibility: "private"}).Return(7, nil)
+	tx.FavouritesRepo.On("CreateFavourite", &models.Favourites{F_idUser: 3, F_name: "Брошено", F_position: 4, F_visibility: "private"}).Return(8, nil)
+	tx.StatisticRepo.On("GetStatistic").Return(stat, nil)
+	tx.StatisticRepo.On("UpdateStatistic", stat).Return(nil)
+
+	require.NoError(t, ctrl.CreateUser(context.Background(), user))
+	assert.Equal(t, 1, uow.Calls)
+	assert.Equal(t, 5, user.GetIdFavourites())
+	assert.Equal(t, 1, stat.St_gender_female)
+	assert.Equal(t, 1, stat.St_role_user)
+	tx.UsersRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertExpectations(t)
+	tx.StatisticRepo.AssertExpectations(t)
+}
+
+func TestUsersCtrl_CreateUser_Exists(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewUsersCtrl(nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("CheckUser", "test").Return(true)
+
+	err := ctrl.CreateUser(context.Background(), &models.Users{U_login: "test"})
+	assert.ErrorIs(t, err, controllers.ErrUserExists)
+	tx.FavouritesRepo.AssertNotCalled(t, "CreateFavourite", mock.Anything)
+}
+
+func TestUsersCtrl_CreateUser_StatisticError(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewUsersCtrl(nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+	user := &models.Users{U_login: "test"}
+	errStat := errors.New("statistic is unavailable")
+
+	tx.UsersRepo.On("CheckUser", "test").Return(false)
+	tx.FavouritesRepo.On("CreateFavourite", mock.Anything).Return(5, nil)
+	tx.UsersRepo.On("CreateUser", user).Return(nil)
+	tx.FavouritesRepo.On("UpdateFavourite", mock.Anything).Return(nil)
+	tx.StatisticRepo.On("GetStatistic").Return((*models.Statistic)(nil), errStat)
+
+	assert.ErrorIs(t, ctrl.CreateUser(context.Background(), user), errStat)
+}
+
+func TestUsersCtrl_DeleteUser(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewUsersCtrl(nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+	user := &models.Users{
+		U_id:           1,
+		U_idFavourites: 5,
+		U_role:         "user",
+		U_gender:       "мужской",
+		U_bdate:        "01.01.2000",
+	}
+	stat := &models.Statistic{St_id: 1, St_gender_male: 1, St_role_user: 1, St_age_19_30: 1}
+	serial := &models.Serial{S_id: 3, S_rating: 7, S_votes: 2}
+
+	tx.UsersRepo.On("GetUserById", 1).Return(user, nil)
+	tx.FavouritesRepo.On("GetFavouritesByUserId", 1).
+		Return([]*models.Favourites{{F_id: 5, F_idUser: 1}, {F_id: 6, F_idUser: 1}}, nil)
+	tx.SerialsFavouritesRepo.On("GetSerialsByFavouriteId", 5).
+		Return([]*models.SerialsFavourites{{Sf_id: 7}}, nil)
+	tx.SerialsFavouritesRepo.On("GetSerialsByFavouriteId", 6).
+		Return([]*models.SerialsFavourites{{Sf_id: 9}}, nil)
+	tx.SerialsFavouritesRepo.On("DeleteSerialsFavourites", 7).Return(nil)
+	tx.SerialsFavouritesRepo.On("DeleteSerialsFavourites", 9).Return(nil)
+	voted := &models.Comments{C_id: 11, C_idUser: 2, C_up: 1, C_down: 1}
+	tx.CommentsRepo.On("GetCommentsByUserId", 1).Return([]*models.Comments{{C_id: 8, C_idSerial: 3}}, nil)
+	tx.CommentsRepo.On("GetRepliesBySerialId", 3).Return([]*models.Comments{{C_id: 10, C_idParent: 8}}, nil)
+	tx.CommentsVotesRepo.On("DeleteCommentVotesByCommentId", 10).Return(nil)
+	tx.CommentsReportsRepo.On("DeleteCommentReportsByCommentId", 10).Return(nil)
+	tx.CommentsRepo.On("DeleteComment", 10).Return(nil)
+	tx.CommentsVotesRepo.On("DeleteCommentVotesByCommentId", 8).Return(nil)
+	tx.CommentsReportsRepo.On("DeleteCommentReportsByCommentId", 8).Return(nil)
+	tx.CommentsRepo.On("DeleteComment", 8).Return(nil)
+	tx.CommentsVotesRepo.On("GetCommentsVotesByUserId", 1).Return([]*models.CommentsVotes{{Cv_id: 12, Cv_idComment: 11, Cv_idUser: 1, Cv_value: -1}}, nil)
+	tx.CommentsVotesRepo.On("DeleteCommentVote", 12).Return(nil)
+	tx.CommentsRepo.On("LockComment", 11).Return(nil)
+	tx.CommentsRepo.On("GetCommentById", 11).Return(voted, nil)
+	tx.CommentsVotesRepo.On("CountCommentVotes", 11).Return(1, 0, nil)
+	tx.CommentsRepo.On("UpdateComment", voted).Return(nil)
+	tx.CommentsReportsRepo.On("GetCommentsReportsByUserId", 1).Return([]*models.CommentsReports{{Cr_id: 13, Cr_idComment: 11, Cr_idUser: 1}}, nil)
+	tx.CommentsReportsRepo.On("DeleteCommentReport", 13).Return(nil)
+	tx.RatingsRepo.On("GetRatingsByUserId", 1).Return([]*models.Ratings{{R_id: 9, R_idSerial: 3}}, nil)
+	tx.RatingsRepo.On("DeleteRating", 9).Return(nil)
+	tx.SerialsRepo.On("LockSerial", 3).Return(nil)
+	tx.RatingsRepo.On("SumRatings", 3).Return(8, 1, nil)
+	tx.SerialsRepo.On("GetSerialById", 3).Return(serial, nil)
+	tx.SerialsRepo.On("UpdateSerial", serial).Return(nil)
+	tx.EpisodesUsersRepo.On("DeleteEpisodesByUserId", 1).Return(nil)
+	tx.SerialsUsersRepo.On("DeleteSerialsByUserId", 1).Return(nil)
+	tx.RecommendationsRepo.On("DeleteRecommendationsByUserId", 1).Return(nil)
+	tx.ComparisonsRepo.On("DeleteComparisonsByUserId", 1).Return(nil)
+	tx.UsersRepo.On("DeleteUser", 1).Return(nil)
+	tx.FavouritesRepo.On("DeleteFavourite", 5).Return(nil)
+	tx.FavouritesRepo.On("DeleteFavourite", 6).Return(nil)
+	tx.StatisticRepo.On("GetStatistic").Return(stat, nil)
+	tx.StatisticRepo.On("UpdateStatistic", stat).Return(nil)
+
+	require.NoError(t, ctrl.DeleteUser(context.Background(), 1))
+	assert.Equal(t, &models.Statistic{St_id: 1}, stat)
+	assert.Equal(t, &models.Serial{S_id: 3, S_rating: 8, S_votes: 1}, serial)
+	assert.Equal(t, &models.Comments{C_id: 11, C_idUser: 2, C_up: 1}, voted)
+	tx.UsersRepo.AssertExpectations(t)
+	tx.SerialsFavouritesRepo.AssertExpectations(t)
+	tx.CommentsRepo.AssertExpectations(t)
+	tx.CommentsVotesRepo.AssertExpectations(t)
+	tx.CommentsReportsRepo.AssertExpectations(t)
+	tx.RatingsRepo.AssertExpectations(t)
+	tx.RecommendationsRepo.AssertExpectations(t)
+	tx.ComparisonsRepo.AssertExpectations(t)
+	tx.SerialsRepo.AssertExpectations(t)
+	tx.EpisodesUsersRepo.AssertExpectations(t)
+	tx.SerialsUsersRepo.AssertExpectations(t)
+	tx.FavouritesRepo.AssertExpectations(t)
+	tx.StatisticRepo.AssertExpectations(t)
+}
+
+func TestUsersCtrl_DeleteUser_NotFound(t *testing.T) {
+	tx := newMockTx()
+	ctrl := controllers.NewUsersCtrl(nil, nil, &mocks.MockUnitOfWork{Tx: tx})
+
+	tx.UsersRepo.On("GetUserById", 1).Return((*models.Users)(nil), models.ErrNotFound)
+
+	assert.ErrorIs(t, ctrl.DeleteUser(context.Background(), 1), controllers.ErrUserNotFound)
+	tx.UsersRepo.AssertNotCalled(t, "DeleteUser", 1)
+}
@@ -1,11 +1,15 @@
 package logger
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,23 +19,88 @@ type CustomTextFormatter struct {
 
 func (f *CustomTextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	// Get the file and line number where the log was called
-	_, filename, line, _ := runtime.Caller(7)
+	filename, line := caller()
 
 	// Get the script name from the full file path
 	scriptName := filepath.Base(filename)
 
+	// Prefix the message with the request id, if any
+	text := entry.Message
+	if id, ok := entry.Data[requestIDField]; ok {
+		text = fmt.Sprintf("[%s] %s", id, text)
+	}
+
 	// Format the log message
 	message := fmt.Sprintf("[%s] [%s] [%s:%d] %s\n",
 		entry.Time.Format("2006-01-02 15:04:05"), // Date-time
 		entry.Level.String(),                     // Log level
 		scriptName,                               // Script name
 		line,                                     // Line number
-		entry.Message,                            // Log message
+		text,                                     // Log message
 	)
 
 	return []byte(message), nil
 }
 
+// caller returns the position of the first call outside of logrus and this
+// package. The depth of the logrus calls depends on whether the message is
+// logged by a Logger or by an Entry.
+func caller() (string, int) {
+	pc := make([]uintptr, 16)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/sirupsen/logrus.") &&
+			!strings.HasPrefix(frame.Function, "app/logger.") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return frame.File, frame.Line
+		}
+	}
+}
+
+const requestIDField = "request_id"
+
+type requestIDKey struct{}
+
+// NewRequestID returns a random id identifying one request in the log.
+func NewRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a copy of ctx carrying the request id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id carried by ctx, "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDHook adds the request id to the entries logged with a context,
+// see logrus.Entry.WithContext.
+type requestIDHook struct{}
+
+func (requestIDHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (requestIDHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if id := RequestID(entry.Context); id != "" {
+		entry.Data[requestIDField] = id
+	}
+	return nil
+}
+
 func InitLog(filename string) (*logrus.Logger, error) {
 	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
 	if err != nil {
@@ -40,6 +109,7 @@ func InitLog(filename string) (*logrus.Logger, error) {
 	log := logrus.New()
 	log.SetOutput(io.MultiWriter(file, os.Stdout))
 	log.SetFormatter(&CustomTextFormatter{})
+	log.AddHook(requestIDHook{})
 
 	log.Info("Logger initialized")
 
@@ -4,6 +4,7 @@ import (
 	"app/internal/controllers"
 	"app/internal/models"
 	"app/internal/repositories"
+	"context"
 	"fmt"
 	"time"
 
@@ -23,7 +24,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 		// зарегистрироваться
 		case 1:
 			{
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
 				user := &models.Users{}
 				fmt.Println("Введите логин:")
 				fmt.Scan(&user.U_login)
@@ -39,7 +40,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				fmt.Scan(&user.U_bdate)
 				user.SetRole("user")
 
-				err := ctrl.CreateUser(user)
+				err := ctrl.CreateUser(context.Background(), user)
 				if err != nil {
 					log.Error(err)
 					break
@@ -50,13 +51,13 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 		// войти в аккаунт
 		case 2:
 			{
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
 				var login, password string
 				fmt.Println("Введите логин:")
 				fmt.Scan(&login)
 				fmt.Println("Введите пароль:")
 				fmt.Scan(&password)
-				user, err := ctrl.AuthUser(login, password)
+				user, err := ctrl.AuthUser(context.Background(), login, password)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -73,8 +74,8 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 		// посмотреть список сериалов
 		case 4:
 			{
-				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log))
-				serials, err := ctrl.GetSerials()
+				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log), repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
+				serials, err := ctrl.GetSerials(context.Background())
 				if err != nil {
 					log.Error(err)
 					break
@@ -87,11 +88,11 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 		// найти сериал
 		case 5:
 			{
-				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log))
+				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log), repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
 				fmt.Println("Введите название сериала:")
 				var title string
 				fmt.Scan(&title)
-				serials, err := ctrl.SerialsService.GetSerialsByTitle(title)
+				serials, err := ctrl.SerialsService.GetSerialsByTitle(context.Background(), title)
 				if err != nil {
 					log.Error(err)
 					break
@@ -114,7 +115,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может оставлять отзывы")
 					break
 				}
-				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log))
+				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log), repositories.NewUnitOfWork(db, log), nil)
 				comment := &models.Comments{}
 				fmt.Println("Введите id сериала:")
 				fmt.Scan(&comment.C_idSerial)
@@ -123,7 +124,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				comment.SetIdUser(curUser.GetId())
 				comment.SetDate(time.Now().Format("2006-01-02"))
 				fmt.Println(comment)
-				err := ctrl.CreateComment(comment)
+				err := ctrl.CreateComment(context.Background(), comment)
 				if err != nil {
 					log.Error(err)
 					break
@@ -143,18 +144,18 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может изменять отзывы")
 					break
 				}
-				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log))
+				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log), repositories.NewUnitOfWork(db, log), nil)
 				var id int
 				fmt.Println("Введите id отзыва:")
 				fmt.Scan(&id)
-				comment, err := ctrl.GetCommentById(id)
+				comment, err := ctrl.GetCommentById(context.Background(), id)
 				if err != nil {
 					log.Error(err)
 					break
 				}
 				fmt.Println("Введите новый текст отзыва:")
 				fmt.Scan(&comment.C_text)
-				err = ctrl.UpdateComment(comment)
+				err = ctrl.UpdateComment(context.Background(), comment)
 				if err != nil {
 					log.Error(err)
 					break
@@ -174,12 +175,12 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может удалять отзывы")
 					break
 				}
-				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log))
+				ctrl := controllers.NewCommentsCtrl(repositories.NewCommentsRepo(db, log), repositories.NewUnitOfWork(db, log), nil)
 				comment := &models.Comments{}
 				fmt.Println("Введите id отзыва:")
 				fmt.Scan(&comment.C_id)
 				comment.SetIdUser(curUser.GetId())
-				err := ctrl.DeleteComment(comment.GetId())
+				err := ctrl.DeleteComment(context.Background(), comment.GetId())
 				if err != nil {
 					log.Error(err)
 					break
@@ -199,32 +200,18 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может сохранять сериалы в избранное")
 					break
 				}
-				ctrl := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(db, log))
-				serialFav := &models.SerialsFavourites{}
+				ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
+				var idSerial int
 				fmt.Println("Введите id сериала:")
-				fmt.Scan(&serialFav.Sf_idSerial)
-				serialFav.SetIdFavourite(curUser.GetIdFavourites())
-				err := ctrl.CreateSerialsFavourites(serialFav)
-				if err != nil {
-					log.Error(err)
-					break
-				}
-
-				ctrlFav := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(db, log))
-				fav, err := ctrlFav.GetFavouriteById(curUser.GetIdFavourites())
+				fmt.Scan(&idSerial)
+				err := ctrl.AddSerial(context.Background(), curUser.GetId(), curUser.GetIdFavourites(), idSerial)
 				if err != nil {
 					log.Error(err)
 				} else {
-					fav.SetCntSerials(fav.GetCntSerials() + 1)
-					err = ctrlFav.UpdateFavourite(fav)
-					if err != nil {
-						log.Error(err)
-					} else {
-						log.Info("User added serial to favourites")
-					}
+					log.Info("User added serial to favourites")
 				}
 			}
-		// посмотреть список избранных сериалов
+		// посмотреть списки сериалов
 		case 10:
 			{
 				if curUser == nil {
@@ -237,16 +224,25 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может просматривать список избранных сериалов")
 					break
 				}
-				ctrl := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(db, log))
-				serialsFav, err := ctrl.GetSerialsByFavouriteId(curUser.GetIdFavourites())
+				ctrl := controllers.NewFavouritesCtrl(repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
+				lists, err := ctrl.GetLists(context.Background(), curUser.GetId())
 				if err != nil {
 					log.Error(err)
-				} else {
+					break
+				}
+				ctrlSF := controllers.NewSerialsFavouritesCtrl(repositories.NewSerialsFavouritesRepo(db, log))
+				for _, list := range lists {
+					fmt.Println(*list)
+					serialsFav, err := ctrlSF.GetSerialsByFavouriteId(context.Background(), list.GetId())
+					if err != nil {
+						log.Error(err)
+						break
+					}
 					for _, s := range serialsFav {
 						fmt.Println(*s)
 					}
-					log.Info("User checked favourites")
 				}
+				log.Info("User checked favourites")
 			}
 		// посмотреть профиль
 		case 11:
@@ -261,8 +257,8 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может просматривать профиль")
 					break
 				}
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
-				user, err := ctrl.GetUserById(curUser.GetId())
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
+				user, err := ctrl.GetUserById(context.Background(), curUser.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -283,7 +279,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Администратор не может редактировать профиль")
 					break
 				}
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
 				fmt.Println("Поля, доступные для изменения:\n" +
 					"1. Логин\n" +
 					"2. Пароль\n" +
@@ -326,7 +322,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 						fmt.Scan(&curUser.U_bdate)
 					}
 				}
-				err := ctrl.UpdateUser(curUser)
+				err := ctrl.UpdateUser(context.Background(), curUser)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -347,7 +343,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					break
 				}
 				ctrl := controllers.NewSerialsUsersCtrl(repositories.NewSerialsUsersRepo(db, log))
-				serialsUsers, err := ctrl.GetSerialsByUserId(curUser.GetId())
+				serialsUsers, err := ctrl.GetSerialsByUserId(context.Background(), curUser.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -370,7 +366,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может добавлять сериалы")
 					break
 				}
-				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log))
+				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log), repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
 				serial := &models.Serial{}
 				fmt.Println("Введите название сериала:")
 				fmt.Scan(&serial.S_name)
@@ -387,7 +383,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				fmt.Println("Введите id продюсера сериала:")
 				fmt.Scan(&serial.S_idProducer)
 				serial.SetSeasons(0)
-				err := ctrl.CreateSerial(serial)
+				err := ctrl.CreateSerial(context.Background(), serial)
 				if err != nil {
 					log.Error(err)
 				}
@@ -405,11 +401,11 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может изменять сериалы")
 					break
 				}
-				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log))
+				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log), repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
 				var id int
 				fmt.Println("Введите id сериала:")
 				fmt.Scan(&id)
-				serial, err := ctrl.GetSerialById(id)
+				serial, err := ctrl.GetSerialById(context.Background(), id)
 				if err != nil {
 					log.Error(err)
 					break
@@ -462,7 +458,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 						fmt.Scan(&serial.S_idProducer)
 					}
 				}
-				err = ctrl.UpdateSerial(serial)
+				err = ctrl.UpdateSerial(context.Background(), serial)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -482,11 +478,11 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может удалять сериалы")
 					break
 				}
-				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log))
+				ctrl := controllers.NewSerialsCtrl(repositories.NewSerialsRepo(db, log), repositories.NewSeasonsRepo(db, log), repositories.NewEpisodesRepo(db, log))
 				serial := &models.Serial{}
 				fmt.Println("Введите id сериала:")
 				fmt.Scan(&serial.S_id)
-				err := ctrl.DeleteSerial(serial.GetId())
+				err := ctrl.DeleteSerial(context.Background(), serial.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -516,7 +512,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				fmt.Scan(&actor.A_bdate)
 				fmt.Println("Введите пол актера:")
 				fmt.Scan(&actor.A_gender)
-				err := ctrl.CreateActor(actor)
+				err := ctrl.CreateActor(context.Background(), actor)
 				if err != nil {
 					log.Error(err)
 					break
@@ -528,7 +524,8 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				serialActor := &models.SerialsActors{}
 				serialActor.SetIdActor(actor.GetId())
 				serialActor.SetIdSerial(serial)
-				err = ctrlSerialsActors.CreateSerialsActors(serialActor)
+				serialActor.SetRole(models.CastMain)
+				err = ctrlSerialsActors.CreateSerialsActors(context.Background(), serialActor)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -552,7 +549,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				var id int
 				fmt.Println("Введите id актера:")
 				fmt.Scan(&id)
-				actor, err := ctrl.GetActorById(id)
+				actor, err := ctrl.GetActorById(context.Background(), id)
 				if err != nil {
 					log.Error(err)
 					break
@@ -587,7 +584,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 						fmt.Scan(&actor.A_gender)
 					}
 				}
-				err = ctrl.UpdateActor(actor)
+				err = ctrl.UpdateActor(context.Background(), actor)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -611,7 +608,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				actor := &models.Actors{}
 				fmt.Println("Введите id актера:")
 				fmt.Scan(&actor.A_id)
-				err := ctrl.DeleteActor(actor.GetId())
+				err := ctrl.DeleteActor(context.Background(), actor.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -637,7 +634,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				fmt.Scan(&producer.P_name)
 				fmt.Println("Введите фамилию режиссера:")
 				fmt.Scan(&producer.P_surname)
-				err := ctrl.CreateProducer(producer)
+				err := ctrl.CreateProducer(context.Background(), producer)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -661,7 +658,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				var id int
 				fmt.Println("Введите id режиссера:")
 				fmt.Scan(&id)
-				producer, err := ctrl.GetProducerById(id)
+				producer, err := ctrl.GetProducerById(context.Background(), id)
 				if err != nil {
 					log.Error(err)
 					break
@@ -684,7 +681,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 						fmt.Scan(&producer.P_surname)
 					}
 				}
-				err = ctrl.UpdateProducer(producer)
+				err = ctrl.UpdateProducer(context.Background(), producer)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -708,7 +705,7 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 				producer := &models.Producers{}
 				fmt.Println("Введите id режиссера:")
 				fmt.Scan(&producer.P_id)
-				err := ctrl.DeleteProducer(producer.GetId())
+				err := ctrl.DeleteProducer(context.Background(), producer.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -728,8 +725,8 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может просматривать список пользователей")
 					break
 				}
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
-				users, err := ctrl.GetUsers()
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
+				users, err := ctrl.GetUsers(context.Background())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -752,11 +749,11 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может удалять пользователей")
 					break
 				}
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
 				user := &models.Users{}
 				fmt.Println("Введите id пользователя:")
 				fmt.Scan(&user.U_id)
-				err := ctrl.DeleteUser(user.GetId())
+				err := ctrl.DeleteUser(context.Background(), user.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -776,11 +773,11 @@ func Run(db *sqlx.DB, log *logrus.Logger) {
 					fmt.Println("Только администратор может выдавать права администратора")
 					break
 				}
-				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log))
+				ctrl := controllers.NewUsersCtrl(repositories.NewUsersRepo(db, log), repositories.NewFavouritesRepo(db, log), repositories.NewUnitOfWork(db, log))
 				user := &models.Users{}
 				fmt.Println("Введите id пользователя:")
 				fmt.Scan(&user.U_id)
-				err := ctrl.GrantAdmin(user.GetId())
+				err := ctrl.GrantAdmin(context.Background(), user.GetId())
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -804,7 +801,7 @@ func Menu() int {
 		"7. Изменить отзыв\n" +
 		"8. Удалить отзыв\n" +
 		"9. Сохранить сериал в избранное\n" +
-		"10. Посмотреть список избранных сериалов\n" +
+		"10. Посмотреть списки сериалов\n" +
 		"11. Посмотреть профиль\n" +
 		"12. Редактировать профиль\n" +
 		"13. Посмотреть историю просмотров\n\n" +